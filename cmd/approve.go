@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/approval"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+var (
+	approveAs            string
+	approveApprovalsFile string
+)
+
+// approveCmd represents the approve command
+var approveCmd = &cobra.Command{
+	Use:   "approve <context> <stack-name>",
+	Short: "Record an approval of the pending change to a stack",
+	Long: `Record that a person approves deploying the current change to a stack.
+
+Stacks configured with required_approvals are only deployed once that many
+different people have approved the change. The stack is resolved as a
+deployment would resolve it, and the approval is tied to a hash of its
+template, parameters, tags, capabilities and stack policy. Any later change
+to these needs approving again.
+
+Approvals are kept in a local file, stackaroo-approvals.json unless
+--approvals-file names another; deploy reads the same file.
+
+Examples:
+  stackaroo approve prod app --as alice
+  stackaroo approve prod app --as bob --approvals-file approvals/prod.json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if approveAs == "" {
+			return fmt.Errorf("--as is required")
+		}
+
+		configFile, _ := cmd.Flags().GetString("config")
+		_, resolver := createResolver(configFile)
+
+		return approveStack(context.Background(), cmd.OutOrStdout(), resolver, args[0], args[1], approveAs, approveApprovalsFile)
+	},
+}
+
+// approveStack resolves a stack and records the approver's approval of its current change
+func approveStack(ctx context.Context, w io.Writer, resolver resolve.Resolver, contextName, stackName, approver, path string) error {
+	stack, err := resolver.ResolveStack(ctx, contextName, stackName)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		path = approval.DefaultPath
+	}
+
+	record, err := approval.Load(path)
+	if err != nil {
+		return err
+	}
+
+	changeHash := approval.ChangeHash(stack)
+	record.Approve(approval.Approval{
+		StackName:  stackName,
+		Context:    contextName,
+		ChangeHash: changeHash,
+		Approver:   approver,
+		ApprovedAt: time.Now().UTC(),
+	})
+	if err := record.Save(path); err != nil {
+		return err
+	}
+
+	approvals := len(record.Approvers(contextName, stackName, changeHash))
+	if stack.RequiredApprovals <= 0 {
+		_, err = fmt.Fprintf(w, "Recorded approval of stack %s by %s; the stack does not require approvals\n", stackName, approver)
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Recorded approval of stack %s by %s (%d of %d required)\n", stackName, approver, approvals, stack.RequiredApprovals)
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(approveCmd)
+	approveCmd.Flags().StringVar(&approveAs, "as", "", "name of the person approving the change")
+	approveCmd.Flags().StringVar(&approveApprovalsFile, "approvals-file", "", "file recording approvals (default stackaroo-approvals.json)")
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/approval"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproveCommand_Exists(t *testing.T) {
+	approveCmd := findCommand(rootCmd, "approve")
+
+	require.NotNil(t, approveCmd, "approve command should be registered")
+	assert.Equal(t, "approve <context> <stack-name>", approveCmd.Use)
+	assert.NotNil(t, approveCmd.Flags().Lookup("as"))
+	assert.NotNil(t, approveCmd.Flags().Lookup("approvals-file"))
+}
+
+func TestApproveCommand_RequiresApprover(t *testing.T) {
+	rootCmd.SetArgs([]string{"approve", "prod", "app"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--as is required")
+}
+
+func TestApproveStack_RecordsApprovalOfCurrentChange(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "approvals.json")
+	stack := model.NewTestStack("app", model.NewTestContext("prod", "us-east-1", "123456789012"))
+	stack.RequiredApprovals = 2
+	mockResolver := &resolve.MockResolver{}
+	mockResolver.On("ResolveStack", mock.Anything, "prod", "app").Return(stack, nil)
+
+	var output bytes.Buffer
+	require.NoError(t, approveStack(ctx, &output, mockResolver, "prod", "app", "alice", path))
+	require.NoError(t, approveStack(ctx, &output, mockResolver, "prod", "app", "bob", path))
+
+	assert.Equal(t, "Recorded approval of stack app by alice (1 of 2 required)\n"+
+		"Recorded approval of stack app by bob (2 of 2 required)\n", output.String())
+	record, err := approval.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, record.Approvers("prod", "app", approval.ChangeHash(stack)))
+}
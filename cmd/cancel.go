@@ -0,0 +1,60 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+
+	"codeberg.org/orien/stackaroo/internal/recovery"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cancelNoWait         bool
+	cancelAllowProtected bool
+)
+
+// cancelCmd represents the cancel command
+var cancelCmd = &cobra.Command{
+	Use:   "cancel <context> <stack-name>",
+	Short: "Cancel the in-progress update of a stack",
+	Long: `Cancel an update that CloudFormation is still applying to a stack.
+
+Only a stack in UPDATE_IN_PROGRESS can be cancelled. CloudFormation stops the
+update and rolls the stack back to its previous configuration. The command
+streams the rollback events and waits for the stack to reach
+UPDATE_ROLLBACK_COMPLETE; add --no-wait to return once the cancellation has
+been requested.
+
+If the rollback itself fails, the stack is left in UPDATE_ROLLBACK_FAILED; use
+'stackaroo recover' to continue it.
+
+Cancelling an update of a stack in a protected context requires --allow-protected.
+
+Examples:
+  stackaroo cancel prod app             # Cancel the update of app and wait for the rollback
+  stackaroo cancel prod app --no-wait   # Request the cancellation and return`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contextName := args[0]
+		stackName := args[1]
+		ctx := context.Background()
+
+		configFile, _ := cmd.Flags().GetString("config")
+
+		options := recovery.CancelOptions{
+			NoWait:         cancelNoWait,
+			AllowProtected: cancelAllowProtected,
+		}
+
+		return getStackRecoverer(configFile).CancelUpdate(ctx, contextName, stackName, options)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cancelCmd)
+	cancelCmd.Flags().BoolVar(&cancelNoWait, "no-wait", false, "return once the cancellation is requested instead of waiting for the rollback")
+	cancelCmd.Flags().BoolVar(&cancelAllowProtected, "allow-protected", false, "allow cancelling an update of a stack in a protected context")
+}
@@ -0,0 +1,49 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/recovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelCommand_Exists(t *testing.T) {
+	cancelCmd := findCommand(rootCmd, "cancel")
+
+	require.NotNil(t, cancelCmd, "cancel command should be registered")
+	assert.Equal(t, "cancel <context> <stack-name>", cancelCmd.Use)
+	assert.NotNil(t, cancelCmd.Flags().Lookup("no-wait"))
+	assert.NotNil(t, cancelCmd.Flags().Lookup("allow-protected"))
+	assert.NoError(t, cancelCmd.Args(cancelCmd, []string{"dev", "app"}))
+	assert.Error(t, cancelCmd.Args(cancelCmd, []string{"dev"}))
+}
+
+func TestCancelCommand_PassesOptions(t *testing.T) {
+	mockRecoverer := withMockStackRecoverer(t)
+	mockRecoverer.On("CancelUpdate", mock.Anything, "prod", "app", recovery.CancelOptions{NoWait: true, AllowProtected: true}).Return(nil)
+
+	rootCmd.SetArgs([]string{"cancel", "prod", "app", "--no-wait", "--allow-protected"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockRecoverer.AssertExpectations(t)
+}
+
+func TestCancelCommand_ReturnsCancelError(t *testing.T) {
+	mockRecoverer := withMockStackRecoverer(t)
+	mockRecoverer.On("CancelUpdate", mock.Anything, "dev", "app", recovery.CancelOptions{}).
+		Return(recovery.NotCancellableError{StackName: "app", Status: "UPDATE_COMPLETE"})
+
+	rootCmd.SetArgs([]string{"cancel", "dev", "app"})
+	err := rootCmd.Execute()
+
+	assert.True(t, errors.As(err, &recovery.NotCancellableError{}))
+	assert.EqualError(t, err, "stack app is in UPDATE_COMPLETE; only stacks in UPDATE_IN_PROGRESS can be cancelled")
+}
@@ -6,6 +6,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
 	"codeberg.org/orien/stackaroo/internal/delete"
 	"github.com/spf13/cobra"
@@ -14,6 +15,13 @@ import (
 var (
 	// deleter can be injected for testing
 	deleter delete.Deleter
+
+	deleteForce          bool
+	deleteRequireStacks  bool
+	deleteOutput         string
+	deleteRetain         []string
+	deleteSkipAccount    bool
+	deleteAllowProtected bool
 )
 
 // deleteCmd represents the delete command
@@ -29,11 +37,38 @@ This command safely deletes CloudFormation stacks by:
 • Prompting for confirmation before deletion
 
 When deleting multiple stacks, they are processed in reverse dependency order
-to ensure dependent stacks are deleted before their dependencies.
+to ensure dependent stacks are deleted before their dependencies. A context
+with no stacks is reported and skipped; use --require-stacks to treat it as an
+error instead. Use --output json to print a JSON report of each stack's
+outcome once the deletion finishes.
+
+Deleting a single stack that other configured stacks depend on is refused,
+since removing a foundational stack can break its dependents. Use --force to
+delete it anyway.
+
+Stacks with CloudFormation termination protection enabled are also refused.
+--force disables the protection once the deletion is confirmed, then deletes
+the stack.
+
+A stack in DELETE_FAILED usually holds a resource that CloudFormation could
+not remove, such as a non-empty S3 bucket. Use --retain with the logical IDs
+of those resources to retry the deletion while leaving them in place. Retained
+resources are no longer managed by CloudFormation, so clean them up yourself.
+--retain applies only to a named stack in DELETE_FAILED.
+
+When a context sets an account, the AWS credentials in use are checked against
+it before any stack is deleted, and the deletion stops if they belong to a
+different account. Use --skip-account-check to bypass this check.
+
+Deleting stacks in a context marked as protected requires --allow-protected,
+and each deletion is confirmed by typing the context name instead of y/N. Use
+--yes to skip confirmation prompts in non-interactive runs.
 
 Examples:
-  stackaroo delete dev vpc        # Delete single stack with confirmation
-  stackaroo delete dev            # Delete all stacks in context with confirmation
+  stackaroo delete dev vpc            # Delete single stack with confirmation
+  stackaroo delete dev vpc --force    # Delete even if other stacks depend on it
+  stackaroo delete dev                # Delete all stacks in context with confirmation
+  stackaroo delete dev app --retain LogsBucket  # Retry a failed deletion, keeping LogsBucket
 
 CAUTION: Deletion is destructive and cannot be undone. Always verify what
 will be deleted before confirming.`,
@@ -42,14 +77,32 @@ will be deleted before confirming.`,
 		contextName := args[0]
 		ctx := context.Background()
 
+		jsonOutput, err := isJSONOutput(deleteOutput)
+		if err != nil {
+			return err
+		}
+
+		if len(deleteRetain) > 0 && len(args) < 2 {
+			return fmt.Errorf("--retain requires a stack name")
+		}
+
 		configFile, _ := cmd.Flags().GetString("config")
 		d := getDeleter(configFile)
 
+		options := delete.Options{
+			Force:            deleteForce,
+			RequireStacks:    deleteRequireStacks,
+			JSONOutput:       jsonOutput,
+			Retain:           deleteRetain,
+			SkipAccountCheck: deleteSkipAccount,
+			AllowProtected:   deleteAllowProtected,
+		}
+
 		if len(args) > 1 {
 			stackName := args[1]
-			return d.DeleteSingleStack(ctx, stackName, contextName)
+			return d.DeleteSingleStack(ctx, stackName, contextName, options)
 		}
-		return d.DeleteAllStacks(ctx, contextName)
+		return d.DeleteAllStacks(ctx, contextName, options)
 	},
 }
 
@@ -72,4 +125,11 @@ func SetDeleter(d delete.Deleter) {
 
 func init() {
 	rootCmd.AddCommand(deleteCmd)
+
+	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "delete the stack even if other stacks depend on it or it has termination protection enabled")
+	deleteCmd.Flags().BoolVar(&deleteRequireStacks, "require-stacks", false, "fail when the context has no stacks instead of doing nothing")
+	deleteCmd.Flags().StringVar(&deleteOutput, "output", "text", "output format: text or json")
+	deleteCmd.Flags().StringSliceVar(&deleteRetain, "retain", nil, "logical IDs of resources to keep when retrying the deletion of a stack in DELETE_FAILED")
+	deleteCmd.Flags().BoolVar(&deleteSkipAccount, "skip-account-check", false, "delete even if the credentials belong to a different account than the context")
+	deleteCmd.Flags().BoolVar(&deleteAllowProtected, "allow-protected", false, "allow deleting stacks in a protected context")
 }
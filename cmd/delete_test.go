@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"codeberg.org/orien/stackaroo/internal/delete"
+	"codeberg.org/orien/stackaroo/internal/prompt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -108,7 +109,7 @@ stacks:
 	}()
 
 	// Set up mock expectations for the new DeleteSingleStack method
-	mockDeleter.On("DeleteSingleStack", mock.Anything, "vpc", "dev").Return(nil)
+	mockDeleter.On("DeleteSingleStack", mock.Anything, "vpc", "dev", delete.Options{}).Return(nil)
 
 	// Execute command
 	rootCmd.SetArgs([]string{"delete", "dev", "vpc"})
@@ -164,7 +165,7 @@ stacks:
 
 	// Set up mock expectations - app should be deleted before vpc (reverse dependency order)
 	// Set up mock expectations
-	mockDeleter.On("DeleteAllStacks", mock.Anything, "dev").Return(nil)
+	mockDeleter.On("DeleteAllStacks", mock.Anything, "dev", delete.Options{}).Return(nil)
 
 	// Execute command
 	rootCmd.SetArgs([]string{"delete", "dev"})
@@ -213,7 +214,7 @@ stacks:
 	}()
 
 	// Set up mock expectations with error
-	mockDeleter.On("DeleteSingleStack", mock.Anything, "vpc", "dev").Return(errors.New("deletion failed"))
+	mockDeleter.On("DeleteSingleStack", mock.Anything, "vpc", "dev", delete.Options{}).Return(errors.New("deletion failed"))
 
 	// Execute command
 	rootCmd.SetArgs([]string{"delete", "dev", "vpc"})
@@ -259,7 +260,7 @@ stacks: []
 	}()
 
 	// Set up mock expectations for DeleteAllStacks
-	mockDeleter.On("DeleteAllStacks", mock.Anything, "dev").Return(nil)
+	mockDeleter.On("DeleteAllStacks", mock.Anything, "dev", delete.Options{}).Return(nil)
 
 	// Execute command
 	rootCmd.SetArgs([]string{"delete", "dev"})
@@ -308,7 +309,7 @@ stacks:
 	}()
 
 	// Set up mock expectations for DeleteAllStacks with error
-	mockDeleter.On("DeleteAllStacks", mock.Anything, "invalid-context").Return(errors.New("failed to get stacks for context invalid-context"))
+	mockDeleter.On("DeleteAllStacks", mock.Anything, "invalid-context", delete.Options{}).Return(errors.New("failed to get stacks for context invalid-context"))
 
 	// Execute command with invalid context
 	rootCmd.SetArgs([]string{"delete", "invalid-context"})
@@ -358,7 +359,7 @@ stacks:
 	}()
 
 	// Set up mock expectations for DeleteSingleStack to return error
-	mockDeleter.On("DeleteSingleStack", mock.Anything, "non-existent-stack", "dev").Return(errors.New("failed to resolve stack dependencies"))
+	mockDeleter.On("DeleteSingleStack", mock.Anything, "non-existent-stack", "dev", delete.Options{}).Return(errors.New("failed to resolve stack dependencies"))
 
 	// Execute command with non-existent stack
 	rootCmd.SetArgs([]string{"delete", "dev", "non-existent-stack"})
@@ -414,7 +415,7 @@ stacks:
 	}()
 
 	// Set up mock expectations for DeleteAllStacks
-	mockDeleter.On("DeleteAllStacks", mock.Anything, "dev").Return(nil)
+	mockDeleter.On("DeleteAllStacks", mock.Anything, "dev", delete.Options{}).Return(nil)
 
 	// Execute command to delete all stacks
 	rootCmd.SetArgs([]string{"delete", "dev"})
@@ -481,3 +482,175 @@ func createTempConfigWithTemplates(t *testing.T, configContent string, templateN
 
 	return tmpDir
 }
+
+func TestDeleteCommand_ForceFlag(t *testing.T) {
+	// Test that --force is passed through to the deleter
+	mockDeleter := &delete.MockDeleter{}
+
+	oldDeleter := deleter
+	SetDeleter(mockDeleter)
+	defer SetDeleter(oldDeleter)
+	defer func() { deleteForce = false }()
+
+	configContent := `
+project: test-project
+region: us-east-1
+
+contexts:
+  dev:
+    account: "123456789012"
+    region: us-west-2
+
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+`
+
+	tmpDir := createTempConfigWithTemplates(t, configContent, []string{"vpc.yaml"})
+
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	err = os.Chdir(tmpDir)
+	require.NoError(t, err)
+	defer func() {
+		err := os.Chdir(oldWd)
+		require.NoError(t, err)
+	}()
+
+	mockDeleter.On("DeleteSingleStack", mock.Anything, "vpc", "dev", delete.Options{Force: true}).Return(nil)
+
+	rootCmd.SetArgs([]string{"delete", "dev", "vpc", "--force"})
+	err = rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDeleteCommand_RequireStacksFlag(t *testing.T) {
+	// Test that --require-stacks is passed through to the deleter
+	mockDeleter := &delete.MockDeleter{}
+
+	oldDeleter := deleter
+	SetDeleter(mockDeleter)
+	defer SetDeleter(oldDeleter)
+	defer func() { deleteRequireStacks = false }()
+
+	mockDeleter.On("DeleteAllStacks", mock.Anything, "dev", delete.Options{RequireStacks: true}).Return(nil)
+
+	rootCmd.SetArgs([]string{"delete", "dev", "--require-stacks"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDeleteCommand_OutputFlag(t *testing.T) {
+	// Test that --output json is passed through to the deleter
+	mockDeleter := &delete.MockDeleter{}
+
+	oldDeleter := deleter
+	SetDeleter(mockDeleter)
+	defer SetDeleter(oldDeleter)
+	defer func() { deleteOutput = "text" }()
+
+	mockDeleter.On("DeleteAllStacks", mock.Anything, "dev", delete.Options{JSONOutput: true}).Return(nil)
+
+	rootCmd.SetArgs([]string{"delete", "dev", "--output", "json"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDeleteCommand_RetainFlag(t *testing.T) {
+	// Test that --retain is passed through to the deleter
+	mockDeleter := &delete.MockDeleter{}
+
+	oldDeleter := deleter
+	SetDeleter(mockDeleter)
+	defer SetDeleter(oldDeleter)
+	defer func() { deleteRetain = nil }()
+
+	mockDeleter.On("DeleteSingleStack", mock.Anything, "app", "dev", delete.Options{Retain: []string{"LogsBucket", "Table"}}).Return(nil)
+
+	rootCmd.SetArgs([]string{"delete", "dev", "app", "--retain", "LogsBucket,Table"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDeleteCommand_SkipAccountCheckFlag(t *testing.T) {
+	// Test that --skip-account-check is passed through to the deleter
+	mockDeleter := &delete.MockDeleter{}
+
+	oldDeleter := deleter
+	SetDeleter(mockDeleter)
+	defer SetDeleter(oldDeleter)
+	defer func() { deleteSkipAccount = false }()
+
+	mockDeleter.On("DeleteSingleStack", mock.Anything, "app", "dev", delete.Options{SkipAccountCheck: true}).Return(nil)
+
+	rootCmd.SetArgs([]string{"delete", "dev", "app", "--skip-account-check"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDeleteCommand_AllowProtectedFlag(t *testing.T) {
+	// Test that --allow-protected is passed through to the deleter
+	mockDeleter := &delete.MockDeleter{}
+
+	oldDeleter := deleter
+	SetDeleter(mockDeleter)
+	defer SetDeleter(oldDeleter)
+	defer func() { deleteAllowProtected = false }()
+
+	mockDeleter.On("DeleteAllStacks", mock.Anything, "prod", delete.Options{AllowProtected: true}).Return(nil)
+
+	rootCmd.SetArgs([]string{"delete", "prod", "--allow-protected"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDeleteCommand_YesFlag(t *testing.T) {
+	// Test that --yes turns on auto-approval for the deletion
+	mockDeleter := &delete.MockDeleter{}
+
+	oldDeleter := deleter
+	SetDeleter(mockDeleter)
+	defer SetDeleter(oldDeleter)
+	defer func() {
+		assumeYes = false
+		prompt.SetAutoApprove(false)
+	}()
+
+	var autoApproved bool
+	mockDeleter.On("DeleteSingleStack", mock.Anything, "vpc", "dev", delete.Options{}).
+		Run(func(mock.Arguments) { autoApproved = prompt.AutoApprove() }).Return(nil)
+
+	rootCmd.SetArgs([]string{"delete", "dev", "vpc", "--yes"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	assert.True(t, autoApproved)
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDeleteCommand_RetainRequiresStackName(t *testing.T) {
+	mockDeleter := &delete.MockDeleter{}
+
+	oldDeleter := deleter
+	SetDeleter(mockDeleter)
+	defer SetDeleter(oldDeleter)
+	defer func() { deleteRetain = nil }()
+
+	rootCmd.SetArgs([]string{"delete", "dev", "--retain", "LogsBucket"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--retain requires a stack name")
+	mockDeleter.AssertNotCalled(t, "DeleteAllStacks", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -7,13 +7,48 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/deploy"
+	"codeberg.org/orien/stackaroo/internal/resolve"
 	"github.com/spf13/cobra"
 )
 
 var (
+	deployTimeout            time.Duration
+	deployPollInterval       time.Duration
+	deployContinueOnError    bool
+	deploySummaryFile        string
+	deployRequireStacks      bool
+	deployOutput             string
+	deployExplain            bool
+	deployMetadataFields     []string
+	deployMessage            string
+	deployPruneParameters    bool
+	deploySkipAccountCheck   bool
+	deployAllowProtected     bool
+	deployEvents             string
+	deployWatchEventsOnly    bool
+	deployPlan               bool
+	deployDryRun             bool
+	deployFailFastOnRollback bool
+	deployWatch              bool
+	deployForce              bool
+	deployApprovalsFile      string
+	deployChangeSet          string
+	deployParameters         []string
+	deployTimings            bool
+	deployStamp              bool
+	deployNoRollback         bool
+	deployTemplate           string
+
 	// deployer can be injected for testing
 	deployer deploy.Deployer
 )
@@ -38,12 +73,116 @@ deployment proceeds. For new stacks, the command prompts for confirmation
 before proceeding with stack creation.
 
 If no stack name is provided, all stacks in the context will be deployed in
-dependency order.
+dependency order. Use --timeout to bound how long each stack may take, and
+--continue-on-error to keep deploying stacks that do not depend on a failed or
+timed-out stack. A summary of every stack's outcome is printed at the end.
+Use --poll-interval to change how often stack status and events are checked
+while waiting. Use --fail-fast-on-rollback to fail a stack as soon as it starts
+rolling back, with the reason for the failure that caused it, rather than
+waiting for the rollback to finish; the rollback continues in CloudFormation.
+A stack still running when its timeout passes is reported with the last
+status seen.
+A context with no stacks is reported and skipped; use --require-stacks to
+treat it as an error instead.
+
+Use --output json to print a JSON report of each stack's outcome, status, stack
+ID and any error once the deployment finishes. Progress is still shown while
+stacks deploy; the report is the last thing written.
+
+Use --summary-file to record the parameters and tags of each deployed stack.
+'stackaroo diff --since-last' compares against this record.
+
+Use --explain to print how each parameter was resolved: the resolver type, its
+inputs, any AWS calls made and the final value. Secret values are masked.
+
+Use --changeset-metadata to record deployment details in the description of
+each changeset for audit correlation. Choose any of commit (the current git
+revision, also appended to the changeset name), user (the local user) and
+message (the text given with --message).
+
+Use --prune-parameters to fail, listing the offending names, when a stack's
+configuration sets parameters that its template does not declare. This keeps
+configuration free of parameters left behind after template changes.
+
+When a context sets an account, the AWS credentials in use are checked against
+it before any stack is created or updated, and the deployment stops if they
+belong to a different account. Use --skip-account-check to bypass this check.
+
+Deploying to a context marked as protected requires --allow-protected, and
+each change is confirmed by typing the context name instead of y/N. Use --yes
+to skip confirmation prompts in non-interactive runs.
+
+Stacks configured with required_approvals are deployed only once that many
+people have approved the change with 'stackaroo approve'. Use --force to
+deploy without the approvals.
+
+Use --events json to print each stack event as a line of JSON with its
+timestamp, stack name, logical ID, resource type, status and reason, for CI
+systems to follow progress.
+
+Use --watch to follow each stack operation as a live view of its resources,
+redrawn in place as their statuses change, with a progress bar counting the
+resources that have finished. When output is not a terminal, such as in CI,
+events are printed line by line instead. NO_COLOR disables colours.
+
+Use --watch-events-only with a stack name to attach to an operation already in
+progress, for example one started elsewhere, and stream its events until it
+finishes. Nothing is changed, and the command fails if the stack has no
+operation in progress.
+
+Use --plan without a stack name to check a whole rollout before running it.
+Each stack is resolved in dependency order and a changeset is created for AWS
+to validate, shown, and then deleted without being executed; templates of
+stacks that do not exist yet are validated instead. Nothing is deployed. The
+plan stops at the first failing stack unless --continue-on-error is set.
+Parameters read from stacks that are not deployed yet cannot be resolved.
+
+Use --dry-run with a stack name to resolve the stack exactly as a deployment
+would, create its changeset and show the preview, then delete the changeset
+without executing it. Nothing is prompted for or changed, so protected
+contexts may be previewed. The template of a stack that does not exist yet is
+validated instead.
+
+Use --parameter key=value, repeated as needed, to replace the resolved value of
+a parameter for this run without editing configuration. Each override applies
+to every stack being deployed that has the parameter, and --explain shows it
+as resolved by override.
+
+Use --timings to print, once the run ends, how long each stack spent loading
+configuration, resolving, creating its changeset and waiting for its operation
+to finish, to see where time goes in large deployments. The table is written
+to standard error so it never mixes with --output json.
+
+Use --no-rollback to leave a stack whose update fails in its failed state
+instead of rolling it back, so the failed resources can be inspected. Deploy
+again once the problem is fixed, or continue the rollback with 'stackaroo
+recover'. For stacks being created, use --disable-rollback instead.
+
+Use --stamp to record provenance in each deployed template. A
+Metadata.StackarooBuildInfo block is added with a hash of the template, the
+deployment time and the stackaroo version. The hash is taken before the block
+is added, and diffs ignore the block, so stamping never shows as a change.
+Stamped templates are always sent inline, even when configured in S3.
+
+Use --changeset with a stack name to execute a changeset saved by
+'stackaroo diff --save-changeset' instead of creating a new one, so exactly
+the reviewed changes are deployed. The changeset must still exist, belong to
+the stack and be ready to execute.
+
+Use --template with a stack name to deploy a local template file in place of
+the stack's configured template, for trying out template changes before
+committing them. Everything else is resolved from configuration as usual.
 
 Examples:
   stackaroo deploy dev            # Deploy all stacks with confirmation prompts
   stackaroo deploy dev vpc        # Deploy single stack with confirmation prompt
   stackaroo deploy prod app       # Deploy stack after confirming changes
+  stackaroo deploy dev --timeout 20m --continue-on-error
+  stackaroo deploy prod --plan    # Validate every stack's changes without deploying
+  stackaroo deploy prod app --dry-run
+  stackaroo deploy dev app --parameter ImageTag=abc123
+  stackaroo deploy prod app --changeset arn:aws:cloudformation:...
+  stackaroo deploy dev app --template ./app-experimental.yaml
 
 The preview shows the same detailed diff information as 'stackaroo diff' and
 waits for your confirmation before applying the changes.`,
@@ -57,24 +196,190 @@ waits for your confirmation before applying the changes.`,
 			return fmt.Errorf("failed to set STACKAROO_PLAIN environment variable: %w", err)
 		}
 
+		jsonOutput, err := isJSONOutput(deployOutput)
+		if err != nil {
+			return err
+		}
+
+		jsonEvents, err := isJSONEvents(deployEvents)
+		if err != nil {
+			return err
+		}
+		if deployWatch && jsonEvents {
+			return fmt.Errorf("--watch cannot be combined with --events json")
+		}
+
+		metadata, err := buildChangeSetMetadata(deployMetadataFields, deployMessage)
+		if err != nil {
+			return err
+		}
+
+		parameterOverrides, err := parseParameterOverrides(deployParameters)
+		if err != nil {
+			return err
+		}
+
+		var templateOverrides map[string]string
+		if deployTemplate != "" {
+			if len(args) < 2 {
+				return fmt.Errorf("--template requires a stack name; it cannot be used when deploying multiple stacks")
+			}
+			if deployChangeSet != "" {
+				return fmt.Errorf("--template cannot be combined with --changeset")
+			}
+			templateURI, err := templateFileURI(deployTemplate)
+			if err != nil {
+				return err
+			}
+			templateOverrides = map[string]string{args[1]: templateURI}
+		}
+
 		configFile, _ := cmd.Flags().GetString("config")
-		d := getDeployer(configFile)
+		d := getDeployer(configFile, templateOverrides)
+
+		options := deploy.Options{
+			StackTimeout:       deployTimeout,
+			PollInterval:       deployPollInterval,
+			ContinueOnError:    deployContinueOnError,
+			SummaryFile:        deploySummaryFile,
+			RequireStacks:      deployRequireStacks,
+			JSONOutput:         jsonOutput,
+			Explain:            deployExplain,
+			ChangeSetMetadata:  metadata,
+			PruneParameters:    deployPruneParameters,
+			SkipAccountCheck:   deploySkipAccountCheck,
+			AllowProtected:     deployAllowProtected,
+			JSONEvents:         jsonEvents,
+			DryRun:             deployDryRun,
+			FailFastOnRollback: deployFailFastOnRollback,
+			Watch:              deployWatch,
+			Force:              deployForce,
+			ApprovalsFile:      deployApprovalsFile,
+			ChangeSetID:        deployChangeSet,
+			OnFailure:          onFailureOverride,
+			DisableRollback:    disableRollbackOverride,
+			ParameterOverrides: parameterOverrides,
+			Timings:            deployTimings,
+			NoRollback:         deployNoRollback,
+		}
+
+		if deployWatchEventsOnly {
+			if len(args) < 2 {
+				return fmt.Errorf("--watch-events-only requires a stack name")
+			}
+			return d.WatchStack(ctx, args[1], contextName, options)
+		}
+
+		if deployPlan {
+			if len(args) > 1 {
+				return fmt.Errorf("--plan previews every stack in a context; omit the stack name")
+			}
+			return d.PlanAllStacks(ctx, contextName, options)
+		}
+
+		if deployChangeSet != "" && len(args) < 2 {
+			return fmt.Errorf("--changeset requires a stack name")
+		}
+
+		if deployDryRun && len(args) < 2 {
+			return fmt.Errorf("--dry-run requires a stack name; use --plan to preview every stack in a context")
+		}
 
 		if len(args) > 1 {
 			stackName := args[1]
-			return d.DeploySingleStack(ctx, stackName, contextName)
+			return d.DeploySingleStack(ctx, stackName, contextName, options)
 		}
-		return d.DeployAllStacks(ctx, contextName)
+		return d.DeployAllStacks(ctx, contextName, options)
 	},
 }
 
-// getDeployer returns the deployer instance, creating a default one if none is set
-func getDeployer(configFile string) deploy.Deployer {
+// isJSONEvents validates an --events flag value and reports whether JSON events were requested
+func isJSONEvents(format string) (bool, error) {
+	switch format {
+	case "", "text":
+		return false, nil
+	case "json":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported events format %q: must be text or json", format)
+	}
+}
+
+// gitCommit returns the short hash of the current git revision (injectable for testing)
+var gitCommit = func() (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine git commit: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// currentUser returns the name of the user running the deployment (injectable for testing)
+var currentUser = func() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine current user: %w", err)
+	}
+	return u.Username, nil
+}
+
+// buildChangeSetMetadata gathers the requested metadata fields for deployment changesets
+func buildChangeSetMetadata(fields []string, message string) (aws.ChangeSetMetadata, error) {
+	var metadata aws.ChangeSetMetadata
+
+	for _, field := range fields {
+		switch field {
+		case "commit":
+			commit, err := gitCommit()
+			if err != nil {
+				return aws.ChangeSetMetadata{}, err
+			}
+			metadata.Commit = commit
+		case "user":
+			username, err := currentUser()
+			if err != nil {
+				return aws.ChangeSetMetadata{}, err
+			}
+			metadata.User = username
+		case "message":
+			if message == "" {
+				return aws.ChangeSetMetadata{}, fmt.Errorf("--message is required when changeset metadata includes message")
+			}
+			metadata.Message = message
+		default:
+			return aws.ChangeSetMetadata{}, fmt.Errorf("unsupported changeset metadata field %q: must be commit, user or message", field)
+		}
+	}
+
+	return metadata, nil
+}
+
+// templateFileURI checks that a --template file exists and returns its file:// URI
+func templateFileURI(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve template path %s: %w", path, err)
+	}
+	if _, err := os.Stat(absPath); err != nil {
+		return "", fmt.Errorf("failed to read template file %s: %w", path, err)
+	}
+	return (&url.URL{Scheme: "file", Path: absPath}).String(), nil
+}
+
+// getDeployer returns the deployer instance, creating a default one if none is set. Stacks named
+// in templateOverrides are resolved with the given template URI instead of their configured one.
+func getDeployer(configFile string, templateOverrides map[string]string) deploy.Deployer {
 	if deployer != nil {
 		return deployer
 	}
 
 	provider, resolver := createResolver(configFile)
+	if deployStamp {
+		resolver.SetTemplateProcessor(resolve.NewStampingTemplateProcessor(resolve.NewCfnTemplateProcessor()))
+	}
+	for stackName, templateURI := range templateOverrides {
+		resolver.SetTemplateOverride(stackName, templateURI)
+	}
 	clientFactory := getClientFactory()
 	deployer = deploy.NewStackDeployer(clientFactory, provider, resolver)
 	return deployer
@@ -87,4 +392,31 @@ func SetDeployer(d deploy.Deployer) {
 
 func init() {
 	rootCmd.AddCommand(deployCmd)
+
+	deployCmd.Flags().DurationVar(&deployTimeout, "timeout", 0, "maximum time to wait for each stack (e.g. 30m); zero means no limit")
+	deployCmd.Flags().DurationVar(&deployPollInterval, "poll-interval", 0, "time between status checks while waiting for a stack; zero uses 5s")
+	deployCmd.Flags().StringVar(&deploySummaryFile, "summary-file", "", "record deployed parameters and tags to this file")
+	deployCmd.Flags().BoolVar(&deployContinueOnError, "continue-on-error", false, "continue deploying independent stacks when a stack fails or times out")
+	deployCmd.Flags().BoolVar(&deployRequireStacks, "require-stacks", false, "fail when the context has no stacks instead of doing nothing")
+	deployCmd.Flags().StringVar(&deployOutput, "output", "text", "output format: text or json")
+	deployCmd.Flags().BoolVar(&deployExplain, "explain", false, "print how each parameter value was resolved")
+	deployCmd.Flags().StringSliceVar(&deployMetadataFields, "changeset-metadata", nil, "record these fields in changeset descriptions: commit, user, message")
+	deployCmd.Flags().StringVar(&deployMessage, "message", "", "deployment message recorded when changeset metadata includes message")
+	deployCmd.Flags().BoolVar(&deployPruneParameters, "prune-parameters", false, "fail when configured parameters are not declared by the template")
+	deployCmd.Flags().BoolVar(&deploySkipAccountCheck, "skip-account-check", false, "deploy even if the credentials belong to a different account than the context")
+	deployCmd.Flags().BoolVar(&deployAllowProtected, "allow-protected", false, "allow deploying to a protected context")
+	deployCmd.Flags().BoolVar(&deployWatchEventsOnly, "watch-events-only", false, "stream events of an operation already in progress on the stack without changing it")
+	deployCmd.Flags().BoolVar(&deployPlan, "plan", false, "validate and preview the changes to every stack in the context without deploying")
+	deployCmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "create and show the changeset for a stack, then delete it without deploying")
+	deployCmd.Flags().BoolVar(&deployFailFastOnRollback, "fail-fast-on-rollback", false, "fail as soon as a stack starts rolling back instead of waiting for the rollback to finish")
+	deployCmd.Flags().BoolVar(&deployForce, "force", false, "deploy stacks even when their changes lack the required approvals")
+	deployCmd.Flags().StringVar(&deployApprovalsFile, "approvals-file", "", "file of approvals recorded with 'stackaroo approve' (default stackaroo-approvals.json)")
+	deployCmd.Flags().StringVar(&deployChangeSet, "changeset", "", "execute this changeset saved by 'stackaroo diff --save-changeset' instead of creating one")
+	deployCmd.Flags().BoolVar(&deployWatch, "watch", false, "show a live view of resource statuses during stack operations on a terminal")
+	deployCmd.Flags().StringVar(&deployEvents, "events", "text", "stack event format: text or json (one JSON object per line)")
+	deployCmd.Flags().StringArrayVar(&deployParameters, "parameter", nil, "override a resolved parameter value as key=value (repeatable)")
+	deployCmd.Flags().BoolVar(&deployTimings, "timings", false, "print how long each phase of each stack took when the run ends")
+	deployCmd.Flags().BoolVar(&deployNoRollback, "no-rollback", false, "leave stacks whose update fails in their failed state instead of rolling back")
+	deployCmd.Flags().BoolVar(&deployStamp, "stamp", false, "record a template hash, timestamp and stackaroo version in each template's Metadata")
+	deployCmd.Flags().StringVar(&deployTemplate, "template", "", "deploy the stack with this local template file instead of its configured template")
 }
@@ -9,8 +9,11 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/deploy"
+	"codeberg.org/orien/stackaroo/internal/prompt"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -119,7 +122,7 @@ Resources:
 
 	// Mock deployer that expects two deployments
 	mockDeployer := &deploy.MockDeployer{}
-	mockDeployer.On("DeployAllStacks", mock.Anything, "test-context").Return(nil).Once()
+	mockDeployer.On("DeployAllStacks", mock.Anything, "test-context", deploy.Options{}).Return(nil).Once()
 
 	oldDeployer := deployer
 	SetDeployer(mockDeployer)
@@ -163,7 +166,7 @@ stacks: {}
 
 	// Mock deployer that expects DeployAllStacks call (will handle no stacks internally)
 	mockDeployer := &deploy.MockDeployer{}
-	mockDeployer.On("DeployAllStacks", mock.Anything, "empty-context").Return(nil).Once()
+	mockDeployer.On("DeployAllStacks", mock.Anything, "empty-context", deploy.Options{}).Return(nil).Once()
 
 	oldDeployer := deployer
 	SetDeployer(mockDeployer)
@@ -212,7 +215,7 @@ stacks:
 
 	// Set up mock deployer that returns an error
 	mockDeployer := &deploy.MockDeployer{}
-	mockDeployer.On("DeploySingleStack", mock.Anything, "test-stack", "test").Return(errors.New("deployment failed"))
+	mockDeployer.On("DeploySingleStack", mock.Anything, "test-stack", "test", deploy.Options{}).Return(errors.New("deployment failed"))
 
 	oldDeployer := deployer
 	SetDeployer(mockDeployer)
@@ -242,7 +245,7 @@ func TestDeployCommand_AcceptsOneOrTwoArgs(t *testing.T) {
 
 	// Mock deployer for valid calls
 	mockDeployer := &deploy.MockDeployer{}
-	mockDeployer.On("DeployAllStacks", mock.Anything, "dev").Return(nil).Once()
+	mockDeployer.On("DeployAllStacks", mock.Anything, "dev", deploy.Options{}).Return(nil).Once()
 
 	oldDeployer := deployer
 	SetDeployer(mockDeployer)
@@ -306,9 +309,9 @@ stacks:
 	mockDeployer := &deploy.MockDeployer{}
 
 	// Expect specific calls with exact argument matching
-	mockDeployer.On("DeploySingleStack", mock.Anything, "stack-1", "test").Return(nil).Once()
+	mockDeployer.On("DeploySingleStack", mock.Anything, "stack-1", "test", deploy.Options{}).Return(nil).Once()
 
-	mockDeployer.On("DeploySingleStack", mock.Anything, "stack-2", "test").Return(errors.New("second deployment failed")).Once()
+	mockDeployer.On("DeploySingleStack", mock.Anything, "stack-2", "test", deploy.Options{}).Return(errors.New("second deployment failed")).Once()
 
 	oldDeployer := deployer
 	SetDeployer(mockDeployer)
@@ -396,7 +399,7 @@ stacks:
 	// Set up mock deployer that expects config-resolved values
 	mockDeployer := &deploy.MockDeployer{}
 	// Expect DeploySingleStack call for vpc in dev context
-	mockDeployer.On("DeploySingleStack", mock.Anything, "vpc", "dev").Return(nil)
+	mockDeployer.On("DeploySingleStack", mock.Anything, "vpc", "dev", deploy.Options{}).Return(nil)
 
 	oldDeployer := deployer
 	SetDeployer(mockDeployer)
@@ -477,7 +480,7 @@ stacks:
 
 	// This test will fail because current implementation doesn't resolve dependencies
 	// We expect DeploySingleStack to be called for the app stack
-	mockDeployer.On("DeploySingleStack", mock.Anything, "app", "test").Return(nil)
+	mockDeployer.On("DeploySingleStack", mock.Anything, "app", "test", deploy.Options{}).Return(nil)
 
 	oldDeployer := deployer
 	SetDeployer(mockDeployer)
@@ -552,7 +555,7 @@ stacks:
 
 	// Current implementation only deploys the directly requested stack
 	// Transitive dependency resolution is not yet implemented
-	mockDeployer.On("DeploySingleStack", mock.Anything, "app", "test").Return(nil).Once()
+	mockDeployer.On("DeploySingleStack", mock.Anything, "app", "test", deploy.Options{}).Return(nil).Once()
 
 	oldDeployer := deployer
 	SetDeployer(mockDeployer)
@@ -587,3 +590,658 @@ func findCommand(parent *cobra.Command, name string) *cobra.Command {
 	}
 	return nil
 }
+
+func TestDeployCommand_TimeoutAndContinueOnErrorFlags(t *testing.T) {
+	// Test that --timeout and --continue-on-error are mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() {
+		deployTimeout = 0
+		deployContinueOnError = false
+	}()
+
+	expectedOptions := deploy.Options{StackTimeout: 15 * time.Minute, ContinueOnError: true}
+	mockDeployer.On("DeployAllStacks", mock.Anything, "dev", expectedOptions).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--timeout", "15m", "--continue-on-error"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_RequireStacksFlag(t *testing.T) {
+	// Test that --require-stacks is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployRequireStacks = false }()
+
+	mockDeployer.On("DeployAllStacks", mock.Anything, "dev", deploy.Options{RequireStacks: true}).
+		Return(errors.New("no stacks found in context dev")).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--require-stacks"})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no stacks found in context dev")
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_OutputFlag(t *testing.T) {
+	// Test that --output json is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployOutput = "text" }()
+
+	mockDeployer.On("DeploySingleStack", mock.Anything, "vpc", "dev", deploy.Options{JSONOutput: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "vpc", "--output", "json"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_NoRollbackFlag(t *testing.T) {
+	// Test that --no-rollback is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployNoRollback = false }()
+
+	mockDeployer.On("DeploySingleStack", mock.Anything, "vpc", "dev", deploy.Options{NoRollback: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "vpc", "--no-rollback"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_StampFlag(t *testing.T) {
+	deployCmd := findCommand(rootCmd, "deploy")
+	require.NotNil(t, deployCmd)
+
+	stampFlag := deployCmd.Flags().Lookup("stamp")
+	require.NotNil(t, stampFlag, "deploy should have a --stamp flag")
+	assert.Equal(t, "false", stampFlag.DefValue)
+}
+
+func TestDeployCommand_ExplainFlag(t *testing.T) {
+	// Test that --explain is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployExplain = false }()
+
+	mockDeployer.On("DeploySingleStack", mock.Anything, "vpc", "dev", deploy.Options{Explain: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "vpc", "--explain"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_PruneParametersFlag(t *testing.T) {
+	// Test that --prune-parameters is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployPruneParameters = false }()
+
+	mockDeployer.On("DeploySingleStack", mock.Anything, "vpc", "dev", deploy.Options{PruneParameters: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "vpc", "--prune-parameters"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_SkipAccountCheckFlag(t *testing.T) {
+	// Test that --skip-account-check is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deploySkipAccountCheck = false }()
+
+	mockDeployer.On("DeployAllStacks", mock.Anything, "dev", deploy.Options{SkipAccountCheck: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--skip-account-check"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_AllowProtectedFlag(t *testing.T) {
+	// Test that --allow-protected is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployAllowProtected = false }()
+
+	mockDeployer.On("DeploySingleStack", mock.Anything, "vpc", "prod", deploy.Options{AllowProtected: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "prod", "vpc", "--allow-protected"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_EventsFlag(t *testing.T) {
+	// Test that --events json is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployEvents = "text" }()
+
+	mockDeployer.On("DeployAllStacks", mock.Anything, "dev", deploy.Options{JSONEvents: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--events", "json"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_EventsFlag_RejectsUnknownFormat(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployEvents = "text" }()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--events", "xml"})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported events format "xml"`)
+	mockDeployer.AssertNotCalled(t, "DeployAllStacks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployCommand_WatchEventsOnlyFlag(t *testing.T) {
+	// Test that --watch-events-only watches the stack instead of deploying it
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployWatchEventsOnly = false }()
+
+	mockDeployer.On("WatchStack", mock.Anything, "app", "dev", deploy.Options{}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "app", "--watch-events-only"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+	mockDeployer.AssertNotCalled(t, "DeploySingleStack", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployCommand_WatchEventsOnlyFlag_RequiresStackName(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployWatchEventsOnly = false }()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--watch-events-only"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--watch-events-only requires a stack name")
+	mockDeployer.AssertNotCalled(t, "DeployAllStacks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployCommand_PlanFlag(t *testing.T) {
+	// Test that --plan previews the context instead of deploying it
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployPlan = false; deployContinueOnError = false }()
+
+	mockDeployer.On("PlanAllStacks", mock.Anything, "prod", deploy.Options{ContinueOnError: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "prod", "--plan", "--continue-on-error"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+	mockDeployer.AssertNotCalled(t, "DeployAllStacks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployCommand_PlanFlag_RejectsStackName(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployPlan = false }()
+
+	rootCmd.SetArgs([]string{"deploy", "prod", "app", "--plan"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--plan previews every stack in a context; omit the stack name")
+	mockDeployer.AssertNotCalled(t, "DeploySingleStack", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployCommand_DryRunFlag(t *testing.T) {
+	// Test that --dry-run is mapped onto deploy options for a single stack
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployDryRun = false }()
+
+	mockDeployer.On("DeploySingleStack", mock.Anything, "app", "prod", deploy.Options{DryRun: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "prod", "app", "--dry-run"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_DryRunFlag_RequiresStackName(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployDryRun = false }()
+
+	rootCmd.SetArgs([]string{"deploy", "prod", "--dry-run"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--dry-run requires a stack name; use --plan to preview every stack in a context")
+	mockDeployer.AssertNotCalled(t, "DeployAllStacks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployCommand_FailFastOnRollbackFlag(t *testing.T) {
+	// Test that --fail-fast-on-rollback is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployFailFastOnRollback = false }()
+
+	mockDeployer.On("DeployAllStacks", mock.Anything, "dev", deploy.Options{FailFastOnRollback: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--fail-fast-on-rollback"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_ApprovalFlags(t *testing.T) {
+	// Test that --force and --approvals-file are mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() {
+		deployForce = false
+		deployApprovalsFile = ""
+	}()
+
+	mockDeployer.On("DeployAllStacks", mock.Anything, "prod", deploy.Options{Force: true, ApprovalsFile: "approvals.json"}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "prod", "--force", "--approvals-file", "approvals.json"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_WatchFlag(t *testing.T) {
+	// Test that --watch is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployWatch = false }()
+
+	mockDeployer.On("DeploySingleStack", mock.Anything, "vpc", "dev", deploy.Options{Watch: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "vpc", "--watch"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_WatchRejectsJSONEvents(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() {
+		deployWatch = false
+		deployEvents = "text"
+	}()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--watch", "--events", "json"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--watch cannot be combined with --events json")
+	mockDeployer.AssertNotCalled(t, "DeployAllStacks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployCommand_PollIntervalFlag(t *testing.T) {
+	// Test that --poll-interval is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployPollInterval = 0 }()
+
+	mockDeployer.On("DeployAllStacks", mock.Anything, "dev", deploy.Options{PollInterval: 10 * time.Second}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--poll-interval", "10s"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_ChangeSetMetadataFlags(t *testing.T) {
+	// Test that --changeset-metadata and --message are gathered into deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+
+	oldGitCommit, oldCurrentUser := gitCommit, currentUser
+	gitCommit = func() (string, error) { return "a1b2c3d", nil }
+	currentUser = func() (string, error) { return "alice", nil }
+	defer func() {
+		gitCommit, currentUser = oldGitCommit, oldCurrentUser
+		deployMetadataFields = nil
+		deployMessage = ""
+	}()
+
+	expected := deploy.Options{
+		ChangeSetMetadata: aws.ChangeSetMetadata{Commit: "a1b2c3d", User: "alice", Message: "Rotate certificates"},
+	}
+	mockDeployer.On("DeploySingleStack", mock.Anything, "vpc", "dev", expected).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "vpc", "--changeset-metadata", "commit,user,message", "--message", "Rotate certificates"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_ParameterFlags(t *testing.T) {
+	// Test that repeated --parameter flags are gathered into deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployParameters = nil }()
+
+	expected := deploy.Options{
+		ParameterOverrides: map[string]string{"ImageTag": "abc123", "Replicas": "2"},
+	}
+	mockDeployer.On("DeploySingleStack", mock.Anything, "app", "dev", expected).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "app", "--parameter", "ImageTag=abc123", "--parameter", "Replicas=2"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_TimingsFlag(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployTimings = false }()
+
+	mockDeployer.On("DeployAllStacks", mock.Anything, "dev", deploy.Options{Timings: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--timings"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_YesFlag(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() {
+		assumeYes = false
+		deployAllowProtected = false
+		prompt.SetAutoApprove(false)
+	}()
+
+	var autoApproved bool
+	mockDeployer.On("DeploySingleStack", mock.Anything, "vpc", "prod", deploy.Options{AllowProtected: true}).
+		Run(func(mock.Arguments) { autoApproved = prompt.AutoApprove() }).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "prod", "vpc", "--allow-protected", "-y"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.True(t, autoApproved, "--yes should turn on auto-approval before the deployer runs")
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestBuildChangeSetMetadata_Errors(t *testing.T) {
+	_, err := buildChangeSetMetadata([]string{"message"}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--message is required")
+
+	_, err = buildChangeSetMetadata([]string{"branch"}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported changeset metadata field "branch"`)
+
+	oldGitCommit := gitCommit
+	gitCommit = func() (string, error) { return "", errors.New("not a git repository") }
+	defer func() { gitCommit = oldGitCommit }()
+
+	_, err = buildChangeSetMetadata([]string{"commit"}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a git repository")
+}
+
+func TestBuildChangeSetMetadata_SelectsFields(t *testing.T) {
+	oldCurrentUser := currentUser
+	currentUser = func() (string, error) { return "alice", nil }
+	defer func() { currentUser = oldCurrentUser }()
+
+	metadata, err := buildChangeSetMetadata([]string{"user"}, "ignored")
+
+	require.NoError(t, err)
+	assert.Equal(t, aws.ChangeSetMetadata{User: "alice"}, metadata)
+}
+
+func TestDeployCommand_OutputFlag_RejectsUnknownFormat(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployOutput = "text" }()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "vpc", "--output", "yaml"})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported output format "yaml"`)
+	mockDeployer.AssertNotCalled(t, "DeploySingleStack", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployCommand_ChangeSetFlag(t *testing.T) {
+	// Test that --changeset is mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployChangeSet = "" }()
+
+	mockDeployer.On("DeploySingleStack", mock.Anything, "app", "prod", deploy.Options{ChangeSetID: "saved-changeset"}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "prod", "app", "--changeset", "saved-changeset"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_ChangeSetRequiresStackName(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployChangeSet = "" }()
+
+	rootCmd.SetArgs([]string{"deploy", "prod", "--changeset", "saved-changeset"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--changeset requires a stack name")
+	mockDeployer.AssertNotCalled(t, "DeployAllStacks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployCommand_OnFailureOverrideFlags(t *testing.T) {
+	// Test that the root-level failure overrides are mapped onto deploy options
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() {
+		onFailureOverride = ""
+		disableRollbackOverride = false
+	}()
+
+	mockDeployer.On("DeployAllStacks", mock.Anything, "dev", deploy.Options{OnFailure: "DO_NOTHING"}).Return(nil).Once()
+	mockDeployer.On("DeployAllStacks", mock.Anything, "dev", deploy.Options{DisableRollback: true}).Return(nil).Once()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--on-failure", "DO_NOTHING"})
+	require.NoError(t, rootCmd.Execute())
+
+	onFailureOverride = ""
+	rootCmd.SetArgs([]string{"deploy", "dev", "--disable-rollback"})
+	require.NoError(t, rootCmd.Execute())
+
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_TemplateFlag_DeploysOverrideTemplate(t *testing.T) {
+	// Test that --template replaces the configured template of the stack being deployed
+	tmpDir := t.TempDir()
+	// The override path is relative to the working directory, like the default config file
+	t.Chdir(tmpDir)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "stackaroo.yaml"), []byte(`
+project: test-project
+contexts:
+  dev:
+    region: us-east-1
+stacks:
+  app:
+    template: templates/app.yaml
+`), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "templates"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "templates", "app.yaml"), []byte("Resources:\n  Queue:\n    Type: AWS::SQS::Queue\n"), 0644))
+	overrideTemplate := "Resources:\n  Queue:\n    Type: AWS::SQS::Queue\n  Topic:\n    Type: AWS::SNS::Topic\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app-experimental.yaml"), []byte(overrideTemplate), 0644))
+
+	// A dry run of a new stack validates the template it would deploy
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockCfnOps.On("StackExists", mock.Anything, "app").Return(false, nil)
+	mockCfnOps.On("ValidateTemplate", mock.Anything, overrideTemplate).Return(nil)
+
+	oldFactory := clientFactory
+	clientFactory = mockFactory
+	oldDeployer := deployer
+	SetDeployer(nil)
+	defer func() {
+		clientFactory = oldFactory
+		SetDeployer(oldDeployer)
+		deployTemplate = ""
+		deployDryRun = false
+		deploySkipAccountCheck = false
+	}()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "app", "--template", "app-experimental.yaml", "--dry-run", "--skip-account-check"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeployCommand_TemplateFlag_RequiresSingleStack(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployTemplate = "" }()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "--template", "app.yaml"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--template requires a stack name; it cannot be used when deploying multiple stacks")
+	mockDeployer.AssertNotCalled(t, "DeployAllStacks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployCommand_TemplateFlag_MissingFile(t *testing.T) {
+	mockDeployer := &deploy.MockDeployer{}
+
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	defer SetDeployer(oldDeployer)
+	defer func() { deployTemplate = "" }()
+
+	rootCmd.SetArgs([]string{"deploy", "dev", "app", "--template", filepath.Join(t.TempDir(), "missing.yaml")})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read template file")
+	mockDeployer.AssertNotCalled(t, "DeploySingleStack", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
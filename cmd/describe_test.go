@@ -101,6 +101,11 @@ stacks:
 	require.NoError(t, err)
 	templateContent := `{
 		"AWSTemplateFormatVersion": "2010-09-09",
+		"Parameters": {
+			"Environment": {
+				"Type": "String"
+			}
+		},
 		"Resources": {
 			"TestResource": {
 				"Type": "AWS::CloudFormation::WaitConditionHandle"
@@ -197,6 +202,11 @@ stacks:
 	require.NoError(t, err)
 	templateContent := `{
 		"AWSTemplateFormatVersion": "2010-09-09",
+		"Parameters": {
+			"Environment": {
+				"Type": "String"
+			}
+		},
 		"Resources": {
 			"TestResource": {
 				"Type": "AWS::CloudFormation::WaitConditionHandle"
@@ -328,6 +338,11 @@ stacks:
 	require.NoError(t, err)
 	templateContent := `{
 		"AWSTemplateFormatVersion": "2010-09-09",
+		"Parameters": {
+			"Environment": {
+				"Type": "String"
+			}
+		},
 		"Resources": {
 			"TestResource": {
 				"Type": "AWS::CloudFormation::WaitConditionHandle"
@@ -7,15 +7,28 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
+	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/diff"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"codeberg.org/orien/stackaroo/internal/snapshot"
 	"github.com/spf13/cobra"
 )
 
 var (
-	diffTemplateOnly   bool
-	diffParametersOnly bool
-	diffTagsOnly       bool
+	diffTemplateOnly    bool
+	diffParametersOnly  bool
+	diffTagsOnly        bool
+	diffSinceLast       string
+	diffExplain         bool
+	diffDetectRenames   bool
+	diffOutput          string
+	diffSaveChangeSet   bool
+	diffAll             bool
+	diffParameters      []string
+	diffRefreshInterval time.Duration
 
 	// differ can be injected for testing
 	differ diff.Differ
@@ -35,19 +48,69 @@ the current configuration. It compares:
 • Tag differences (current vs. resolved tags)
 • Resource-level changes (when possible via AWS ChangeSets)
 
+Use --since-last with a summary file recorded by 'stackaroo deploy --summary-file'
+to compare parameters and tags with what stackaroo last deployed, rather than
+the live stack. This reveals configuration changes even if the live stack was
+modified outside stackaroo.
+
+Use --explain to print how each parameter was resolved: the resolver type, its
+inputs, any AWS calls made and the final value. Secret values are masked.
+
+Use --output json to print the diff as a JSON document for tooling, for example
+to gate deployments in CI. Secret values are masked in JSON output too.
+
+Use --save-changeset to keep the changeset created for the diff instead of
+deleting it, and print its ID. Deploy exactly those changes with
+'stackaroo deploy <context> <stack-name> --changeset <id>'.
+
+Use --parameter key=value, repeated as needed, to try a parameter value without
+editing configuration. It replaces the resolved value of a matching parameter,
+and the diff marks the parameter as overridden.
+
+Use --all in place of a stack name to diff every stack in the context in
+dependency order, followed by a summary of changed, new and unchanged stacks.
+The command then exits with a non-zero status if any stack has changes, making
+it suitable as a drift gate in CI/CD pipelines.
+
+While CloudFormation builds the changeset, its status and the time elapsed are
+shown on standard error: a spinner on a terminal, or a status line per check
+otherwise. Use --refresh-interval to change how often the status is checked.
+
 Examples:
   stackaroo diff dev vpc                        # Show all changes
   stackaroo diff prod vpc --template            # Template diff only
-  stackaroo diff dev vpc --parameters           # Parameter diff only`,
-	Args: cobra.ExactArgs(2),
+  stackaroo diff dev vpc --parameters           # Parameter diff only
+  stackaroo diff dev vpc --since-last deploy-summary.json
+  stackaroo diff dev app --explain              # Show how parameters were resolved
+  stackaroo diff prod app --output json         # Machine-readable diff
+  stackaroo diff prod app --save-changeset      # Keep the changeset for deploy --changeset
+  stackaroo diff prod --all                     # Diff every stack in the context
+  stackaroo diff dev app --parameter ImageTag=abc123`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if diffAll {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		contextName := args[0]
-		stackName := args[1]
 		ctx := context.Background()
 
 		configFile, _ := cmd.Flags().GetString("config")
 
-		return diffSingleStack(ctx, stackName, contextName, configFile)
+		if diffRefreshInterval <= 0 {
+			return fmt.Errorf("--refresh-interval must be greater than zero")
+		}
+
+		overrides, err := parseParameterOverrides(diffParameters)
+		if err != nil {
+			return err
+		}
+
+		if diffAll {
+			return diffAllStacks(ctx, contextName, configFile, overrides)
+		}
+		return diffSingleStack(ctx, args[1], contextName, configFile, overrides)
 	},
 }
 
@@ -68,7 +131,15 @@ func SetDiffer(d diff.Differ) {
 }
 
 // diffSingleStack handles diff using configuration file
-func diffSingleStack(ctx context.Context, stackName, contextName, configFile string) error {
+func diffSingleStack(ctx context.Context, stackName, contextName, configFile string, overrides map[string]string) error {
+	jsonOutput, err := isJSONOutput(diffOutput)
+	if err != nil {
+		return err
+	}
+	if diffSaveChangeSet && (diffTemplateOnly || diffParametersOnly || diffTagsOnly) {
+		return fmt.Errorf("--save-changeset cannot be combined with --template, --parameters or --tags")
+	}
+
 	_, resolver := createResolver(configFile)
 
 	// Resolve the target stack
@@ -76,23 +147,40 @@ func diffSingleStack(ctx context.Context, stackName, contextName, configFile str
 	if err != nil {
 		return err
 	}
+	if err := resolve.ApplyParameterOverrides(targetStack, overrides); err != nil {
+		return err
+	}
 
-	// Create diff options based on command flags
-	options := diff.Options{
-		TemplateOnly:   diffTemplateOnly,
-		ParametersOnly: diffParametersOnly,
-		TagsOnly:       diffTagsOnly,
+	if diffExplain && !jsonOutput {
+		fmt.Print(resolve.FormatExplanation(targetStack))
+	}
+
+	options, err := diffOptions(contextName, stackName)
+	if err != nil {
+		return err
 	}
 
 	// Get or create differ
 	d := getDiffer()
 
 	// Perform the diff
+	done := showChangeSetProgress(stackName)
 	result, err := d.DiffStack(ctx, targetStack, options)
+	done()
 	if err != nil {
 		return err
 	}
 
+	// Print only the JSON document so tooling can parse the output directly
+	if jsonOutput {
+		encoded, err := result.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Print(encoded)
+		return nil
+	}
+
 	// Output the results using plain text
 	fmt.Print(result.String())
 
@@ -106,9 +194,127 @@ func diffSingleStack(ctx context.Context, stackName, contextName, configFile str
 			diff.Highlight(stackName), diff.Highlight(contextName))
 	}
 
+	if diffSaveChangeSet && result.ChangeSet != nil {
+		fmt.Printf("\nSaved changeset %s\nDeploy it with: stackaroo deploy %s %s --changeset %s\n",
+			result.ChangeSet.ChangeSetID, contextName, stackName, result.ChangeSet.ChangeSetID)
+	}
+
 	return nil
 }
 
+// diffAllStacks diffs every stack in a context in dependency order and summarises the results.
+// It returns a diff.ChangesDetectedError when any stack has changes.
+func diffAllStacks(ctx context.Context, contextName, configFile string, overrides map[string]string) error {
+	if jsonOutput, err := isJSONOutput(diffOutput); err != nil {
+		return err
+	} else if jsonOutput {
+		return fmt.Errorf("--output json cannot be combined with --all")
+	}
+	if diffSaveChangeSet {
+		return fmt.Errorf("--save-changeset cannot be combined with --all")
+	}
+
+	provider, resolver := createResolver(configFile)
+
+	stackNames, err := provider.ListStacks(contextName)
+	if err != nil {
+		return err
+	}
+	if len(stackNames) == 0 {
+		fmt.Printf("No stacks found in context %s\n", diff.Highlight(contextName))
+		return nil
+	}
+
+	order, err := resolver.GetDependencyOrder(contextName, stackNames)
+	if err != nil {
+		return err
+	}
+
+	d := getDiffer()
+	var changed, created, unchanged int
+	for _, stackName := range order {
+		// Resolve each stack in turn so parameters see the outputs of its dependencies
+		stack, err := resolver.ResolveStack(ctx, contextName, stackName)
+		if err != nil {
+			return err
+		}
+		if err := resolve.ApplyParameterOverrides(stack, overrides); err != nil {
+			return err
+		}
+
+		if diffExplain {
+			fmt.Print(resolve.FormatExplanation(stack))
+		}
+
+		options, err := diffOptions(contextName, stackName)
+		if err != nil {
+			return err
+		}
+
+		done := showChangeSetProgress(stackName)
+		result, err := d.DiffStack(ctx, stack, options)
+		done()
+		if err != nil {
+			return fmt.Errorf("failed to diff stack %s: %w", stackName, err)
+		}
+		fmt.Print(result.String())
+
+		switch {
+		case !result.StackExists:
+			created++
+		case result.HasChanges():
+			changed++
+		default:
+			unchanged++
+		}
+	}
+
+	fmt.Printf("\nSummary for context %s: %d changed, %d new, %d unchanged\n",
+		diff.Highlight(contextName), changed, created, unchanged)
+
+	if changed+created > 0 {
+		return diff.ChangesDetectedError{Context: contextName, Changed: changed, New: created}
+	}
+	return nil
+}
+
+// showChangeSetProgress reports the status of the changeset created for a stack's diff on
+// standard error, and returns a function clearing the report once the diff is complete
+func showChangeSetProgress(stackName string) func() {
+	printer := diff.NewChangeSetProgressPrinter(os.Stderr, stackName, diff.IsInteractiveTerminal(os.Stderr))
+	getClientFactory().SetWaitConfig(aws.WaitConfig{
+		ChangeSetPollInterval: diffRefreshInterval,
+		ChangeSetProgress:     printer.Update,
+	})
+	return printer.Done
+}
+
+// diffOptions builds the diff options for a stack from the command flags
+func diffOptions(contextName, stackName string) (diff.Options, error) {
+	options := diff.Options{
+		TemplateOnly:   diffTemplateOnly,
+		ParametersOnly: diffParametersOnly,
+		TagsOnly:       diffTagsOnly,
+		DetectRenames:  diffDetectRenames,
+		KeepChangeSet:  diffSaveChangeSet,
+	}
+
+	// Compare against the last recorded deployment if requested
+	if diffSinceLast != "" {
+		summary, err := snapshot.Load(diffSinceLast)
+		if err != nil {
+			return diff.Options{}, err
+		}
+		baseline, ok := summary.Find(contextName, stackName)
+		if !ok {
+			return diff.Options{}, fmt.Errorf("no recorded deployment of stack %s in context %s found in %s", stackName, contextName, diffSinceLast)
+		}
+		options.Baseline = baseline
+	}
+
+	return options, nil
+}
+
 func init() {
 	rootCmd.AddCommand(diffCmd)
 
@@ -116,4 +322,12 @@ func init() {
 	diffCmd.Flags().BoolVar(&diffTemplateOnly, "template", false, "show only template differences")
 	diffCmd.Flags().BoolVar(&diffParametersOnly, "parameters", false, "show only parameter differences")
 	diffCmd.Flags().BoolVar(&diffTagsOnly, "tags", false, "show only tag differences")
+	diffCmd.Flags().StringVar(&diffSinceLast, "since-last", "", "compare parameters and tags with the deployment recorded in this summary file")
+	diffCmd.Flags().BoolVar(&diffExplain, "explain", false, "print how each parameter value was resolved")
+	diffCmd.Flags().StringVar(&diffOutput, "output", "text", "output format: text or json")
+	diffCmd.Flags().BoolVar(&diffSaveChangeSet, "save-changeset", false, "keep the changeset created for the diff and print its ID for deploy --changeset")
+	diffCmd.Flags().BoolVar(&diffAll, "all", false, "diff every stack in the context and exit non-zero if any has changes")
+	diffCmd.Flags().StringArrayVar(&diffParameters, "parameter", nil, "override a resolved parameter value as key=value (repeatable)")
+	diffCmd.Flags().BoolVar(&diffDetectRenames, "detect-renames", false, "report removed and added resources with identical definitions as renames")
+	diffCmd.Flags().DurationVar(&diffRefreshInterval, "refresh-interval", aws.DefaultChangeSetPollInterval, "how often to check and show the status of the changeset while it is built")
 }
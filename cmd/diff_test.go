@@ -7,10 +7,16 @@ package cmd
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
+	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/diff"
 	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/snapshot"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -38,6 +44,10 @@ func TestDiffCmd_Structure(t *testing.T) {
 	require.NotNil(t, tagsFlag)
 	assert.Equal(t, "false", tagsFlag.DefValue)
 
+	explainFlag := flags.Lookup("explain")
+	require.NotNil(t, explainFlag)
+	assert.Equal(t, "false", explainFlag.DefValue)
+
 }
 
 func TestDiffCmd_RequiredArgs(t *testing.T) {
@@ -367,6 +377,14 @@ func resetDiffFlags() {
 	diffTemplateOnly = false
 	diffParametersOnly = false
 	diffTagsOnly = false
+	diffSinceLast = ""
+	diffExplain = false
+	diffDetectRenames = false
+	diffOutput = "text"
+	diffSaveChangeSet = false
+	diffAll = false
+	diffParameters = nil
+	diffRefreshInterval = aws.DefaultChangeSetPollInterval
 }
 
 func TestMain(m *testing.M) {
@@ -374,3 +392,381 @@ func TestMain(m *testing.M) {
 	resetDiffFlags()
 	m.Run()
 }
+
+func TestDiffCommand_SinceLast_UsesRecordedBaseline(t *testing.T) {
+	configContent := `
+project: test-project
+contexts:
+  dev:
+    region: us-east-1
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+    parameters:
+      CidrBlock: 10.1.0.0/16
+`
+	tmpDir := createTempConfigWithTemplates(t, configContent, nil)
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "templates"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "templates", "vpc.yaml"), []byte(`{"Parameters": {"CidrBlock": {"Type": "String"}}, "Resources": {}}`), 0644))
+
+	summaryFile := filepath.Join(tmpDir, "summary.json")
+	summary := &snapshot.Summary{}
+	summary.Record(snapshot.StackSnapshot{
+		StackName:  "vpc",
+		Context:    "dev",
+		Parameters: map[string]string{"CidrBlock": "10.0.0.0/16"},
+	})
+	require.NoError(t, summary.Save(summaryFile))
+
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() {
+		require.NoError(t, os.Chdir(oldWd))
+	}()
+
+	mockDiffer := &diff.MockDiffer{}
+	originalDiffer := differ
+	SetDiffer(mockDiffer)
+	defer SetDiffer(originalDiffer)
+	defer resetDiffFlags()
+
+	mockDiffer.On("DiffStack", mock.Anything, mock.Anything, mock.MatchedBy(func(options diff.Options) bool {
+		return options.Baseline != nil && options.Baseline.Parameters["CidrBlock"] == "10.0.0.0/16"
+	})).Return(&diff.Result{StackName: "vpc", Context: "dev", StackExists: true}, nil)
+
+	rootCmd.SetArgs([]string{"diff", "dev", "vpc", "--since-last", summaryFile})
+	err = rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDiffer.AssertExpectations(t)
+}
+
+func TestDiffCommand_SinceLast_MissingRecord(t *testing.T) {
+	configContent := `
+project: test-project
+contexts:
+  dev:
+    region: us-east-1
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+`
+	tmpDir := createTempConfigWithTemplates(t, configContent, []string{"vpc.yaml"})
+
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() {
+		require.NoError(t, os.Chdir(oldWd))
+	}()
+
+	mockDiffer := &diff.MockDiffer{}
+	originalDiffer := differ
+	SetDiffer(mockDiffer)
+	defer SetDiffer(originalDiffer)
+	defer resetDiffFlags()
+
+	rootCmd.SetArgs([]string{"diff", "dev", "vpc", "--since-last", filepath.Join(tmpDir, "summary.json")})
+	err = rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no recorded deployment of stack vpc in context dev")
+	mockDiffer.AssertNotCalled(t, "DiffStack", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDiffCommand_SaveChangeSet_KeepsChangeSet(t *testing.T) {
+	configContent := `
+project: test-project
+contexts:
+  dev:
+    region: us-east-1
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+`
+	tmpDir := createTempConfigWithTemplates(t, configContent, []string{"vpc.yaml"})
+
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() {
+		require.NoError(t, os.Chdir(oldWd))
+	}()
+
+	mockDiffer := &diff.MockDiffer{}
+	originalDiffer := differ
+	SetDiffer(mockDiffer)
+	defer SetDiffer(originalDiffer)
+	defer resetDiffFlags()
+
+	mockDiffer.On("DiffStack", mock.Anything, mock.Anything, diff.Options{KeepChangeSet: true}).Return(&diff.Result{
+		StackName:   "vpc",
+		Context:     "dev",
+		StackExists: true,
+		ChangeSet:   &aws.ChangeSetInfo{ChangeSetID: "saved-changeset"},
+	}, nil)
+
+	rootCmd.SetArgs([]string{"diff", "dev", "vpc", "--save-changeset"})
+	err = rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDiffer.AssertExpectations(t)
+}
+
+func TestDiffCommand_SaveChangeSet_RejectsFilters(t *testing.T) {
+	mockDiffer := &diff.MockDiffer{}
+	originalDiffer := differ
+	SetDiffer(mockDiffer)
+	defer SetDiffer(originalDiffer)
+	defer resetDiffFlags()
+
+	rootCmd.SetArgs([]string{"diff", "dev", "vpc", "--save-changeset", "--template"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--save-changeset cannot be combined with --template, --parameters or --tags")
+	mockDiffer.AssertNotCalled(t, "DiffStack", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDiffCmd_AllArgs(t *testing.T) {
+	defer resetDiffFlags()
+	diffAll = true
+
+	assert.NoError(t, diffCmd.Args(diffCmd, []string{"dev"}))
+	assert.Error(t, diffCmd.Args(diffCmd, []string{"dev", "vpc"}), "a stack name cannot be combined with --all")
+}
+
+func TestDiffCommand_All(t *testing.T) {
+	configContent := `
+project: test-project
+contexts:
+  dev:
+    region: us-east-1
+stacks:
+  app:
+    template: templates/app.yaml
+    depends_on: [vpc]
+  queue:
+    template: templates/queue.yaml
+  vpc:
+    template: templates/vpc.yaml
+`
+
+	tests := []struct {
+		name          string
+		results       map[string]*diff.Result
+		expectedError error
+	}{
+		{
+			name: "mixed changed, new and unchanged stacks",
+			results: map[string]*diff.Result{
+				"vpc":   {StackName: "vpc", Context: "dev", StackExists: true},
+				"app":   {StackName: "app", Context: "dev", StackExists: true, ParameterDiffs: []diff.ParameterDiff{{Key: "Size", CurrentValue: "1", ProposedValue: "2", ChangeType: diff.ChangeTypeModify}}},
+				"queue": {StackName: "queue", Context: "dev", StackExists: false},
+			},
+			expectedError: diff.ChangesDetectedError{Context: "dev", Changed: 1, New: 1},
+		},
+		{
+			name: "all stacks unchanged",
+			results: map[string]*diff.Result{
+				"vpc":   {StackName: "vpc", Context: "dev", StackExists: true},
+				"app":   {StackName: "app", Context: "dev", StackExists: true},
+				"queue": {StackName: "queue", Context: "dev", StackExists: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTempConfigWithTemplates(t, configContent, []string{"app.yaml", "queue.yaml", "vpc.yaml"})
+
+			oldWd, err := os.Getwd()
+			require.NoError(t, err)
+			require.NoError(t, os.Chdir(tmpDir))
+			defer func() {
+				require.NoError(t, os.Chdir(oldWd))
+			}()
+
+			mockDiffer := &diff.MockDiffer{}
+			originalDiffer := differ
+			SetDiffer(mockDiffer)
+			defer SetDiffer(originalDiffer)
+			defer resetDiffFlags()
+
+			var diffed []string
+			for stackName, result := range tt.results {
+				mockDiffer.On("DiffStack", mock.Anything, mock.MatchedBy(func(stack *model.Stack) bool {
+					return stack.Name == stackName
+				}), diff.Options{}).Run(func(args mock.Arguments) {
+					diffed = append(diffed, stackName)
+				}).Return(result, nil).Once()
+			}
+
+			rootCmd.SetArgs([]string{"diff", "dev", "--all"})
+			err = rootCmd.Execute()
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Less(t, slices.Index(diffed, "vpc"), slices.Index(diffed, "app"), "dependencies are diffed first")
+			mockDiffer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDiffCommand_All_RejectsSaveChangeSet(t *testing.T) {
+	mockDiffer := &diff.MockDiffer{}
+	originalDiffer := differ
+	SetDiffer(mockDiffer)
+	defer SetDiffer(originalDiffer)
+	defer resetDiffFlags()
+
+	rootCmd.SetArgs([]string{"diff", "dev", "--all", "--save-changeset"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--save-changeset cannot be combined with --all")
+	mockDiffer.AssertNotCalled(t, "DiffStack", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDiffCommand_ParameterOverridesConfiguration(t *testing.T) {
+	configContent := `
+project: test-project
+contexts:
+  dev:
+    region: us-east-1
+stacks:
+  app:
+    template: templates/app.yaml
+    parameters:
+      ImageTag: v1.2.0
+      Environment: dev
+`
+	tmpDir := createTempConfigWithTemplates(t, configContent, nil)
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "templates"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "templates", "app.yaml"), []byte(`{"Parameters": {"ImageTag": {"Type": "String"}, "Environment": {"Type": "String"}}, "Resources": {}}`), 0644))
+
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() {
+		require.NoError(t, os.Chdir(oldWd))
+	}()
+
+	mockDiffer := &diff.MockDiffer{}
+	originalDiffer := differ
+	SetDiffer(mockDiffer)
+	defer SetDiffer(originalDiffer)
+	defer resetDiffFlags()
+
+	mockDiffer.On("DiffStack", mock.Anything, mock.MatchedBy(func(stack *model.Stack) bool {
+		return assert.ObjectsAreEqual(map[string]string{"ImageTag": "abc123", "Environment": "dev"}, stack.Parameters) &&
+			assert.ObjectsAreEqual(map[string]bool{"ImageTag": true}, stack.OverriddenParameters)
+	}), mock.Anything).Return(&diff.Result{StackName: "app", Context: "dev", StackExists: true}, nil)
+
+	rootCmd.SetArgs([]string{"diff", "dev", "app", "--parameter", "ImageTag=v2", "--parameter", "ImageTag=abc123", "--parameter", "Unused=x"})
+	err = rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockDiffer.AssertExpectations(t)
+}
+
+func TestDiffCommand_RefreshIntervalConfiguresChangeSetProgress(t *testing.T) {
+	configContent := `
+project: test-project
+contexts:
+  dev:
+    region: us-east-1
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+`
+	tmpDir := createTempConfigWithTemplates(t, configContent, []string{"vpc.yaml"})
+
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() {
+		require.NoError(t, os.Chdir(oldWd))
+	}()
+
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	oldFactory := clientFactory
+	clientFactory = mockFactory
+	defer func() { clientFactory = oldFactory }()
+
+	mockDiffer := &diff.MockDiffer{}
+	originalDiffer := differ
+	SetDiffer(mockDiffer)
+	defer SetDiffer(originalDiffer)
+	defer resetDiffFlags()
+
+	mockDiffer.On("DiffStack", mock.Anything, mock.Anything, mock.Anything).Return(&diff.Result{StackName: "vpc", Context: "dev", StackExists: true}, nil)
+
+	rootCmd.SetArgs([]string{"diff", "dev", "vpc", "--refresh-interval", "500ms"})
+	err = rootCmd.Execute()
+
+	require.NoError(t, err)
+	waitConfig := mockFactory.GetWaitConfig()
+	assert.Equal(t, 500*time.Millisecond, waitConfig.ChangeSetPollInterval)
+	assert.NotNil(t, waitConfig.ChangeSetProgress)
+}
+
+func TestDiffCommand_RejectsNonPositiveRefreshInterval(t *testing.T) {
+	mockDiffer := &diff.MockDiffer{}
+	originalDiffer := differ
+	SetDiffer(mockDiffer)
+	defer SetDiffer(originalDiffer)
+	defer resetDiffFlags()
+
+	rootCmd.SetArgs([]string{"diff", "dev", "vpc", "--refresh-interval", "0s"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "--refresh-interval must be greater than zero")
+	mockDiffer.AssertNotCalled(t, "DiffStack", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDiffCommand_RejectsMalformedParameter(t *testing.T) {
+	mockDiffer := &diff.MockDiffer{}
+	originalDiffer := differ
+	SetDiffer(mockDiffer)
+	defer SetDiffer(originalDiffer)
+	defer resetDiffFlags()
+
+	rootCmd.SetArgs([]string{"diff", "dev", "app", "--parameter", "ImageTag"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, `invalid --parameter "ImageTag": must be key=value`)
+	mockDiffer.AssertNotCalled(t, "DiffStack", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestParseParameterOverrides(t *testing.T) {
+	tests := []struct {
+		name          string
+		entries       []string
+		expected      map[string]string
+		expectedError string
+	}{
+		{name: "no entries", entries: nil, expected: nil},
+		{name: "key and value", entries: []string{"ImageTag=abc123"}, expected: map[string]string{"ImageTag": "abc123"}},
+		{name: "value containing equals", entries: []string{"Query=a=b"}, expected: map[string]string{"Query": "a=b"}},
+		{name: "empty value", entries: []string{"Suffix="}, expected: map[string]string{"Suffix": ""}},
+		{name: "later entry wins", entries: []string{"Size=1", "Size=2"}, expected: map[string]string{"Size": "2"}},
+		{name: "missing equals", entries: []string{"ImageTag"}, expectedError: `invalid --parameter "ImageTag": must be key=value`},
+		{name: "missing key", entries: []string{"=abc123"}, expectedError: `invalid --parameter "=abc123": must be key=value`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrides, err := parseParameterOverrides(tt.entries)
+
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, overrides)
+		})
+	}
+}
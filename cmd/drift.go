@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"codeberg.org/orien/stackaroo/internal/drift"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// driftDetector can be injected for testing
+	driftDetector drift.Detector
+)
+
+// driftCmd represents the drift command
+var driftCmd = &cobra.Command{
+	Use:   "drift <context> <stack-name>",
+	Short: "Detect drift between a deployed stack and its template",
+	Long: `Detect whether a deployed CloudFormation stack has drifted from its template.
+
+This command runs CloudFormation drift detection against the deployed stack,
+waits for it to complete and lists every resource that has been modified or
+deleted outside CloudFormation, together with the property differences.
+
+The command exits with a non-zero status when drift is found, making it
+suitable for scheduled checks in CI/CD pipelines.
+
+Examples:
+  stackaroo drift dev vpc           # Check the VPC stack in dev context for drift
+  stackaroo drift prod app          # Check the app stack in prod context for drift`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contextName := args[0]
+		stackName := args[1]
+		ctx := context.Background()
+
+		configFile, _ := cmd.Flags().GetString("config")
+
+		return detectSingleStackDrift(ctx, stackName, contextName, configFile)
+	},
+}
+
+// getDriftDetector returns the drift detector instance, creating a default one if none is set
+func getDriftDetector() drift.Detector {
+	if driftDetector != nil {
+		return driftDetector
+	}
+
+	clientFactory := getClientFactory()
+	driftDetector = drift.NewDriftDetector(clientFactory)
+	return driftDetector
+}
+
+// SetDriftDetector allows injection of a drift detector (for testing)
+func SetDriftDetector(d drift.Detector) {
+	driftDetector = d
+}
+
+// detectSingleStackDrift handles drift detection for a single stack using configuration file
+func detectSingleStackDrift(ctx context.Context, stackName, contextName, configFile string) error {
+	_, resolver := createResolver(configFile)
+
+	// Resolve the target stack configuration
+	stack, err := resolver.ResolveStack(ctx, contextName, stackName)
+	if err != nil {
+		return err
+	}
+
+	result, err := getDriftDetector().DetectDrift(ctx, stack)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(drift.FormatResult(result))
+
+	if result.HasDrift() {
+		return drift.DriftDetectedError{StackName: stackName, Count: len(result.DriftedResources())}
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(driftCmd)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/drift"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDriftDetector implements the drift.Detector interface for testing
+type MockDriftDetector struct {
+	mock.Mock
+}
+
+func (m *MockDriftDetector) DetectDrift(ctx context.Context, stack *model.Stack) (*drift.Result, error) {
+	args := m.Called(ctx, stack)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*drift.Result), args.Error(1)
+}
+
+// setupDriftTestConfig writes a minimal configuration and changes into its directory
+func setupDriftTestConfig(t *testing.T) {
+	configContent := `
+project: test-project
+region: us-east-1
+
+contexts:
+  dev:
+    account: "123456789012"
+    region: us-west-2
+
+stacks:
+  app:
+    template: templates/app.yaml
+`
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "stackaroo.yaml"), []byte(configContent), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "templates"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "templates", "app.yaml"), []byte("Resources: {}\n"), 0644))
+
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(oldWd))
+	})
+}
+
+func TestDriftCommand_Exists(t *testing.T) {
+	driftCmd := findCommand(rootCmd, "drift")
+
+	assert.NotNil(t, driftCmd, "drift command should be registered")
+	assert.Equal(t, "drift <context> <stack-name>", driftCmd.Use)
+}
+
+func TestDriftCommand_RequiresExactlyTwoArgs(t *testing.T) {
+	driftCmd := findCommand(rootCmd, "drift")
+	require.NotNil(t, driftCmd)
+
+	assert.NoError(t, driftCmd.Args(driftCmd, []string{"dev", "app"}))
+	assert.Error(t, driftCmd.Args(driftCmd, []string{"dev"}))
+	assert.Error(t, driftCmd.Args(driftCmd, []string{"dev", "app", "extra"}))
+}
+
+func TestDriftCommand_NoDrift(t *testing.T) {
+	setupDriftTestConfig(t)
+
+	mockDetector := &MockDriftDetector{}
+	mockDetector.On("DetectDrift", mock.Anything, mock.MatchedBy(func(stack *model.Stack) bool {
+		return stack.Name == "app" && stack.Context.Name == "dev"
+	})).Return(&drift.Result{StackName: "app", Region: "us-west-2", Status: "IN_SYNC"}, nil)
+
+	oldDetector := driftDetector
+	SetDriftDetector(mockDetector)
+	defer SetDriftDetector(oldDetector)
+
+	rootCmd.SetArgs([]string{"drift", "dev", "app"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err, "drift command should succeed when the stack is in sync")
+	mockDetector.AssertExpectations(t)
+}
+
+func TestDriftCommand_FailsWhenDrifted(t *testing.T) {
+	setupDriftTestConfig(t)
+
+	mockDetector := &MockDriftDetector{}
+	mockDetector.On("DetectDrift", mock.Anything, mock.AnythingOfType("*model.Stack")).Return(&drift.Result{
+		StackName: "app",
+		Region:    "us-west-2",
+		Status:    "DRIFTED",
+		Resources: []aws.ResourceDrift{
+			{LogicalID: "Bucket", ResourceType: "AWS::S3::Bucket", DriftStatus: "DELETED"},
+		},
+	}, nil)
+
+	oldDetector := driftDetector
+	SetDriftDetector(mockDetector)
+	defer SetDriftDetector(oldDetector)
+
+	rootCmd.SetArgs([]string{"drift", "dev", "app"})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	var driftErr drift.DriftDetectedError
+	require.ErrorAs(t, err, &driftErr)
+	assert.Equal(t, "app", driftErr.StackName)
+	assert.Equal(t, 1, driftErr.Count)
+}
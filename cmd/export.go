@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"codeberg.org/orien/stackaroo/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// stackExporter can be injected for testing
+	stackExporter export.Exporter
+	exportOut     string
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export <context> <stack-name>",
+	Short: "Export the deployed state of a stack to a JSON file",
+	Long: `Export the deployed state of a CloudFormation stack as a single JSON document.
+
+The document records the stack's template, parameters, tags and outputs as
+they are in AWS, together with its status, stack ID and timestamps. Keep it
+for disaster recovery or audit, or compare it against a later export.
+
+Parameters declared with NoEcho are exported as masked by CloudFormation.
+Without --out the document is printed to standard output.
+
+Examples:
+  stackaroo export prod app --out app-state.json   # Save the state of app in prod
+  stackaroo export dev vpc                         # Print the state of vpc in dev`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contextName := args[0]
+		stackName := args[1]
+		ctx := context.Background()
+
+		configFile, _ := cmd.Flags().GetString("config")
+
+		return exportStack(ctx, contextName, stackName, configFile, exportOut)
+	},
+}
+
+// getStackExporter returns the stack exporter instance, creating a default one if none is set
+func getStackExporter(configFile string) export.Exporter {
+	if stackExporter != nil {
+		return stackExporter
+	}
+
+	provider := file.NewConfigProvider(configFile)
+	stackExporter = export.NewStackExporter(provider, getClientFactory())
+	return stackExporter
+}
+
+// SetStackExporter allows injection of a stack exporter (for testing)
+func SetStackExporter(e export.Exporter) {
+	stackExporter = e
+}
+
+// exportStack captures the state of a deployed stack and writes it to a file or standard output
+func exportStack(ctx context.Context, contextName, stackName, configFile, outFile string) error {
+	state, err := getStackExporter(configFile).ExportStack(ctx, contextName, stackName)
+	if err != nil {
+		return err
+	}
+
+	if outFile == "" {
+		data, err := state.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if err := state.Save(outFile); err != nil {
+		return err
+	}
+	fmt.Printf("Exported state of stack %s to %s\n", stackName, outFile)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVar(&exportOut, "out", "", "write the exported state to this file instead of standard output")
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockStackExporter implements the export.Exporter interface for testing
+type MockStackExporter struct {
+	mock.Mock
+}
+
+func (m *MockStackExporter) ExportStack(ctx context.Context, contextName, stackName string) (*export.StackState, error) {
+	args := m.Called(ctx, contextName, stackName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*export.StackState), args.Error(1)
+}
+
+// withMockStackExporter injects a stack exporter and resets the export flags after the test
+func withMockStackExporter(t *testing.T) *MockStackExporter {
+	mockExporter := &MockStackExporter{}
+	oldExporter := stackExporter
+	SetStackExporter(mockExporter)
+	t.Cleanup(func() {
+		SetStackExporter(oldExporter)
+		exportOut = ""
+	})
+	return mockExporter
+}
+
+func TestExportCommand_Exists(t *testing.T) {
+	exportCmd := findCommand(rootCmd, "export")
+
+	require.NotNil(t, exportCmd, "export command should be registered")
+	assert.Equal(t, "export <context> <stack-name>", exportCmd.Use)
+	assert.NotNil(t, exportCmd.Flags().Lookup("out"))
+}
+
+func TestExportCommand_WritesStateFile(t *testing.T) {
+	mockExporter := withMockStackExporter(t)
+	mockExporter.On("ExportStack", mock.Anything, "prod", "app").Return(&export.StackState{
+		StackName:  "app",
+		Context:    "prod",
+		Status:     "UPDATE_COMPLETE",
+		Parameters: map[string]string{"InstanceType": "t3.large"},
+		Template:   "Resources: {}\n",
+	}, nil)
+
+	outFile := filepath.Join(t.TempDir(), "state.json")
+	rootCmd.SetArgs([]string{"export", "prod", "app", "--out", outFile})
+	err := rootCmd.Execute()
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	var state export.StackState
+	require.NoError(t, json.Unmarshal(data, &state))
+	assert.Equal(t, "app", state.StackName)
+	assert.Equal(t, map[string]string{"InstanceType": "t3.large"}, state.Parameters)
+	mockExporter.AssertExpectations(t)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"github.com/spf13/cobra"
+)
+
+var exportsOutput string
+
+// exportsCmd represents the exports command
+var exportsCmd = &cobra.Command{
+	Use:   "exports <context>",
+	Short: "List the CloudFormation exports in a context's region",
+	Long: `List every CloudFormation export in the account and region of a context.
+
+Exports are stack outputs shared under a region-wide name. For each export
+this command shows its name, value and the stack that exports it, whether or
+not that stack is configured in stackaroo. Use an export name with a parameter
+of type export to read its value without naming the exporting stack:
+
+  parameters:
+    VpcId:
+      type: export
+      name: network-VpcId
+
+Use --output json to print the exports as a JSON document for tooling.
+
+Examples:
+  stackaroo exports dev                  # List the exports in the region of dev
+  stackaroo exports prod --output json   # Print the exports of prod as JSON`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jsonOutput, err := isJSONOutput(exportsOutput)
+		if err != nil {
+			return err
+		}
+
+		configFile, _ := cmd.Flags().GetString("config")
+		provider := file.NewConfigProvider(configFile)
+
+		return listExports(context.Background(), cmd.OutOrStdout(), provider, getClientFactory(), args[0], jsonOutput)
+	},
+}
+
+// listedExport is the JSON representation of a CloudFormation export
+type listedExport struct {
+	Name           string `json:"name"`
+	Value          string `json:"value"`
+	ExportingStack string `json:"exporting_stack"`
+}
+
+// listExports prints the exports in a context's region in name order
+func listExports(ctx context.Context, w io.Writer, provider config.ConfigProvider, factory aws.ClientFactory, contextName string, jsonOutput bool) error {
+	cfg, err := provider.LoadConfig(ctx, contextName)
+	if err != nil {
+		return err
+	}
+
+	region := cfg.Context.Region
+	cfnOps, err := factory.GetCloudFormationOperations(ctx, region)
+	if err != nil {
+		return fmt.Errorf("failed to get CloudFormation operations for region %s: %w", region, err)
+	}
+
+	exports, err := cfnOps.ListExports(ctx)
+	if err != nil {
+		return err
+	}
+
+	listed := make([]listedExport, 0, len(exports))
+	for _, export := range exports {
+		listed = append(listed, listedExport{Name: export.Name, Value: export.Value, ExportingStack: export.ExportingStack})
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].Name < listed[j].Name })
+
+	if jsonOutput {
+		return writeListJSON(w, struct {
+			Context string         `json:"context"`
+			Region  string         `json:"region"`
+			Exports []listedExport `json:"exports"`
+		}{Context: contextName, Region: region, Exports: listed})
+	}
+
+	if len(listed) == 0 {
+		_, err := fmt.Fprintf(w, "No exports in region %s\n", region)
+		return err
+	}
+
+	rows := make([][]string, 0, len(listed))
+	for _, export := range listed {
+		rows = append(rows, []string{export.Name, export.Value, export.ExportingStack})
+	}
+	return writeListTable(w, []string{"NAME", "VALUE", "EXPORTING STACK"}, rows)
+}
+
+func init() {
+	rootCmd.AddCommand(exportsCmd)
+	exportsCmd.Flags().StringVar(&exportsOutput, "output", "text", "output format: text or json")
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupExportsTest returns a configuration with context dev in us-west-2 and its CloudFormation mock
+func setupExportsTest(ctx context.Context) (*config.MockConfigProvider, *aws.MockClientFactory, *aws.MockCloudFormationOperations) {
+	mockProvider := &config.MockConfigProvider{}
+	mockProvider.On("LoadConfig", ctx, "dev").Return(&config.Config{
+		Context: &config.ContextConfig{Name: "dev", Region: "us-west-2"},
+	}, nil)
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-west-2")
+	return mockProvider, mockFactory, mockCfnOps
+}
+
+func TestExportsCommand_Registered(t *testing.T) {
+	exportsCmd := findCommand(rootCmd, "exports")
+	require.NotNil(t, exportsCmd, "exports command should be registered")
+	assert.Equal(t, "exports <context>", exportsCmd.Use)
+	assert.NotNil(t, exportsCmd.Flags().Lookup("output"))
+}
+
+func TestListExports_Text(t *testing.T) {
+	ctx := context.Background()
+	mockProvider, mockFactory, mockCfnOps := setupExportsTest(ctx)
+	mockCfnOps.On("ListExports", ctx).Return([]aws.Export{
+		{Name: "network-VpcId", Value: "vpc-0abc123", ExportingStack: "network"},
+		{Name: "SharedKmsKeyArn", Value: "arn:aws:kms:us-west-2:123456789012:key/abc", ExportingStack: "security"},
+	}, nil)
+	var output bytes.Buffer
+
+	err := listExports(ctx, &output, mockProvider, mockFactory, "dev", false)
+
+	require.NoError(t, err)
+	assert.Equal(t, `NAME             VALUE                                       EXPORTING STACK
+SharedKmsKeyArn  arn:aws:kms:us-west-2:123456789012:key/abc  security
+network-VpcId    vpc-0abc123                                 network
+`, output.String())
+}
+
+func TestListExports_JSON(t *testing.T) {
+	ctx := context.Background()
+	mockProvider, mockFactory, mockCfnOps := setupExportsTest(ctx)
+	mockCfnOps.On("ListExports", ctx).Return([]aws.Export{
+		{Name: "network-VpcId", Value: "vpc-0abc123", ExportingStack: "network", ExportingStackID: "arn:aws:cloudformation:us-west-2:123456789012:stack/network/abc"},
+	}, nil)
+	var output bytes.Buffer
+
+	err := listExports(ctx, &output, mockProvider, mockFactory, "dev", true)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"context": "dev",
+		"region": "us-west-2",
+		"exports": [
+			{"name": "network-VpcId", "value": "vpc-0abc123", "exporting_stack": "network"}
+		]
+	}`, output.String())
+}
+
+func TestListExports_None(t *testing.T) {
+	ctx := context.Background()
+	mockProvider, mockFactory, mockCfnOps := setupExportsTest(ctx)
+	mockCfnOps.On("ListExports", ctx).Return([]aws.Export{}, nil)
+	var output bytes.Buffer
+
+	err := listExports(ctx, &output, mockProvider, mockFactory, "dev", false)
+
+	require.NoError(t, err)
+	assert.Equal(t, "No exports in region us-west-2\n", output.String())
+}
@@ -0,0 +1,162 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// Supported graph formats
+const (
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+)
+
+var graphFormat string
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph <context> [stack-name...]",
+	Short: "Print the stack dependency graph of a context",
+	Long: `Print the dependencies between the stacks of a context as a Graphviz DOT graph,
+or a Mermaid flowchart with --format mermaid, without contacting AWS.
+
+Each edge points from a stack to a stack it depends on. Give stack names to graph
+just those stacks; dependencies outside them are left out. When the dependencies
+form a cycle, the graph is still printed with the edges of the cycle highlighted,
+and the command fails naming the stacks in the cycle.
+
+Examples:
+  stackaroo graph dev | dot -Tsvg > stacks.svg   # Render the dev stacks with Graphviz
+  stackaroo graph prod vpc app                   # Graph vpc and app only
+  stackaroo graph dev --format mermaid           # Print a Mermaid flowchart for Markdown`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, _ := cmd.Flags().GetString("config")
+		provider := file.NewConfigProvider(configFile)
+
+		return printGraph(cmd.OutOrStdout(), provider, args[0], args[1:], graphFormat)
+	},
+}
+
+// printGraph prints the dependency graph of stacks in a context in the given format. Every
+// stack in the context is included when no stack names are given. A graph containing a cycle
+// is printed with the cycle highlighted before an error naming it is returned.
+func printGraph(w io.Writer, provider config.ConfigProvider, contextName string, stackNames []string, format string) error {
+	if format != graphFormatDOT && format != graphFormatMermaid {
+		return fmt.Errorf("unsupported graph format %q: must be dot or mermaid", format)
+	}
+
+	if len(stackNames) == 0 {
+		var err error
+		stackNames, err = provider.ListStacks(contextName)
+		if err != nil {
+			return err
+		}
+	}
+
+	graph, err := resolve.NewStackResolver(provider, nil).GetDependencyGraph(contextName, stackNames)
+	if err != nil {
+		return err
+	}
+
+	cycle := resolve.FindCycle(graph)
+	inCycle := make(map[[2]string]bool, len(cycle))
+	for i := 1; i < len(cycle); i++ {
+		inCycle[[2]string{cycle[i-1], cycle[i]}] = true
+	}
+
+	if format == graphFormatMermaid {
+		err = writeMermaidGraph(w, graph, inCycle)
+	} else {
+		err = writeDOTGraph(w, contextName, graph, inCycle)
+	}
+	if err != nil {
+		return err
+	}
+
+	if cycle != nil {
+		return fmt.Errorf("circular dependency detected in stacks: %s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+// writeDOTGraph writes the graph in Graphviz DOT, drawing edges in the cycle in red
+func writeDOTGraph(w io.Writer, contextName string, graph map[string][]string, inCycle map[[2]string]bool) error {
+	var output strings.Builder
+	fmt.Fprintf(&output, "digraph %s {\n", strconv.Quote(contextName))
+	output.WriteString("  rankdir=LR;\n")
+	output.WriteString("  node [shape=box];\n")
+
+	names := slices.Sorted(maps.Keys(graph))
+	for _, name := range names {
+		fmt.Fprintf(&output, "  %s;\n", strconv.Quote(name))
+	}
+	for _, name := range names {
+		for _, dependency := range graph[name] {
+			attributes := ""
+			if inCycle[[2]string{name, dependency}] {
+				attributes = " [color=red]"
+			}
+			fmt.Fprintf(&output, "  %s -> %s%s;\n", strconv.Quote(name), strconv.Quote(dependency), attributes)
+		}
+	}
+	output.WriteString("}\n")
+
+	_, err := io.WriteString(w, output.String())
+	return err
+}
+
+// mermaidUnsafe matches characters that cannot appear in a Mermaid node ID
+var mermaidUnsafe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// writeMermaidGraph writes the graph as a Mermaid flowchart, drawing edges in the cycle in red
+func writeMermaidGraph(w io.Writer, graph map[string][]string, inCycle map[[2]string]bool) error {
+	var output strings.Builder
+	output.WriteString("flowchart LR\n")
+
+	// Stack names may contain hyphens and other characters Mermaid IDs cannot, so each
+	// stack gets a numbered ID and is labelled with its name
+	names := slices.Sorted(maps.Keys(graph))
+	ids := make(map[string]string, len(names))
+	for i, name := range names {
+		ids[name] = fmt.Sprintf("s%d_%s", i+1, mermaidUnsafe.ReplaceAllString(name, "_"))
+		fmt.Fprintf(&output, "  %s[\"%s\"]\n", ids[name], strings.ReplaceAll(name, `"`, "#quot;"))
+	}
+
+	var cycleEdges []string
+	edge := 0
+	for _, name := range names {
+		for _, dependency := range graph[name] {
+			fmt.Fprintf(&output, "  %s --> %s\n", ids[name], ids[dependency])
+			if inCycle[[2]string{name, dependency}] {
+				cycleEdges = append(cycleEdges, strconv.Itoa(edge))
+			}
+			edge++
+		}
+	}
+	if len(cycleEdges) > 0 {
+		fmt.Fprintf(&output, "  linkStyle %s stroke:red\n", strings.Join(cycleEdges, ","))
+	}
+
+	_, err := io.WriteString(w, output.String())
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+	graphCmd.Flags().StringVar(&graphFormat, "format", graphFormatDOT, "graph format: dot or mermaid")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphCommand_Registered(t *testing.T) {
+	graphCmd := findCommand(rootCmd, "graph")
+	require.NotNil(t, graphCmd, "graph command should be registered")
+	formatFlag := graphCmd.Flags().Lookup("format")
+	require.NotNil(t, formatFlag)
+	assert.Equal(t, "dot", formatFlag.DefValue)
+}
+
+func TestPrintGraph_DOT(t *testing.T) {
+	provider := setupOrderTestConfig(t)
+	var output bytes.Buffer
+
+	err := printGraph(&output, provider, "dev", nil, "dot")
+
+	require.NoError(t, err)
+	assert.Equal(t, `digraph "dev" {
+  rankdir=LR;
+  node [shape=box];
+  "app";
+  "database";
+  "queue";
+  "vpc";
+  "app" -> "database";
+  "app" -> "queue";
+  "database" -> "vpc";
+}
+`, output.String())
+}
+
+func TestPrintGraph_Mermaid(t *testing.T) {
+	provider := setupOrderTestConfig(t)
+	var output bytes.Buffer
+
+	err := printGraph(&output, provider, "dev", []string{"app", "database"}, "mermaid")
+
+	require.NoError(t, err)
+	assert.Equal(t, `flowchart LR
+  s1_app["app"]
+  s2_database["database"]
+  s1_app --> s2_database
+`, output.String())
+}
+
+func TestPrintGraph_ReportsCycle(t *testing.T) {
+	configContent := `
+project: test-project
+region: us-east-1
+
+contexts:
+  dev:
+    account: "123456789012"
+
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+  network:
+    template: templates/network.yaml
+    depends_on:
+      - vpc
+      - app
+  app:
+    template: templates/app.yaml
+    depends_on:
+      - network
+`
+	tmpDir := createTempConfigWithTemplates(t, configContent, []string{"vpc.yaml", "network.yaml", "app.yaml"})
+	provider := file.NewFileConfigProvider(filepath.Join(tmpDir, "stackaroo.yaml"))
+	var output bytes.Buffer
+
+	err := printGraph(&output, provider, "dev", nil, "dot")
+
+	require.EqualError(t, err, "circular dependency detected in stacks: app -> network -> app")
+	assert.Contains(t, output.String(), `"app" -> "network" [color=red];`)
+	assert.Contains(t, output.String(), `"network" -> "app" [color=red];`)
+	assert.Contains(t, output.String(), "  \"network\" -> \"vpc\";\n")
+}
+
+func TestPrintGraph_UnsupportedFormat(t *testing.T) {
+	provider := setupOrderTestConfig(t)
+
+	err := printGraph(&bytes.Buffer{}, provider, "dev", nil, "png")
+
+	assert.EqualError(t, err, `unsupported graph format "png": must be dot or mermaid`)
+}
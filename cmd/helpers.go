@@ -7,6 +7,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/config/file"
@@ -15,12 +16,48 @@ import (
 
 // createResolver creates a configuration provider and resolver
 func createResolver(configFile string) (*file.FileConfigProvider, *resolve.StackResolver) {
-	provider := file.NewFileConfigProvider(configFile)
+	provider := file.NewConfigProvider(configFile)
 	clientFactory := getClientFactory()
 	resolver := resolve.NewStackResolver(provider, clientFactory)
+
+	// Templates of a remote configuration are fetched from the same server
+	if fetcher := provider.RemoteFetcher(); fetcher != nil {
+		resolver.SetFileSystemResolver(fetcher)
+	}
 	return provider, resolver
 }
 
+// isJSONOutput validates an --output flag value and reports whether JSON output was requested
+func isJSONOutput(format string) (bool, error) {
+	switch format {
+	case "", "text":
+		return false, nil
+	case "json":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported output format %q: must be text or json", format)
+	}
+}
+
+// parseParameterOverrides parses repeated --parameter key=value flags. Values may contain
+// further = signs; a later entry for the same key replaces an earlier one.
+func parseParameterOverrides(entries []string) (map[string]string, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	overrides := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		key, value, found := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid --parameter %q: must be key=value", entry)
+		}
+		overrides[key] = value
+	}
+	return overrides, nil
+}
+
 // Global factory instance (created once per command execution)
 var clientFactory aws.ClientFactory
 
@@ -32,7 +69,7 @@ func getClientFactory() aws.ClientFactory {
 
 	ctx := context.Background()
 
-	factory, err := aws.NewClientFactory(ctx)
+	factory, err := aws.NewClientFactory(ctx, endpointURL)
 	if err != nil {
 		panic(fmt.Sprintf("failed to create AWS client factory: %v", err))
 	}
@@ -0,0 +1,231 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"github.com/spf13/cobra"
+)
+
+var listOutput string
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured stacks and contexts",
+	Long: `List what is defined in the configuration file without contacting AWS.
+
+Use 'list contexts' to see every context with its account and region, and
+'list stacks <context>' to see the stacks configured for a context with their
+templates and dependencies. Add --output json for machine-readable output.
+
+Examples:
+  stackaroo list contexts                 # Show every context
+  stackaroo list stacks dev               # Show the stacks configured for dev
+  stackaroo list stacks prod --output json`,
+}
+
+// listStacksCmd represents the list stacks command
+var listStacksCmd = &cobra.Command{
+	Use:   "stacks <context>",
+	Short: "List the stacks configured for a context",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jsonOutput, err := isJSONOutput(listOutput)
+		if err != nil {
+			return err
+		}
+
+		configFile, _ := cmd.Flags().GetString("config")
+		provider := file.NewConfigProvider(configFile)
+
+		return listStacks(context.Background(), cmd.OutOrStdout(), provider, args[0], jsonOutput)
+	},
+}
+
+// listContextsCmd represents the list contexts command
+var listContextsCmd = &cobra.Command{
+	Use:   "contexts",
+	Short: "List the configured contexts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jsonOutput, err := isJSONOutput(listOutput)
+		if err != nil {
+			return err
+		}
+
+		configFile, _ := cmd.Flags().GetString("config")
+		provider := file.NewConfigProvider(configFile)
+
+		return listContexts(context.Background(), cmd.OutOrStdout(), provider, jsonOutput)
+	},
+}
+
+// listedStack is the JSON representation of a configured stack
+type listedStack struct {
+	Name      string   `json:"name"`
+	Template  string   `json:"template,omitempty"`
+	DependsOn []string `json:"depends_on"`
+}
+
+// listedContext is the JSON representation of a configured context
+type listedContext struct {
+	Name    string `json:"name"`
+	Account string `json:"account,omitempty"`
+	Region  string `json:"region"`
+}
+
+// listStacks prints the stacks configured for a context in name order
+func listStacks(ctx context.Context, w io.Writer, provider config.ConfigProvider, contextName string, jsonOutput bool) error {
+	cfg, err := provider.LoadConfig(ctx, contextName)
+	if err != nil {
+		return err
+	}
+
+	stacks := make([]listedStack, 0, len(cfg.Stacks))
+	for _, stack := range cfg.Stacks {
+		dependencies := append([]string{}, stack.Dependencies...)
+		stacks = append(stacks, listedStack{
+			Name:      stack.Name,
+			Template:  displayTemplatePath(stack.Template),
+			DependsOn: dependencies,
+		})
+	}
+	sort.Slice(stacks, func(i, j int) bool { return stacks[i].Name < stacks[j].Name })
+
+	if jsonOutput {
+		return writeListJSON(w, struct {
+			Context string        `json:"context"`
+			Stacks  []listedStack `json:"stacks"`
+		}{Context: contextName, Stacks: stacks})
+	}
+
+	if len(stacks) == 0 {
+		_, err := fmt.Fprintf(w, "No stacks configured for context %s\n", contextName)
+		return err
+	}
+
+	rows := make([][]string, 0, len(stacks))
+	for _, stack := range stacks {
+		template, dependencies := stack.Template, "-"
+		if template == "" {
+			template = "(deployed template)"
+		}
+		if len(stack.DependsOn) > 0 {
+			dependencies = strings.Join(stack.DependsOn, ", ")
+		}
+		rows = append(rows, []string{stack.Name, template, dependencies})
+	}
+	return writeListTable(w, []string{"STACK", "TEMPLATE", "DEPENDS ON"}, rows)
+}
+
+// listContexts prints every configured context with its account and region in name order
+func listContexts(ctx context.Context, w io.Writer, provider config.ConfigProvider, jsonOutput bool) error {
+	names, err := provider.ListContexts()
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	contexts := make([]listedContext, 0, len(names))
+	for _, name := range names {
+		cfg, err := provider.LoadConfig(ctx, name)
+		if err != nil {
+			return err
+		}
+		contexts = append(contexts, listedContext{Name: name, Account: cfg.Context.Account, Region: cfg.Context.Region})
+	}
+
+	if jsonOutput {
+		return writeListJSON(w, struct {
+			Contexts []listedContext `json:"contexts"`
+		}{Contexts: contexts})
+	}
+
+	if len(contexts) == 0 {
+		_, err := fmt.Fprintln(w, "No contexts configured")
+		return err
+	}
+
+	rows := make([][]string, 0, len(contexts))
+	for _, listed := range contexts {
+		account := listed.Account
+		if account == "" {
+			account = "-"
+		}
+		rows = append(rows, []string{listed.Name, account, listed.Region})
+	}
+	return writeListTable(w, []string{"CONTEXT", "ACCOUNT", "REGION"}, rows)
+}
+
+// displayTemplatePath turns a resolved file:// template URI back into a path relative to the working directory
+func displayTemplatePath(templateURI string) string {
+	parsed, err := url.Parse(templateURI)
+	if err != nil || parsed.Scheme != "file" {
+		return templateURI
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		if relative, err := filepath.Rel(wd, parsed.Path); err == nil && !strings.HasPrefix(relative, "..") {
+			return relative
+		}
+	}
+	return parsed.Path
+}
+
+// writeListTable prints rows as an aligned table with a header
+func writeListTable(w io.Writer, header []string, rows [][]string) error {
+	widths := make([]int, len(header))
+	for i, title := range header {
+		widths[i] = len(title)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+
+	var output strings.Builder
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i == len(row)-1 {
+				output.WriteString(cell + "\n")
+				continue
+			}
+			fmt.Fprintf(&output, "%-*s  ", widths[i], cell)
+		}
+	}
+
+	_, err := io.WriteString(w, output.String())
+	return err
+}
+
+// writeListJSON prints a list result as indented JSON
+func writeListJSON(w io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode list output: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+	listCmd.AddCommand(listStacksCmd)
+	listCmd.AddCommand(listContextsCmd)
+	listCmd.PersistentFlags().StringVar(&listOutput, "output", "text", "output format: text or json")
+}
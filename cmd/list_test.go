@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const listTestConfig = `
+project: test-project
+region: us-east-1
+
+contexts:
+  dev:
+    account: "123456789012"
+    region: us-west-2
+  prod:
+    account: "987654321098"
+    region: eu-west-1
+
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+  app:
+    template: templates/app.yaml
+    depends_on:
+      - vpc
+  legacy:
+    parameters:
+      InstanceType: t3.small
+`
+
+// setupListTestConfig writes the list test configuration and changes into its directory
+func setupListTestConfig(t *testing.T) *file.FileConfigProvider {
+	tmpDir := createTempConfigWithTemplates(t, listTestConfig, []string{"vpc.yaml", "app.yaml"})
+
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(oldWd))
+	})
+
+	return file.NewFileConfigProvider(filepath.Join(tmpDir, "stackaroo.yaml"))
+}
+
+func TestListCommand_HasSubcommands(t *testing.T) {
+	listCmd := findCommand(rootCmd, "list")
+	require.NotNil(t, listCmd, "list command should be registered")
+
+	assert.NotNil(t, findCommand(listCmd, "stacks"))
+	assert.NotNil(t, findCommand(listCmd, "contexts"))
+}
+
+func TestListStacks_Text(t *testing.T) {
+	provider := setupListTestConfig(t)
+	var output bytes.Buffer
+
+	err := listStacks(context.Background(), &output, provider, "dev", false)
+
+	require.NoError(t, err)
+	assert.Equal(t, `STACK   TEMPLATE             DEPENDS ON
+app     templates/app.yaml   vpc
+legacy  (deployed template)  -
+vpc     templates/vpc.yaml   -
+`, output.String())
+}
+
+func TestListStacks_JSON(t *testing.T) {
+	provider := setupListTestConfig(t)
+	var output bytes.Buffer
+
+	err := listStacks(context.Background(), &output, provider, "dev", true)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"context": "dev",
+		"stacks": [
+			{"name": "app", "template": "templates/app.yaml", "depends_on": ["vpc"]},
+			{"name": "legacy", "depends_on": []},
+			{"name": "vpc", "template": "templates/vpc.yaml", "depends_on": []}
+		]
+	}`, output.String())
+}
+
+func TestListStacks_UnknownContext(t *testing.T) {
+	provider := setupListTestConfig(t)
+	var output bytes.Buffer
+
+	err := listStacks(context.Background(), &output, provider, "staging", false)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "context 'staging' not found")
+}
+
+func TestListContexts_Text(t *testing.T) {
+	provider := setupListTestConfig(t)
+	var output bytes.Buffer
+
+	err := listContexts(context.Background(), &output, provider, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, `CONTEXT  ACCOUNT       REGION
+dev      123456789012  us-west-2
+prod     987654321098  eu-west-1
+`, output.String())
+}
+
+func TestListContexts_JSON(t *testing.T) {
+	provider := setupListTestConfig(t)
+	var output bytes.Buffer
+
+	err := listContexts(context.Background(), &output, provider, true)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"contexts": [
+			{"name": "dev", "account": "123456789012", "region": "us-west-2"},
+			{"name": "prod", "account": "987654321098", "region": "eu-west-1"}
+		]
+	}`, output.String())
+}
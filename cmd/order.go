@@ -0,0 +1,149 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+var orderOutput string
+
+// orderCmd represents the order command
+var orderCmd = &cobra.Command{
+	Use:   "order <context> [stack-name...]",
+	Short: "Show the order stacks are deployed and deleted in",
+	Long: `Show the dependency order used to deploy the stacks of a context, and the
+reverse order used to delete them, without contacting AWS.
+
+Each stack is given a level: level 1 stacks depend on nothing, and every other
+stack sits one level above the highest of its dependencies. Stacks on the same
+level do not depend on each other. Give stack names to order just those stacks;
+dependencies outside them are ignored. Add --output json for machine-readable
+output.
+
+Examples:
+  stackaroo order dev             # Show the order of every stack in dev
+  stackaroo order prod vpc app    # Show the order of vpc and app only
+  stackaroo order prod --output json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jsonOutput, err := isJSONOutput(orderOutput)
+		if err != nil {
+			return err
+		}
+
+		configFile, _ := cmd.Flags().GetString("config")
+		provider := file.NewConfigProvider(configFile)
+
+		return printOrder(cmd.OutOrStdout(), provider, args[0], args[1:], jsonOutput)
+	},
+}
+
+// orderReport is the JSON representation of the deployment and deletion order of a context
+type orderReport struct {
+	Context     string     `json:"context"`
+	DeployOrder []string   `json:"deploy_order"`
+	DeleteOrder []string   `json:"delete_order"`
+	Levels      [][]string `json:"levels"`
+}
+
+// printOrder prints the deployment order, dependency levels and deletion order of stacks in a context.
+// Every stack in the context is ordered when no stack names are given.
+func printOrder(w io.Writer, provider config.ConfigProvider, contextName string, stackNames []string, jsonOutput bool) error {
+	if len(stackNames) == 0 {
+		var err error
+		stackNames, err = provider.ListStacks(contextName)
+		if err != nil {
+			return err
+		}
+	}
+
+	resolver := resolve.NewStackResolver(provider, nil)
+	deployOrder, err := resolver.GetDependencyOrder(contextName, stackNames)
+	if err != nil {
+		return err
+	}
+
+	deleteOrder := slices.Clone(deployOrder)
+	slices.Reverse(deleteOrder)
+
+	levels, dependencies, err := dependencyLevels(provider, contextName, deployOrder)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		report := orderReport{Context: contextName, DeployOrder: deployOrder, DeleteOrder: deleteOrder, Levels: [][]string{}}
+		for _, stackName := range deployOrder {
+			level := levels[stackName]
+			if level > len(report.Levels) {
+				report.Levels = append(report.Levels, []string{})
+			}
+			report.Levels[level-1] = append(report.Levels[level-1], stackName)
+		}
+		return writeListJSON(w, report)
+	}
+
+	if len(deployOrder) == 0 {
+		_, err := fmt.Fprintf(w, "No stacks configured for context %s\n", contextName)
+		return err
+	}
+
+	rows := make([][]string, 0, len(deployOrder))
+	for i, stackName := range deployOrder {
+		dependsOn := "-"
+		if len(dependencies[stackName]) > 0 {
+			dependsOn = strings.Join(dependencies[stackName], ", ")
+		}
+		rows = append(rows, []string{strconv.Itoa(i + 1), strconv.Itoa(levels[stackName]), stackName, dependsOn})
+	}
+	if err := writeListTable(w, []string{"ORDER", "LEVEL", "STACK", "DEPENDS ON"}, rows); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "\nDeletion order: %s\n", strings.Join(deleteOrder, ", "))
+	return err
+}
+
+// dependencyLevels assigns each stack, given in dependency order, a level one above the highest of
+// its dependencies among those stacks, and returns the dependencies considered for each stack
+func dependencyLevels(provider config.ConfigProvider, contextName string, deployOrder []string) (map[string]int, map[string][]string, error) {
+	levels := make(map[string]int, len(deployOrder))
+	dependencies := make(map[string][]string, len(deployOrder))
+
+	for _, stackName := range deployOrder {
+		stackConfig, err := provider.GetStack(stackName, contextName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get stack config %s: %w", stackName, err)
+		}
+
+		level := 1
+		for _, dependency := range stackConfig.Dependencies {
+			dependencyLevel, ordered := levels[dependency]
+			if !ordered {
+				continue
+			}
+			dependencies[stackName] = append(dependencies[stackName], dependency)
+			level = max(level, dependencyLevel+1)
+		}
+		levels[stackName] = level
+	}
+
+	return levels, dependencies, nil
+}
+
+func init() {
+	rootCmd.AddCommand(orderCmd)
+	orderCmd.Flags().StringVar(&orderOutput, "output", "text", "output format: text or json")
+}
@@ -0,0 +1,153 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderTestConfig = `
+project: test-project
+region: us-east-1
+
+contexts:
+  dev:
+    account: "123456789012"
+
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+  database:
+    template: templates/database.yaml
+    depends_on:
+      - vpc
+  queue:
+    template: templates/queue.yaml
+  app:
+    template: templates/app.yaml
+    depends_on:
+      - database
+      - queue
+`
+
+func setupOrderTestConfig(t *testing.T) *file.FileConfigProvider {
+	tmpDir := createTempConfigWithTemplates(t, orderTestConfig, []string{"vpc.yaml", "database.yaml", "queue.yaml", "app.yaml"})
+	return file.NewFileConfigProvider(filepath.Join(tmpDir, "stackaroo.yaml"))
+}
+
+func TestOrderCommand_Registered(t *testing.T) {
+	orderCmd := findCommand(rootCmd, "order")
+	require.NotNil(t, orderCmd, "order command should be registered")
+	assert.NotNil(t, orderCmd.Flags().Lookup("output"))
+}
+
+func TestPrintOrder_Text(t *testing.T) {
+	provider := setupOrderTestConfig(t)
+	var output bytes.Buffer
+
+	err := printOrder(&output, provider, "dev", nil, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, `ORDER  LEVEL  STACK     DEPENDS ON
+1      1      queue     -
+2      1      vpc       -
+3      2      database  vpc
+4      3      app       database, queue
+
+Deletion order: app, database, vpc, queue
+`, output.String())
+}
+
+func TestPrintOrder_JSON_MatchesDependencyOrder(t *testing.T) {
+	provider := setupOrderTestConfig(t)
+	stackNames, err := provider.ListStacks("dev")
+	require.NoError(t, err)
+	expected, err := resolve.NewStackResolver(provider, nil).GetDependencyOrder("dev", stackNames)
+	require.NoError(t, err)
+	var output bytes.Buffer
+
+	err = printOrder(&output, provider, "dev", nil, true)
+
+	require.NoError(t, err)
+	var report orderReport
+	require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	assert.Equal(t, "dev", report.Context)
+	assert.Equal(t, expected, report.DeployOrder)
+	reversed := slices.Clone(expected)
+	slices.Reverse(reversed)
+	assert.Equal(t, reversed, report.DeleteOrder)
+	assert.Equal(t, [][]string{{"queue", "vpc"}, {"database"}, {"app"}}, report.Levels)
+}
+
+func TestPrintOrder_SelectedStacks(t *testing.T) {
+	// Dependencies outside the selected stacks are left out of the order and levels
+	provider := setupOrderTestConfig(t)
+	var output bytes.Buffer
+
+	err := printOrder(&output, provider, "dev", []string{"app", "database"}, true)
+
+	require.NoError(t, err)
+	var report orderReport
+	require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	assert.Equal(t, []string{"database", "app"}, report.DeployOrder)
+	assert.Equal(t, [][]string{{"database"}, {"app"}}, report.Levels)
+}
+
+func TestPrintOrder_LeavesOutStacksDisabledInContext(t *testing.T) {
+	configContent := `
+project: test-project
+region: us-east-1
+
+contexts:
+  dev:
+    account: "123456789012"
+  prod:
+    account: "210987654321"
+
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+  monitoring:
+    template: templates/monitoring.yaml
+    depends_on:
+      - vpc
+    contexts:
+      dev:
+        enabled: false
+`
+	tmpDir := createTempConfigWithTemplates(t, configContent, []string{"vpc.yaml", "monitoring.yaml"})
+	provider := file.NewFileConfigProvider(filepath.Join(tmpDir, "stackaroo.yaml"))
+
+	var devOutput bytes.Buffer
+	require.NoError(t, printOrder(&devOutput, provider, "dev", nil, true))
+	var devReport orderReport
+	require.NoError(t, json.Unmarshal(devOutput.Bytes(), &devReport))
+	assert.Equal(t, []string{"vpc"}, devReport.DeployOrder)
+
+	var prodOutput bytes.Buffer
+	require.NoError(t, printOrder(&prodOutput, provider, "prod", nil, true))
+	var prodReport orderReport
+	require.NoError(t, json.Unmarshal(prodOutput.Bytes(), &prodReport))
+	assert.Equal(t, []string{"vpc", "monitoring"}, prodReport.DeployOrder)
+}
+
+func TestPrintOrder_UnknownStack(t *testing.T) {
+	provider := setupOrderTestConfig(t)
+	var output bytes.Buffer
+
+	err := printOrder(&output, provider, "dev", []string{"missing"}, false)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing")
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"codeberg.org/orien/stackaroo/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// policyManager can be injected for testing
+	policyManager        policy.Manager
+	policyFile           string
+	policyAllowProtected bool
+)
+
+// policyCmd represents the policy command
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Read or replace the stack policy of a deployed stack",
+	Long: `Read or replace the stack policy of a deployed stack without deploying it.
+
+Use 'policy get' to print a stack's current policy, and 'policy set' to
+replace it with a JSON policy document. Setting a policy leaves the stack's
+template, parameters and tags unchanged.
+
+Examples:
+  stackaroo policy get prod database                          # Print the policy of database
+  stackaroo policy set prod database --policy policy.json     # Replace the policy of database`,
+}
+
+// policyGetCmd represents the policy get command
+var policyGetCmd = &cobra.Command{
+	Use:   "get <context> <stack-name>",
+	Short: "Print the stack policy of a deployed stack",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, _ := cmd.Flags().GetString("config")
+
+		return printStackPolicy(context.Background(), cmd.OutOrStdout(), args[0], args[1], configFile)
+	},
+}
+
+// policySetCmd represents the policy set command
+var policySetCmd = &cobra.Command{
+	Use:   "set <context> <stack-name>",
+	Short: "Replace the stack policy of a deployed stack",
+	Long: `Replace the stack policy of a deployed stack with the JSON document in the
+file given by --policy. The stack's template and parameters are not changed.
+
+Setting the policy of a stack in a protected context requires --allow-protected.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if policyFile == "" {
+			return fmt.Errorf("--policy is required")
+		}
+		configFile, _ := cmd.Flags().GetString("config")
+
+		policyBody, err := os.ReadFile(policyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read stack policy file: %w", err)
+		}
+
+		options := policy.Options{AllowProtected: policyAllowProtected}
+		return getPolicyManager(configFile).SetPolicy(context.Background(), args[0], args[1], string(policyBody), options)
+	},
+}
+
+// getPolicyManager returns the policy manager instance, creating a default one if none is set
+func getPolicyManager(configFile string) policy.Manager {
+	if policyManager != nil {
+		return policyManager
+	}
+
+	provider := file.NewConfigProvider(configFile)
+	policyManager = policy.NewStackPolicyManager(provider, getClientFactory())
+	return policyManager
+}
+
+// SetPolicyManager allows injection of a policy manager (for testing)
+func SetPolicyManager(m policy.Manager) {
+	policyManager = m
+}
+
+// printStackPolicy writes the stack policy of a deployed stack, noting when it has none
+func printStackPolicy(ctx context.Context, w io.Writer, contextName, stackName, configFile string) error {
+	policyBody, err := getPolicyManager(configFile).GetPolicy(ctx, contextName, stackName)
+	if err != nil {
+		return err
+	}
+
+	if policyBody == "" {
+		_, err = fmt.Fprintf(w, "Stack %s has no stack policy\n", stackName)
+		return err
+	}
+	_, err = fmt.Fprintln(w, policyBody)
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(policyCmd)
+	policyCmd.AddCommand(policyGetCmd)
+	policyCmd.AddCommand(policySetCmd)
+	policySetCmd.Flags().StringVar(&policyFile, "policy", "", "path to the JSON stack policy document")
+	policySetCmd.Flags().BoolVar(&policyAllowProtected, "allow-protected", false, "allow changing the policy of a stack in a protected context")
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPolicyManager implements the policy.Manager interface for testing
+type MockPolicyManager struct {
+	mock.Mock
+}
+
+func (m *MockPolicyManager) SetPolicy(ctx context.Context, contextName, stackName, policyBody string, options policy.Options) error {
+	args := m.Called(ctx, contextName, stackName, policyBody, options)
+	return args.Error(0)
+}
+
+func (m *MockPolicyManager) GetPolicy(ctx context.Context, contextName, stackName string) (string, error) {
+	args := m.Called(ctx, contextName, stackName)
+	return args.String(0), args.Error(1)
+}
+
+// withMockPolicyManager injects a policy manager and resets the policy flags after the test
+func withMockPolicyManager(t *testing.T) *MockPolicyManager {
+	mockManager := &MockPolicyManager{}
+	oldManager := policyManager
+	SetPolicyManager(mockManager)
+	t.Cleanup(func() {
+		SetPolicyManager(oldManager)
+		policyFile = ""
+		policyAllowProtected = false
+	})
+	return mockManager
+}
+
+func TestPolicyCommand_Exists(t *testing.T) {
+	policyCmd := findCommand(rootCmd, "policy")
+
+	require.NotNil(t, policyCmd, "policy command should be registered")
+	assert.NotNil(t, findCommand(policyCmd, "get"))
+	setCmd := findCommand(policyCmd, "set")
+	require.NotNil(t, setCmd)
+	assert.Equal(t, "set <context> <stack-name>", setCmd.Use)
+	assert.NotNil(t, setCmd.Flags().Lookup("policy"))
+	assert.NotNil(t, setCmd.Flags().Lookup("allow-protected"))
+}
+
+func TestPolicySetCommand_SetsPolicyFromFile(t *testing.T) {
+	mockManager := withMockPolicyManager(t)
+	policyBody := `{"Statement": [{"Effect": "Deny", "Action": "Update:Delete", "Principal": "*", "Resource": "*"}]}`
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(policyBody), 0644))
+	mockManager.On("SetPolicy", mock.Anything, "prod", "database", policyBody, policy.Options{AllowProtected: true}).Return(nil)
+
+	rootCmd.SetArgs([]string{"policy", "set", "prod", "database", "--policy", path, "--allow-protected"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockManager.AssertExpectations(t)
+}
+
+func TestPolicySetCommand_RequiresPolicyFile(t *testing.T) {
+	mockManager := withMockPolicyManager(t)
+
+	rootCmd.SetArgs([]string{"policy", "set", "prod", "database"})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--policy is required")
+	mockManager.AssertNotCalled(t, "SetPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPolicySetCommand_MissingPolicyFile(t *testing.T) {
+	withMockPolicyManager(t)
+
+	rootCmd.SetArgs([]string{"policy", "set", "prod", "database", "--policy", filepath.Join(t.TempDir(), "missing.json")})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read stack policy file")
+}
+
+func TestPrintStackPolicy(t *testing.T) {
+	mockManager := withMockPolicyManager(t)
+	mockManager.On("GetPolicy", mock.Anything, "prod", "database").Return(`{"Statement": []}`, nil)
+	mockManager.On("GetPolicy", mock.Anything, "prod", "app").Return("", nil)
+	mockManager.On("GetPolicy", mock.Anything, "prod", "missing").Return("", errors.New("stack missing does not exist in region us-east-1"))
+
+	var output bytes.Buffer
+	require.NoError(t, printStackPolicy(context.Background(), &output, "prod", "database", ""))
+	assert.Equal(t, "{\"Statement\": []}\n", output.String())
+
+	output.Reset()
+	require.NoError(t, printStackPolicy(context.Background(), &output, "prod", "app", ""))
+	assert.Equal(t, "Stack app has no stack policy\n", output.String())
+
+	err := printStackPolicy(context.Background(), &output, "prod", "missing", "")
+	assert.Error(t, err)
+}
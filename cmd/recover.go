@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"codeberg.org/orien/stackaroo/internal/recovery"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// stackRecoverer can be injected for testing
+	stackRecoverer        recovery.Recoverer
+	recoverSkipResources  []string
+	recoverAllowProtected bool
+)
+
+// recoverCmd represents the recover command
+var recoverCmd = &cobra.Command{
+	Use:   "recover <context> <stack-name>",
+	Short: "Continue the rollback of a stack stuck in UPDATE_ROLLBACK_FAILED",
+	Long: `Recover a stack whose update rollback failed.
+
+A stack in UPDATE_ROLLBACK_FAILED cannot be updated or deployed until its
+rollback is completed. This command asks CloudFormation to continue the
+rollback and waits for the stack to reach UPDATE_ROLLBACK_COMPLETE, after
+which it can be deployed again.
+
+When a resource cannot be rolled back, for example because it was changed or
+deleted outside CloudFormation, name it with --skip-resources. CloudFormation
+marks skipped resources as rolled back without changing them, so fix their
+state by hand afterwards.
+
+A stack in ROLLBACK_FAILED failed during creation and cannot be recovered;
+delete it and deploy it again.
+
+Recovering a stack in a protected context requires --allow-protected.
+
+Examples:
+  stackaroo recover prod app                                # Continue the rollback of app
+  stackaroo recover prod app --skip-resources Database      # Skip a resource that cannot roll back`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contextName := args[0]
+		stackName := args[1]
+		ctx := context.Background()
+
+		configFile, _ := cmd.Flags().GetString("config")
+
+		options := recovery.Options{
+			ResourcesToSkip: recoverSkipResources,
+			AllowProtected:  recoverAllowProtected,
+		}
+
+		return getStackRecoverer(configFile).RecoverStack(ctx, contextName, stackName, options)
+	},
+}
+
+// getStackRecoverer returns the stack recoverer instance, creating a default one if none is set
+func getStackRecoverer(configFile string) recovery.Recoverer {
+	if stackRecoverer != nil {
+		return stackRecoverer
+	}
+
+	provider := file.NewConfigProvider(configFile)
+	stackRecoverer = recovery.NewStackRecoverer(provider, getClientFactory())
+	return stackRecoverer
+}
+
+// SetStackRecoverer allows injection of a stack recoverer (for testing)
+func SetStackRecoverer(r recovery.Recoverer) {
+	stackRecoverer = r
+}
+
+func init() {
+	rootCmd.AddCommand(recoverCmd)
+	recoverCmd.Flags().StringSliceVar(&recoverSkipResources, "skip-resources", nil, "logical IDs of resources to skip while continuing the rollback")
+	recoverCmd.Flags().BoolVar(&recoverAllowProtected, "allow-protected", false, "allow recovering a stack in a protected context")
+}
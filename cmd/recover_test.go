@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/recovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockStackRecoverer implements the recovery.Recoverer interface for testing
+type MockStackRecoverer struct {
+	mock.Mock
+}
+
+func (m *MockStackRecoverer) RecoverStack(ctx context.Context, contextName, stackName string, options recovery.Options) error {
+	args := m.Called(ctx, contextName, stackName, options)
+	return args.Error(0)
+}
+
+func (m *MockStackRecoverer) CancelUpdate(ctx context.Context, contextName, stackName string, options recovery.CancelOptions) error {
+	args := m.Called(ctx, contextName, stackName, options)
+	return args.Error(0)
+}
+
+// withMockStackRecoverer injects a stack recoverer and resets the recover flags after the test
+func withMockStackRecoverer(t *testing.T) *MockStackRecoverer {
+	mockRecoverer := &MockStackRecoverer{}
+	oldRecoverer := stackRecoverer
+	SetStackRecoverer(mockRecoverer)
+	t.Cleanup(func() {
+		SetStackRecoverer(oldRecoverer)
+		recoverSkipResources = nil
+		recoverAllowProtected = false
+		cancelNoWait = false
+		cancelAllowProtected = false
+	})
+	return mockRecoverer
+}
+
+func TestRecoverCommand_Exists(t *testing.T) {
+	recoverCmd := findCommand(rootCmd, "recover")
+
+	require.NotNil(t, recoverCmd, "recover command should be registered")
+	assert.Equal(t, "recover <context> <stack-name>", recoverCmd.Use)
+	assert.NotNil(t, recoverCmd.Flags().Lookup("skip-resources"))
+}
+
+func TestRecoverCommand_RequiresExactlyTwoArgs(t *testing.T) {
+	recoverCmd := findCommand(rootCmd, "recover")
+	require.NotNil(t, recoverCmd)
+
+	assert.NoError(t, recoverCmd.Args(recoverCmd, []string{"dev", "app"}))
+	assert.Error(t, recoverCmd.Args(recoverCmd, []string{"dev"}))
+}
+
+func TestRecoverCommand_PassesSkipResources(t *testing.T) {
+	mockRecoverer := withMockStackRecoverer(t)
+	mockRecoverer.On("RecoverStack", mock.Anything, "prod", "app", recovery.Options{ResourcesToSkip: []string{"Database", "Cache"}}).Return(nil)
+
+	rootCmd.SetArgs([]string{"recover", "prod", "app", "--skip-resources", "Database,Cache"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockRecoverer.AssertExpectations(t)
+}
+
+func TestRecoverCommand_AllowProtectedFlag(t *testing.T) {
+	mockRecoverer := withMockStackRecoverer(t)
+	mockRecoverer.On("RecoverStack", mock.Anything, "prod", "app", recovery.Options{AllowProtected: true}).Return(nil)
+
+	rootCmd.SetArgs([]string{"recover", "prod", "app", "--allow-protected"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockRecoverer.AssertExpectations(t)
+}
+
+func TestRecoverCommand_ReturnsRecoveryError(t *testing.T) {
+	mockRecoverer := withMockStackRecoverer(t)
+	mockRecoverer.On("RecoverStack", mock.Anything, "prod", "app", recovery.Options{}).Return(errors.New("rollback failed"))
+
+	rootCmd.SetArgs([]string{"recover", "prod", "app"})
+	err := rootCmd.Execute()
+
+	assert.EqualError(t, err, "rollback failed")
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"codeberg.org/orien/stackaroo/internal/delete"
+	"codeberg.org/orien/stackaroo/internal/deploy"
+	"codeberg.org/orien/stackaroo/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recreateForce          bool
+	recreateSkipAccount    bool
+	recreateAllowProtected bool
+)
+
+// recreateCmd represents the recreate command
+var recreateCmd = &cobra.Command{
+	Use:   "recreate <context> <stack-name>",
+	Short: "Delete a stack and deploy it again from scratch",
+	Long: `Delete a stack and deploy it again, for changes CloudFormation cannot apply in place.
+
+After a single confirmation covering both steps, the stack is deleted and the
+deletion is waited on, then the stack is resolved and created fresh from its
+configuration. If the deletion fails, the recreate stops and nothing is
+deployed. A stack that does not exist yet is simply created.
+
+Recreating a stack that other configured stacks depend on is refused, as with
+delete; use --force to recreate it anyway. --force also disables termination
+protection before the deletion.
+
+When a context sets an account, the AWS credentials in use are checked against
+it first. Use --skip-account-check to bypass this check. Recreating a stack in
+a protected context requires --allow-protected.
+
+Examples:
+  stackaroo recreate dev queue            # Delete and redeploy queue after one confirmation
+  stackaroo recreate dev vpc --force      # Recreate even though other stacks depend on vpc
+
+CAUTION: Every resource in the stack is deleted, along with its data, before
+the stack is created again.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contextName := args[0]
+		stackName := args[1]
+		ctx := context.Background()
+
+		configFile, _ := cmd.Flags().GetString("config")
+		return recreateStack(ctx, stackName, contextName, configFile)
+	},
+}
+
+// recreateStack deletes a stack and deploys it again after one confirmation, stopping if the deletion fails
+func recreateStack(ctx context.Context, stackName, contextName, configFile string) error {
+	message := fmt.Sprintf("Do you want to recreate stack %s in context %s? It will be deleted with all its resources, then deployed again. This cannot be undone.", stackName, contextName)
+	confirmed, err := prompt.Confirm(message)
+	if err != nil {
+		return fmt.Errorf("failed to get user confirmation: %w", err)
+	}
+	if !confirmed {
+		fmt.Printf("Recreate of stack %s cancelled by user\n", stackName)
+		return nil
+	}
+
+	deleteOptions := delete.Options{
+		Force:            recreateForce,
+		SkipAccountCheck: recreateSkipAccount,
+		AllowProtected:   recreateAllowProtected,
+		Confirmed:        true,
+	}
+	if err := getDeleter(configFile).DeleteSingleStack(ctx, stackName, contextName, deleteOptions); err != nil {
+		return fmt.Errorf("recreate of stack %s aborted because it could not be deleted: %w", stackName, err)
+	}
+
+	deployOptions := deploy.Options{
+		SkipAccountCheck: recreateSkipAccount,
+		AllowProtected:   recreateAllowProtected,
+		Confirmed:        true,
+	}
+	if err := getDeployer(configFile, nil).DeploySingleStack(ctx, stackName, contextName, deployOptions); err != nil {
+		return fmt.Errorf("stack %s was deleted but could not be deployed again: %w", stackName, err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(recreateCmd)
+
+	recreateCmd.Flags().BoolVar(&recreateForce, "force", false, "recreate the stack even if other stacks depend on it or it has termination protection enabled")
+	recreateCmd.Flags().BoolVar(&recreateSkipAccount, "skip-account-check", false, "recreate even if the credentials belong to a different account than the context")
+	recreateCmd.Flags().BoolVar(&recreateAllowProtected, "allow-protected", false, "allow recreating a stack in a protected context")
+}
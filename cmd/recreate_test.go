@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/delete"
+	"codeberg.org/orien/stackaroo/internal/deploy"
+	"codeberg.org/orien/stackaroo/internal/prompt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// withRecreateMocks injects a deleter, deployer and prompter that answers the confirmation,
+// and resets the recreate flags after the test
+func withRecreateMocks(t *testing.T, confirm bool) (*delete.MockDeleter, *deploy.MockDeployer) {
+	mockDeleter := &delete.MockDeleter{}
+	mockDeployer := &deploy.MockDeployer{}
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(confirm, nil)
+
+	oldDeleter := deleter
+	oldDeployer := deployer
+	oldPrompter := prompt.GetDefaultPrompter()
+	SetDeleter(mockDeleter)
+	SetDeployer(mockDeployer)
+	prompt.SetPrompter(mockPrompter)
+	t.Cleanup(func() {
+		SetDeleter(oldDeleter)
+		SetDeployer(oldDeployer)
+		prompt.SetPrompter(oldPrompter)
+		recreateForce = false
+		recreateSkipAccount = false
+		recreateAllowProtected = false
+	})
+	return mockDeleter, mockDeployer
+}
+
+func TestRecreateCommand_Exists(t *testing.T) {
+	recreateCmd := findCommand(rootCmd, "recreate")
+
+	require.NotNil(t, recreateCmd, "recreate command should be registered")
+	assert.Equal(t, "recreate <context> <stack-name>", recreateCmd.Use)
+	assert.NotNil(t, recreateCmd.Flags().Lookup("force"))
+	assert.NoError(t, recreateCmd.Args(recreateCmd, []string{"dev", "queue"}))
+	assert.Error(t, recreateCmd.Args(recreateCmd, []string{"dev"}))
+}
+
+func TestRecreateCommand_DeletesThenDeploys(t *testing.T) {
+	mockDeleter, mockDeployer := withRecreateMocks(t, true)
+
+	var calls []string
+	mockDeleter.On("DeleteSingleStack", mock.Anything, "queue", "dev", delete.Options{Force: true, Confirmed: true}).
+		Run(func(mock.Arguments) { calls = append(calls, "delete") }).Return(nil)
+	mockDeployer.On("DeploySingleStack", mock.Anything, "queue", "dev", deploy.Options{Confirmed: true}).
+		Run(func(mock.Arguments) { calls = append(calls, "deploy") }).Return(nil)
+
+	rootCmd.SetArgs([]string{"recreate", "dev", "queue", "--force"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"delete", "deploy"}, calls)
+	mockDeleter.AssertExpectations(t)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestRecreateCommand_DeleteFailureSkipsDeploy(t *testing.T) {
+	mockDeleter, mockDeployer := withRecreateMocks(t, true)
+	mockDeleter.On("DeleteSingleStack", mock.Anything, "queue", "dev", mock.Anything).Return(errors.New("stack deletion failed"))
+
+	rootCmd.SetArgs([]string{"recreate", "dev", "queue"})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "recreate of stack queue aborted")
+	assert.Contains(t, err.Error(), "stack deletion failed")
+	mockDeployer.AssertNotCalled(t, "DeploySingleStack", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecreateCommand_DeclinedDoesNothing(t *testing.T) {
+	mockDeleter, mockDeployer := withRecreateMocks(t, false)
+
+	rootCmd.SetArgs([]string{"recreate", "dev", "queue"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockDeleter.AssertNotCalled(t, "DeleteSingleStack", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockDeployer.AssertNotCalled(t, "DeploySingleStack", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
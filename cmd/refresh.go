@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+
+	"codeberg.org/orien/stackaroo/internal/deploy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	refreshAllowProtected   bool
+	refreshSkipAccountCheck bool
+	refreshEvents           string
+)
+
+// refreshCmd represents the refresh command
+var refreshCmd = &cobra.Command{
+	Use:   "refresh <context> <stack-name>",
+	Short: "Apply parameter and tag changes to a stack without changing its template",
+	Long: `Apply configuration-only changes to a deployed stack.
+
+The stack is resolved as for deploy. If its configured template matches the
+deployed template, the parameter and tag changes are shown and, once
+confirmed, the stack is updated with them while CloudFormation keeps the
+deployed template. No changeset is created, making this faster than deploy for
+changes to parameters or tags alone, and templates stored in S3 or too large
+to send inline are refreshed the same way.
+
+If the template has changed, nothing is updated and the command fails; use
+'stackaroo deploy' to review and apply template changes.
+
+As with deploy, the AWS credentials are checked against the context's account
+unless --skip-account-check is given, refreshing a stack in a protected context
+requires --allow-protected and typing the context name, and --yes skips the
+confirmation.
+
+Examples:
+  stackaroo refresh dev app        # Apply new parameter values and tags to app`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contextName := args[0]
+		stackName := args[1]
+		ctx := context.Background()
+
+		jsonEvents, err := isJSONEvents(refreshEvents)
+		if err != nil {
+			return err
+		}
+
+		configFile, _ := cmd.Flags().GetString("config")
+
+		options := deploy.Options{
+			AllowProtected:   refreshAllowProtected,
+			SkipAccountCheck: refreshSkipAccountCheck,
+			JSONEvents:       jsonEvents,
+		}
+		return getDeployer(configFile, nil).RefreshSingleStack(ctx, stackName, contextName, options)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(refreshCmd)
+	refreshCmd.Flags().BoolVar(&refreshAllowProtected, "allow-protected", false, "allow refreshing a stack in a protected context")
+	refreshCmd.Flags().BoolVar(&refreshSkipAccountCheck, "skip-account-check", false, "refresh even if the credentials belong to a different account than the context")
+	refreshCmd.Flags().StringVar(&refreshEvents, "events", "text", "stack event format: text or json (one JSON object per line)")
+}
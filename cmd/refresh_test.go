@@ -0,0 +1,67 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// withMockRefreshDeployer injects a deployer and resets the refresh flags after the test
+func withMockRefreshDeployer(t *testing.T) *deploy.MockDeployer {
+	mockDeployer := &deploy.MockDeployer{}
+	oldDeployer := deployer
+	SetDeployer(mockDeployer)
+	t.Cleanup(func() {
+		SetDeployer(oldDeployer)
+		refreshAllowProtected = false
+		refreshSkipAccountCheck = false
+		refreshEvents = "text"
+	})
+	return mockDeployer
+}
+
+func TestRefreshCommand_Exists(t *testing.T) {
+	refreshCmd := findCommand(rootCmd, "refresh")
+
+	require.NotNil(t, refreshCmd, "refresh command should be registered")
+	assert.Equal(t, "refresh <context> <stack-name>", refreshCmd.Use)
+	assert.NotNil(t, refreshCmd.Flags().Lookup("allow-protected"))
+	assert.NotNil(t, refreshCmd.Flags().Lookup("skip-account-check"))
+	assert.NoError(t, refreshCmd.Args(refreshCmd, []string{"dev", "app"}))
+	assert.Error(t, refreshCmd.Args(refreshCmd, []string{"dev"}))
+}
+
+func TestRefreshCommand_PassesOptions(t *testing.T) {
+	mockDeployer := withMockRefreshDeployer(t)
+	mockDeployer.On("RefreshSingleStack", mock.Anything, "app", "prod", deploy.Options{
+		AllowProtected:   true,
+		SkipAccountCheck: true,
+		JSONEvents:       true,
+	}).Return(nil)
+
+	rootCmd.SetArgs([]string{"refresh", "prod", "app", "--allow-protected", "--skip-account-check", "--events", "json"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestRefreshCommand_ReturnsTemplateChangedError(t *testing.T) {
+	mockDeployer := withMockRefreshDeployer(t)
+	mockDeployer.On("RefreshSingleStack", mock.Anything, "app", "dev", deploy.Options{}).
+		Return(deploy.TemplateChangedError{StackName: "app"})
+
+	rootCmd.SetArgs([]string{"refresh", "dev", "app"})
+	err := rootCmd.Execute()
+
+	assert.True(t, errors.As(err, &deploy.TemplateChangedError{}))
+	assert.EqualError(t, err, "the template of stack app has changed; use 'stackaroo deploy' to apply template changes")
+}
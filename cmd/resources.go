@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"codeberg.org/orien/stackaroo/internal/resources"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// resourceLister can be injected for testing
+	resourceLister  resources.Lister
+	resourcesOutput string
+)
+
+// resourcesCmd represents the resources command
+var resourcesCmd = &cobra.Command{
+	Use:   "resources <context> <stack-name>",
+	Short: "List the resources a deployed stack manages",
+	Long: `List every resource managed by a deployed CloudFormation stack.
+
+For each resource this command shows its logical ID, resource type, current
+status and physical ID, giving a quick inventory of a stack without opening
+the AWS console. Resources not created yet have no physical ID.
+
+Use --output json to print the resources as a JSON document for tooling.
+
+Examples:
+  stackaroo resources prod app                 # List the resources of app in prod
+  stackaroo resources dev vpc --output json    # Print the resources of vpc as JSON`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contextName := args[0]
+		stackName := args[1]
+		ctx := context.Background()
+
+		jsonOutput, err := isJSONOutput(resourcesOutput)
+		if err != nil {
+			return err
+		}
+
+		configFile, _ := cmd.Flags().GetString("config")
+
+		return listResources(ctx, contextName, stackName, configFile, jsonOutput)
+	},
+}
+
+// getResourceLister returns the resource lister instance, creating a default one if none is set
+func getResourceLister(configFile string) resources.Lister {
+	if resourceLister != nil {
+		return resourceLister
+	}
+
+	provider := file.NewConfigProvider(configFile)
+	resourceLister = resources.NewStackLister(provider, getClientFactory())
+	return resourceLister
+}
+
+// SetResourceLister allows injection of a resource lister (for testing)
+func SetResourceLister(l resources.Lister) {
+	resourceLister = l
+}
+
+// listResources prints the resources of a deployed stack as a table or JSON document
+func listResources(ctx context.Context, contextName, stackName, configFile string, jsonOutput bool) error {
+	stackResources, err := getResourceLister(configFile).ListResources(ctx, contextName, stackName)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := resources.JSON(contextName, stackName, stackResources)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(data))
+		return nil
+	}
+
+	fmt.Print(resources.FormatResources(stackName, stackResources))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(resourcesCmd)
+	resourcesCmd.Flags().StringVar(&resourcesOutput, "output", "text", "output format: text or json")
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockResourceLister implements the resources.Lister interface for testing
+type MockResourceLister struct {
+	mock.Mock
+}
+
+func (m *MockResourceLister) ListResources(ctx context.Context, contextName, stackName string) ([]aws.StackResource, error) {
+	args := m.Called(ctx, contextName, stackName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]aws.StackResource), args.Error(1)
+}
+
+// withMockResourceLister injects a resource lister and resets the resources flags after the test
+func withMockResourceLister(t *testing.T) *MockResourceLister {
+	mockLister := &MockResourceLister{}
+	oldLister := resourceLister
+	SetResourceLister(mockLister)
+	t.Cleanup(func() {
+		SetResourceLister(oldLister)
+		resourcesOutput = "text"
+	})
+	return mockLister
+}
+
+func TestResourcesCommand_Exists(t *testing.T) {
+	resourcesCmd := findCommand(rootCmd, "resources")
+
+	require.NotNil(t, resourcesCmd, "resources command should be registered")
+	assert.Equal(t, "resources <context> <stack-name>", resourcesCmd.Use)
+	assert.NotNil(t, resourcesCmd.Flags().Lookup("output"))
+}
+
+func TestResourcesCommand_ListsResources(t *testing.T) {
+	mockLister := withMockResourceLister(t)
+	mockLister.On("ListResources", mock.Anything, "prod", "app").Return([]aws.StackResource{
+		{LogicalID: "Bucket", PhysicalID: "app-bucket-123", ResourceType: "AWS::S3::Bucket", Status: "CREATE_COMPLETE"},
+	}, nil)
+
+	rootCmd.SetArgs([]string{"resources", "prod", "app", "--output", "json"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	mockLister.AssertExpectations(t)
+}
+
+func TestResourcesCommand_PropagatesError(t *testing.T) {
+	mockLister := withMockResourceLister(t)
+	mockLister.On("ListResources", mock.Anything, "prod", "app").Return(nil, errors.New("stack app does not exist in region us-east-1"))
+
+	rootCmd.SetArgs([]string{"resources", "prod", "app"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "stack app does not exist in region us-east-1")
+}
+
+func TestResourcesCommand_RejectsUnknownOutput(t *testing.T) {
+	mockLister := withMockResourceLister(t)
+
+	rootCmd.SetArgs([]string{"resources", "prod", "app", "--output", "yaml"})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported output format "yaml"`)
+	mockLister.AssertNotCalled(t, "ListResources", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -6,9 +6,13 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"charm.land/lipgloss/v2"
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/log"
+	"codeberg.org/orien/stackaroo/internal/prompt"
 	"codeberg.org/orien/stackaroo/internal/version"
 	"github.com/charmbracelet/fang"
 	"github.com/spf13/cobra"
@@ -30,6 +34,55 @@ var rootCmd = &cobra.Command{
 
 Use stackaroo to deploy, update, delete, diff, and monitor your CloudFormation stacks
 across multiple contexts with consistent, repeatable configurations.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		prompt.SetAutoApprove(assumeYes)
+		if err := configureLogging(cmd); err != nil {
+			return err
+		}
+		if err := aws.ValidateOnFailure(onFailureOverride); err != nil {
+			return fmt.Errorf("--on-failure: %w", err)
+		}
+		if onFailureOverride != "" && disableRollbackOverride {
+			return fmt.Errorf("--on-failure cannot be combined with --disable-rollback")
+		}
+		return aws.ValidateEndpointURL(endpointURL)
+	},
+}
+
+var (
+	// endpointURL overrides the endpoint of every AWS client, for example to target LocalStack
+	endpointURL string
+
+	// onFailureOverride and disableRollbackOverride replace the on_failure of every stack
+	// created by this invocation, for example to keep failed resources while debugging
+	onFailureOverride       string
+	disableRollbackOverride bool
+
+	// assumeYes answers every confirmation prompt with yes, for automation
+	assumeYes bool
+
+	// logLevel and logFormat control the diagnostic log written to standard error
+	logLevel  string
+	logFormat string
+)
+
+// configureLogging sets the logger components pick up from the log flags. --verbose
+// enables debug records unless --log-level is given explicitly.
+func configureLogging(cmd *cobra.Command) error {
+	level := logLevel
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose && !cmd.Flags().Changed("log-level") {
+		level = "debug"
+	}
+	if _, err := log.ParseLevel(level); err != nil {
+		return fmt.Errorf("--log-level: %w", err)
+	}
+
+	logger, err := log.New(cmd.ErrOrStderr(), level, logFormat)
+	if err != nil {
+		return fmt.Errorf("--log-format: %w", err)
+	}
+	log.SetDefault(logger)
+	return nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -48,8 +101,14 @@ func init() {
 	rootCmd.SetVersionTemplate(version.Info() + "\n")
 
 	// Global flags
-	rootCmd.PersistentFlags().StringP("config", "c", "stackaroo.yaml", "configuration file")
+	rootCmd.PersistentFlags().StringP("config", "c", "stackaroo.yaml", "configuration file or https:// URL")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&endpointURL, "endpoint-url", "", "send AWS requests to this endpoint instead of AWS (e.g. http://localhost:4566 for LocalStack)")
+	rootCmd.PersistentFlags().StringVar(&onFailureOverride, "on-failure", "", "action when creating a stack fails, overriding its on_failure for this run: ROLLBACK, DELETE or DO_NOTHING")
+	rootCmd.PersistentFlags().BoolVar(&disableRollbackOverride, "disable-rollback", false, "keep the resources of failed stack creations in this run instead of rolling back")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "warn", "minimum level of diagnostic log records written to stderr: debug, info, warn or error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", log.FormatText, "format of diagnostic log records: text or json")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "answer yes to every confirmation prompt, including typing the name of a protected context")
 }
 
 // RootCommand returns the root cobra command for documentation or tooling usage.
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"codeberg.org/orien/stackaroo/internal/log"
 	"codeberg.org/orien/stackaroo/internal/version"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -48,6 +49,91 @@ func TestRootCmd_GlobalFlags(t *testing.T) {
 	assert.Equal(t, "v", verboseFlag.Shorthand)
 	assert.Contains(t, verboseFlag.Usage, "verbose output")
 
+	// Test endpoint URL flag
+	endpointFlag := flags.Lookup("endpoint-url")
+	require.NotNil(t, endpointFlag)
+	assert.Equal(t, "", endpointFlag.DefValue)
+	assert.Contains(t, endpointFlag.Usage, "LocalStack")
+
+	// Test yes flag
+	yesFlag := flags.Lookup("yes")
+	require.NotNil(t, yesFlag)
+	assert.Equal(t, "false", yesFlag.DefValue)
+	assert.Equal(t, "y", yesFlag.Shorthand)
+}
+
+func TestRootCmd_InvalidEndpointURL(t *testing.T) {
+	defer func() { endpointURL = "" }()
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"list", "contexts", "--endpoint-url", "localhost:4566"})
+
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid endpoint URL "localhost:4566"`)
+}
+
+func TestRootCmd_OnFailureOverride(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{name: "invalid action", args: []string{"--on-failure", "KEEP"}, expectedError: `--on-failure: invalid on_failure value "KEEP"`},
+		{name: "combined with disable-rollback", args: []string{"--on-failure", "DELETE", "--disable-rollback"}, expectedError: "--on-failure cannot be combined with --disable-rollback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				onFailureOverride = ""
+				disableRollbackOverride = false
+			}()
+
+			var buf bytes.Buffer
+			rootCmd.SetOut(&buf)
+			rootCmd.SetErr(&buf)
+			rootCmd.SetArgs(append([]string{"list", "contexts"}, tt.args...))
+
+			err := rootCmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestRootCmd_LogFlags(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{name: "invalid level", args: []string{"--log-level", "trace"}, expectedError: `--log-level: unsupported log level "trace"`},
+		{name: "invalid format", args: []string{"--log-format", "xml"}, expectedError: `--log-format: unsupported log format "xml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				logLevel = "warn"
+				logFormat = log.FormatText
+			}()
+
+			var buf bytes.Buffer
+			rootCmd.SetOut(&buf)
+			rootCmd.SetErr(&buf)
+			rootCmd.SetArgs(append([]string{"list", "contexts"}, tt.args...))
+
+			err := rootCmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
 }
 
 func TestRootCmd_Help(t *testing.T) {
@@ -0,0 +1,95 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"codeberg.org/orien/stackaroo/internal/config/file"
+	"codeberg.org/orien/stackaroo/internal/status"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// statusChecker can be injected for testing
+	statusChecker status.Checker
+	statusAll     bool
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status <context> [stack-name]",
+	Short: "Show the live status of configured stacks",
+	Long: `Show the current CloudFormation status of configured stacks.
+
+For each stack this command shows its status, when it was last updated and
+the result of the most recent drift detection, if any. Stacks that have not
+been deployed yet are shown as NOT DEPLOYED.
+
+Name a single stack, or use --all to show every stack configured in the
+context. The command exits with a non-zero status when any stack is in a
+failed state.
+
+Examples:
+  stackaroo status dev --all        # Show every stack in dev context
+  stackaroo status prod app         # Show the app stack in prod context`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contextName := args[0]
+		ctx := context.Background()
+
+		var stackNames []string
+		switch {
+		case len(args) > 1 && statusAll:
+			return fmt.Errorf("cannot combine a stack name with --all")
+		case len(args) > 1:
+			stackNames = []string{args[1]}
+		case !statusAll:
+			return fmt.Errorf("specify a stack name or use --all to show every stack")
+		}
+
+		configFile, _ := cmd.Flags().GetString("config")
+
+		return showStatus(ctx, contextName, stackNames, configFile)
+	},
+}
+
+// getStatusChecker returns the status checker instance, creating a default one if none is set
+func getStatusChecker(configFile string) status.Checker {
+	if statusChecker != nil {
+		return statusChecker
+	}
+
+	provider := file.NewConfigProvider(configFile)
+	statusChecker = status.NewStackChecker(provider, getClientFactory())
+	return statusChecker
+}
+
+// SetStatusChecker allows injection of a status checker (for testing)
+func SetStatusChecker(c status.Checker) {
+	statusChecker = c
+}
+
+// showStatus prints the status of the given stacks, or all configured stacks when none are given
+func showStatus(ctx context.Context, contextName string, stackNames []string, configFile string) error {
+	statuses, err := getStatusChecker(configFile).CheckStatus(ctx, contextName, stackNames)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(status.FormatStatuses(contextName, statuses))
+
+	if failed := status.FailedStacks(statuses); len(failed) > 0 {
+		return status.FailedStacksError{StackNames: failed}
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().BoolVar(&statusAll, "all", false, "show every stack configured in the context")
+}
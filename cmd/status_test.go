@@ -0,0 +1,106 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockStatusChecker implements the status.Checker interface for testing
+type MockStatusChecker struct {
+	mock.Mock
+}
+
+func (m *MockStatusChecker) CheckStatus(ctx context.Context, contextName string, stackNames []string) ([]status.StackStatus, error) {
+	args := m.Called(ctx, contextName, stackNames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]status.StackStatus), args.Error(1)
+}
+
+// withMockStatusChecker injects a status checker and resets the status flags after the test
+func withMockStatusChecker(t *testing.T) *MockStatusChecker {
+	mockChecker := &MockStatusChecker{}
+	oldChecker := statusChecker
+	SetStatusChecker(mockChecker)
+	t.Cleanup(func() {
+		SetStatusChecker(oldChecker)
+		statusAll = false
+		_ = statusCmd.Flags().Set("all", "false")
+	})
+	return mockChecker
+}
+
+func TestStatusCommand_Exists(t *testing.T) {
+	statusCmd := findCommand(rootCmd, "status")
+
+	assert.NotNil(t, statusCmd, "status command should be registered")
+	assert.Equal(t, "status <context> [stack-name]", statusCmd.Use)
+	assert.NotNil(t, statusCmd.Flags().Lookup("all"))
+}
+
+func TestStatusCommand_AllStacks(t *testing.T) {
+	mockChecker := withMockStatusChecker(t)
+	mockChecker.On("CheckStatus", mock.Anything, "dev", []string(nil)).Return([]status.StackStatus{
+		{Name: "app", Status: "CREATE_COMPLETE"},
+		{Name: "db", Status: status.NotDeployed},
+	}, nil)
+
+	rootCmd.SetArgs([]string{"status", "dev", "--all"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockChecker.AssertExpectations(t)
+}
+
+func TestStatusCommand_SingleStack(t *testing.T) {
+	mockChecker := withMockStatusChecker(t)
+	mockChecker.On("CheckStatus", mock.Anything, "dev", []string{"app"}).Return([]status.StackStatus{
+		{Name: "app", Status: "UPDATE_COMPLETE"},
+	}, nil)
+
+	rootCmd.SetArgs([]string{"status", "dev", "app"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	mockChecker.AssertExpectations(t)
+}
+
+func TestStatusCommand_FailsWhenStackFailed(t *testing.T) {
+	mockChecker := withMockStatusChecker(t)
+	mockChecker.On("CheckStatus", mock.Anything, "dev", []string(nil)).Return([]status.StackStatus{
+		{Name: "app", Status: "UPDATE_ROLLBACK_FAILED"},
+		{Name: "db", Status: "CREATE_COMPLETE"},
+	}, nil)
+
+	rootCmd.SetArgs([]string{"status", "dev", "--all"})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	var failedErr status.FailedStacksError
+	require.ErrorAs(t, err, &failedErr)
+	assert.Equal(t, []string{"app"}, failedErr.StackNames)
+}
+
+func TestStatusCommand_RequiresStackNameOrAll(t *testing.T) {
+	withMockStatusChecker(t)
+
+	rootCmd.SetArgs([]string{"status", "dev"})
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "specify a stack name or use --all")
+
+	rootCmd.SetArgs([]string{"status", "dev", "app", "--all"})
+	err = rootCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot combine a stack name with --all")
+}
@@ -6,12 +6,16 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
 
 	"codeberg.org/orien/stackaroo/internal/validate"
 	"github.com/spf13/cobra"
 )
 
 var (
+	validateOutput string
+
 	// validator can be injected for testing
 	validator validate.Validator
 )
@@ -26,20 +30,47 @@ This command validates templates for syntax errors, valid resource types,
 parameter definitions, and other AWS-specific requirements. It provides
 fast feedback during development without requiring deployment.
 
+Before calling AWS, each template's Ref, Fn::GetAtt and DependsOn uses are
+checked against the parameters and resources it defines, and references to
+undefined logical IDs are reported. Templates declaring a Transform are not
+checked this way, as macros add resources of their own.
+
+Parameters a template declares but never references are reported as warnings,
+which do not fail validation.
+
 If no stack name is provided, all stacks in the context will be validated.
 
+Use --output json to print the findings as a JSON document for CI dashboards.
+Each finding names its stack, template file, location, severity (error or
+warning) and message. The command still exits non-zero if any finding is an
+error.
+
 Examples:
-  stackaroo validate dev            # Validate all stacks in dev context
-  stackaroo validate dev vpc        # Validate single stack
-  stackaroo validate prod           # Validate all stacks in production`,
+  stackaroo validate dev                # Validate all stacks in dev context
+  stackaroo validate dev vpc            # Validate single stack
+  stackaroo validate prod               # Validate all stacks in production
+  stackaroo validate dev --output json  # Machine-readable findings`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		contextName := args[0]
 		ctx := context.Background()
 
+		jsonOutput, err := isJSONOutput(validateOutput)
+		if err != nil {
+			return err
+		}
+
 		configFile, _ := cmd.Flags().GetString("config")
 		v := getValidator(configFile)
 
+		if jsonOutput {
+			var stackName string
+			if len(args) > 1 {
+				stackName = args[1]
+			}
+			return writeValidationReport(ctx, cmd.OutOrStdout(), v, contextName, stackName)
+		}
+
 		if len(args) > 1 {
 			stackName := args[1]
 			return v.ValidateSingleStack(ctx, stackName, contextName)
@@ -48,6 +79,21 @@ Examples:
 	},
 }
 
+// writeValidationReport prints the findings of validating stacks as JSON, failing if any is an error
+func writeValidationReport(ctx context.Context, w io.Writer, v validate.Validator, contextName, stackName string) error {
+	report, err := v.Report(ctx, contextName, stackName)
+	if err != nil {
+		return err
+	}
+	if err := writeListJSON(w, report); err != nil {
+		return err
+	}
+	if !report.Valid {
+		return fmt.Errorf("validation failed for one or more stacks")
+	}
+	return nil
+}
+
 // getValidator returns the validator instance, creating a default one if none is set
 func getValidator(configFile string) validate.Validator {
 	if validator != nil {
@@ -67,4 +113,6 @@ func SetValidator(v validate.Validator) {
 
 func init() {
 	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().StringVar(&validateOutput, "output", "text", "output format: text or json")
 }
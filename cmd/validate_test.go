@@ -5,10 +5,15 @@ SPDX-License-Identifier: BSD-3-Clause
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/validate"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -234,3 +239,111 @@ func TestValidateCommand_ErrorPropagation(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCommand_JSONOutput_MixedFindings(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "stackaroo.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+project: test-project
+contexts:
+  dev:
+    region: us-east-1
+stacks:
+  app:
+    template: templates/app.yaml
+  broken:
+    template: templates/broken.yaml
+  rejected:
+    template: templates/rejected.yaml
+`), 0644))
+
+	templates := map[string]string{
+		// Valid, but declares a parameter it never uses
+		"app.yaml": "Parameters:\n  Unused:\n    Type: String\n    Default: x\nResources:\n  Queue:\n    Type: AWS::SQS::Queue\n",
+		// References a resource the template does not define
+		"broken.yaml": "Resources:\n  Policy:\n    Type: AWS::SQS::QueuePolicy\n    Properties:\n      Queues: [!Ref Queue]\n",
+		// Rejected by CloudFormation
+		"rejected.yaml": "Resources:\n  Widget:\n    Type: AWS::Made::Up\n",
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "templates"), 0755))
+	for name, content := range templates {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "templates", name), []byte(content), 0644))
+	}
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockCfnOps.On("ValidateTemplate", mock.Anything, templates["app.yaml"]).Return(nil)
+	mockCfnOps.On("ValidateTemplate", mock.Anything, templates["rejected.yaml"]).
+		Return(errors.New("api error ValidationError: Template format error: Unrecognized resource types: [AWS::Made::Up]"))
+
+	oldFactory := clientFactory
+	clientFactory = mockFactory
+	SetValidator(nil)
+	defer func() {
+		clientFactory = oldFactory
+		SetValidator(nil)
+		validateOutput = "text"
+		rootCmd.SetOut(nil)
+	}()
+
+	var output bytes.Buffer
+	rootCmd.SetOut(&output)
+	rootCmd.SetArgs([]string{"validate", "dev", "--config", configPath, "--output", "json"})
+	err := rootCmd.Execute()
+
+	require.EqualError(t, err, "validation failed for one or more stacks")
+
+	var report validate.Report
+	// Cobra follows the report with usage text when the command fails
+	require.NoError(t, json.NewDecoder(&output).Decode(&report))
+	assert.Equal(t, "dev", report.Context)
+	assert.False(t, report.Valid)
+	assert.Equal(t, []validate.Finding{
+		{
+			Stack:    "app",
+			File:     filepath.Join(tmpDir, "templates", "app.yaml"),
+			Location: "Parameters.Unused",
+			Severity: validate.SeverityWarning,
+			Message:  "parameter 'Unused' is declared but never referenced",
+		},
+		{
+			Stack:    "broken",
+			File:     filepath.Join(tmpDir, "templates", "broken.yaml"),
+			Location: "Resources.Policy",
+			Severity: validate.SeverityError,
+			Message:  "Ref refers to 'Queue', which the template does not define",
+		},
+		{
+			Stack:    "rejected",
+			File:     filepath.Join(tmpDir, "templates", "rejected.yaml"),
+			Severity: validate.SeverityError,
+			Message:  "Invalid Resource Type: Resource type 'AWS::Made::Up' is not recognized by CloudFormation",
+		},
+	}, report.Findings)
+}
+
+func TestValidateCommand_JSONOutput_WarningsOnlyPass(t *testing.T) {
+	mockValidator := &validate.MockValidator{}
+	mockValidator.On("Report", mock.Anything, "dev", "app").Return(&validate.Report{
+		Context: "dev",
+		Valid:   true,
+		Findings: []validate.Finding{
+			{Stack: "app", Location: "Parameters.Unused", Severity: validate.SeverityWarning, Message: "parameter 'Unused' is declared but never referenced"},
+		},
+	}, nil)
+
+	SetValidator(mockValidator)
+	defer func() {
+		SetValidator(nil)
+		validateOutput = "text"
+		rootCmd.SetOut(nil)
+	}()
+
+	var output bytes.Buffer
+	rootCmd.SetOut(&output)
+	rootCmd.SetArgs([]string{"validate", "dev", "app", "--output", "json"})
+	err := rootCmd.Execute()
+
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), `"severity": "warning"`)
+	mockValidator.AssertNotCalled(t, "ValidateSingleStack", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+// Package approval records who has approved a change to a stack, so deployments of stacks
+// that require approvals can check them. Approvals are tied to a hash of the change, so
+// any later change to the stack needs approving afresh.
+package approval
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/model"
+)
+
+// DefaultPath is the approvals file used when none is given
+const DefaultPath = "stackaroo-approvals.json"
+
+// Approval records one person's approval of a change to a stack
+type Approval struct {
+	StackName  string    `json:"stack_name"`
+	Context    string    `json:"context"`
+	ChangeHash string    `json:"change_hash"`
+	Approver   string    `json:"approver"`
+	ApprovedAt time.Time `json:"approved_at"`
+}
+
+// Record is the on-disk list of approvals
+type Record struct {
+	Approvals []Approval `json:"approvals"`
+}
+
+// Load reads an approvals file, returning an empty record if the file does not exist
+func Load(path string) (*Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Record{}, nil
+		}
+		return nil, fmt.Errorf("failed to read approvals file %s: %w", path, err)
+	}
+
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse approvals file %s: %w", path, err)
+	}
+	return &record, nil
+}
+
+// Save writes the record to the given path
+func (r *Record) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode approvals: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write approvals file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Approve adds an approval. Approvals of the same stack for other changes no longer count,
+// so they are dropped, and an approver approving the same change again is recorded once.
+func (r *Record) Approve(approval Approval) {
+	kept := r.Approvals[:0]
+	for _, existing := range r.Approvals {
+		sameStack := existing.Context == approval.Context && existing.StackName == approval.StackName
+		if sameStack && (existing.ChangeHash != approval.ChangeHash || existing.Approver == approval.Approver) {
+			continue
+		}
+		kept = append(kept, existing)
+	}
+	r.Approvals = append(kept, approval)
+}
+
+// Approvers returns the distinct people who approved a change to a stack, sorted by name.
+// Approvals recorded for a different change hash are not counted.
+func (r *Record) Approvers(contextName, stackName, changeHash string) []string {
+	seen := make(map[string]bool)
+	var approvers []string
+	for _, approval := range r.Approvals {
+		if approval.Context != contextName || approval.StackName != stackName || approval.ChangeHash != changeHash {
+			continue
+		}
+		if !seen[approval.Approver] {
+			seen[approval.Approver] = true
+			approvers = append(approvers, approval.Approver)
+		}
+	}
+	sort.Strings(approvers)
+	return approvers
+}
+
+// changeContent is the part of a resolved stack covered by its change hash
+type changeContent struct {
+	TemplateBody    string            `json:"template_body"`
+	Parameters      map[string]string `json:"parameters"`
+	Tags            map[string]string `json:"tags"`
+	Capabilities    []string          `json:"capabilities"`
+	StackPolicyBody string            `json:"stack_policy_body"`
+}
+
+// ChangeHash identifies what deploying a resolved stack would apply: its template, parameters,
+// tags, capabilities and stack policy. Any difference in these gives a different hash.
+func ChangeHash(stack *model.Stack) string {
+	// Map keys are encoded in sorted order, so equal stacks always encode identically
+	data, _ := json.Marshal(changeContent{
+		TemplateBody:    stack.TemplateBody,
+		Parameters:      stack.Parameters,
+		Tags:            stack.Tags,
+		Capabilities:    stack.Capabilities,
+		StackPolicyBody: stack.StackPolicyBody,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
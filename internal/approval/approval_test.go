@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package approval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingFile_ReturnsEmptyRecord(t *testing.T) {
+	record, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+
+	require.NoError(t, err)
+	assert.Empty(t, record.Approvals)
+}
+
+func TestLoad_InvalidJSON_ReturnsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "approvals.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0644))
+
+	_, err := Load(path)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse approvals file")
+}
+
+func TestRecord_SaveAndLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "approvals.json")
+	approvedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	record := &Record{}
+	record.Approve(Approval{StackName: "app", Context: "prod", ChangeHash: "abc", Approver: "alice", ApprovedAt: approvedAt})
+	require.NoError(t, record.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Approvals, 1)
+	assert.Equal(t, approvedAt, loaded.Approvals[0].ApprovedAt)
+	assert.Equal(t, []string{"alice"}, loaded.Approvers("prod", "app", "abc"))
+}
+
+func TestRecord_Approvers_CountsDistinctApproversOfTheChange(t *testing.T) {
+	record := &Record{}
+	record.Approve(Approval{StackName: "app", Context: "prod", ChangeHash: "abc", Approver: "bob"})
+	record.Approve(Approval{StackName: "app", Context: "prod", ChangeHash: "abc", Approver: "alice"})
+	record.Approve(Approval{StackName: "app", Context: "prod", ChangeHash: "abc", Approver: "alice"})
+	record.Approve(Approval{StackName: "app", Context: "dev", ChangeHash: "abc", Approver: "carol"})
+	record.Approve(Approval{StackName: "vpc", Context: "prod", ChangeHash: "abc", Approver: "dave"})
+
+	assert.Equal(t, []string{"alice", "bob"}, record.Approvers("prod", "app", "abc"))
+	assert.Len(t, record.Approvals, 4, "a repeated approval should be recorded once")
+}
+
+func TestRecord_Approve_ChangeHashMismatchInvalidatesPriorApprovals(t *testing.T) {
+	record := &Record{}
+	record.Approve(Approval{StackName: "app", Context: "prod", ChangeHash: "abc", Approver: "alice"})
+	record.Approve(Approval{StackName: "app", Context: "prod", ChangeHash: "abc", Approver: "bob"})
+
+	// The stack changed, so approvals of the old change no longer count
+	assert.Empty(t, record.Approvers("prod", "app", "def"))
+
+	record.Approve(Approval{StackName: "app", Context: "prod", ChangeHash: "def", Approver: "carol"})
+
+	assert.Equal(t, []string{"carol"}, record.Approvers("prod", "app", "def"))
+	assert.Empty(t, record.Approvers("prod", "app", "abc"), "approvals of the old change should be dropped")
+}
+
+func TestChangeHash(t *testing.T) {
+	stack := model.NewTestStackWithDefaults("app")
+	stack.Parameters = map[string]string{"InstanceType": "t3.small", "Environment": "prod"}
+
+	same := model.NewTestStackWithDefaults("app")
+	same.Parameters = map[string]string{"Environment": "prod", "InstanceType": "t3.small"}
+	assert.Equal(t, ChangeHash(stack), ChangeHash(same))
+
+	changedParameter := model.NewTestStackWithDefaults("app")
+	changedParameter.Parameters = map[string]string{"InstanceType": "t3.large", "Environment": "prod"}
+	assert.NotEqual(t, ChangeHash(stack), ChangeHash(changedParameter))
+
+	changedTemplate := model.NewTestStackWithDefaults("app")
+	changedTemplate.Parameters = stack.Parameters
+	changedTemplate.TemplateBody = `{"Resources": {}}`
+	assert.NotEqual(t, ChangeHash(stack), ChangeHash(changedTemplate))
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// AccountVerifier checks that the current credentials belong to the account a context targets
+type AccountVerifier interface {
+	// VerifyAccount returns an AccountMismatchError when the credentials for a region belong to a
+	// different account than expected. An empty expected account skips the check.
+	VerifyAccount(ctx context.Context, contextName, region, expectedAccount string) error
+}
+
+// AccountMismatchError indicates that the current credentials belong to a different account than the context
+type AccountMismatchError struct {
+	Context  string
+	Expected string
+	Actual   string
+}
+
+func (e AccountMismatchError) Error() string {
+	return fmt.Sprintf("context %s targets AWS account %s but the current credentials belong to account %s; switch credentials or use --skip-account-check",
+		e.Context, e.Expected, e.Actual)
+}
+
+// Ensure that DefaultAccountVerifier implements AccountVerifier
+var _ AccountVerifier = (*DefaultAccountVerifier)(nil)
+
+// DefaultAccountVerifier looks up the caller identity with STS, once per region
+type DefaultAccountVerifier struct {
+	clientFactory ClientFactory
+	accountIDs    map[string]string
+	mutex         sync.Mutex
+}
+
+// NewAccountVerifier creates an account verifier using the provided client factory
+func NewAccountVerifier(clientFactory ClientFactory) *DefaultAccountVerifier {
+	return &DefaultAccountVerifier{
+		clientFactory: clientFactory,
+		accountIDs:    make(map[string]string),
+	}
+}
+
+// VerifyAccount compares the caller identity's account with the expected account
+func (v *DefaultAccountVerifier) VerifyAccount(ctx context.Context, contextName, region, expectedAccount string) error {
+	if expectedAccount == "" {
+		return nil
+	}
+
+	actual, err := v.accountID(ctx, region)
+	if err != nil {
+		return fmt.Errorf("failed to verify AWS account for context %s: %w", contextName, err)
+	}
+	if actual != expectedAccount {
+		return AccountMismatchError{Context: contextName, Expected: expectedAccount, Actual: actual}
+	}
+	return nil
+}
+
+// accountID returns the caller's account for a region, asking STS only the first time
+func (v *DefaultAccountVerifier) accountID(ctx context.Context, region string) (string, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if accountID, exists := v.accountIDs[region]; exists {
+		return accountID, nil
+	}
+
+	stsOps, err := v.clientFactory.GetSTSOperations(ctx, region)
+	if err != nil {
+		return "", err
+	}
+	accountID, err := stsOps.GetAccountID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	v.accountIDs[region] = accountID
+	return accountID, nil
+}
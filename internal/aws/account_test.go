@@ -0,0 +1,69 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountVerifier_MatchingAccount(t *testing.T) {
+	ctx := context.Background()
+	factory := NewMockClientFactory()
+	mockSTS := &MockSTSOperations{}
+	factory.SetSTSOperations("us-east-1", mockSTS)
+	mockSTS.On("GetAccountID", ctx).Return("123456789012", nil).Once()
+
+	verifier := NewAccountVerifier(factory)
+
+	require.NoError(t, verifier.VerifyAccount(ctx, "dev", "us-east-1", "123456789012"))
+	// The caller identity is looked up once and reused for later stacks
+	require.NoError(t, verifier.VerifyAccount(ctx, "dev", "us-east-1", "123456789012"))
+	mockSTS.AssertExpectations(t)
+}
+
+func TestAccountVerifier_MismatchedAccount(t *testing.T) {
+	ctx := context.Background()
+	factory := NewMockClientFactory()
+	mockSTS := &MockSTSOperations{}
+	factory.SetSTSOperations("us-east-1", mockSTS)
+	mockSTS.On("GetAccountID", ctx).Return("999999999999", nil)
+
+	err := NewAccountVerifier(factory).VerifyAccount(ctx, "prod", "us-east-1", "123456789012")
+
+	var mismatch AccountMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, AccountMismatchError{Context: "prod", Expected: "123456789012", Actual: "999999999999"}, mismatch)
+	assert.Contains(t, err.Error(), "--skip-account-check")
+}
+
+func TestAccountVerifier_NoExpectedAccount(t *testing.T) {
+	ctx := context.Background()
+	factory := NewMockClientFactory()
+	mockSTS := &MockSTSOperations{}
+	factory.SetSTSOperations("us-east-1", mockSTS)
+
+	err := NewAccountVerifier(factory).VerifyAccount(ctx, "dev", "us-east-1", "")
+
+	require.NoError(t, err)
+	mockSTS.AssertNotCalled(t, "GetAccountID", ctx)
+}
+
+func TestAccountVerifier_IdentityLookupFails(t *testing.T) {
+	ctx := context.Background()
+	factory := NewMockClientFactory()
+	mockSTS := &MockSTSOperations{}
+	factory.SetSTSOperations("us-east-1", mockSTS)
+	mockSTS.On("GetAccountID", ctx).Return("", errors.New("failed to get caller identity: access denied"))
+
+	err := NewAccountVerifier(factory).VerifyAccount(ctx, "dev", "us-east-1", "123456789012")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to verify AWS account for context dev")
+}
@@ -6,11 +6,17 @@ package aws
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"sort"
 	"strings"
 	"time"
 
+	"codeberg.org/orien/stackaroo/internal/log"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
@@ -44,29 +50,37 @@ const (
 	StackStatusImportRollbackFailed     StackStatus = "IMPORT_ROLLBACK_FAILED"
 )
 
+// stackResourceType is the resource type of events about the stack itself rather than its resources
+const stackResourceType = "AWS::CloudFormation::Stack"
+
 // Stack represents a CloudFormation stack with essential information
 type Stack struct {
-	Name        string
-	Status      StackStatus
-	CreatedTime *time.Time
-	UpdatedTime *time.Time
-	Description string
-	Parameters  map[string]string
-	Outputs     map[string]string
-	Tags        map[string]string
+	ID                    string
+	Name                  string
+	Status                StackStatus
+	CreatedTime           *time.Time
+	UpdatedTime           *time.Time
+	Description           string
+	Parameters            map[string]string
+	Outputs               map[string]string
+	Tags                  map[string]string
+	TerminationProtection bool
+	DriftStatus           string // Result of the last drift detection, or NOT_CHECKED
 }
 
 // StackInfo represents detailed CloudFormation stack information for diff operations
 type StackInfo struct {
-	Name        string
-	Status      StackStatus
-	CreatedTime *time.Time
-	UpdatedTime *time.Time
-	Description string
-	Parameters  map[string]string
-	Outputs     map[string]string
-	Tags        map[string]string
-	Template    string // The actual template content
+	ID                    string
+	Name                  string
+	Status                StackStatus
+	CreatedTime           *time.Time
+	UpdatedTime           *time.Time
+	Description           string
+	Parameters            map[string]string
+	Outputs               map[string]string
+	Tags                  map[string]string
+	Template              string // The actual template content
+	TerminationProtection bool   // Whether CloudFormation refuses to delete the stack
 }
 
 // Parameter represents a CloudFormation stack parameter
@@ -77,25 +91,64 @@ type Parameter struct {
 
 // DeployStackInput contains parameters for deploying a stack
 type DeployStackInput struct {
-	StackName    string
-	TemplateBody string
-	Parameters   []Parameter
-	Tags         map[string]string
-	Capabilities []string
+	StackName             string
+	TemplateBody          string
+	TemplateURL           string // S3 URL of the template, sent instead of TemplateBody when set
+	Parameters            []Parameter
+	Tags                  map[string]string
+	Capabilities          []string
+	TerminationProtection *bool                  // Desired termination protection (nil leaves it unchanged)
+	StackPolicyBody       string                 // Stack policy JSON document (empty leaves the policy unchanged)
+	NotificationARNs      []string               // SNS topics that receive stack events (nil leaves them unchanged)
+	UsePreviousTemplate   bool                   // Update with the stack's current template instead of TemplateBody
+	OnFailure             string                 // Action when stack creation fails: ROLLBACK, DELETE or DO_NOTHING (ignored on update)
+	TimeoutInMinutes      *int32                 // Time allowed for stack creation before it fails (nil for no limit; ignored on update)
+	DisableRollback       bool                   // Keep the resources of a failed stack creation instead of rolling back (ignored on update)
+	ResourceTypes         []string               // Resource types the stack may create or update (nil allows all)
+	RollbackConfiguration *RollbackConfiguration // Alarms that roll the operation back (nil leaves the configuration unchanged)
+	ClientRequestToken    string                 // Idempotency token for the stack operation (empty derives one from the inputs and run)
+}
+
+// RollbackConfiguration lists CloudWatch alarms CloudFormation monitors during a stack operation
+// and for a period afterwards, rolling the operation back if any of them goes into ALARM
+type RollbackConfiguration struct {
+	AlarmARNs               []string
+	MonitoringTimeInMinutes *int32 // Minutes to keep monitoring once the operation completes (nil for none)
+}
+
+// toAWS converts the configuration for the CloudFormation API, leaving nil unset
+func (rc *RollbackConfiguration) toAWS() *types.RollbackConfiguration {
+	if rc == nil {
+		return nil
+	}
+
+	triggers := make([]types.RollbackTrigger, len(rc.AlarmARNs))
+	for i, arn := range rc.AlarmARNs {
+		triggers[i] = types.RollbackTrigger{
+			Arn:  aws.String(arn),
+			Type: aws.String("AWS::CloudWatch::Alarm"),
+		}
+	}
+	return &types.RollbackConfiguration{
+		RollbackTriggers:        triggers,
+		MonitoringTimeInMinutes: rc.MonitoringTimeInMinutes,
+	}
 }
 
 // UpdateStackInput contains parameters for updating a stack
 type UpdateStackInput struct {
-	StackName    string
-	TemplateBody string
-	Parameters   []Parameter
-	Tags         map[string]string
-	Capabilities []string
+	StackName           string
+	TemplateBody        string
+	UsePreviousTemplate bool // Keep the deployed template; TemplateBody is not sent
+	Parameters          []Parameter
+	Tags                map[string]string
+	Capabilities        []string
 }
 
 // DeleteStackInput contains parameters for deleting a stack
 type DeleteStackInput struct {
-	StackName string
+	StackName       string
+	RetainResources []string // Logical IDs to leave in place; only valid for stacks in DELETE_FAILED
 }
 
 // StackEvent represents a CloudFormation stack event
@@ -121,13 +174,92 @@ func (e NoChangesError) Error() string {
 
 // DefaultCloudFormationOperations provides CloudFormation-specific operations
 type DefaultCloudFormationOperations struct {
-	client CloudFormationClient
+	client      CloudFormationClient
+	waitConfig  WaitConfig
+	retryConfig RetryConfig
+	clock       clock
+	logger      *slog.Logger
+}
+
+// WaitConfig controls how stack operations are waited on
+type WaitConfig struct {
+	PollInterval time.Duration // Time between status checks (zero uses DefaultPollInterval)
+
+	// FailFastOnRollback returns as soon as a rollback starts instead of waiting for it to finish
+	FailFastOnRollback bool
+
+	// ChangeSetPollInterval is the time between status checks while a changeset is created
+	// (zero uses DefaultChangeSetPollInterval)
+	ChangeSetPollInterval time.Duration
+
+	// ChangeSetProgress, when set, is called with the changeset's status after every check
+	ChangeSetProgress func(ChangeSetProgress)
 }
 
+// merge returns the configuration with the non-zero fields of update applied, so commands that
+// set only the waits they use do not reset the others
+func (c WaitConfig) merge(update WaitConfig) WaitConfig {
+	if update.PollInterval > 0 {
+		c.PollInterval = update.PollInterval
+	}
+	if update.FailFastOnRollback {
+		c.FailFastOnRollback = true
+	}
+	if update.ChangeSetPollInterval > 0 {
+		c.ChangeSetPollInterval = update.ChangeSetPollInterval
+	}
+	if update.ChangeSetProgress != nil {
+		c.ChangeSetProgress = update.ChangeSetProgress
+	}
+	return c
+}
+
+// ChangeSetProgress reports the status of a changeset CloudFormation is still creating
+type ChangeSetProgress struct {
+	ChangeSetID string
+	Status      string
+	Elapsed     time.Duration // Time since waiting for the changeset began
+}
+
+// DefaultPollInterval is the time between status checks while waiting for a stack operation
+const DefaultPollInterval = 5 * time.Second
+
+// DefaultChangeSetPollInterval is the time between status checks while a changeset is created
+const DefaultChangeSetPollInterval = 2 * time.Second
+
+// clock abstracts time so waits can be tested without sleeping
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock reads the system clock
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
 // NewCloudFormationOperationsWithClient creates operations with a custom client (for testing)
 func NewCloudFormationOperationsWithClient(client CloudFormationClient) *DefaultCloudFormationOperations {
 	return &DefaultCloudFormationOperations{
-		client: client,
+		client:      client,
+		waitConfig:  WaitConfig{PollInterval: DefaultPollInterval},
+		retryConfig: DefaultRetryConfig,
+		clock:       realClock{},
+		logger:      log.Default(),
+	}
+}
+
+// SetLogger replaces the logger that records API calls and retries
+func (cf *DefaultCloudFormationOperations) SetLogger(logger *slog.Logger) {
+	cf.logger = logger
+}
+
+// SetWaitConfig changes how stack operations are waited on; zero fields keep their current setting
+func (cf *DefaultCloudFormationOperations) SetWaitConfig(config WaitConfig) {
+	cf.waitConfig = cf.waitConfig.merge(config)
+	if cf.waitConfig.PollInterval <= 0 {
+		cf.waitConfig.PollInterval = DefaultPollInterval
 	}
 }
 
@@ -148,6 +280,8 @@ func (cf *DefaultCloudFormationOperations) DeployStackWithCallback(ctx context.C
 		}
 	}
 
+	// Always send the complete tag set: an update replaces the stack's tags with it, so tags removed
+	// from config are cleared, and an empty non-nil list clears them all where nil would keep them
 	tags := make([]types.Tag, 0, len(input.Tags))
 	for k, v := range input.Tags {
 		tags = append(tags, types.Tag{
@@ -172,14 +306,34 @@ func (cf *DefaultCloudFormationOperations) DeployStackWithCallback(ctx context.C
 
 	var operationType string
 	if exists {
+		// Apply protection first so it takes effect even when the stack has no other changes
+		if err := cf.syncTerminationProtection(ctx, input.StackName, input.TerminationProtection); err != nil {
+			return err
+		}
+
 		// Update existing stack
 		operationType = "update"
-		_, err = cf.client.UpdateStack(ctx, &cloudformation.UpdateStackInput{
-			StackName:    aws.String(input.StackName),
-			TemplateBody: aws.String(input.TemplateBody),
-			Parameters:   params,
-			Tags:         tags,
-			Capabilities: capabilities,
+		updateInput := &cloudformation.UpdateStackInput{
+			StackName:             aws.String(input.StackName),
+			TemplateBody:          aws.String(input.TemplateBody),
+			Parameters:            params,
+			Tags:                  tags,
+			Capabilities:          capabilities,
+			StackPolicyBody:       optionalString(input.StackPolicyBody),
+			NotificationARNs:      input.NotificationARNs,
+			ResourceTypes:         input.ResourceTypes,
+			RollbackConfiguration: input.RollbackConfiguration.toAWS(),
+			ClientRequestToken:    aws.String(deploymentToken(operationType, input)),
+		}
+		if input.UsePreviousTemplate {
+			updateInput.TemplateBody = nil
+			updateInput.UsePreviousTemplate = aws.Bool(true)
+		} else if input.TemplateURL != "" {
+			updateInput.TemplateBody = nil
+			updateInput.TemplateURL = aws.String(input.TemplateURL)
+		}
+		_, err = withRetry(ctx, cf, "UpdateStack", func() (*cloudformation.UpdateStackOutput, error) {
+			return cf.client.UpdateStack(ctx, updateInput)
 		})
 
 		if err != nil {
@@ -190,14 +344,41 @@ func (cf *DefaultCloudFormationOperations) DeployStackWithCallback(ctx context.C
 			return fmt.Errorf("failed to update stack %s: %w", input.StackName, err)
 		}
 	} else {
+		if input.UsePreviousTemplate {
+			return fmt.Errorf("stack %s does not exist; a template is required to create it", input.StackName)
+		}
+
+		if err := ValidateOnFailure(input.OnFailure); err != nil {
+			return err
+		}
+		if input.DisableRollback && input.OnFailure != "" {
+			return fmt.Errorf("stack %s cannot both disable rollback and set on_failure", input.StackName)
+		}
+
 		// Create new stack
 		operationType = "create"
-		_, err = cf.client.CreateStack(ctx, &cloudformation.CreateStackInput{
-			StackName:    aws.String(input.StackName),
-			TemplateBody: aws.String(input.TemplateBody),
-			Parameters:   params,
-			Tags:         tags,
-			Capabilities: capabilities,
+		createInput := &cloudformation.CreateStackInput{
+			StackName:                   aws.String(input.StackName),
+			TemplateBody:                aws.String(input.TemplateBody),
+			Parameters:                  params,
+			Tags:                        tags,
+			Capabilities:                capabilities,
+			EnableTerminationProtection: input.TerminationProtection,
+			StackPolicyBody:             optionalString(input.StackPolicyBody),
+			NotificationARNs:            input.NotificationARNs,
+			OnFailure:                   types.OnFailure(input.OnFailure),
+			DisableRollback:             optionalBool(input.DisableRollback),
+			TimeoutInMinutes:            input.TimeoutInMinutes,
+			ResourceTypes:               input.ResourceTypes,
+			RollbackConfiguration:       input.RollbackConfiguration.toAWS(),
+			ClientRequestToken:          aws.String(deploymentToken(operationType, input)),
+		}
+		if input.TemplateURL != "" {
+			createInput.TemplateBody = nil
+			createInput.TemplateURL = aws.String(input.TemplateURL)
+		}
+		_, err = withRetry(ctx, cf, "CreateStack", func() (*cloudformation.CreateStackOutput, error) {
+			return cf.client.CreateStack(ctx, createInput)
 		})
 
 		if err != nil {
@@ -214,6 +395,50 @@ func (cf *DefaultCloudFormationOperations) DeployStackWithCallback(ctx context.C
 	return nil
 }
 
+// ValidateOnFailure checks that a stack creation failure action is one CloudFormation accepts.
+// An empty value is valid and leaves the CloudFormation default of ROLLBACK in place.
+func ValidateOnFailure(onFailure string) error {
+	switch types.OnFailure(onFailure) {
+	case "", types.OnFailureRollback, types.OnFailureDelete, types.OnFailureDoNothing:
+		return nil
+	default:
+		return fmt.Errorf("invalid on_failure value %q: must be ROLLBACK, DELETE or DO_NOTHING", onFailure)
+	}
+}
+
+// optionalString converts an empty string to nil so optional API fields are omitted
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return aws.String(value)
+}
+
+// optionalBool converts a flag to an AWS SDK pointer, leaving false unset
+func optionalBool(value bool) *bool {
+	if !value {
+		return nil
+	}
+	return aws.Bool(true)
+}
+
+// syncTerminationProtection updates a stack's termination protection when it differs from the desired setting
+func (cf *DefaultCloudFormationOperations) syncTerminationProtection(ctx context.Context, stackName string, desired *bool) error {
+	if desired == nil {
+		return nil
+	}
+
+	stack, err := cf.GetStack(ctx, stackName)
+	if err != nil {
+		return err
+	}
+	if stack.TerminationProtection == *desired {
+		return nil
+	}
+
+	return cf.UpdateTerminationProtection(ctx, stackName, *desired)
+}
+
 // isNoChangesError checks if the error indicates no changes are needed.
 // Requires both the ValidationError code and the specific message to avoid
 // swallowing IAM, capability, or template errors that also surface as ValidationError.
@@ -252,15 +477,26 @@ func (cf *DefaultCloudFormationOperations) UpdateStack(ctx context.Context, inpu
 		capabilities[i] = types.Capability(cap)
 	}
 
-	_, err := cf.client.UpdateStack(ctx, &cloudformation.UpdateStackInput{
+	updateInput := &cloudformation.UpdateStackInput{
 		StackName:    aws.String(input.StackName),
-		TemplateBody: aws.String(input.TemplateBody),
 		Parameters:   params,
 		Tags:         tags,
 		Capabilities: capabilities,
+	}
+	if input.UsePreviousTemplate {
+		updateInput.UsePreviousTemplate = aws.Bool(true)
+	} else {
+		updateInput.TemplateBody = aws.String(input.TemplateBody)
+	}
+
+	_, err := withRetry(ctx, cf, "UpdateStack", func() (*cloudformation.UpdateStackOutput, error) {
+		return cf.client.UpdateStack(ctx, updateInput)
 	})
 
 	if err != nil {
+		if isNoChangesError(err) {
+			return NoChangesError{StackName: input.StackName}
+		}
 		return fmt.Errorf("failed to update stack %s: %w", input.StackName, err)
 	}
 
@@ -269,8 +505,15 @@ func (cf *DefaultCloudFormationOperations) UpdateStack(ctx context.Context, inpu
 
 // DeleteStack deletes a CloudFormation stack
 func (cf *DefaultCloudFormationOperations) DeleteStack(ctx context.Context, input DeleteStackInput) error {
-	_, err := cf.client.DeleteStack(ctx, &cloudformation.DeleteStackInput{
+	deleteInput := &cloudformation.DeleteStackInput{
 		StackName: aws.String(input.StackName),
+	}
+	if len(input.RetainResources) > 0 {
+		deleteInput.RetainResources = input.RetainResources
+	}
+
+	_, err := withRetry(ctx, cf, "DeleteStack", func() (*cloudformation.DeleteStackOutput, error) {
+		return cf.client.DeleteStack(ctx, deleteInput)
 	})
 
 	if err != nil {
@@ -280,10 +523,97 @@ func (cf *DefaultCloudFormationOperations) DeleteStack(ctx context.Context, inpu
 	return nil
 }
 
+// ContinueUpdateRollback resumes the rollback of a stack in UPDATE_ROLLBACK_FAILED.
+// Resources that cannot be rolled back can be skipped by logical ID; CloudFormation then
+// treats them as rolled back even though their state may no longer match the template.
+func (cf *DefaultCloudFormationOperations) ContinueUpdateRollback(ctx context.Context, stackName string, resourcesToSkip []string) error {
+	input := &cloudformation.ContinueUpdateRollbackInput{
+		StackName: aws.String(stackName),
+	}
+	if len(resourcesToSkip) > 0 {
+		input.ResourcesToSkip = resourcesToSkip
+	}
+
+	_, err := withRetry(ctx, cf, "ContinueUpdateRollback", func() (*cloudformation.ContinueUpdateRollbackOutput, error) {
+		return cf.client.ContinueUpdateRollback(ctx, input)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to continue update rollback for stack %s: %w", stackName, err)
+	}
+
+	return nil
+}
+
+// CancelUpdateStack cancels the update of a stack in UPDATE_IN_PROGRESS. CloudFormation then
+// rolls the stack back to its previous configuration, ending in UPDATE_ROLLBACK_COMPLETE.
+func (cf *DefaultCloudFormationOperations) CancelUpdateStack(ctx context.Context, stackName string) error {
+	input := &cloudformation.CancelUpdateStackInput{
+		StackName: aws.String(stackName),
+	}
+
+	_, err := withRetry(ctx, cf, "CancelUpdateStack", func() (*cloudformation.CancelUpdateStackOutput, error) {
+		return cf.client.CancelUpdateStack(ctx, input)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cancel update of stack %s: %w", stackName, err)
+	}
+
+	return nil
+}
+
+// UpdateTerminationProtection enables or disables termination protection on a stack
+func (cf *DefaultCloudFormationOperations) UpdateTerminationProtection(ctx context.Context, stackName string, enabled bool) error {
+	_, err := withRetry(ctx, cf, "UpdateTerminationProtection", func() (*cloudformation.UpdateTerminationProtectionOutput, error) {
+		return cf.client.UpdateTerminationProtection(ctx, &cloudformation.UpdateTerminationProtectionInput{
+			StackName:                   aws.String(stackName),
+			EnableTerminationProtection: aws.Bool(enabled),
+		})
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to update termination protection for stack %s: %w", stackName, err)
+	}
+
+	return nil
+}
+
+// SetStackPolicy replaces the stack policy of an existing stack
+func (cf *DefaultCloudFormationOperations) SetStackPolicy(ctx context.Context, stackName string, policyBody string) error {
+	_, err := withRetry(ctx, cf, "SetStackPolicy", func() (*cloudformation.SetStackPolicyOutput, error) {
+		return cf.client.SetStackPolicy(ctx, &cloudformation.SetStackPolicyInput{
+			StackName:       aws.String(stackName),
+			StackPolicyBody: aws.String(policyBody),
+		})
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to set stack policy for stack %s: %w", stackName, err)
+	}
+
+	return nil
+}
+
+// GetStackPolicy returns the stack policy of an existing stack, or an empty string when it has none
+func (cf *DefaultCloudFormationOperations) GetStackPolicy(ctx context.Context, stackName string) (string, error) {
+	result, err := withRetry(ctx, cf, "GetStackPolicy", func() (*cloudformation.GetStackPolicyOutput, error) {
+		return cf.client.GetStackPolicy(ctx, &cloudformation.GetStackPolicyInput{
+			StackName: aws.String(stackName),
+		})
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get stack policy for stack %s: %w", stackName, err)
+	}
+
+	return aws.ToString(result.StackPolicyBody), nil
+}
+
 // GetStack retrieves information about a specific stack
 func (cf *DefaultCloudFormationOperations) GetStack(ctx context.Context, stackName string) (*Stack, error) {
-	result, err := cf.client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
-		StackName: aws.String(stackName),
+	result, err := withRetry(ctx, cf, "DescribeStacks", func() (*cloudformation.DescribeStacksOutput, error) {
+		return cf.client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+			StackName: aws.String(stackName),
+		})
 	})
 
 	if err != nil {
@@ -296,14 +626,20 @@ func (cf *DefaultCloudFormationOperations) GetStack(ctx context.Context, stackNa
 
 	cfnStack := result.Stacks[0]
 	stack := &Stack{
-		Name:        aws.ToString(cfnStack.StackName),
-		Status:      StackStatus(cfnStack.StackStatus),
-		CreatedTime: cfnStack.CreationTime,
-		UpdatedTime: cfnStack.LastUpdatedTime,
-		Description: aws.ToString(cfnStack.Description),
-		Parameters:  make(map[string]string),
-		Outputs:     make(map[string]string),
-		Tags:        make(map[string]string),
+		ID:                    aws.ToString(cfnStack.StackId),
+		Name:                  aws.ToString(cfnStack.StackName),
+		Status:                StackStatus(cfnStack.StackStatus),
+		CreatedTime:           cfnStack.CreationTime,
+		UpdatedTime:           cfnStack.LastUpdatedTime,
+		Description:           aws.ToString(cfnStack.Description),
+		Parameters:            make(map[string]string),
+		Outputs:               make(map[string]string),
+		Tags:                  make(map[string]string),
+		TerminationProtection: aws.ToBool(cfnStack.EnableTerminationProtection),
+	}
+
+	if cfnStack.DriftInformation != nil {
+		stack.DriftStatus = string(cfnStack.DriftInformation.StackDriftStatus)
 	}
 
 	// Convert parameters
@@ -330,7 +666,9 @@ func (cf *DefaultCloudFormationOperations) ListStacks(ctx context.Context) ([]*S
 	paginator := cloudformation.NewListStacksPaginator(cf.client, &cloudformation.ListStacksInput{})
 
 	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
+		page, err := withRetry(ctx, cf, "ListStacks", func() (*cloudformation.ListStacksOutput, error) {
+			return paginator.NextPage(ctx)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to list stacks: %w", err)
 		}
@@ -357,8 +695,10 @@ func (cf *DefaultCloudFormationOperations) ListStacks(ctx context.Context) ([]*S
 
 // ValidateTemplate validates a CloudFormation template
 func (cf *DefaultCloudFormationOperations) ValidateTemplate(ctx context.Context, templateBody string) error {
-	_, err := cf.client.ValidateTemplate(ctx, &cloudformation.ValidateTemplateInput{
-		TemplateBody: aws.String(templateBody),
+	_, err := withRetry(ctx, cf, "ValidateTemplate", func() (*cloudformation.ValidateTemplateOutput, error) {
+		return cf.client.ValidateTemplate(ctx, &cloudformation.ValidateTemplateInput{
+			TemplateBody: aws.String(templateBody),
+		})
 	})
 
 	if err != nil {
@@ -370,8 +710,10 @@ func (cf *DefaultCloudFormationOperations) ValidateTemplate(ctx context.Context,
 
 // StackExists checks if a stack exists
 func (cf *DefaultCloudFormationOperations) StackExists(ctx context.Context, stackName string) (bool, error) {
-	_, err := cf.client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
-		StackName: aws.String(stackName),
+	_, err := withRetry(ctx, cf, "DescribeStacks", func() (*cloudformation.DescribeStacksOutput, error) {
+		return cf.client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+			StackName: aws.String(stackName),
+		})
 	})
 
 	if err != nil {
@@ -411,8 +753,10 @@ func isStackNotFoundError(err error) bool {
 
 // GetTemplate retrieves the template for a CloudFormation stack
 func (cf *DefaultCloudFormationOperations) GetTemplate(ctx context.Context, stackName string) (string, error) {
-	result, err := cf.client.GetTemplate(ctx, &cloudformation.GetTemplateInput{
-		StackName: aws.String(stackName),
+	result, err := withRetry(ctx, cf, "GetTemplate", func() (*cloudformation.GetTemplateOutput, error) {
+		return cf.client.GetTemplate(ctx, &cloudformation.GetTemplateInput{
+			StackName: aws.String(stackName),
+		})
 	})
 
 	if err != nil {
@@ -438,27 +782,33 @@ func (cf *DefaultCloudFormationOperations) DescribeStack(ctx context.Context, st
 
 	// Convert Stack to StackInfo
 	stackInfo := &StackInfo{
-		Name:        stack.Name,
-		Status:      stack.Status,
-		CreatedTime: stack.CreatedTime,
-		UpdatedTime: stack.UpdatedTime,
-		Description: stack.Description,
-		Parameters:  stack.Parameters,
-		Outputs:     stack.Outputs,
-		Tags:        stack.Tags,
-		Template:    template,
+		ID:                    stack.ID,
+		Name:                  stack.Name,
+		Status:                stack.Status,
+		CreatedTime:           stack.CreatedTime,
+		UpdatedTime:           stack.UpdatedTime,
+		Description:           stack.Description,
+		Parameters:            stack.Parameters,
+		Outputs:               stack.Outputs,
+		Tags:                  stack.Tags,
+		Template:              template,
+		TerminationProtection: stack.TerminationProtection,
 	}
 
 	return stackInfo, nil
 }
 
-// ExecuteChangeSet executes a CloudFormation changeset by ID, abstracting AWS SDK details
-func (cf *DefaultCloudFormationOperations) ExecuteChangeSet(ctx context.Context, changeSetID string) error {
+// ExecuteChangeSet executes a CloudFormation changeset by ID, abstracting AWS SDK details.
+// With disableRollback, a stack whose update fails is left in its failed state instead of rolling back.
+func (cf *DefaultCloudFormationOperations) ExecuteChangeSet(ctx context.Context, changeSetID string, disableRollback bool) error {
 	executeInput := &cloudformation.ExecuteChangeSetInput{
-		ChangeSetName: aws.String(changeSetID),
+		ChangeSetName:   aws.String(changeSetID),
+		DisableRollback: optionalBool(disableRollback),
 	}
 
-	_, err := cf.client.ExecuteChangeSet(ctx, executeInput)
+	_, err := withRetry(ctx, cf, "ExecuteChangeSet", func() (*cloudformation.ExecuteChangeSetOutput, error) {
+		return cf.client.ExecuteChangeSet(ctx, executeInput)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to execute changeset %s: %w", changeSetID, err)
 	}
@@ -466,10 +816,17 @@ func (cf *DefaultCloudFormationOperations) ExecuteChangeSet(ctx context.Context,
 	return nil
 }
 
+// DescribeChangeSet returns the stack, status and resource changes of an existing changeset
+func (cf *DefaultCloudFormationOperations) DescribeChangeSet(ctx context.Context, changeSetID string) (*ChangeSetInfo, error) {
+	return cf.describeChangeSetInternal(ctx, changeSetID)
+}
+
 // DeleteChangeSet deletes a CloudFormation changeset
 func (cf *DefaultCloudFormationOperations) DeleteChangeSet(ctx context.Context, changeSetID string) error {
-	_, err := cf.client.DeleteChangeSet(ctx, &cloudformation.DeleteChangeSetInput{
-		ChangeSetName: aws.String(changeSetID),
+	_, err := withRetry(ctx, cf, "DeleteChangeSet", func() (*cloudformation.DeleteChangeSetOutput, error) {
+		return cf.client.DeleteChangeSet(ctx, &cloudformation.DeleteChangeSetInput{
+			ChangeSetName: aws.String(changeSetID),
+		})
 	})
 
 	if err != nil {
@@ -487,7 +844,9 @@ func (cf *DefaultCloudFormationOperations) DescribeStackEvents(ctx context.Conte
 	})
 
 	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
+		page, err := withRetry(ctx, cf, "DescribeStackEvents", func() (*cloudformation.DescribeStackEventsOutput, error) {
+			return paginator.NextPage(ctx)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to describe stack events for %s: %w", stackName, err)
 		}
@@ -513,8 +872,9 @@ func (cf *DefaultCloudFormationOperations) DescribeStackEvents(ctx context.Conte
 // WaitForStackOperation waits for a CloudFormation stack operation to complete,
 // calling the provided callback for each new event
 func (cf *DefaultCloudFormationOperations) WaitForStackOperation(ctx context.Context, stackName string, startTime time.Time, eventCallback func(StackEvent)) error {
-	const pollInterval = 5 * time.Second
 	seenEvents := make(map[string]bool)
+	var failureReason string  // Reason of the first resource failure in this operation
+	var rollbackReason string // Reason the stack itself gave for rolling back, such as an alarm trigger
 
 	for {
 		// Check stack status
@@ -539,30 +899,113 @@ func (cf *DefaultCloudFormationOperations) WaitForStackOperation(ctx context.Con
 			}
 			if !seenEvents[event.EventId] {
 				seenEvents[event.EventId] = true
+				if failureReason == "" && strings.HasSuffix(event.ResourceStatus, "_FAILED") {
+					failureReason = event.ResourceStatusReason
+				}
+				if rollbackReason == "" && event.ResourceType == stackResourceType && isRollbackInProgress(StackStatus(event.ResourceStatus)) {
+					rollbackReason = event.ResourceStatusReason
+				}
 				if eventCallback != nil {
 					eventCallback(event)
 				}
 			}
 		}
 
+		// Report the failure without waiting for the rollback when asked to fail fast
+		if cf.waitConfig.FailFastOnRollback && isRollbackInProgress(stack.Status) {
+			return RollbackStartedError{StackName: stackName, Status: stack.Status, Reason: failureReasonOr(failureReason, rollbackReason)}
+		}
+
 		// Check if operation is complete
 		if isStackOperationComplete(stack.Status) {
 			if isStackOperationSuccessful(stack.Status) {
 				return nil
 			}
-			return fmt.Errorf("stack operation failed with status: %s", stack.Status)
+			return StackOperationFailedError{StackName: stackName, Status: stack.Status, Reason: failureReasonOr(failureReason, rollbackReason)}
 		}
 
-		// Wait before next poll
+		// Wait before next poll, giving up with the last status seen once the caller's deadline passes
 		select {
 		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return WaitTimeoutError{StackName: stackName, Status: stack.Status}
+			}
 			return ctx.Err()
-		case <-time.After(pollInterval):
+		case <-cf.clock.After(cf.waitConfig.PollInterval):
 			continue
 		}
 	}
 }
 
+// WaitTimeoutError indicates that a stack operation was still running when the caller's deadline passed
+type WaitTimeoutError struct {
+	StackName string
+	Status    StackStatus // Last status seen before giving up
+}
+
+func (e WaitTimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for stack %s (last status: %s)", e.StackName, e.Status)
+}
+
+// Unwrap lets callers match a wait timeout as an exceeded deadline
+func (e WaitTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// StackOperationFailedError indicates that a stack operation finished in an unsuccessful status
+type StackOperationFailedError struct {
+	StackName string
+	Status    StackStatus
+	Reason    string // Why the operation failed or rolled back, such as an alarm going into ALARM (empty if unknown)
+}
+
+func (e StackOperationFailedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("stack operation failed with status: %s", e.Status)
+	}
+	return fmt.Sprintf("stack operation failed with status: %s: %s", e.Status, e.Reason)
+}
+
+// RollbackStartedError indicates that a stack began rolling back and the wait returned without
+// waiting for the rollback to finish
+type RollbackStartedError struct {
+	StackName string
+	Status    StackStatus
+	Reason    string // Reason given for the first resource failure, which triggered the rollback
+}
+
+func (e RollbackStartedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("stack %s is rolling back (status: %s)", e.StackName, e.Status)
+	}
+	return fmt.Sprintf("stack %s is rolling back (status: %s): %s", e.StackName, e.Status, e.Reason)
+}
+
+// failureReasonOr prefers the reason a resource failed, falling back to the reason the stack
+// gave for rolling back, which is the only explanation when a rollback trigger's alarm fired
+func failureReasonOr(failureReason, rollbackReason string) string {
+	if failureReason != "" {
+		return failureReason
+	}
+	return rollbackReason
+}
+
+// isRollbackInProgress reports whether a stack is rolling back an operation
+func isRollbackInProgress(status StackStatus) bool {
+	return strings.Contains(string(status), "ROLLBACK") && strings.HasSuffix(string(status), "_IN_PROGRESS")
+}
+
+// IsRollbackFailed reports whether a stack is stuck after a rollback that could not complete
+func IsRollbackFailed(status StackStatus) bool {
+	return strings.HasSuffix(string(status), "ROLLBACK_FAILED")
+}
+
+// IsOperationInProgress reports whether a stack operation is under way and will reach a final status.
+// REVIEW_IN_PROGRESS is excluded, as a stack stays in it until a changeset is executed.
+func IsOperationInProgress(status StackStatus) bool {
+	return strings.HasSuffix(string(status), "_IN_PROGRESS") && status != StackStatusReviewInProgress
+}
+
 // isStackOperationComplete checks if a stack operation has completed
 func isStackOperationComplete(status StackStatus) bool {
 	switch status {
@@ -598,8 +1041,10 @@ func isStackOperationSuccessful(status StackStatus) bool {
 	}
 }
 
-// CreateChangeSetPreview creates a CloudFormation changeset for preview, describes it, then deletes it
-func (cf *DefaultCloudFormationOperations) CreateChangeSetPreview(ctx context.Context, stackName string, template string, parameters map[string]string, capabilities []string, tags map[string]string) (*ChangeSetInfo, error) {
+// CreateChangeSetPreview creates a CloudFormation changeset for preview, describes it, then deletes it.
+// A templateURL is sent in place of the template body, for templates stored in S3. An empty
+// template with no templateURL reuses the stack's current template.
+func (cf *DefaultCloudFormationOperations) CreateChangeSetPreview(ctx context.Context, stackName string, template string, templateURL string, parameters map[string]string, capabilities []string, tags map[string]string) (*ChangeSetInfo, error) {
 	// Generate a unique changeset name
 	changeSetName := fmt.Sprintf("stackaroo-diff-%d", time.Now().Unix())
 
@@ -637,8 +1082,11 @@ func (cf *DefaultCloudFormationOperations) CreateChangeSetPreview(ctx context.Co
 		Capabilities:  awsCapabilities,
 		ChangeSetType: types.ChangeSetTypeUpdate, // Assume it's an update for existing stacks
 	}
+	setChangeSetTemplate(createInput, template, templateURL)
 
-	createOutput, err := cf.client.CreateChangeSet(ctx, createInput)
+	createOutput, err := withRetry(ctx, cf, "CreateChangeSet", func() (*cloudformation.CreateChangeSetOutput, error) {
+		return cf.client.CreateChangeSet(ctx, createInput)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create changeset: %w", err)
 	}
@@ -677,23 +1125,40 @@ func (cf *DefaultCloudFormationOperations) CreateChangeSetPreview(ctx context.Co
 	return changeSetInfo, nil
 }
 
-// CreateChangeSetForDeployment creates a changeset for deployment (doesn't auto-delete)
-func (cf *DefaultCloudFormationOperations) CreateChangeSetForDeployment(ctx context.Context, stackName string, template string, parameters map[string]string, capabilities []string, tags map[string]string) (*ChangeSetInfo, error) {
+// setChangeSetTemplate sets where a changeset's template comes from: the S3 URL when given, as
+// templates over the inline size limit can only be sent that way, then the body, and otherwise
+// the stack's current template
+func setChangeSetTemplate(input *cloudformation.CreateChangeSetInput, template, templateURL string) {
+	switch {
+	case templateURL != "":
+		input.TemplateBody = nil
+		input.TemplateURL = aws.String(templateURL)
+	case template == "":
+		input.TemplateBody = nil
+		input.UsePreviousTemplate = aws.Bool(true)
+	}
+}
+
+// CreateChangeSetForDeployment creates a changeset for deployment (doesn't auto-delete).
+// A TemplateURL is sent in place of the template body, for templates stored in S3. An empty
+// template with no TemplateURL reuses the stack's current template, which requires the stack to exist.
+func (cf *DefaultCloudFormationOperations) CreateChangeSetForDeployment(ctx context.Context, input ChangeSetInput) (*ChangeSetInfo, error) {
 	// Generate a unique changeset name
-	changeSetName := fmt.Sprintf("stackaroo-deploy-%d", time.Now().Unix())
+	changeSetName := deploymentChangeSetName(time.Now(), input.Metadata)
 
 	// Convert parameters to AWS format
-	awsParameters := make([]types.Parameter, 0, len(parameters))
-	for key, value := range parameters {
+	awsParameters := make([]types.Parameter, 0, len(input.Parameters))
+	for key, value := range input.Parameters {
 		awsParameters = append(awsParameters, types.Parameter{
 			ParameterKey:   aws.String(key),
 			ParameterValue: aws.String(value),
 		})
 	}
 
-	// Convert tags to AWS format
-	awsTags := make([]types.Tag, 0, len(tags))
-	for key, value := range tags {
+	// Convert tags to AWS format, always sending the complete set so that executing the changeset
+	// clears tags removed from config, including every tag when none remain
+	awsTags := make([]types.Tag, 0, len(input.Tags))
+	for key, value := range input.Tags {
 		awsTags = append(awsTags, types.Tag{
 			Key:   aws.String(key),
 			Value: aws.String(value),
@@ -701,34 +1166,44 @@ func (cf *DefaultCloudFormationOperations) CreateChangeSetForDeployment(ctx cont
 	}
 
 	// Convert capabilities to AWS format
-	awsCapabilities := make([]types.Capability, 0, len(capabilities))
-	for _, capability := range capabilities {
+	awsCapabilities := make([]types.Capability, 0, len(input.Capabilities))
+	for _, capability := range input.Capabilities {
 		awsCapabilities = append(awsCapabilities, types.Capability(capability))
 	}
 
 	// Determine changeset type based on whether stack exists
-	exists, err := cf.StackExists(ctx, stackName)
+	exists, err := cf.StackExists(ctx, input.StackName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if stack exists: %w", err)
 	}
 
 	changeSetType := types.ChangeSetTypeUpdate
 	if !exists {
+		if input.TemplateBody == "" && input.TemplateURL == "" {
+			return nil, fmt.Errorf("stack %s does not exist; a template is required to create it", input.StackName)
+		}
 		changeSetType = types.ChangeSetTypeCreate
 	}
 
 	// Create the changeset
 	createInput := &cloudformation.CreateChangeSetInput{
-		StackName:     aws.String(stackName),
-		ChangeSetName: aws.String(changeSetName),
-		TemplateBody:  aws.String(template),
-		Parameters:    awsParameters,
-		Tags:          awsTags,
-		Capabilities:  awsCapabilities,
-		ChangeSetType: changeSetType,
+		StackName:             aws.String(input.StackName),
+		ChangeSetName:         aws.String(changeSetName),
+		TemplateBody:          aws.String(input.TemplateBody),
+		Parameters:            awsParameters,
+		Tags:                  awsTags,
+		Capabilities:          awsCapabilities,
+		ChangeSetType:         changeSetType,
+		NotificationARNs:      input.NotificationARNs,
+		ResourceTypes:         input.ResourceTypes,
+		RollbackConfiguration: input.RollbackConfiguration.toAWS(),
+		Description:           optionalString(changeSetDescription(input.Metadata)),
 	}
+	setChangeSetTemplate(createInput, input.TemplateBody, input.TemplateURL)
 
-	createOutput, err := cf.client.CreateChangeSet(ctx, createInput)
+	createOutput, err := withRetry(ctx, cf, "CreateChangeSet", func() (*cloudformation.CreateChangeSetOutput, error) {
+		return cf.client.CreateChangeSet(ctx, createInput)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create changeset: %w", err)
 	}
@@ -743,7 +1218,7 @@ func (cf *DefaultCloudFormationOperations) CreateChangeSetForDeployment(ctx cont
 		// Check if this is a "no changes" error and propagate it with the stack name
 		var noChangesErr NoChangesError
 		if errors.As(err, &noChangesErr) {
-			return nil, NoChangesError{StackName: stackName}
+			return nil, NoChangesError{StackName: input.StackName}
 		}
 		return nil, fmt.Errorf("changeset creation failed: %w", err)
 	}
@@ -760,13 +1235,111 @@ func (cf *DefaultCloudFormationOperations) CreateChangeSetForDeployment(ctx cont
 	return changeSetInfo, nil
 }
 
-// waitForChangeSet waits for a changeset to reach a terminal state
+// CloudFormation limits changeset names to 128 characters and descriptions to 1024
+const (
+	maxChangeSetNameLength        = 128
+	maxChangeSetDescriptionLength = 1024
+)
+
+// runNonce identifies this process, so a derived token is shared by retries within one run but
+// never by a later run that happens to deploy identical inputs
+var runNonce = newRunNonce()
+
+func newRunNonce() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return fmt.Sprintf("%x", b)
+}
+
+// deploymentToken returns the input's ClientRequestToken, or derives one from the operation, every
+// request input and the run nonce so that retrying a deployment within a run is idempotent in CloudFormation
+func deploymentToken(operation string, input DeployStackInput) string {
+	if input.ClientRequestToken != "" {
+		return input.ClientRequestToken
+	}
+
+	sorted := input
+	sorted.Parameters = append([]Parameter(nil), input.Parameters...)
+	sort.Slice(sorted.Parameters, func(i, j int) bool { return sorted.Parameters[i].Key < sorted.Parameters[j].Key })
+
+	// Maps marshal with sorted keys, so identical inputs always encode identically
+	encoded, _ := json.Marshal(sorted)
+
+	hash := sha256.New()
+	for _, part := range []string{runNonce, operation, string(encoded)} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return fmt.Sprintf("stackaroo-%x", hash.Sum(nil)[:16])
+}
+
+// deploymentChangeSetName builds a unique changeset name, suffixed with the commit when known
+func deploymentChangeSetName(now time.Time, metadata ChangeSetMetadata) string {
+	name := fmt.Sprintf("stackaroo-deploy-%d", now.Unix())
+
+	var commit strings.Builder
+	for _, r := range metadata.Commit {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' {
+			commit.WriteRune(r)
+		}
+	}
+	if commit.Len() > 0 {
+		name = name + "-" + commit.String()
+	}
+
+	if len(name) > maxChangeSetNameLength {
+		name = name[:maxChangeSetNameLength]
+	}
+	return name
+}
+
+// changeSetDescription encodes deployment metadata as key=value pairs, or returns "" when there is none
+func changeSetDescription(metadata ChangeSetMetadata) string {
+	var fields []string
+	if metadata.Commit != "" {
+		fields = append(fields, "commit="+metadata.Commit)
+	}
+	if metadata.User != "" {
+		fields = append(fields, "user="+metadata.User)
+	}
+	if metadata.Message != "" {
+		fields = append(fields, "message="+metadata.Message)
+	}
+
+	description := strings.Join(fields, " ")
+	if len(description) > maxChangeSetDescriptionLength {
+		description = strings.ToValidUTF8(description[:maxChangeSetDescriptionLength], "")
+	}
+	return description
+}
+
+// waitForChangeSet waits for a changeset to reach a terminal state, reporting its status to the
+// configured progress callback after every check
 func (cf *DefaultCloudFormationOperations) waitForChangeSet(ctx context.Context, changeSetID string) error {
+	clock := cf.clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	pollInterval := cf.waitConfig.ChangeSetPollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultChangeSetPollInterval
+	}
+
 	// Set a reasonable timeout for changeset creation
 	timeout := 5 * time.Minute
-	deadline := time.Now().Add(timeout)
+	start := clock.Now()
+	deadline := start.Add(timeout)
 
-	for time.Now().Before(deadline) {
+	// A freshly created changeset may not be queryable straight away, so a
+	// not-found response is retried a few times until the first successful describe
+	const maxNotFoundRetries = 3
+	const notFoundRetryDelay = 1 * time.Second
+	described := false
+	notFoundRetries := 0
+
+	for clock.Now().Before(deadline) {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -774,15 +1347,35 @@ func (cf *DefaultCloudFormationOperations) waitForChangeSet(ctx context.Context,
 		}
 
 		// Describe the changeset to check its status
-		describeOutput, err := cf.client.DescribeChangeSet(ctx, &cloudformation.DescribeChangeSetInput{
-			ChangeSetName: aws.String(changeSetID),
+		describeOutput, err := withRetry(ctx, cf, "DescribeChangeSet", func() (*cloudformation.DescribeChangeSetOutput, error) {
+			return cf.client.DescribeChangeSet(ctx, &cloudformation.DescribeChangeSetInput{
+				ChangeSetName: aws.String(changeSetID),
+			})
 		})
 
 		if err != nil {
+			if !described && isChangeSetNotFoundError(err) && notFoundRetries < maxNotFoundRetries {
+				notFoundRetries++
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-clock.After(notFoundRetryDelay):
+				}
+				continue
+			}
 			return fmt.Errorf("failed to describe changeset while waiting: %w", err)
 		}
+		described = true
 
 		status := describeOutput.Status
+		if cf.waitConfig.ChangeSetProgress != nil {
+			cf.waitConfig.ChangeSetProgress(ChangeSetProgress{
+				ChangeSetID: changeSetID,
+				Status:      string(status),
+				Elapsed:     clock.Now().Sub(start),
+			})
+		}
+
 		switch status {
 		case types.ChangeSetStatusCreateComplete:
 			return nil
@@ -798,7 +1391,11 @@ func (cf *DefaultCloudFormationOperations) waitForChangeSet(ctx context.Context,
 			return fmt.Errorf("changeset creation failed: %s", reason)
 		case types.ChangeSetStatusCreatePending, types.ChangeSetStatusCreateInProgress:
 			// Still creating, wait a bit more
-			time.Sleep(2 * time.Second)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-clock.After(pollInterval):
+			}
 			continue
 		default:
 			return fmt.Errorf("unexpected changeset status: %s", status)
@@ -808,20 +1405,35 @@ func (cf *DefaultCloudFormationOperations) waitForChangeSet(ctx context.Context,
 	return fmt.Errorf("timeout waiting for changeset to be created")
 }
 
+// isChangeSetNotFoundError checks if the error indicates the changeset does not exist (yet)
+func isChangeSetNotFoundError(err error) bool {
+	var notFound *types.ChangeSetNotFoundException
+	if errors.As(err, &notFound) {
+		return true
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode() == "ChangeSetNotFound"
+	}
+	return false
+}
+
 // isChangeSetNoChangesMessage checks if a changeset status reason indicates no infrastructure changes
 func isChangeSetNoChangesMessage(statusReason string) bool {
-	// CloudFormation returns this specific message when changeset contains no infrastructure changes
-	// This happens when only metadata (Description, Metadata section, etc.) changes
-	noChangePhrases := []string{
-		"didn't contain changes",
-		"didn't include changes",
+	// CloudFormation returns these messages when a changeset contains no infrastructure changes,
+	// either because nothing changed or because only metadata (Description, Metadata section, etc.) did.
+	// The reason must start with one of them: validation failures can quote them mid-message, and
+	// treating those as "no changes" would hide a real error.
+	noChangePrefixes := []string{
+		"the submitted information didn't contain changes",
+		"the submitted information didn't include changes",
 		"no updates are to be performed",
 		"no updates to be performed",
 	}
 
-	lowerReason := strings.ToLower(statusReason)
-	for _, phrase := range noChangePhrases {
-		if strings.Contains(lowerReason, phrase) {
+	lowerReason := strings.ToLower(strings.TrimSpace(statusReason))
+	for _, prefix := range noChangePrefixes {
+		if strings.HasPrefix(lowerReason, prefix) {
 			return true
 		}
 	}
@@ -830,8 +1442,10 @@ func isChangeSetNoChangesMessage(statusReason string) bool {
 
 // describeChangeSetInternal gets the detailed information about a changeset
 func (cf *DefaultCloudFormationOperations) describeChangeSetInternal(ctx context.Context, changeSetID string) (*ChangeSetInfo, error) {
-	describeOutput, err := cf.client.DescribeChangeSet(ctx, &cloudformation.DescribeChangeSetInput{
-		ChangeSetName: aws.String(changeSetID),
+	describeOutput, err := withRetry(ctx, cf, "DescribeChangeSet", func() (*cloudformation.DescribeChangeSetOutput, error) {
+		return cf.client.DescribeChangeSet(ctx, &cloudformation.DescribeChangeSetInput{
+			ChangeSetName: aws.String(changeSetID),
+		})
 	})
 
 	if err != nil {
@@ -840,9 +1454,11 @@ func (cf *DefaultCloudFormationOperations) describeChangeSetInternal(ctx context
 
 	// Convert AWS changeset to our format
 	changeSetInfo := &ChangeSetInfo{
-		ChangeSetID: changeSetID,
-		Status:      string(describeOutput.Status),
-		Changes:     make([]ResourceChange, 0, len(describeOutput.Changes)),
+		ChangeSetID:     changeSetID,
+		StackName:       aws.ToString(describeOutput.StackName),
+		Status:          string(describeOutput.Status),
+		ExecutionStatus: string(describeOutput.ExecutionStatus),
+		Changes:         make([]ResourceChange, 0, len(describeOutput.Changes)),
 	}
 
 	// Convert each change
@@ -865,6 +1481,14 @@ func (cf *DefaultCloudFormationOperations) describeChangeSetInternal(ctx context
 						detailText += fmt.Sprintf(" (%s)", detail.Target.Attribute)
 					}
 					resourceChange.Details = append(resourceChange.Details, detailText)
+
+					resourceChange.Properties = append(resourceChange.Properties, PropertyChange{
+						Name:               aws.ToString(detail.Target.Name),
+						Attribute:          string(detail.Target.Attribute),
+						RequiresRecreation: string(detail.Target.RequiresRecreation),
+						ChangeSource:       string(detail.ChangeSource),
+						CausingEntity:      aws.ToString(detail.CausingEntity),
+					})
 				}
 			}
 
@@ -8,8 +8,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
@@ -185,6 +187,635 @@ func TestDeployStack_UpdateNoChanges_Success(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestDeployStack_CreateNewStack_EnablesTerminationProtection(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	input := DeployStackInput{
+		StackName:             "protected-stack",
+		TemplateBody:          `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+		TerminationProtection: aws.Bool(true),
+	}
+
+	mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+		Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+	mockClient.On("CreateStack", ctx, mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+		return aws.ToBool(input.EnableTerminationProtection)
+	})).Return(nil, errors.New("stop after create"))
+
+	err := cfOps.DeployStack(ctx, input)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stop after create")
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeployStack_CreateNewStack_PassesOnFailure(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+		Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+	mockClient.On("CreateStack", ctx, mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+		return input.OnFailure == types.OnFailureDoNothing
+	})).Return(nil, errors.New("stop after create"))
+
+	err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", OnFailure: "DO_NOTHING"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stop after create")
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeployStack_CreateNewStack_PassesTimeoutInMinutes(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+		Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+	mockClient.On("CreateStack", ctx, mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+		return aws.ToInt32(input.TimeoutInMinutes) == 15
+	})).Return(nil, errors.New("stop after create"))
+
+	err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", TimeoutInMinutes: aws.Int32(15)})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stop after create")
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeployStack_CreateNewStack_DisableRollback(t *testing.T) {
+	t.Run("passes disable rollback", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+		mockClient.On("CreateStack", ctx, mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+			return aws.ToBool(input.DisableRollback) && input.OnFailure == ""
+		})).Return(nil, errors.New("stop after create"))
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", DisableRollback: true})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "stop after create")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("rejects on failure as well", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", DisableRollback: true, OnFailure: "DELETE"})
+
+		require.EqualError(t, err, "stack test-stack cannot both disable rollback and set on_failure")
+		mockClient.AssertNotCalled(t, "CreateStack", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeployStack_PassesResourceTypesAndClientRequestToken(t *testing.T) {
+	resourceTypes := []string{"AWS::S3::*", "AWS::SQS::Queue", "Custom::*"}
+
+	t.Run("create", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+		mockClient.On("CreateStack", ctx, mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+			return assert.ObjectsAreEqual(resourceTypes, input.ResourceTypes) && aws.ToString(input.ClientRequestToken) == "deploy-42"
+		})).Return(nil, errors.New("stop after create"))
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", ResourceTypes: resourceTypes, ClientRequestToken: "deploy-42"})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("UpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.UpdateStackInput) bool {
+			return assert.ObjectsAreEqual(resourceTypes, input.ResourceTypes) && aws.ToString(input.ClientRequestToken) == "deploy-42"
+		})).Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", ResourceTypes: resourceTypes, ClientRequestToken: "deploy-42"})
+
+		var noChangesErr NoChangesError
+		require.ErrorAs(t, err, &noChangesErr)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("changeset", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("CreateChangeSet", ctx, mock.MatchedBy(func(input *cloudformation.CreateChangeSetInput) bool {
+			return assert.ObjectsAreEqual(resourceTypes, input.ResourceTypes)
+		})).Return((*cloudformation.CreateChangeSetOutput)(nil), errors.New("stop after create"))
+
+		_, err := cfOps.CreateChangeSetForDeployment(ctx, ChangeSetInput{StackName: "test-stack", TemplateBody: "{}", Parameters: map[string]string{}, Capabilities: []string{}, Tags: map[string]string{}, ResourceTypes: resourceTypes})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestDeployStack_CreateNewStack_RejectsInvalidOnFailure(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+		Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+
+	err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", OnFailure: "KEEP"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid on_failure value "KEEP"`)
+	mockClient.AssertNotCalled(t, "CreateStack", mock.Anything, mock.Anything)
+}
+
+func TestDeployStack_UpdateIgnoresOnFailureAndTimeout(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+		Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+		}, nil)
+	mockClient.On("UpdateStack", ctx, mock.AnythingOfType("*cloudformation.UpdateStackInput")).
+		Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+	err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", OnFailure: "DO_NOTHING", TimeoutInMinutes: aws.Int32(15)})
+
+	var noChangesErr NoChangesError
+	require.ErrorAs(t, err, &noChangesErr)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeployStack_PrefersTemplateURL(t *testing.T) {
+	const templateURL = "https://templates.s3.us-east-1.amazonaws.com/vpc.yaml"
+
+	t.Run("create", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+		mockClient.On("CreateStack", ctx, mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+			return aws.ToString(input.TemplateURL) == templateURL && input.TemplateBody == nil
+		})).Return(nil, errors.New("stop after create"))
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", TemplateURL: templateURL})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "stop after create")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("UpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.UpdateStackInput) bool {
+			return aws.ToString(input.TemplateURL) == templateURL && input.TemplateBody == nil
+		})).Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", TemplateURL: templateURL})
+
+		var noChangesErr NoChangesError
+		require.ErrorAs(t, err, &noChangesErr)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestDeployStack_Update_SendsCompleteTagSet(t *testing.T) {
+	tests := []struct {
+		name         string
+		tags         map[string]string
+		expectedTags []types.Tag
+	}{
+		{
+			name:         "tag removed from config is omitted",
+			tags:         map[string]string{"Environment": "prod"},
+			expectedTags: []types.Tag{{Key: aws.String("Environment"), Value: aws.String("prod")}},
+		},
+		{
+			name:         "every tag removed sends an empty list",
+			tags:         nil,
+			expectedTags: []types.Tag{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockClient := &MockCloudFormationClient{}
+			cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+			mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+				Return(&cloudformation.DescribeStacksOutput{
+					Stacks: []types.Stack{{
+						StackName:   aws.String("test-stack"),
+						StackStatus: types.StackStatusCreateComplete,
+						Tags:        []types.Tag{{Key: aws.String("Environment"), Value: aws.String("prod")}, {Key: aws.String("Owner"), Value: aws.String("platform")}},
+					}},
+				}, nil)
+			mockClient.On("UpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.UpdateStackInput) bool {
+				return input.Tags != nil && assert.ObjectsAreEqual(tt.expectedTags, input.Tags)
+			})).Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+			err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", Tags: tt.tags})
+
+			var noChangesErr NoChangesError
+			require.ErrorAs(t, err, &noChangesErr)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeployStack_PassesStackPolicy(t *testing.T) {
+	policy := `{"Statement":[{"Effect":"Allow","Action":"Update:*","Principal":"*","Resource":"*"}]}`
+
+	t.Run("create", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+		mockClient.On("CreateStack", ctx, mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+			return aws.ToString(input.StackPolicyBody) == policy
+		})).Return(nil, errors.New("stop after create"))
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", StackPolicyBody: policy})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("UpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.UpdateStackInput) bool {
+			return aws.ToString(input.StackPolicyBody) == policy
+		})).Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", StackPolicyBody: policy})
+
+		var noChangesErr NoChangesError
+		require.ErrorAs(t, err, &noChangesErr)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("omitted when empty", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+		mockClient.On("CreateStack", ctx, mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+			return input.StackPolicyBody == nil
+		})).Return(nil, errors.New("stop after create"))
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}"})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestDeployStack_PassesNotificationARNs(t *testing.T) {
+	topics := []string{"arn:aws:sns:us-east-1:123456789012:stack-events"}
+
+	t.Run("create", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+		mockClient.On("CreateStack", ctx, mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+			return assert.ObjectsAreEqual(topics, input.NotificationARNs)
+		})).Return(nil, errors.New("stop after create"))
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", NotificationARNs: topics})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("UpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.UpdateStackInput) bool {
+			return assert.ObjectsAreEqual(topics, input.NotificationARNs)
+		})).Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", NotificationARNs: topics})
+
+		var noChangesErr NoChangesError
+		require.ErrorAs(t, err, &noChangesErr)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("changeset", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("CreateChangeSet", ctx, mock.MatchedBy(func(input *cloudformation.CreateChangeSetInput) bool {
+			return assert.ObjectsAreEqual(topics, input.NotificationARNs)
+		})).Return((*cloudformation.CreateChangeSetOutput)(nil), errors.New("stop after create"))
+
+		_, err := cfOps.CreateChangeSetForDeployment(ctx, ChangeSetInput{StackName: "test-stack", TemplateBody: "{}", Parameters: map[string]string{}, Capabilities: []string{}, Tags: map[string]string{}, NotificationARNs: topics})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestDeployStack_PassesRollbackConfiguration(t *testing.T) {
+	alarm := "arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-5xx"
+	rollback := &RollbackConfiguration{AlarmARNs: []string{alarm}, MonitoringTimeInMinutes: aws.Int32(10)}
+	hasTriggers := func(config *types.RollbackConfiguration) bool {
+		return config != nil &&
+			len(config.RollbackTriggers) == 1 &&
+			aws.ToString(config.RollbackTriggers[0].Arn) == alarm &&
+			aws.ToString(config.RollbackTriggers[0].Type) == "AWS::CloudWatch::Alarm" &&
+			aws.ToInt32(config.MonitoringTimeInMinutes) == 10
+	}
+
+	t.Run("create", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+		mockClient.On("CreateStack", ctx, mock.MatchedBy(func(input *cloudformation.CreateStackInput) bool {
+			return hasTriggers(input.RollbackConfiguration)
+		})).Return(nil, errors.New("stop after create"))
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", RollbackConfiguration: rollback})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("UpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.UpdateStackInput) bool {
+			return hasTriggers(input.RollbackConfiguration)
+		})).Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", RollbackConfiguration: rollback})
+
+		require.ErrorAs(t, err, &NoChangesError{})
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("changeset", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("CreateChangeSet", ctx, mock.MatchedBy(func(input *cloudformation.CreateChangeSetInput) bool {
+			return hasTriggers(input.RollbackConfiguration)
+		})).Return((*cloudformation.CreateChangeSetOutput)(nil), errors.New("stop after create"))
+
+		_, err := cfOps.CreateChangeSetForDeployment(ctx, ChangeSetInput{StackName: "test-stack", TemplateBody: "{}", Parameters: map[string]string{}, Capabilities: []string{}, Tags: map[string]string{}, RollbackConfiguration: rollback})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("UpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.UpdateStackInput) bool {
+			return input.RollbackConfiguration == nil
+		})).Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}"})
+
+		require.ErrorAs(t, err, &NoChangesError{})
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestDeployStack_UsePreviousTemplate(t *testing.T) {
+	t.Run("update", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("UpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.UpdateStackInput) bool {
+			return input.TemplateBody == nil && aws.ToBool(input.UsePreviousTemplate)
+		})).Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", TemplateBody: "{}", UsePreviousTemplate: true})
+
+		var noChangesErr NoChangesError
+		require.ErrorAs(t, err, &noChangesErr)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack does not exist"}).Once()
+
+		err := cfOps.DeployStack(ctx, DeployStackInput{StackName: "test-stack", UsePreviousTemplate: true})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a template is required to create it")
+		mockClient.AssertNotCalled(t, "CreateStack", mock.Anything, mock.Anything)
+	})
+
+	t.Run("changeset", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusCreateComplete}},
+			}, nil)
+		mockClient.On("CreateChangeSet", ctx, mock.MatchedBy(func(input *cloudformation.CreateChangeSetInput) bool {
+			return input.TemplateBody == nil && aws.ToBool(input.UsePreviousTemplate)
+		})).Return((*cloudformation.CreateChangeSetOutput)(nil), errors.New("stop after create"))
+
+		_, err := cfOps.CreateChangeSetForDeployment(ctx, ChangeSetInput{StackName: "test-stack", Parameters: map[string]string{}, Capabilities: []string{}, Tags: map[string]string{}})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCreateChangeSet_TemplateURL(t *testing.T) {
+	templateURL := "https://templates.s3.us-east-1.amazonaws.com/app.yaml"
+	sendsURL := mock.MatchedBy(func(input *cloudformation.CreateChangeSetInput) bool {
+		return input.TemplateBody == nil && aws.ToString(input.TemplateURL) == templateURL && !aws.ToBool(input.UsePreviousTemplate)
+	})
+
+	t.Run("deployment", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{StackName: aws.String("test-stack"), StackStatus: types.StackStatusUpdateComplete}},
+			}, nil)
+		mockClient.On("CreateChangeSet", ctx, sendsURL).Return((*cloudformation.CreateChangeSetOutput)(nil), errors.New("stop after create"))
+
+		_, err := cfOps.CreateChangeSetForDeployment(ctx, ChangeSetInput{StackName: "test-stack", TemplateBody: "Resources: {}", TemplateURL: templateURL, Parameters: map[string]string{}, Capabilities: []string{}, Tags: map[string]string{}})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("preview", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockCloudFormationClient{}
+		cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+		mockClient.On("CreateChangeSet", ctx, sendsURL).Return((*cloudformation.CreateChangeSetOutput)(nil), errors.New("stop after create"))
+
+		_, err := cfOps.CreateChangeSetPreview(ctx, "test-stack", "Resources: {}", templateURL, map[string]string{}, []string{}, map[string]string{})
+
+		require.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestDeployStack_Update_TerminationProtection(t *testing.T) {
+	tests := []struct {
+		name          string
+		desired       *bool
+		current       bool
+		expectUpdate  bool
+		expectedValue bool
+	}{
+		{name: "nil leaves protection untouched", desired: nil, current: true},
+		{name: "unchanged protection is not updated", desired: aws.Bool(true), current: true},
+		{name: "enables protection", desired: aws.Bool(true), current: false, expectUpdate: true, expectedValue: true},
+		{name: "disables protection", desired: aws.Bool(false), current: true, expectUpdate: true, expectedValue: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockClient := &MockCloudFormationClient{}
+			cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+			input := DeployStackInput{
+				StackName:             "existing-stack",
+				TemplateBody:          `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+				TerminationProtection: tt.desired,
+			}
+
+			mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+				Return(&cloudformation.DescribeStacksOutput{
+					Stacks: []types.Stack{
+						{
+							StackName:                   aws.String("existing-stack"),
+							StackStatus:                 types.StackStatusCreateComplete,
+							EnableTerminationProtection: aws.Bool(tt.current),
+						},
+					},
+				}, nil)
+			if tt.expectUpdate {
+				mockClient.On("UpdateTerminationProtection", ctx, mock.MatchedBy(func(input *cloudformation.UpdateTerminationProtectionInput) bool {
+					return aws.ToString(input.StackName) == "existing-stack" &&
+						aws.ToBool(input.EnableTerminationProtection) == tt.expectedValue
+				})).Return(&cloudformation.UpdateTerminationProtectionOutput{}, nil).Once()
+			}
+			mockClient.On("UpdateStack", ctx, mock.AnythingOfType("*cloudformation.UpdateStackInput")).
+				Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+			err := cfOps.DeployStack(ctx, input)
+
+			var noChangesErr NoChangesError
+			require.ErrorAs(t, err, &noChangesErr)
+			mockClient.AssertExpectations(t)
+			if !tt.expectUpdate {
+				mockClient.AssertNotCalled(t, "UpdateTerminationProtection", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
 func TestDeployStack_Update_IAMDenial_NotSwallowed(t *testing.T) {
 	ctx := context.Background()
 	mockClient := &MockCloudFormationClient{}
@@ -245,6 +876,221 @@ func TestDeployStack_CreateStack_Failure(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestGetStack_IncludesDriftStatus(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := &DefaultCloudFormationOperations{client: mockClient}
+
+	mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []types.Stack{
+			{
+				StackName:   aws.String("app"),
+				StackStatus: types.StackStatusUpdateComplete,
+				DriftInformation: &types.StackDriftInformation{
+					StackDriftStatus: types.StackDriftStatusDrifted,
+				},
+			},
+		},
+	}, nil)
+
+	stack, err := cf.GetStack(ctx, "app")
+
+	require.NoError(t, err)
+	assert.Equal(t, "DRIFTED", stack.DriftStatus)
+}
+
+func TestContinueUpdateRollback_SkipsResources(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("ContinueUpdateRollback", ctx, mock.MatchedBy(func(input *cloudformation.ContinueUpdateRollbackInput) bool {
+		return aws.ToString(input.StackName) == "app" &&
+			assert.ObjectsAreEqual([]string{"Database", "Cache"}, input.ResourcesToSkip)
+	})).Return(&cloudformation.ContinueUpdateRollbackOutput{}, nil)
+
+	err := cfOps.ContinueUpdateRollback(ctx, "app", []string{"Database", "Cache"})
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestContinueUpdateRollback_Failure(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("ContinueUpdateRollback", ctx, mock.Anything).
+		Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack app is in UPDATE_COMPLETE state and can not continue rollback"})
+
+	err := cfOps.ContinueUpdateRollback(ctx, "app", nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to continue update rollback for stack app")
+}
+
+func TestCancelUpdateStack(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("CancelUpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.CancelUpdateStackInput) bool {
+		return aws.ToString(input.StackName) == "app"
+	})).Return(&cloudformation.CancelUpdateStackOutput{}, nil)
+
+	err := cfOps.CancelUpdateStack(ctx, "app")
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCancelUpdateStack_Failure(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("CancelUpdateStack", ctx, mock.Anything).
+		Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "CancelUpdateStack cannot be called from current stack status"})
+
+	err := cfOps.CancelUpdateStack(ctx, "app")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to cancel update of stack app")
+}
+
+func TestUpdateStack_SendsTemplateParametersAndTags(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("UpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.UpdateStackInput) bool {
+		return aws.ToString(input.StackName) == "app" &&
+			aws.ToString(input.TemplateBody) == "Resources: {}" &&
+			len(input.Parameters) == 1 && aws.ToString(input.Parameters[0].ParameterValue) == "t3.small" &&
+			len(input.Tags) == 1 && aws.ToString(input.Tags[0].Key) == "Team"
+	})).Return(&cloudformation.UpdateStackOutput{}, nil)
+
+	err := cfOps.UpdateStack(ctx, UpdateStackInput{
+		StackName:    "app",
+		TemplateBody: "Resources: {}",
+		Parameters:   []Parameter{{Key: "InstanceType", Value: "t3.small"}},
+		Tags:         map[string]string{"Team": "platform"},
+	})
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateStack_UsePreviousTemplate(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("UpdateStack", ctx, mock.MatchedBy(func(input *cloudformation.UpdateStackInput) bool {
+		return aws.ToBool(input.UsePreviousTemplate) && input.TemplateBody == nil
+	})).Return(&cloudformation.UpdateStackOutput{}, nil)
+
+	err := cfOps.UpdateStack(ctx, UpdateStackInput{
+		StackName:           "app",
+		UsePreviousTemplate: true,
+		Parameters:          []Parameter{{Key: "InstanceType", Value: "t3.small"}},
+	})
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateStack_NoChanges(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("UpdateStack", ctx, mock.AnythingOfType("*cloudformation.UpdateStackInput")).
+		Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "No updates are to be performed"})
+
+	err := cfOps.UpdateStack(ctx, UpdateStackInput{StackName: "app", TemplateBody: "Resources: {}"})
+
+	var noChangesErr NoChangesError
+	require.ErrorAs(t, err, &noChangesErr)
+	assert.Equal(t, "app", noChangesErr.StackName)
+}
+
+func TestGetStackPolicy_ReturnsPolicy(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	policy := `{"Statement": [{"Effect": "Deny", "Action": "Update:Replace", "Principal": "*", "Resource": "*"}]}`
+	mockClient.On("GetStackPolicy", ctx, mock.MatchedBy(func(input *cloudformation.GetStackPolicyInput) bool {
+		return aws.ToString(input.StackName) == "app"
+	})).Return(&cloudformation.GetStackPolicyOutput{StackPolicyBody: aws.String(policy)}, nil)
+
+	result, err := cfOps.GetStackPolicy(ctx, "app")
+
+	require.NoError(t, err)
+	assert.Equal(t, policy, result)
+}
+
+func TestGetStackPolicy_NoPolicy(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("GetStackPolicy", ctx, mock.Anything).Return(&cloudformation.GetStackPolicyOutput{}, nil)
+
+	result, err := cfOps.GetStackPolicy(ctx, "app")
+
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestGetStackPolicy_Failure(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("GetStackPolicy", ctx, mock.Anything).
+		Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack with id app does not exist"})
+
+	_, err := cfOps.GetStackPolicy(ctx, "app")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get stack policy for stack app")
+}
+
+func TestDescribeChangeSet_ReturnsStackAndExecutionStatus(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("DescribeChangeSet", ctx, mock.MatchedBy(func(input *cloudformation.DescribeChangeSetInput) bool {
+		return aws.ToString(input.ChangeSetName) == "changeset-123"
+	})).Return(&cloudformation.DescribeChangeSetOutput{
+		StackName:       aws.String("app"),
+		Status:          types.ChangeSetStatusCreateComplete,
+		ExecutionStatus: types.ExecutionStatusAvailable,
+		Changes: []types.Change{
+			{
+				ResourceChange: &types.ResourceChange{
+					Action:            types.ChangeActionModify,
+					LogicalResourceId: aws.String("Bucket"),
+					ResourceType:      aws.String("AWS::S3::Bucket"),
+				},
+			},
+		},
+	}, nil)
+
+	info, err := cfOps.DescribeChangeSet(ctx, "changeset-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "changeset-123", info.ChangeSetID)
+	assert.Equal(t, "app", info.StackName)
+	assert.Equal(t, "CREATE_COMPLETE", info.Status)
+	assert.Equal(t, "AVAILABLE", info.ExecutionStatus)
+	require.Len(t, info.Changes, 1)
+	assert.Equal(t, "Bucket", info.Changes[0].LogicalID)
+}
+
 func TestDescribeStackEvents_Success(t *testing.T) {
 	ctx := context.Background()
 	mockClient := &MockCloudFormationClient{}
@@ -350,6 +1196,23 @@ func TestIsStackOperationSuccessful(t *testing.T) {
 	}
 }
 
+func TestIsRollbackFailed(t *testing.T) {
+	assert.True(t, IsRollbackFailed(StackStatusUpdateRollbackFailed))
+	assert.True(t, IsRollbackFailed(StackStatusRollbackFailed))
+	assert.True(t, IsRollbackFailed(StackStatusImportRollbackFailed))
+	assert.False(t, IsRollbackFailed(StackStatusUpdateRollbackComplete))
+	assert.False(t, IsRollbackFailed(StackStatusUpdateFailed))
+}
+
+func TestIsOperationInProgress(t *testing.T) {
+	assert.True(t, IsOperationInProgress(StackStatusUpdateInProgress))
+	assert.True(t, IsOperationInProgress(StackStatusRollbackInProgress))
+	assert.True(t, IsOperationInProgress(StackStatus("UPDATE_COMPLETE_CLEANUP_IN_PROGRESS")))
+	assert.False(t, IsOperationInProgress(StackStatusReviewInProgress))
+	assert.False(t, IsOperationInProgress(StackStatusUpdateComplete))
+	assert.False(t, IsOperationInProgress(StackStatusUpdateRollbackFailed))
+}
+
 func TestIsNoChangesError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -601,7 +1464,27 @@ func TestDefaultCloudFormationOperations_ExecuteChangeSet_Success(t *testing.T)
 
 	mockClient.On("ExecuteChangeSet", ctx, executeInput).Return(expectedOutput, nil)
 
-	err := cfOps.ExecuteChangeSet(ctx, changeSetID)
+	err := cfOps.ExecuteChangeSet(ctx, changeSetID, false)
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDefaultCloudFormationOperations_ExecuteChangeSet_DisableRollback(t *testing.T) {
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+	ctx := context.Background()
+
+	changeSetID := "arn:aws:cloudformation:us-east-1:123456789012:changeSet/test-changeset/test-stack"
+
+	executeInput := &cloudformation.ExecuteChangeSetInput{
+		ChangeSetName:   aws.String(changeSetID),
+		DisableRollback: aws.Bool(true),
+	}
+
+	mockClient.On("ExecuteChangeSet", ctx, executeInput).Return(&cloudformation.ExecuteChangeSetOutput{}, nil)
+
+	err := cfOps.ExecuteChangeSet(ctx, changeSetID, true)
 
 	require.NoError(t, err)
 	mockClient.AssertExpectations(t)
@@ -622,7 +1505,7 @@ func TestDefaultCloudFormationOperations_ExecuteChangeSet_Error(t *testing.T) {
 
 	mockClient.On("ExecuteChangeSet", ctx, executeInput).Return((*cloudformation.ExecuteChangeSetOutput)(nil), expectedError)
 
-	err := cfOps.ExecuteChangeSet(ctx, changeSetID)
+	err := cfOps.ExecuteChangeSet(ctx, changeSetID, false)
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to execute changeset")
@@ -707,7 +1590,7 @@ func TestDefaultCloudFormationOperations_CreateChangeSetPreview_Success(t *testi
 	})).Return(&cloudformation.DeleteChangeSetOutput{}, nil)
 
 	// Execute
-	result, err := cf.CreateChangeSetPreview(ctx, stackName, template, parameters, capabilities, map[string]string{})
+	result, err := cf.CreateChangeSetPreview(ctx, stackName, template, "", parameters, capabilities, map[string]string{})
 
 	// Verify
 	require.NoError(t, err)
@@ -743,7 +1626,7 @@ func TestDefaultCloudFormationOperations_CreateChangeSetPreview_CreateError(t *t
 	mockClient.On("CreateChangeSet", ctx, mock.AnythingOfType("*cloudformation.CreateChangeSetInput")).Return((*cloudformation.CreateChangeSetOutput)(nil), errors.New("access denied"))
 
 	// Execute
-	result, err := cf.CreateChangeSetPreview(ctx, stackName, template, parameters, capabilities, map[string]string{})
+	result, err := cf.CreateChangeSetPreview(ctx, stackName, template, "", parameters, capabilities, map[string]string{})
 
 	// Verify
 	assert.Error(t, err)
@@ -778,7 +1661,7 @@ func TestDefaultCloudFormationOperations_CreateChangeSetPreview_WaitError(t *tes
 	mockClient.On("DeleteChangeSet", ctx, mock.AnythingOfType("*cloudformation.DeleteChangeSetInput")).Return(&cloudformation.DeleteChangeSetOutput{}, nil)
 
 	// Execute
-	result, err := cf.CreateChangeSetPreview(ctx, stackName, template, parameters, capabilities, map[string]string{})
+	result, err := cf.CreateChangeSetPreview(ctx, stackName, template, "", parameters, capabilities, map[string]string{})
 
 	// Verify
 	assert.Error(t, err)
@@ -812,7 +1695,7 @@ func TestDefaultCloudFormationOperations_CreateChangeSetPreview_NoInfrastructure
 	mockClient.On("DeleteChangeSet", ctx, mock.AnythingOfType("*cloudformation.DeleteChangeSetInput")).Return(&cloudformation.DeleteChangeSetOutput{}, nil)
 
 	// Execute
-	result, err := cf.CreateChangeSetPreview(ctx, stackName, template, parameters, capabilities, map[string]string{})
+	result, err := cf.CreateChangeSetPreview(ctx, stackName, template, "", parameters, capabilities, map[string]string{})
 
 	// Verify - should return NoChangesError
 	assert.Error(t, err)
@@ -867,7 +1750,7 @@ func TestDefaultCloudFormationOperations_CreateChangeSetForDeployment_NewStack(t
 	})).Return(createTestDescribeChangeSetOutput(changeSetId, types.ChangeSetStatusCreateComplete), nil).Once()
 
 	// Execute
-	result, err := cf.CreateChangeSetForDeployment(ctx, stackName, template, parameters, capabilities, tags)
+	result, err := cf.CreateChangeSetForDeployment(ctx, ChangeSetInput{StackName: stackName, TemplateBody: template, Parameters: parameters, Capabilities: capabilities, Tags: tags})
 
 	// Verify
 	require.NoError(t, err)
@@ -915,7 +1798,7 @@ func TestDefaultCloudFormationOperations_CreateChangeSetForDeployment_ExistingSt
 		createTestDescribeChangeSetOutput(changeSetId, types.ChangeSetStatusCreateComplete), nil).Times(2)
 
 	// Execute
-	result, err := cf.CreateChangeSetForDeployment(ctx, stackName, template, parameters, capabilities, tags)
+	result, err := cf.CreateChangeSetForDeployment(ctx, ChangeSetInput{StackName: stackName, TemplateBody: template, Parameters: parameters, Capabilities: capabilities, Tags: tags})
 
 	// Verify
 	require.NoError(t, err)
@@ -925,6 +1808,171 @@ func TestDefaultCloudFormationOperations_CreateChangeSetForDeployment_ExistingSt
 	mockClient.AssertExpectations(t)
 }
 
+func TestDefaultCloudFormationOperations_CreateChangeSetForDeployment_SendsCompleteTagSet(t *testing.T) {
+	tests := []struct {
+		name         string
+		tags         map[string]string
+		expectedTags []types.Tag
+	}{
+		{
+			name:         "tag removed from config is omitted",
+			tags:         map[string]string{"Environment": "prod"},
+			expectedTags: []types.Tag{{Key: aws.String("Environment"), Value: aws.String("prod")}},
+		},
+		{
+			name:         "every tag removed sends an empty list",
+			tags:         nil,
+			expectedTags: []types.Tag{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockClient := &MockCloudFormationClient{}
+			cf := &DefaultCloudFormationOperations{client: mockClient}
+
+			mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+				Return(&cloudformation.DescribeStacksOutput{
+					Stacks: []types.Stack{{
+						StackName:   aws.String("test-stack"),
+						StackStatus: types.StackStatusCreateComplete,
+						Tags:        []types.Tag{{Key: aws.String("Environment"), Value: aws.String("prod")}, {Key: aws.String("Owner"), Value: aws.String("platform")}},
+					}},
+				}, nil)
+			mockClient.On("CreateChangeSet", ctx, mock.MatchedBy(func(input *cloudformation.CreateChangeSetInput) bool {
+				return input.Tags != nil && assert.ObjectsAreEqual(tt.expectedTags, input.Tags)
+			})).Return(createTestChangeSetOutput("test-changeset-123"), nil)
+			mockClient.On("DescribeChangeSet", ctx, mock.AnythingOfType("*cloudformation.DescribeChangeSetInput")).Return(
+				createTestDescribeChangeSetOutput("test-changeset-123", types.ChangeSetStatusCreateComplete), nil)
+
+			_, err := cf.CreateChangeSetForDeployment(ctx, ChangeSetInput{StackName: "test-stack", TemplateBody: "{}", Tags: tt.tags})
+
+			require.NoError(t, err)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultCloudFormationOperations_CreateChangeSetForDeployment_RecordsMetadata(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := &DefaultCloudFormationOperations{client: mockClient}
+
+	stackName := "test-stack"
+	changeSetId := "test-changeset-123"
+	metadata := ChangeSetMetadata{Commit: "a1b2c3d", User: "alice", Message: "Rotate certificates"}
+
+	mockClient.On("DescribeStacks", ctx, mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []types.Stack{{StackName: aws.String(stackName), StackStatus: types.StackStatusCreateComplete}},
+	}, nil)
+
+	mockClient.On("CreateChangeSet", ctx, mock.MatchedBy(func(input *cloudformation.CreateChangeSetInput) bool {
+		return aws.ToString(input.Description) == "commit=a1b2c3d user=alice message=Rotate certificates" &&
+			strings.HasPrefix(aws.ToString(input.ChangeSetName), "stackaroo-deploy-") &&
+			strings.HasSuffix(aws.ToString(input.ChangeSetName), "-a1b2c3d")
+	})).Return(createTestChangeSetOutput(changeSetId), nil)
+
+	mockClient.On("DescribeChangeSet", ctx, mock.AnythingOfType("*cloudformation.DescribeChangeSetInput")).Return(
+		createTestDescribeChangeSetOutput(changeSetId, types.ChangeSetStatusCreateComplete), nil).Times(2)
+
+	_, err := cf.CreateChangeSetForDeployment(ctx, ChangeSetInput{StackName: stackName, TemplateBody: `{}`, Parameters: map[string]string{}, Capabilities: []string{}, Tags: map[string]string{}, Metadata: metadata})
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestChangeSetDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata ChangeSetMetadata
+		expected string
+	}{
+		{"empty", ChangeSetMetadata{}, ""},
+		{"commit only", ChangeSetMetadata{Commit: "a1b2c3d"}, "commit=a1b2c3d"},
+		{"user and message", ChangeSetMetadata{User: "alice", Message: "Scale out"}, "user=alice message=Scale out"},
+		{"all fields", ChangeSetMetadata{Commit: "a1b2c3d", User: "alice", Message: "Scale out"}, "commit=a1b2c3d user=alice message=Scale out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, changeSetDescription(tt.metadata))
+		})
+	}
+}
+
+func TestChangeSetDescription_TruncatesLongMessages(t *testing.T) {
+	description := changeSetDescription(ChangeSetMetadata{Message: strings.Repeat("é", 1000)})
+
+	assert.LessOrEqual(t, len(description), 1024)
+	assert.True(t, utf8.ValidString(description))
+}
+
+func TestDeploymentToken(t *testing.T) {
+	input := DeployStackInput{
+		StackName:    "app",
+		TemplateBody: `{"Resources": {}}`,
+		Parameters:   []Parameter{{Key: "Env", Value: "prod"}, {Key: "Size", Value: "large"}},
+	}
+
+	token := deploymentToken("update", input)
+	assert.Regexp(t, `^stackaroo-[0-9a-f]{32}$`, token)
+
+	t.Run("identical inputs produce the same token", func(t *testing.T) {
+		reordered := input
+		reordered.Parameters = []Parameter{{Key: "Size", Value: "large"}, {Key: "Env", Value: "prod"}}
+		assert.Equal(t, token, deploymentToken("update", reordered))
+	})
+
+	t.Run("differing inputs produce different tokens", func(t *testing.T) {
+		changedParam := input
+		changedParam.Parameters = []Parameter{{Key: "Env", Value: "prod"}, {Key: "Size", Value: "small"}}
+		changedTemplate := input
+		changedTemplate.TemplateBody = `{"Resources": {"Queue": {"Type": "AWS::SQS::Queue"}}}`
+		changedStack := input
+		changedStack.StackName = "other"
+		changedTags := input
+		changedTags.Tags = map[string]string{"Team": "platform"}
+		changedNotifications := input
+		changedNotifications.NotificationARNs = []string{"arn:aws:sns:us-east-1:123456789012:events"}
+		changedRollback := input
+		changedRollback.RollbackConfiguration = &RollbackConfiguration{AlarmARNs: []string{"arn:aws:cloudwatch:us-east-1:123456789012:alarm:errors"}}
+		changedCapabilities := input
+		changedCapabilities.Capabilities = []string{"CAPABILITY_NAMED_IAM"}
+
+		assert.NotEqual(t, token, deploymentToken("update", changedParam))
+		assert.NotEqual(t, token, deploymentToken("update", changedTemplate))
+		assert.NotEqual(t, token, deploymentToken("update", changedStack))
+		assert.NotEqual(t, token, deploymentToken("update", changedTags))
+		assert.NotEqual(t, token, deploymentToken("update", changedNotifications))
+		assert.NotEqual(t, token, deploymentToken("update", changedRollback))
+		assert.NotEqual(t, token, deploymentToken("update", changedCapabilities))
+		assert.NotEqual(t, token, deploymentToken("create", input))
+	})
+
+	t.Run("separate runs produce different tokens", func(t *testing.T) {
+		original := runNonce
+		t.Cleanup(func() { runNonce = original })
+		runNonce = newRunNonce()
+
+		assert.NotEqual(t, token, deploymentToken("update", input))
+	})
+
+	t.Run("configured token overrides the derived one", func(t *testing.T) {
+		overridden := input
+		overridden.ClientRequestToken = "deploy-42"
+		assert.Equal(t, "deploy-42", deploymentToken("update", overridden))
+	})
+}
+
+func TestDeploymentChangeSetName(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	assert.Equal(t, "stackaroo-deploy-1700000000", deploymentChangeSetName(now, ChangeSetMetadata{}))
+	assert.Equal(t, "stackaroo-deploy-1700000000-a1b2c3d", deploymentChangeSetName(now, ChangeSetMetadata{Commit: "a1b2c3d"}))
+	assert.Equal(t, "stackaroo-deploy-1700000000-a1b2c3d", deploymentChangeSetName(now, ChangeSetMetadata{Commit: "a1b2c3d*", User: "alice"}))
+}
+
 func TestDefaultCloudFormationOperations_CreateChangeSetForDeployment_StackExistsError(t *testing.T) {
 	ctx := context.Background()
 	mockClient := &MockCloudFormationClient{}
@@ -942,7 +1990,7 @@ func TestDefaultCloudFormationOperations_CreateChangeSetForDeployment_StackExist
 		(*cloudformation.DescribeStacksOutput)(nil), errors.New("access denied"))
 
 	// Execute
-	result, err := cf.CreateChangeSetForDeployment(ctx, stackName, template, parameters, capabilities, tags)
+	result, err := cf.CreateChangeSetForDeployment(ctx, ChangeSetInput{StackName: stackName, TemplateBody: template, Parameters: parameters, Capabilities: capabilities, Tags: tags})
 
 	// Verify
 	assert.Error(t, err)
@@ -996,6 +2044,103 @@ func TestDefaultCloudFormationOperations_WaitForChangeSet_PendingThenComplete(t
 	mockClient.AssertExpectations(t)
 }
 
+func TestDefaultCloudFormationOperations_WaitForChangeSet_ReportsProgress(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	for _, status := range []types.ChangeSetStatus{types.ChangeSetStatusCreatePending, types.ChangeSetStatusCreateInProgress, types.ChangeSetStatusCreateInProgress, types.ChangeSetStatusCreateComplete} {
+		mockClient.On("DescribeChangeSet", ctx, mock.AnythingOfType("*cloudformation.DescribeChangeSetInput")).Return(&cloudformation.DescribeChangeSetOutput{
+			Status: status,
+		}, nil).Once()
+	}
+
+	var reported []ChangeSetProgress
+	cf := NewCloudFormationOperationsWithClient(mockClient)
+	clock := &fakeClock{now: time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)}
+	cf.clock = clock
+	cf.SetWaitConfig(WaitConfig{
+		ChangeSetPollInterval: 3 * time.Second,
+		ChangeSetProgress:     func(progress ChangeSetProgress) { reported = append(reported, progress) },
+	})
+
+	err := cf.waitForChangeSet(ctx, "test-changeset-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, []ChangeSetProgress{
+		{ChangeSetID: "test-changeset-123", Status: "CREATE_PENDING", Elapsed: 0},
+		{ChangeSetID: "test-changeset-123", Status: "CREATE_IN_PROGRESS", Elapsed: 3 * time.Second},
+		{ChangeSetID: "test-changeset-123", Status: "CREATE_IN_PROGRESS", Elapsed: 6 * time.Second},
+		{ChangeSetID: "test-changeset-123", Status: "CREATE_COMPLETE", Elapsed: 9 * time.Second},
+	}, reported)
+	assert.Equal(t, []time.Duration{3 * time.Second, 3 * time.Second, 3 * time.Second}, clock.waited)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDefaultCloudFormationOperations_WaitForChangeSet_RetriesWhenNotYetQueryable(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := &DefaultCloudFormationOperations{client: mockClient}
+
+	changeSetId := "test-changeset-123"
+
+	// First describe races the changeset creation and reports it missing
+	mockClient.On("DescribeChangeSet", ctx, mock.AnythingOfType("*cloudformation.DescribeChangeSetInput")).Return(
+		(*cloudformation.DescribeChangeSetOutput)(nil), &types.ChangeSetNotFoundException{Message: aws.String("ChangeSet [test-changeset-123] does not exist")},
+	).Once()
+
+	mockClient.On("DescribeChangeSet", ctx, mock.AnythingOfType("*cloudformation.DescribeChangeSetInput")).Return(&cloudformation.DescribeChangeSetOutput{
+		Status: types.ChangeSetStatusCreateComplete,
+	}, nil).Once()
+
+	// Execute
+	err := cf.waitForChangeSet(ctx, changeSetId)
+
+	// Verify
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDefaultCloudFormationOperations_WaitForChangeSet_DoesNotRetryGenuineErrors(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := &DefaultCloudFormationOperations{client: mockClient}
+
+	changeSetId := "test-changeset-123"
+
+	mockClient.On("DescribeChangeSet", ctx, mock.AnythingOfType("*cloudformation.DescribeChangeSetInput")).Return(
+		(*cloudformation.DescribeChangeSetOutput)(nil), &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorised"},
+	).Once()
+
+	// Execute
+	err := cf.waitForChangeSet(ctx, changeSetId)
+
+	// Verify
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to describe changeset while waiting")
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "DescribeChangeSet", 1)
+}
+
+func TestDefaultCloudFormationOperations_WaitForChangeSet_GivesUpWhenNeverQueryable(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := &DefaultCloudFormationOperations{client: mockClient}
+
+	changeSetId := "test-changeset-123"
+
+	mockClient.On("DescribeChangeSet", ctx, mock.AnythingOfType("*cloudformation.DescribeChangeSetInput")).Return(
+		(*cloudformation.DescribeChangeSetOutput)(nil), &types.ChangeSetNotFoundException{Message: aws.String("does not exist")},
+	)
+
+	// Execute
+	err := cf.waitForChangeSet(ctx, changeSetId)
+
+	// Verify - the initial attempt plus three retries
+	require.Error(t, err)
+	var notFound *types.ChangeSetNotFoundException
+	assert.ErrorAs(t, err, &notFound)
+	mockClient.AssertNumberOfCalls(t, "DescribeChangeSet", 4)
+}
+
 func TestDefaultCloudFormationOperations_WaitForChangeSet_Failed(t *testing.T) {
 	ctx := context.Background()
 	mockClient := &MockCloudFormationClient{}
@@ -1041,6 +2186,24 @@ func TestDefaultCloudFormationOperations_WaitForChangeSet_NoInfrastructureChange
 	mockClient.AssertExpectations(t)
 }
 
+func TestDefaultCloudFormationOperations_WaitForChangeSet_ValidationFailureIsNotNoChanges(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := &DefaultCloudFormationOperations{client: mockClient}
+
+	reason := "Template format error: Unresolved resource dependencies [Topic] in the Resources block of the template"
+	mockClient.On("DescribeChangeSet", ctx, mock.AnythingOfType("*cloudformation.DescribeChangeSetInput")).Return(&cloudformation.DescribeChangeSetOutput{
+		Status:       types.ChangeSetStatusFailed,
+		StatusReason: aws.String(reason),
+	}, nil)
+
+	err := cf.waitForChangeSet(ctx, "test-changeset-123")
+
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &NoChangesError{}), "validation failures must not be reported as no changes")
+	assert.EqualError(t, err, "changeset creation failed: "+reason)
+}
+
 func TestDefaultCloudFormationOperations_WaitForChangeSet_ContextCancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	mockClient := &MockCloudFormationClient{}
@@ -1093,6 +2256,51 @@ func TestDefaultCloudFormationOperations_DescribeChangeSetInternal_Success(t *te
 	mockClient.AssertExpectations(t)
 }
 
+func TestDefaultCloudFormationOperations_DescribeChangeSetInternal_ReplacementCause(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := &DefaultCloudFormationOperations{client: mockClient}
+
+	output := &cloudformation.DescribeChangeSetOutput{
+		Status: types.ChangeSetStatusCreateComplete,
+		Changes: []types.Change{
+			{
+				Type: types.ChangeTypeResource,
+				ResourceChange: &types.ResourceChange{
+					Action:            types.ChangeActionModify,
+					LogicalResourceId: aws.String("WebServer"),
+					ResourceType:      aws.String("AWS::EC2::Instance"),
+					Replacement:       types.ReplacementTrue,
+					Details: []types.ResourceChangeDetail{
+						{
+							ChangeSource:  types.ChangeSourceParameterReference,
+							CausingEntity: aws.String("AmiId"),
+							Target: &types.ResourceTargetDefinition{
+								Attribute:          types.ResourceAttributeProperties,
+								Name:               aws.String("ImageId"),
+								RequiresRecreation: types.RequiresRecreationAlways,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	mockClient.On("DescribeChangeSet", ctx, mock.Anything).Return(output, nil)
+
+	result, err := cf.describeChangeSetInternal(ctx, "test-changeset-123")
+
+	require.NoError(t, err)
+	require.Len(t, result.Changes, 1)
+	assert.Equal(t, []PropertyChange{{
+		Name:               "ImageId",
+		Attribute:          "Properties",
+		RequiresRecreation: "Always",
+		ChangeSource:       "ParameterReference",
+		CausingEntity:      "AmiId",
+	}}, result.Changes[0].Properties)
+}
+
 func TestDefaultCloudFormationOperations_DescribeChangeSetInternal_Error(t *testing.T) {
 	ctx := context.Background()
 	mockClient := &MockCloudFormationClient{}
@@ -1193,6 +2401,11 @@ func TestIsChangeSetNoChangesMessage(t *testing.T) {
 			statusReason: "Invalid parameter value",
 			expected:     false,
 		},
+		{
+			name:         "Validation error quoting a no-change phrase",
+			statusReason: "Template error: resource Queue depends on Topic, but no updates are to be performed on Topic",
+			expected:     false,
+		},
 		{
 			name:         "Permission denied",
 			statusReason: "Access Denied",
@@ -1330,6 +2543,22 @@ func TestUpdateStackInput_Structure(t *testing.T) {
 	assert.Len(t, input.Capabilities, 1)
 }
 
+func TestDeleteStack_PassesRetainResources(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("DeleteStack", ctx, mock.MatchedBy(func(input *cloudformation.DeleteStackInput) bool {
+		return aws.ToString(input.StackName) == "app" &&
+			assert.ObjectsAreEqual([]string{"LogsBucket"}, input.RetainResources)
+	})).Return(&cloudformation.DeleteStackOutput{}, nil)
+
+	err := cfOps.DeleteStack(ctx, DeleteStackInput{StackName: "app", RetainResources: []string{"LogsBucket"}})
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
 func TestDeleteStackInput_Structure(t *testing.T) {
 	input := DeleteStackInput{
 		StackName: "stack-to-delete",
@@ -1523,3 +2752,123 @@ func TestNewCloudFormationOperationsWithClient(t *testing.T) {
 	// Client field is private, but successful creation indicates dependency injection worked
 	mockClient.AssertExpectations(t)
 }
+
+// fakeClock advances time instantly whenever a wait is requested
+type fakeClock struct {
+	now    time.Time
+	waited []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waited = append(c.waited, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// newWaitingOperations returns operations whose stack reports the given statuses in turn, with no events
+func newWaitingOperations(ctx context.Context, statuses ...types.StackStatus) (*DefaultCloudFormationOperations, *fakeClock) {
+	return newWaitingOperationsWithEvents(ctx, nil, statuses...)
+}
+
+// newWaitingOperationsWithEvents returns operations whose stack reports the given statuses in turn,
+// with the same events on every poll
+func newWaitingOperationsWithEvents(ctx context.Context, events []types.StackEvent, statuses ...types.StackStatus) (*DefaultCloudFormationOperations, *fakeClock) {
+	mockClient := &MockCloudFormationClient{}
+	for i, status := range statuses {
+		call := mockClient.On("DescribeStacks", ctx, mock.AnythingOfType("*cloudformation.DescribeStacksInput")).
+			Return(&cloudformation.DescribeStacksOutput{Stacks: []types.Stack{{StackName: aws.String("app"), StackStatus: status}}}, nil)
+		if i < len(statuses)-1 {
+			call.Once()
+		}
+	}
+	mockClient.On("DescribeStackEvents", ctx, mock.AnythingOfType("*cloudformation.DescribeStackEventsInput")).
+		Return(&cloudformation.DescribeStackEventsOutput{StackEvents: events}, nil)
+
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+	clock := &fakeClock{now: time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)}
+	cfOps.clock = clock
+	return cfOps, clock
+}
+
+func TestDefaultCloudFormationOperations_WaitForStackOperation_UsesPollInterval(t *testing.T) {
+	ctx := context.Background()
+	cfOps, clock := newWaitingOperations(ctx, types.StackStatusUpdateInProgress, types.StackStatusUpdateInProgress, types.StackStatusUpdateComplete)
+	cfOps.SetWaitConfig(WaitConfig{PollInterval: 30 * time.Second})
+
+	err := cfOps.WaitForStackOperation(ctx, "app", clock.now, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{30 * time.Second, 30 * time.Second}, clock.waited)
+}
+
+func TestDefaultCloudFormationOperations_WaitForStackOperation_FailFastOnRollback(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)
+	events := []types.StackEvent{
+		{EventId: aws.String("3"), StackName: aws.String("app"), LogicalResourceId: aws.String("Queue"), ResourceStatus: types.ResourceStatusUpdateFailed, ResourceStatusReason: aws.String("Resource update cancelled"), Timestamp: aws.Time(start.Add(2 * time.Second))},
+		{EventId: aws.String("2"), StackName: aws.String("app"), LogicalResourceId: aws.String("Bucket"), ResourceStatus: types.ResourceStatusUpdateFailed, ResourceStatusReason: aws.String("Bucket name already exists"), Timestamp: aws.Time(start.Add(time.Second))},
+		{EventId: aws.String("1"), StackName: aws.String("app"), LogicalResourceId: aws.String("app"), ResourceStatus: types.ResourceStatusUpdateInProgress, Timestamp: aws.Time(start)},
+	}
+	cfOps, clock := newWaitingOperationsWithEvents(ctx, events, types.StackStatusUpdateInProgress, types.StackStatusUpdateRollbackInProgress, types.StackStatusUpdateRollbackComplete)
+	cfOps.SetWaitConfig(WaitConfig{FailFastOnRollback: true})
+
+	err := cfOps.WaitForStackOperation(ctx, "app", clock.now, nil)
+
+	var rollbackErr RollbackStartedError
+	require.ErrorAs(t, err, &rollbackErr)
+	assert.Equal(t, StackStatusUpdateRollbackInProgress, rollbackErr.Status)
+	assert.Equal(t, "stack app is rolling back (status: UPDATE_ROLLBACK_IN_PROGRESS): Bucket name already exists", err.Error())
+	assert.Len(t, clock.waited, 1, "should return on the poll that sees the rollback start")
+}
+
+func TestDefaultCloudFormationOperations_WaitForStackOperation_ReportsAlarmRollback(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)
+	reason := "Rollback triggered by alarm arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-5xx"
+	events := []types.StackEvent{
+		{EventId: aws.String("2"), StackName: aws.String("app"), LogicalResourceId: aws.String("app"), ResourceType: aws.String("AWS::CloudFormation::Stack"), ResourceStatus: types.ResourceStatus(types.StackStatusUpdateRollbackInProgress), ResourceStatusReason: aws.String(reason), Timestamp: aws.Time(start.Add(time.Second))},
+		{EventId: aws.String("1"), StackName: aws.String("app"), LogicalResourceId: aws.String("app"), ResourceType: aws.String("AWS::CloudFormation::Stack"), ResourceStatus: types.ResourceStatusUpdateInProgress, Timestamp: aws.Time(start)},
+	}
+	cfOps, clock := newWaitingOperationsWithEvents(ctx, events, types.StackStatusUpdateInProgress, types.StackStatusUpdateRollbackInProgress, types.StackStatusUpdateRollbackComplete)
+
+	err := cfOps.WaitForStackOperation(ctx, "app", clock.now, nil)
+
+	var failedErr StackOperationFailedError
+	require.ErrorAs(t, err, &failedErr)
+	assert.Equal(t, StackStatusUpdateRollbackComplete, failedErr.Status)
+	assert.Equal(t, reason, failedErr.Reason)
+	assert.Equal(t, "stack operation failed with status: UPDATE_ROLLBACK_COMPLETE: "+reason, err.Error())
+}
+
+func TestDefaultCloudFormationOperations_WaitForStackOperation_WaitsForRollbackByDefault(t *testing.T) {
+	ctx := context.Background()
+	cfOps, clock := newWaitingOperations(ctx, types.StackStatusUpdateInProgress, types.StackStatusUpdateRollbackInProgress, types.StackStatusUpdateRollbackComplete)
+
+	err := cfOps.WaitForStackOperation(ctx, "app", clock.now, nil)
+
+	var failedErr StackOperationFailedError
+	require.ErrorAs(t, err, &failedErr)
+	assert.Equal(t, StackStatusUpdateRollbackComplete, failedErr.Status)
+	assert.Len(t, clock.waited, 2)
+}
+
+func TestDefaultCloudFormationOperations_WaitForStackOperation_ContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	cfOps, _ := newWaitingOperations(ctx, types.StackStatusUpdateInProgress)
+	cfOps.clock = realClock{}
+
+	err := cfOps.WaitForStackOperation(ctx, "app", time.Now(), nil)
+
+	var timeoutErr WaitTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "app", timeoutErr.StackName)
+	assert.Equal(t, StackStatusUpdateInProgress, timeoutErr.Status)
+	assert.Equal(t, "timed out waiting for stack app (last status: UPDATE_IN_PROGRESS)", err.Error())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, errors.As(err, &StackOperationFailedError{}))
+}
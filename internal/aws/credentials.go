@@ -0,0 +1,69 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// credentialsErrorCodes are the API error codes returned when a request is signed with bad credentials
+var credentialsErrorCodes = map[string]bool{
+	"ExpiredToken":                true,
+	"ExpiredTokenException":       true,
+	"InvalidClientTokenId":        true,
+	"UnrecognizedClientException": true,
+	"InvalidAccessKeyId":          true,
+	"SignatureDoesNotMatch":       true,
+	"InvalidSignatureException":   true,
+}
+
+// CredentialsError indicates that AWS rejected or could not load the configured credentials.
+// Every further AWS call would fail the same way, so multi-stack operations stop at the first one.
+type CredentialsError struct {
+	Err error
+}
+
+func (e CredentialsError) Error() string {
+	return "AWS credentials are invalid or expired; run 'aws sso login' or otherwise refresh your credentials, then try again"
+}
+
+func (e CredentialsError) Unwrap() error {
+	return e.Err
+}
+
+// IsCredentialsError reports whether an error was caused by invalid or expired AWS credentials
+func IsCredentialsError(err error) bool {
+	var credentialsErr CredentialsError
+	return errors.As(err, &credentialsErr)
+}
+
+// isCredentialsFailure recognises the SDK errors produced by invalid, expired or missing credentials
+func isCredentialsFailure(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && credentialsErrorCodes[apiErr.ErrorCode()] {
+		return true
+	}
+	return strings.Contains(err.Error(), "failed to refresh cached credentials")
+}
+
+// credentialsErrorMiddleware converts credential failures from any AWS API call into a CredentialsError
+var credentialsErrorMiddleware = middleware.InitializeMiddlewareFunc("StackarooCredentialsError",
+	func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		if err != nil && !IsCredentialsError(err) && isCredentialsFailure(err) {
+			err = CredentialsError{Err: err}
+		}
+		return out, metadata, err
+	})
+
+// addCredentialsErrorMiddleware registers credentialsErrorMiddleware on an API client's middleware stack
+func addCredentialsErrorMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(credentialsErrorMiddleware, middleware.Before)
+}
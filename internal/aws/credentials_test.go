@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expiredTokenHTTPClient answers every request with CloudFormation's expired token error
+type expiredTokenHTTPClient struct{}
+
+func (expiredTokenHTTPClient) Do(*http.Request) (*http.Response, error) {
+	body := `<ErrorResponse><Error><Type>Sender</Type><Code>ExpiredToken</Code>` +
+		`<Message>The security token included in the request is expired</Message></Error>` +
+		`<RequestId>request-1</RequestId></ErrorResponse>`
+	return &http.Response{
+		StatusCode: http.StatusForbidden,
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func TestDefaultClientFactory_ReportsExpiredCredentials(t *testing.T) {
+	ctx := context.Background()
+	factory := newClientFactoryWithConfig(aws.Config{
+		Credentials:      credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secret", "expired-session"),
+		HTTPClient:       expiredTokenHTTPClient{},
+		RetryMaxAttempts: 1,
+	})
+
+	cfnOps, err := factory.GetCloudFormationOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+
+	_, err = cfnOps.StackExists(ctx, "app")
+
+	require.Error(t, err)
+	assert.True(t, IsCredentialsError(err))
+	assert.Contains(t, err.Error(), "AWS credentials are invalid or expired")
+}
+
+func TestCredentialsErrorMiddleware(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		wantCredentials bool
+	}{
+		{name: "expired token", err: &smithy.GenericAPIError{Code: "ExpiredToken", Message: "The security token included in the request is expired"}, wantCredentials: true},
+		{name: "invalid access key", err: &smithy.GenericAPIError{Code: "InvalidClientTokenId", Message: "The security token included in the request is invalid"}, wantCredentials: true},
+		{name: "credential refresh failure", err: errors.New("failed to refresh cached credentials, the SSO session has expired"), wantCredentials: true},
+		{name: "unrelated API error", err: &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack with id app does not exist"}, wantCredentials: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := middleware.InitializeHandlerFunc(func(ctx context.Context, in middleware.InitializeInput) (middleware.InitializeOutput, middleware.Metadata, error) {
+				return middleware.InitializeOutput{}, middleware.Metadata{}, fmt.Errorf("operation error: %w", tt.err)
+			})
+
+			_, _, err := credentialsErrorMiddleware.HandleInitialize(context.Background(), middleware.InitializeInput{}, next)
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCredentials, IsCredentialsError(err))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
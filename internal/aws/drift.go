@@ -0,0 +1,145 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+// StackDriftResult contains the outcome of a drift detection run for a stack
+type StackDriftResult struct {
+	StackName   string
+	DriftStatus string // DRIFTED, IN_SYNC, UNKNOWN or NOT_CHECKED
+	Resources   []ResourceDrift
+}
+
+// ResourceDrift describes the drift status of a single stack resource
+type ResourceDrift struct {
+	LogicalID    string
+	PhysicalID   string
+	ResourceType string
+	DriftStatus  string // IN_SYNC, MODIFIED, DELETED, NOT_CHECKED, UNKNOWN or UNSUPPORTED
+	Differences  []PropertyDifference
+}
+
+// PropertyDifference describes how a resource property differs from its template definition
+type PropertyDifference struct {
+	Path           string
+	ExpectedValue  string
+	ActualValue    string
+	DifferenceType string // ADD, REMOVE or NOT_EQUAL
+}
+
+// IsDrifted reports whether the resource no longer matches its template definition
+func (r ResourceDrift) IsDrifted() bool {
+	return r.DriftStatus == string(types.StackResourceDriftStatusModified) ||
+		r.DriftStatus == string(types.StackResourceDriftStatusDeleted)
+}
+
+// DetectStackDrift runs drift detection on a stack, waits for it to finish and returns per-resource results
+func (cf *DefaultCloudFormationOperations) DetectStackDrift(ctx context.Context, stackName string) (*StackDriftResult, error) {
+	detectOutput, err := cf.client.DetectStackDrift(ctx, &cloudformation.DetectStackDriftInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start drift detection for stack %s: %w", stackName, err)
+	}
+
+	driftStatus, err := cf.waitForDriftDetection(ctx, aws.ToString(detectOutput.StackDriftDetectionId))
+	if err != nil {
+		return nil, fmt.Errorf("drift detection for stack %s did not complete: %w", stackName, err)
+	}
+
+	resources, err := cf.describeResourceDrifts(ctx, stackName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StackDriftResult{
+		StackName:   stackName,
+		DriftStatus: driftStatus,
+		Resources:   resources,
+	}, nil
+}
+
+// waitForDriftDetection polls a drift detection run until it finishes and returns the stack drift status
+func (cf *DefaultCloudFormationOperations) waitForDriftDetection(ctx context.Context, detectionID string) (string, error) {
+	const pollInterval = 2 * time.Second
+	timeout := 10 * time.Minute
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		statusOutput, err := cf.client.DescribeStackDriftDetectionStatus(ctx, &cloudformation.DescribeStackDriftDetectionStatusInput{
+			StackDriftDetectionId: aws.String(detectionID),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get drift detection status: %w", err)
+		}
+
+		switch statusOutput.DetectionStatus {
+		case types.StackDriftDetectionStatusDetectionComplete:
+			return string(statusOutput.StackDriftStatus), nil
+		case types.StackDriftDetectionStatusDetectionFailed:
+			reason := aws.ToString(statusOutput.DetectionStatusReason)
+			if reason == "" {
+				reason = "unknown reason"
+			}
+			return "", fmt.Errorf("drift detection failed: %s", reason)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+
+	return "", fmt.Errorf("timeout waiting for drift detection to complete")
+}
+
+// describeResourceDrifts collects the drift results of every resource in a stack
+func (cf *DefaultCloudFormationOperations) describeResourceDrifts(ctx context.Context, stackName string) ([]ResourceDrift, error) {
+	var resources []ResourceDrift
+	var nextToken *string
+
+	for {
+		output, err := cf.client.DescribeStackResourceDrifts(ctx, &cloudformation.DescribeStackResourceDriftsInput{
+			StackName: aws.String(stackName),
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe resource drifts for stack %s: %w", stackName, err)
+		}
+
+		for _, drift := range output.StackResourceDrifts {
+			resource := ResourceDrift{
+				LogicalID:    aws.ToString(drift.LogicalResourceId),
+				PhysicalID:   aws.ToString(drift.PhysicalResourceId),
+				ResourceType: aws.ToString(drift.ResourceType),
+				DriftStatus:  string(drift.StackResourceDriftStatus),
+			}
+			for _, difference := range drift.PropertyDifferences {
+				resource.Differences = append(resource.Differences, PropertyDifference{
+					Path:           aws.ToString(difference.PropertyPath),
+					ExpectedValue:  aws.ToString(difference.ExpectedValue),
+					ActualValue:    aws.ToString(difference.ActualValue),
+					DifferenceType: string(difference.DifferenceType),
+				})
+			}
+			resources = append(resources, resource)
+		}
+
+		if output.NextToken == nil {
+			return resources, nil
+		}
+		nextToken = output.NextToken
+	}
+}
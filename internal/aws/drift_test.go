@@ -0,0 +1,129 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectStackDrift_ReturnsResourceDrifts(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("DetectStackDrift", ctx, mock.MatchedBy(func(input *cloudformation.DetectStackDriftInput) bool {
+		return aws.ToString(input.StackName) == "app"
+	})).Return(&cloudformation.DetectStackDriftOutput{
+		StackDriftDetectionId: aws.String("detection-1"),
+	}, nil)
+
+	mockClient.On("DescribeStackDriftDetectionStatus", ctx, mock.MatchedBy(func(input *cloudformation.DescribeStackDriftDetectionStatusInput) bool {
+		return aws.ToString(input.StackDriftDetectionId) == "detection-1"
+	})).Return(&cloudformation.DescribeStackDriftDetectionStatusOutput{
+		DetectionStatus:  types.StackDriftDetectionStatusDetectionComplete,
+		StackDriftStatus: types.StackDriftStatusDrifted,
+	}, nil)
+
+	mockClient.On("DescribeStackResourceDrifts", ctx, mock.MatchedBy(func(input *cloudformation.DescribeStackResourceDriftsInput) bool {
+		return input.NextToken == nil
+	})).Return(&cloudformation.DescribeStackResourceDriftsOutput{
+		StackResourceDrifts: []types.StackResourceDrift{
+			{
+				LogicalResourceId:        aws.String("Bucket"),
+				PhysicalResourceId:       aws.String("app-bucket-123"),
+				ResourceType:             aws.String("AWS::S3::Bucket"),
+				StackResourceDriftStatus: types.StackResourceDriftStatusModified,
+				PropertyDifferences: []types.PropertyDifference{
+					{
+						PropertyPath:   aws.String("/VersioningConfiguration/Status"),
+						ExpectedValue:  aws.String("Enabled"),
+						ActualValue:    aws.String("Suspended"),
+						DifferenceType: types.DifferenceTypeNotEqual,
+					},
+				},
+			},
+		},
+		NextToken: aws.String("page-2"),
+	}, nil)
+
+	mockClient.On("DescribeStackResourceDrifts", ctx, mock.MatchedBy(func(input *cloudformation.DescribeStackResourceDriftsInput) bool {
+		return aws.ToString(input.NextToken) == "page-2"
+	})).Return(&cloudformation.DescribeStackResourceDriftsOutput{
+		StackResourceDrifts: []types.StackResourceDrift{
+			{
+				LogicalResourceId:        aws.String("Queue"),
+				ResourceType:             aws.String("AWS::SQS::Queue"),
+				StackResourceDriftStatus: types.StackResourceDriftStatusInSync,
+			},
+		},
+	}, nil)
+
+	result, err := cf.DetectStackDrift(ctx, "app")
+
+	require.NoError(t, err)
+	assert.Equal(t, "app", result.StackName)
+	assert.Equal(t, "DRIFTED", result.DriftStatus)
+	require.Len(t, result.Resources, 2)
+
+	assert.Equal(t, ResourceDrift{
+		LogicalID:    "Bucket",
+		PhysicalID:   "app-bucket-123",
+		ResourceType: "AWS::S3::Bucket",
+		DriftStatus:  "MODIFIED",
+		Differences: []PropertyDifference{
+			{
+				Path:           "/VersioningConfiguration/Status",
+				ExpectedValue:  "Enabled",
+				ActualValue:    "Suspended",
+				DifferenceType: "NOT_EQUAL",
+			},
+		},
+	}, result.Resources[0])
+	assert.True(t, result.Resources[0].IsDrifted())
+	assert.False(t, result.Resources[1].IsDrifted())
+	mockClient.AssertExpectations(t)
+}
+
+func TestDetectStackDrift_DetectionFailed(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("DetectStackDrift", ctx, mock.Anything).Return(&cloudformation.DetectStackDriftOutput{
+		StackDriftDetectionId: aws.String("detection-1"),
+	}, nil)
+	mockClient.On("DescribeStackDriftDetectionStatus", ctx, mock.Anything).Return(&cloudformation.DescribeStackDriftDetectionStatusOutput{
+		DetectionStatus:       types.StackDriftDetectionStatusDetectionFailed,
+		DetectionStatusReason: aws.String("Failed to detect drift on resource Bucket"),
+	}, nil)
+
+	_, err := cf.DetectStackDrift(ctx, "app")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "drift detection failed: Failed to detect drift on resource Bucket")
+	mockClient.AssertNotCalled(t, "DescribeStackResourceDrifts", mock.Anything, mock.Anything)
+}
+
+func TestDetectStackDrift_StartError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("DetectStackDrift", ctx, mock.Anything).Return(nil, errors.New("stack app does not exist"))
+
+	_, err := cf.DetectStackDrift(ctx, "app")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to start drift detection for stack app")
+}
@@ -16,7 +16,7 @@ func ExampleClientFactory() {
 	// ctx := context.Background()
 
 	// Create a client factory (would use actual AWS credentials in real usage)
-	// factory, _ := aws.NewClientFactory(ctx)
+	// factory, _ := aws.NewClientFactory(ctx, "")
 
 	// Example of getting CloudFormation operations for a specific region
 	region := "us-east-1"
@@ -0,0 +1,63 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+)
+
+// Export is a stack output shared under a region-wide name for other stacks to import
+type Export struct {
+	Name             string
+	Value            string
+	ExportingStack   string // Name of the stack that exports the value
+	ExportingStackID string
+}
+
+// ListExports returns every export in the region, following pagination
+func (cf *DefaultCloudFormationOperations) ListExports(ctx context.Context) ([]Export, error) {
+	var exports []Export
+	var nextToken *string
+
+	for {
+		output, err := cf.client.ListExports(ctx, &cloudformation.ListExportsInput{
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list exports: %w", err)
+		}
+
+		for _, export := range output.Exports {
+			stackID := aws.ToString(export.ExportingStackId)
+			exports = append(exports, Export{
+				Name:             aws.ToString(export.Name),
+				Value:            aws.ToString(export.Value),
+				ExportingStack:   stackNameFromID(stackID),
+				ExportingStackID: stackID,
+			})
+		}
+
+		if output.NextToken == nil {
+			return exports, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// stackNameFromID extracts the stack name from a stack ID of the form
+// arn:aws:cloudformation:<region>:<account>:stack/<name>/<uuid>, returning other values unchanged
+func stackNameFromID(stackID string) string {
+	_, resource, found := strings.Cut(stackID, ":stack/")
+	if !found {
+		return stackID
+	}
+	name, _, _ := strings.Cut(resource, "/")
+	return name
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListExports_FollowsPagination(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("ListExports", ctx, mock.MatchedBy(func(input *cloudformation.ListExportsInput) bool {
+		return input.NextToken == nil
+	})).Return(&cloudformation.ListExportsOutput{
+		Exports: []types.Export{
+			{
+				Name:             aws.String("network-VpcId"),
+				Value:            aws.String("vpc-0abc123"),
+				ExportingStackId: aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/network/4b1c3d90-0000-11ef-8000-0a1b2c3d4e5f"),
+			},
+		},
+		NextToken: aws.String("page-2"),
+	}, nil).Once()
+
+	mockClient.On("ListExports", ctx, mock.MatchedBy(func(input *cloudformation.ListExportsInput) bool {
+		return aws.ToString(input.NextToken) == "page-2"
+	})).Return(&cloudformation.ListExportsOutput{
+		Exports: []types.Export{
+			{
+				Name:             aws.String("SharedKmsKeyArn"),
+				Value:            aws.String("arn:aws:kms:us-east-1:123456789012:key/abc"),
+				ExportingStackId: aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/security/5c2d4ea0-0000-11ef-8000-0a1b2c3d4e5f"),
+			},
+		},
+	}, nil).Once()
+
+	exports, err := cf.ListExports(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Export{
+		{
+			Name:             "network-VpcId",
+			Value:            "vpc-0abc123",
+			ExportingStack:   "network",
+			ExportingStackID: "arn:aws:cloudformation:us-east-1:123456789012:stack/network/4b1c3d90-0000-11ef-8000-0a1b2c3d4e5f",
+		},
+		{
+			Name:             "SharedKmsKeyArn",
+			Value:            "arn:aws:kms:us-east-1:123456789012:key/abc",
+			ExportingStack:   "security",
+			ExportingStackID: "arn:aws:cloudformation:us-east-1:123456789012:stack/security/5c2d4ea0-0000-11ef-8000-0a1b2c3d4e5f",
+		},
+	}, exports)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListExports_Error(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("ListExports", ctx, mock.Anything).Return(nil, errors.New("access denied"))
+
+	exports, err := cf.ListExports(ctx)
+
+	assert.Nil(t, exports)
+	require.EqualError(t, err, "failed to list exports: access denied")
+}
+
+func TestStackNameFromID(t *testing.T) {
+	assert.Equal(t, "network", stackNameFromID("arn:aws:cloudformation:us-east-1:123456789012:stack/network/4b1c3d90-0000-11ef-8000-0a1b2c3d4e5f"))
+	assert.Equal(t, "network", stackNameFromID("network"))
+}
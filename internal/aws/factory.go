@@ -7,11 +7,16 @@ package aws
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 // ClientFactory creates AWS clients with proper region configuration
@@ -19,6 +24,22 @@ type ClientFactory interface {
 	// GetCloudFormationOperations returns CloudFormation operations for specified region
 	GetCloudFormationOperations(ctx context.Context, region string) (CloudFormationOperations, error)
 
+	// GetSSMOperations returns SSM Parameter Store operations for specified region
+	GetSSMOperations(ctx context.Context, region string) (SSMOperations, error)
+
+	// GetS3Operations returns S3 object operations for specified region
+	GetS3Operations(ctx context.Context, region string) (S3Operations, error)
+
+	// GetSecretsManagerOperations returns Secrets Manager operations for specified region
+	GetSecretsManagerOperations(ctx context.Context, region string) (SecretsManagerOperations, error)
+
+	// GetSTSOperations returns STS operations for specified region
+	GetSTSOperations(ctx context.Context, region string) (STSOperations, error)
+
+	// SetWaitConfig changes how CloudFormation operations wait for stack operations to finish;
+	// zero fields keep their current setting
+	SetWaitConfig(config WaitConfig)
+
 	// GetBaseConfig returns the shared AWS configuration (for debugging)
 	GetBaseConfig() aws.Config
 
@@ -30,21 +51,60 @@ type ClientFactory interface {
 type DefaultClientFactory struct {
 	baseConfig  aws.Config
 	clientCache map[string]CloudFormationOperations
+	ssmCache    map[string]SSMOperations
+	s3Cache     map[string]S3Operations
+	secretCache map[string]SecretsManagerOperations
+	stsCache    map[string]STSOperations
+	waitConfig  WaitConfig
 	mutex       sync.RWMutex
 }
 
-// NewClientFactory creates a client factory with shared authentication
-func NewClientFactory(ctx context.Context) (ClientFactory, error) {
+// NewClientFactory creates a client factory with shared authentication.
+// A non-empty endpointURL sends requests from every client to that endpoint, such as LocalStack,
+// instead of the standard AWS endpoints.
+func NewClientFactory(ctx context.Context, endpointURL string) (ClientFactory, error) {
+	if err := ValidateEndpointURL(endpointURL); err != nil {
+		return nil, err
+	}
+
 	// Load base config with credentials but allow region override per-client
 	baseConfig, err := config.LoadDefaultConfig(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
 	}
+	if endpointURL != "" {
+		baseConfig.BaseEndpoint = aws.String(endpointURL)
+	}
+
+	return newClientFactoryWithConfig(baseConfig), nil
+}
+
+// ValidateEndpointURL checks that an endpoint override is an absolute http:// or https:// URL.
+// An empty value means no override and is accepted.
+func ValidateEndpointURL(endpointURL string) error {
+	if endpointURL == "" {
+		return nil
+	}
+	parsed, err := url.Parse(endpointURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid endpoint URL %q: must be an absolute http:// or https:// URL", endpointURL)
+	}
+	return nil
+}
+
+// newClientFactoryWithConfig creates a client factory around an already loaded configuration
+func newClientFactoryWithConfig(baseConfig aws.Config) *DefaultClientFactory {
+	// Report credential failures from every client as a single recognisable error
+	baseConfig.APIOptions = append(baseConfig.APIOptions, addCredentialsErrorMiddleware)
 
 	return &DefaultClientFactory{
 		baseConfig:  baseConfig,
 		clientCache: make(map[string]CloudFormationOperations),
-	}, nil
+		ssmCache:    make(map[string]SSMOperations),
+		s3Cache:     make(map[string]S3Operations),
+		secretCache: make(map[string]SecretsManagerOperations),
+		stsCache:    make(map[string]STSOperations),
+	}
 }
 
 // GetCloudFormationOperations returns CloudFormation operations for the specified region
@@ -61,6 +121,13 @@ func (f *DefaultClientFactory) GetCloudFormationOperations(ctx context.Context,
 	}
 	f.mutex.RUnlock()
 
+	// Re-check under the write lock so concurrent callers share a single client
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if ops, exists := f.clientCache[region]; exists {
+		return ops, nil
+	}
+
 	// Create region-specific config from base config
 	regionConfig := f.baseConfig.Copy()
 	regionConfig.Region = region
@@ -68,15 +135,153 @@ func (f *DefaultClientFactory) GetCloudFormationOperations(ctx context.Context,
 	// Create service client with region-specific config
 	cfnClient := cloudformation.NewFromConfig(regionConfig)
 	ops := NewCloudFormationOperationsWithClient(cfnClient)
+	ops.SetWaitConfig(f.waitConfig)
+	f.clientCache[region] = ops
+
+	return ops, nil
+}
+
+// GetSSMOperations returns SSM Parameter Store operations for the specified region
+func (f *DefaultClientFactory) GetSSMOperations(ctx context.Context, region string) (SSMOperations, error) {
+	if region == "" {
+		return nil, fmt.Errorf("region cannot be empty")
+	}
 
-	// Cache for future use (write lock)
+	// Check cache first (read lock)
+	f.mutex.RLock()
+	if ops, exists := f.ssmCache[region]; exists {
+		f.mutex.RUnlock()
+		return ops, nil
+	}
+	f.mutex.RUnlock()
+
+	// Re-check under the write lock so concurrent callers share a single client
 	f.mutex.Lock()
-	f.clientCache[region] = ops
-	f.mutex.Unlock()
+	defer f.mutex.Unlock()
+	if ops, exists := f.ssmCache[region]; exists {
+		return ops, nil
+	}
+
+	// Create region-specific config from base config
+	regionConfig := f.baseConfig.Copy()
+	regionConfig.Region = region
+
+	ops := NewSSMOperationsWithClient(ssm.NewFromConfig(regionConfig))
+	f.ssmCache[region] = ops
 
 	return ops, nil
 }
 
+// GetS3Operations returns S3 object operations for the specified region
+func (f *DefaultClientFactory) GetS3Operations(ctx context.Context, region string) (S3Operations, error) {
+	if region == "" {
+		return nil, fmt.Errorf("region cannot be empty")
+	}
+
+	// Check cache first (read lock)
+	f.mutex.RLock()
+	if ops, exists := f.s3Cache[region]; exists {
+		f.mutex.RUnlock()
+		return ops, nil
+	}
+	f.mutex.RUnlock()
+
+	// Re-check under the write lock so concurrent callers share a single client
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if ops, exists := f.s3Cache[region]; exists {
+		return ops, nil
+	}
+
+	// Create region-specific config from base config
+	regionConfig := f.baseConfig.Copy()
+	regionConfig.Region = region
+
+	// Endpoint overrides such as LocalStack serve buckets by path rather than by subdomain
+	ops := NewS3OperationsWithClient(s3.NewFromConfig(regionConfig, func(o *s3.Options) {
+		o.UsePathStyle = regionConfig.BaseEndpoint != nil
+	}))
+	f.s3Cache[region] = ops
+
+	return ops, nil
+}
+
+// GetSecretsManagerOperations returns Secrets Manager operations for the specified region
+func (f *DefaultClientFactory) GetSecretsManagerOperations(ctx context.Context, region string) (SecretsManagerOperations, error) {
+	if region == "" {
+		return nil, fmt.Errorf("region cannot be empty")
+	}
+
+	// Check cache first (read lock)
+	f.mutex.RLock()
+	if ops, exists := f.secretCache[region]; exists {
+		f.mutex.RUnlock()
+		return ops, nil
+	}
+	f.mutex.RUnlock()
+
+	// Re-check under the write lock so concurrent callers share a single client
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if ops, exists := f.secretCache[region]; exists {
+		return ops, nil
+	}
+
+	// Create region-specific config from base config
+	regionConfig := f.baseConfig.Copy()
+	regionConfig.Region = region
+
+	ops := NewSecretsManagerOperationsWithClient(secretsmanager.NewFromConfig(regionConfig))
+	f.secretCache[region] = ops
+
+	return ops, nil
+}
+
+// GetSTSOperations returns STS operations for the specified region
+func (f *DefaultClientFactory) GetSTSOperations(ctx context.Context, region string) (STSOperations, error) {
+	if region == "" {
+		return nil, fmt.Errorf("region cannot be empty")
+	}
+
+	// Check cache first (read lock)
+	f.mutex.RLock()
+	if ops, exists := f.stsCache[region]; exists {
+		f.mutex.RUnlock()
+		return ops, nil
+	}
+	f.mutex.RUnlock()
+
+	// Re-check under the write lock so concurrent callers share a single client
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if ops, exists := f.stsCache[region]; exists {
+		return ops, nil
+	}
+
+	// Create region-specific config from base config
+	regionConfig := f.baseConfig.Copy()
+	regionConfig.Region = region
+
+	ops := NewSTSOperationsWithClient(sts.NewFromConfig(regionConfig))
+	f.stsCache[region] = ops
+
+	return ops, nil
+}
+
+// SetWaitConfig changes how CloudFormation operations wait, including operations already created.
+// Only the non-zero fields are applied, so the settings of one command do not reset another's.
+func (f *DefaultClientFactory) SetWaitConfig(config WaitConfig) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.waitConfig = f.waitConfig.merge(config)
+	for _, ops := range f.clientCache {
+		if defaultOps, ok := ops.(*DefaultCloudFormationOperations); ok {
+			defaultOps.SetWaitConfig(config)
+		}
+	}
+}
+
 // GetBaseConfig returns the shared AWS configuration
 func (f *DefaultClientFactory) GetBaseConfig() aws.Config {
 	return f.baseConfig
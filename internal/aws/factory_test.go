@@ -0,0 +1,171 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultClientFactory_ReusesClientsPerRegion(t *testing.T) {
+	ctx := context.Background()
+	factory := newClientFactoryWithConfig(aws.Config{})
+
+	first, err := factory.GetCloudFormationOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+	second, err := factory.GetCloudFormationOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+	other, err := factory.GetCloudFormationOperations(ctx, "eu-west-1")
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	assert.NotSame(t, first, other)
+
+	firstSSM, err := factory.GetSSMOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+	secondSSM, err := factory.GetSSMOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+	assert.Same(t, firstSSM, secondSSM)
+
+	firstS3, err := factory.GetS3Operations(ctx, "us-east-1")
+	require.NoError(t, err)
+	secondS3, err := factory.GetS3Operations(ctx, "us-east-1")
+	require.NoError(t, err)
+	assert.Same(t, firstS3, secondS3)
+
+	firstSecrets, err := factory.GetSecretsManagerOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+	secondSecrets, err := factory.GetSecretsManagerOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+	assert.Same(t, firstSecrets, secondSecrets)
+}
+
+func TestDefaultClientFactory_SetWaitConfig(t *testing.T) {
+	ctx := context.Background()
+	factory := newClientFactoryWithConfig(aws.Config{})
+
+	existing, err := factory.GetCloudFormationOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPollInterval, existing.(*DefaultCloudFormationOperations).waitConfig.PollInterval)
+
+	factory.SetWaitConfig(WaitConfig{PollInterval: 10 * time.Second, FailFastOnRollback: true})
+	created, err := factory.GetCloudFormationOperations(ctx, "eu-west-1")
+	require.NoError(t, err)
+
+	expected := WaitConfig{PollInterval: 10 * time.Second, FailFastOnRollback: true}
+	assert.Equal(t, expected, existing.(*DefaultCloudFormationOperations).waitConfig)
+	assert.Equal(t, expected, created.(*DefaultCloudFormationOperations).waitConfig)
+}
+
+func TestDefaultClientFactory_SetWaitConfigKeepsUnsetFields(t *testing.T) {
+	ctx := context.Background()
+	factory := newClientFactoryWithConfig(aws.Config{})
+
+	ops, err := factory.GetCloudFormationOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+
+	// A deploy's poll interval survives a diff setting only the changeset waits
+	factory.SetWaitConfig(WaitConfig{PollInterval: 10 * time.Second})
+	factory.SetWaitConfig(WaitConfig{ChangeSetPollInterval: time.Second})
+
+	waitConfig := ops.(*DefaultCloudFormationOperations).waitConfig
+	assert.Equal(t, 10*time.Second, waitConfig.PollInterval)
+	assert.Equal(t, time.Second, waitConfig.ChangeSetPollInterval)
+}
+
+func TestDefaultClientFactory_ConcurrentRequestsShareClient(t *testing.T) {
+	ctx := context.Background()
+	factory := newClientFactoryWithConfig(aws.Config{})
+
+	const callers = 32
+	results := make([]CloudFormationOperations, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ops, err := factory.GetCloudFormationOperations(ctx, "us-east-1")
+			assert.NoError(t, err)
+			results[i] = ops
+		}(i)
+	}
+	wg.Wait()
+
+	for _, ops := range results {
+		assert.Same(t, results[0], ops)
+	}
+}
+
+func TestDefaultClientFactory_EmptyRegion(t *testing.T) {
+	ctx := context.Background()
+	factory := newClientFactoryWithConfig(aws.Config{})
+
+	_, err := factory.GetCloudFormationOperations(ctx, "")
+	assert.EqualError(t, err, "region cannot be empty")
+	_, err = factory.GetSSMOperations(ctx, "")
+	assert.EqualError(t, err, "region cannot be empty")
+	_, err = factory.GetSecretsManagerOperations(ctx, "")
+	assert.EqualError(t, err, "region cannot be empty")
+}
+
+func TestNewClientFactory_EndpointURL(t *testing.T) {
+	ctx := context.Background()
+
+	factory, err := NewClientFactory(ctx, "http://localhost:4566")
+	require.NoError(t, err)
+	require.NotNil(t, factory.GetBaseConfig().BaseEndpoint)
+	assert.Equal(t, "http://localhost:4566", *factory.GetBaseConfig().BaseEndpoint)
+
+	cfnOps, err := factory.GetCloudFormationOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+	cfnClient := cfnOps.(*DefaultCloudFormationOperations).client.(*cloudformation.Client)
+	assert.Equal(t, "http://localhost:4566", *cfnClient.Options().BaseEndpoint)
+
+	ssmOps, err := factory.GetSSMOperations(ctx, "us-east-1")
+	require.NoError(t, err)
+	ssmClient := ssmOps.(*DefaultSSMOperations).client.(*ssm.Client)
+	assert.Equal(t, "http://localhost:4566", *ssmClient.Options().BaseEndpoint)
+}
+
+func TestNewClientFactory_NoEndpointURL(t *testing.T) {
+	factory, err := NewClientFactory(context.Background(), "")
+
+	require.NoError(t, err)
+	assert.Nil(t, factory.GetBaseConfig().BaseEndpoint)
+}
+
+func TestValidateEndpointURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		endpointURL string
+		wantErr     bool
+	}{
+		{name: "empty", endpointURL: ""},
+		{name: "http", endpointURL: "http://localhost:4566"},
+		{name: "https", endpointURL: "https://cloudformation.example.com"},
+		{name: "missing scheme", endpointURL: "localhost:4566", wantErr: true},
+		{name: "unsupported scheme", endpointURL: "ftp://localhost:4566", wantErr: true},
+		{name: "missing host", endpointURL: "http://", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEndpointURL(tt.endpointURL)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "must be an absolute http:// or https:// URL")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -17,6 +17,8 @@ type CloudFormationClient interface {
 	CreateStack(ctx context.Context, params *cloudformation.CreateStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.CreateStackOutput, error)
 	UpdateStack(ctx context.Context, params *cloudformation.UpdateStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.UpdateStackOutput, error)
 	DeleteStack(ctx context.Context, params *cloudformation.DeleteStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DeleteStackOutput, error)
+	ContinueUpdateRollback(ctx context.Context, params *cloudformation.ContinueUpdateRollbackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ContinueUpdateRollbackOutput, error)
+	CancelUpdateStack(ctx context.Context, params *cloudformation.CancelUpdateStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.CancelUpdateStackOutput, error)
 	DescribeStacks(ctx context.Context, params *cloudformation.DescribeStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error)
 	ListStacks(ctx context.Context, params *cloudformation.ListStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStacksOutput, error)
 	ValidateTemplate(ctx context.Context, params *cloudformation.ValidateTemplateInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ValidateTemplateOutput, error)
@@ -26,6 +28,14 @@ type CloudFormationClient interface {
 	DeleteChangeSet(ctx context.Context, params *cloudformation.DeleteChangeSetInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DeleteChangeSetOutput, error)
 	DescribeChangeSet(ctx context.Context, params *cloudformation.DescribeChangeSetInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeChangeSetOutput, error)
 	DescribeStackEvents(ctx context.Context, params *cloudformation.DescribeStackEventsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackEventsOutput, error)
+	UpdateTerminationProtection(ctx context.Context, params *cloudformation.UpdateTerminationProtectionInput, optFns ...func(*cloudformation.Options)) (*cloudformation.UpdateTerminationProtectionOutput, error)
+	SetStackPolicy(ctx context.Context, params *cloudformation.SetStackPolicyInput, optFns ...func(*cloudformation.Options)) (*cloudformation.SetStackPolicyOutput, error)
+	GetStackPolicy(ctx context.Context, params *cloudformation.GetStackPolicyInput, optFns ...func(*cloudformation.Options)) (*cloudformation.GetStackPolicyOutput, error)
+	DetectStackDrift(ctx context.Context, params *cloudformation.DetectStackDriftInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DetectStackDriftOutput, error)
+	DescribeStackDriftDetectionStatus(ctx context.Context, params *cloudformation.DescribeStackDriftDetectionStatusInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error)
+	DescribeStackResourceDrifts(ctx context.Context, params *cloudformation.DescribeStackResourceDriftsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackResourceDriftsOutput, error)
+	ListStackResources(ctx context.Context, params *cloudformation.ListStackResourcesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error)
+	ListExports(ctx context.Context, params *cloudformation.ListExportsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListExportsOutput, error)
 }
 
 // Ensure that the actual CloudFormation client implements our interface
@@ -43,25 +53,58 @@ type CloudFormationOperations interface {
 	DeployStackWithCallback(ctx context.Context, input DeployStackInput, eventCallback func(StackEvent)) error
 	UpdateStack(ctx context.Context, input UpdateStackInput) error
 	DeleteStack(ctx context.Context, input DeleteStackInput) error
+	ContinueUpdateRollback(ctx context.Context, stackName string, resourcesToSkip []string) error
+	CancelUpdateStack(ctx context.Context, stackName string) error
+	UpdateTerminationProtection(ctx context.Context, stackName string, enabled bool) error
+	SetStackPolicy(ctx context.Context, stackName string, policyBody string) error
+	GetStackPolicy(ctx context.Context, stackName string) (string, error)
 	GetStack(ctx context.Context, stackName string) (*Stack, error)
 	ListStacks(ctx context.Context) ([]*Stack, error)
 	ValidateTemplate(ctx context.Context, templateBody string) error
 	StackExists(ctx context.Context, stackName string) (bool, error)
 	GetTemplate(ctx context.Context, stackName string) (string, error)
 	DescribeStack(ctx context.Context, stackName string) (*StackInfo, error)
-	ExecuteChangeSet(ctx context.Context, changeSetID string) error
+	ExecuteChangeSet(ctx context.Context, changeSetID string, disableRollback bool) error
+	DescribeChangeSet(ctx context.Context, changeSetID string) (*ChangeSetInfo, error)
 	DeleteChangeSet(ctx context.Context, changeSetID string) error
 	DescribeStackEvents(ctx context.Context, stackName string) ([]StackEvent, error)
 	WaitForStackOperation(ctx context.Context, stackName string, startTime time.Time, eventCallback func(StackEvent)) error
-	CreateChangeSetPreview(ctx context.Context, stackName string, template string, parameters map[string]string, capabilities []string, tags map[string]string) (*ChangeSetInfo, error)
-	CreateChangeSetForDeployment(ctx context.Context, stackName string, template string, parameters map[string]string, capabilities []string, tags map[string]string) (*ChangeSetInfo, error)
+	CreateChangeSetPreview(ctx context.Context, stackName string, template string, templateURL string, parameters map[string]string, capabilities []string, tags map[string]string) (*ChangeSetInfo, error)
+	CreateChangeSetForDeployment(ctx context.Context, input ChangeSetInput) (*ChangeSetInfo, error)
+	DetectStackDrift(ctx context.Context, stackName string) (*StackDriftResult, error)
+	ListStackResources(ctx context.Context, stackName string) ([]StackResource, error)
+	ListExports(ctx context.Context) ([]Export, error)
 }
 
 // ChangeSetInfo contains information from AWS CloudFormation changeset
 type ChangeSetInfo struct {
-	ChangeSetID string
-	Status      string
-	Changes     []ResourceChange
+	ChangeSetID     string
+	StackName       string // Stack the changeset applies to
+	Status          string
+	ExecutionStatus string // AVAILABLE once the changeset can be executed
+	Changes         []ResourceChange
+}
+
+// ChangeSetInput contains the settings of a deployment changeset
+type ChangeSetInput struct {
+	StackName             string
+	TemplateBody          string // Empty with no TemplateURL reuses the stack's current template
+	TemplateURL           string // S3 URL of the template, sent instead of TemplateBody when set
+	Parameters            map[string]string
+	Capabilities          []string
+	Tags                  map[string]string
+	NotificationARNs      []string               // SNS topics that receive stack events (nil leaves them unchanged)
+	ResourceTypes         []string               // Resource types the stack may create or update (nil allows all)
+	RollbackConfiguration *RollbackConfiguration // Alarms that roll the operation back (nil leaves the configuration unchanged)
+	Metadata              ChangeSetMetadata      // Recorded in the changeset description
+}
+
+// ChangeSetMetadata identifies a deployment for audit correlation.
+// It is recorded in the description of deployment changesets; empty fields are omitted.
+type ChangeSetMetadata struct {
+	Commit  string // Source revision being deployed
+	User    string // Person or system running the deployment
+	Message string // Free-form reason for the deployment
 }
 
 // ResourceChange represents a change to a CloudFormation resource
@@ -72,4 +115,14 @@ type ResourceChange struct {
 	PhysicalID   string
 	Replacement  string // True, False, or Conditional
 	Details      []string
+	Properties   []PropertyChange // Structured form of Details
+}
+
+// PropertyChange describes a single change within a resource change
+type PropertyChange struct {
+	Name               string // Property name when Attribute is Properties
+	Attribute          string // Properties, Metadata, Tags, etc.
+	RequiresRecreation string // Never, Conditionally, or Always
+	ChangeSource       string // DirectModification, ParameterReference, ResourceReference, etc.
+	CausingEntity      string // Parameter or resource that caused the change, if any
 }
@@ -0,0 +1,56 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+)
+
+// StackResource describes a resource managed by a stack
+type StackResource struct {
+	LogicalID    string
+	PhysicalID   string // Empty until the resource has been created
+	ResourceType string
+	Status       string
+	StatusReason string
+	UpdatedTime  *time.Time
+}
+
+// ListStackResources returns every resource of a stack, following pagination
+func (cf *DefaultCloudFormationOperations) ListStackResources(ctx context.Context, stackName string) ([]StackResource, error) {
+	var resources []StackResource
+	var nextToken *string
+
+	for {
+		output, err := cf.client.ListStackResources(ctx, &cloudformation.ListStackResourcesInput{
+			StackName: aws.String(stackName),
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resources of stack %s: %w", stackName, err)
+		}
+
+		for _, summary := range output.StackResourceSummaries {
+			resources = append(resources, StackResource{
+				LogicalID:    aws.ToString(summary.LogicalResourceId),
+				PhysicalID:   aws.ToString(summary.PhysicalResourceId),
+				ResourceType: aws.ToString(summary.ResourceType),
+				Status:       string(summary.ResourceStatus),
+				StatusReason: aws.ToString(summary.ResourceStatusReason),
+				UpdatedTime:  summary.LastUpdatedTimestamp,
+			})
+		}
+
+		if output.NextToken == nil {
+			return resources, nil
+		}
+		nextToken = output.NextToken
+	}
+}
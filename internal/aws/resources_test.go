@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListStackResources_FollowsPagination(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := NewCloudFormationOperationsWithClient(mockClient)
+	updated := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+
+	mockClient.On("ListStackResources", ctx, mock.MatchedBy(func(input *cloudformation.ListStackResourcesInput) bool {
+		return aws.ToString(input.StackName) == "app" && input.NextToken == nil
+	})).Return(&cloudformation.ListStackResourcesOutput{
+		StackResourceSummaries: []types.StackResourceSummary{
+			{
+				LogicalResourceId:    aws.String("Bucket"),
+				PhysicalResourceId:   aws.String("app-bucket-123"),
+				ResourceType:         aws.String("AWS::S3::Bucket"),
+				ResourceStatus:       types.ResourceStatusCreateComplete,
+				LastUpdatedTimestamp: &updated,
+			},
+		},
+		NextToken: aws.String("page-2"),
+	}, nil).Once()
+
+	mockClient.On("ListStackResources", ctx, mock.MatchedBy(func(input *cloudformation.ListStackResourcesInput) bool {
+		return aws.ToString(input.NextToken) == "page-2"
+	})).Return(&cloudformation.ListStackResourcesOutput{
+		StackResourceSummaries: []types.StackResourceSummary{
+			{
+				LogicalResourceId:    aws.String("Queue"),
+				ResourceType:         aws.String("AWS::SQS::Queue"),
+				ResourceStatus:       types.ResourceStatusCreateFailed,
+				ResourceStatusReason: aws.String("Resource limit exceeded"),
+				LastUpdatedTimestamp: &updated,
+			},
+		},
+	}, nil).Once()
+
+	resources, err := cf.ListStackResources(ctx, "app")
+
+	require.NoError(t, err)
+	assert.Equal(t, []StackResource{
+		{LogicalID: "Bucket", PhysicalID: "app-bucket-123", ResourceType: "AWS::S3::Bucket", Status: "CREATE_COMPLETE", UpdatedTime: &updated},
+		{LogicalID: "Queue", ResourceType: "AWS::SQS::Queue", Status: "CREATE_FAILED", StatusReason: "Resource limit exceeded", UpdatedTime: &updated},
+	}, resources)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListStackResources_Error(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockCloudFormationClient{}
+	cf := NewCloudFormationOperationsWithClient(mockClient)
+
+	mockClient.On("ListStackResources", ctx, mock.Anything).Return(nil, errors.New("Stack with id app does not exist"))
+
+	_, err := cf.ListStackResources(ctx, "app")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list resources of stack app")
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/log"
+	smithy "github.com/aws/smithy-go"
+)
+
+// RetryConfig controls how CloudFormation calls rejected by throttling are retried
+type RetryConfig struct {
+	MaxAttempts int           // Attempts per call, including the first; one disables retries
+	BaseDelay   time.Duration // Delay before the first retry, doubled for each retry after it
+	MaxDelay    time.Duration // Upper bound on the delay before any retry
+}
+
+// DefaultRetryConfig retries throttled calls up to four times over roughly eight seconds
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts: 5,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    20 * time.Second,
+}
+
+// SetRetryConfig changes how throttled calls are retried; zero fields keep their defaults
+func (cf *DefaultCloudFormationOperations) SetRetryConfig(config RetryConfig) {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultRetryConfig.MaxAttempts
+	}
+	if config.BaseDelay <= 0 {
+		config.BaseDelay = DefaultRetryConfig.BaseDelay
+	}
+	if config.MaxDelay <= 0 {
+		config.MaxDelay = DefaultRetryConfig.MaxDelay
+	}
+	cf.retryConfig = config
+}
+
+// withRetry makes a CloudFormation call, retrying it with exponential backoff and jitter while
+// it is throttled. Other errors, and the last throttling error, are returned as they are.
+func withRetry[T any](ctx context.Context, cf *DefaultCloudFormationOperations, operation string, call func() (T, error)) (T, error) {
+	logger := cf.logger
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	delay := cf.retryConfig.BaseDelay
+	for attempt := 1; ; attempt++ {
+		logger.DebugContext(ctx, "calling CloudFormation", "operation", operation, "attempt", attempt)
+		result, err := call()
+		if err == nil || !isThrottlingError(err) || attempt >= cf.retryConfig.MaxAttempts {
+			if err != nil {
+				logger.DebugContext(ctx, "CloudFormation call failed", "operation", operation, "error", err)
+			}
+			return result, err
+		}
+
+		wait := withJitter(delay)
+		logger.InfoContext(ctx, "CloudFormation call throttled; retrying", "operation", operation, "attempt", attempt, "delay", wait)
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-cf.clock.After(wait):
+		}
+		delay = min(delay*2, cf.retryConfig.MaxDelay)
+	}
+}
+
+// withJitter returns a random delay between half and all of the given delay, so clients
+// throttled together do not all retry at once
+func withJitter(delay time.Duration) time.Duration {
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// isThrottlingError reports whether AWS rejected a call because its request rate was exceeded
+func isThrottlingError(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "Throttling", "ThrottlingException", "RequestLimitExceeded", "TooManyRequestsException":
+		return true
+	}
+	return false
+}
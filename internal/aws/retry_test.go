@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	smithy "github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var throttlingError = &smithy.GenericAPIError{Code: "Throttling", Message: "Rate exceeded"}
+
+// newRetryingOperations returns operations on a mock client with a fake clock that records each backoff
+func newRetryingOperations() (*DefaultCloudFormationOperations, *MockCloudFormationClient, *fakeClock) {
+	mockClient := &MockCloudFormationClient{}
+	cfOps := NewCloudFormationOperationsWithClient(mockClient)
+	clock := &fakeClock{now: time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)}
+	cfOps.clock = clock
+	return cfOps, mockClient, clock
+}
+
+func TestWithRetry_RetriesThrottledCallsUntilSuccess(t *testing.T) {
+	ctx := context.Background()
+	cfOps, mockClient, clock := newRetryingOperations()
+	mockClient.On("GetTemplate", ctx, mock.Anything).Return((*cloudformation.GetTemplateOutput)(nil), throttlingError).Times(3)
+	mockClient.On("GetTemplate", ctx, mock.Anything).Return(&cloudformation.GetTemplateOutput{
+		TemplateBody: aws.String("Resources: {}"),
+	}, nil).Once()
+
+	template, err := cfOps.GetTemplate(ctx, "app")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Resources: {}", template)
+	mockClient.AssertNumberOfCalls(t, "GetTemplate", 4)
+
+	// Each delay is between half and all of a base delay that doubles per retry
+	require.Len(t, clock.waited, 3)
+	for i, base := range []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second} {
+		assert.GreaterOrEqual(t, clock.waited[i], base/2)
+		assert.LessOrEqual(t, clock.waited[i], base)
+	}
+}
+
+func TestWithRetry_LogsCallsAndThrottling(t *testing.T) {
+	ctx := context.Background()
+	cfOps, mockClient, _ := newRetryingOperations()
+	var logs bytes.Buffer
+	cfOps.SetLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	mockClient.On("GetTemplate", ctx, mock.Anything).Return((*cloudformation.GetTemplateOutput)(nil), throttlingError).Once()
+	mockClient.On("GetTemplate", ctx, mock.Anything).Return(&cloudformation.GetTemplateOutput{
+		TemplateBody: aws.String("Resources: {}"),
+	}, nil).Once()
+
+	_, err := cfOps.GetTemplate(ctx, "app")
+
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), `level=DEBUG msg="calling CloudFormation" operation=GetTemplate attempt=1`)
+	assert.Contains(t, logs.String(), `level=INFO msg="CloudFormation call throttled; retrying" operation=GetTemplate attempt=1`)
+	assert.Contains(t, logs.String(), `level=DEBUG msg="calling CloudFormation" operation=GetTemplate attempt=2`)
+}
+
+func TestWithRetry_OtherErrorsAreNotRetried(t *testing.T) {
+	ctx := context.Background()
+	cfOps, mockClient, clock := newRetryingOperations()
+	mockClient.On("GetTemplate", ctx, mock.Anything).Return((*cloudformation.GetTemplateOutput)(nil), &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorised"}).Once()
+
+	_, err := cfOps.GetTemplate(ctx, "app")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not authorised")
+	mockClient.AssertNumberOfCalls(t, "GetTemplate", 1)
+	assert.Empty(t, clock.waited)
+}
+
+func TestWithRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	cfOps, mockClient, clock := newRetryingOperations()
+	cfOps.SetRetryConfig(RetryConfig{MaxAttempts: 3, BaseDelay: 4 * time.Second, MaxDelay: 5 * time.Second})
+	mockClient.On("GetTemplate", ctx, mock.Anything).Return((*cloudformation.GetTemplateOutput)(nil), throttlingError)
+
+	_, err := cfOps.GetTemplate(ctx, "app")
+
+	require.Error(t, err)
+	assert.True(t, isThrottlingError(err), "the last throttling error should be returned")
+	mockClient.AssertNumberOfCalls(t, "GetTemplate", 3)
+	require.Len(t, clock.waited, 2)
+	assert.LessOrEqual(t, clock.waited[1], 5*time.Second, "delays should be capped at the maximum")
+}
+
+func TestWithRetry_WaitForStackOperationSurvivesThrottling(t *testing.T) {
+	ctx := context.Background()
+	cfOps, mockClient, _ := newRetryingOperations()
+	mockClient.On("DescribeStacks", ctx, mock.Anything).Return(nil, &smithy.GenericAPIError{Code: "RequestLimitExceeded"}).Twice()
+	mockClient.On("DescribeStacks", ctx, mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []types.Stack{{StackName: aws.String("app"), StackStatus: types.StackStatusUpdateComplete}},
+	}, nil)
+	mockClient.On("DescribeStackEvents", ctx, mock.Anything).Return((*cloudformation.DescribeStackEventsOutput)(nil), throttlingError).Once()
+	mockClient.On("DescribeStackEvents", ctx, mock.Anything).Return(&cloudformation.DescribeStackEventsOutput{}, nil)
+
+	err := cfOps.WaitForStackOperation(ctx, "app", time.Now(), nil)
+
+	require.NoError(t, err)
+}
+
+func TestSetRetryConfig_ZeroFieldsKeepDefaults(t *testing.T) {
+	cfOps, _, _ := newRetryingOperations()
+
+	cfOps.SetRetryConfig(RetryConfig{MaxAttempts: 2})
+
+	assert.Equal(t, RetryConfig{MaxAttempts: 2, BaseDelay: DefaultRetryConfig.BaseDelay, MaxDelay: DefaultRetryConfig.MaxDelay}, cfOps.retryConfig)
+}
+
+func TestIsThrottlingError(t *testing.T) {
+	assert.True(t, isThrottlingError(throttlingError))
+	assert.True(t, isThrottlingError(&smithy.GenericAPIError{Code: "RequestLimitExceeded"}))
+	assert.False(t, isThrottlingError(&smithy.GenericAPIError{Code: "ValidationError"}))
+	assert.False(t, isThrottlingError(errors.New("Throttling")))
+	assert.False(t, isThrottlingError(nil))
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	smithy "github.com/aws/smithy-go"
+)
+
+// S3Client defines the interface for S3 client operations
+// This allows for easier testing with mock implementations
+type S3Client interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+// Ensure that the actual S3 client implements our interface
+var _ S3Client = (*s3.Client)(nil)
+
+// Ensure that DefaultS3Operations implements S3Operations
+var _ S3Operations = (*DefaultS3Operations)(nil)
+
+// S3Operations defines the interface for S3 object operations
+type S3Operations interface {
+	// GetObject returns the contents of an object as a string
+	GetObject(ctx context.Context, bucket string, key string) (string, error)
+}
+
+// ObjectNotFoundError indicates that an S3 object (or its bucket) does not exist
+type ObjectNotFoundError struct {
+	Bucket string
+	Key    string
+}
+
+func (e ObjectNotFoundError) Error() string {
+	return fmt.Sprintf("S3 object s3://%s/%s not found", e.Bucket, e.Key)
+}
+
+// DefaultS3Operations provides S3 object operations
+type DefaultS3Operations struct {
+	client S3Client
+}
+
+// NewS3OperationsWithClient creates operations with a custom client (for testing)
+func NewS3OperationsWithClient(client S3Client) *DefaultS3Operations {
+	return &DefaultS3Operations{
+		client: client,
+	}
+}
+
+// GetObject reads the whole body of an object
+func (s *DefaultS3Operations) GetObject(ctx context.Context, bucket string, key string) (string, error) {
+	result, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noKey *types.NoSuchKey
+		var noBucket *types.NoSuchBucket
+		if errors.As(err, &noKey) || errors.As(err, &noBucket) {
+			return "", ObjectNotFoundError{Bucket: bucket, Key: key}
+		}
+		if isS3AccessDeniedError(err) {
+			return "", fmt.Errorf("access denied reading S3 object s3://%s/%s: %w", bucket, key, err)
+		}
+		return "", fmt.Errorf("failed to get S3 object s3://%s/%s: %w", bucket, key, err)
+	}
+	defer func() { _ = result.Body.Close() }()
+
+	var body strings.Builder
+	if _, err := io.Copy(&body, result.Body); err != nil {
+		return "", fmt.Errorf("failed to read S3 object s3://%s/%s: %w", bucket, key, err)
+	}
+
+	return body.String(), nil
+}
+
+// ParseS3URI splits an s3://bucket/key URI into its bucket and key
+func ParseS3URI(uri string) (bucket string, key string, err error) {
+	path, ok := strings.CutPrefix(uri, "s3://")
+	if !ok {
+		return "", "", fmt.Errorf("invalid S3 URI %q: must start with s3://", uri)
+	}
+	bucket, key, _ = strings.Cut(path, "/")
+	if bucket == "" || key == "" {
+		return "", "", fmt.Errorf("invalid S3 URI %q: must name both a bucket and a key", uri)
+	}
+	return bucket, key, nil
+}
+
+// S3ObjectURL returns the virtual-hosted HTTPS URL CloudFormation accepts as a TemplateURL
+func S3ObjectURL(bucket, key, region string) string {
+	objectURL := url.URL{
+		Scheme: "https",
+		Host:   fmt.Sprintf("%s.s3.%s.amazonaws.com", bucket, region),
+		Path:   "/" + key,
+	}
+	return objectURL.String()
+}
+
+// isS3AccessDeniedError checks if the error indicates the caller lacks permission to read an object.
+// S3 reports this with the AccessDenied code rather than the AccessDeniedException other services use.
+func isS3AccessDeniedError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode() == "AccessDenied"
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	smithy "github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3GetObject_Success(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockS3Client{}
+	ops := NewS3OperationsWithClient(mockClient)
+
+	mockClient.On("GetObject", ctx, mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+		return aws.ToString(input.Bucket) == "templates" && aws.ToString(input.Key) == "vpc/template.yaml"
+	})).Return(&s3.GetObjectOutput{
+		Body: io.NopCloser(strings.NewReader("Resources: {}")),
+	}, nil)
+
+	body, err := ops.GetObject(ctx, "templates", "vpc/template.yaml")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Resources: {}", body)
+	mockClient.AssertExpectations(t)
+}
+
+func TestS3GetObject_NotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "missing key", err: &types.NoSuchKey{Message: aws.String("not found")}},
+		{name: "missing bucket", err: &types.NoSuchBucket{Message: aws.String("not found")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockClient := &MockS3Client{}
+			ops := NewS3OperationsWithClient(mockClient)
+
+			mockClient.On("GetObject", ctx, mock.Anything).Return(nil, tt.err)
+
+			_, err := ops.GetObject(ctx, "templates", "missing.yaml")
+
+			var notFound ObjectNotFoundError
+			require.ErrorAs(t, err, &notFound)
+			assert.Equal(t, "S3 object s3://templates/missing.yaml not found", err.Error())
+		})
+	}
+}
+
+func TestS3GetObject_AccessDenied(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockS3Client{}
+	ops := NewS3OperationsWithClient(mockClient)
+
+	mockClient.On("GetObject", ctx, mock.Anything).Return(nil, &smithy.GenericAPIError{
+		Code:    "AccessDenied",
+		Message: "Access Denied",
+	})
+
+	_, err := ops.GetObject(ctx, "templates", "private.yaml")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied reading S3 object s3://templates/private.yaml")
+}
+
+func TestParseS3URI(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		bucket      string
+		key         string
+		expectError string
+	}{
+		{name: "bucket and key", uri: "s3://templates/vpc.yaml", bucket: "templates", key: "vpc.yaml"},
+		{name: "nested key", uri: "s3://templates/network/vpc.yaml", bucket: "templates", key: "network/vpc.yaml"},
+		{name: "other scheme", uri: "file:///templates/vpc.yaml", expectError: "must start with s3://"},
+		{name: "bucket only", uri: "s3://templates", expectError: "must name both a bucket and a key"},
+		{name: "empty key", uri: "s3://templates/", expectError: "must name both a bucket and a key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, key, err := ParseS3URI(tt.uri)
+
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.bucket, bucket)
+			assert.Equal(t, tt.key, key)
+		})
+	}
+}
+
+func TestS3ObjectURL(t *testing.T) {
+	assert.Equal(t, "https://templates.s3.eu-west-1.amazonaws.com/network/vpc.yaml",
+		S3ObjectURL("templates", "network/vpc.yaml", "eu-west-1"))
+	assert.Equal(t, "https://templates.s3.eu-west-1.amazonaws.com/my%20template.yaml",
+		S3ObjectURL("templates", "my template.yaml", "eu-west-1"))
+}
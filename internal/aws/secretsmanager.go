@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// SecretsManagerClient defines the interface for Secrets Manager client operations
+// This allows for easier testing with mock implementations
+type SecretsManagerClient interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// Ensure that the actual Secrets Manager client implements our interface
+var _ SecretsManagerClient = (*secretsmanager.Client)(nil)
+
+// Ensure that DefaultSecretsManagerOperations implements SecretsManagerOperations
+var _ SecretsManagerOperations = (*DefaultSecretsManagerOperations)(nil)
+
+// SecretsManagerOperations defines the interface for Secrets Manager operations
+type SecretsManagerOperations interface {
+	// GetSecretValue returns the current string value of a secret
+	GetSecretValue(ctx context.Context, secretID string) (string, error)
+}
+
+// SecretNotFoundError indicates that a secret does not exist
+type SecretNotFoundError struct {
+	SecretID string
+}
+
+func (e SecretNotFoundError) Error() string {
+	return fmt.Sprintf("secret %s not found", e.SecretID)
+}
+
+// DefaultSecretsManagerOperations provides Secrets Manager operations
+type DefaultSecretsManagerOperations struct {
+	client SecretsManagerClient
+}
+
+// NewSecretsManagerOperationsWithClient creates operations with a custom client (for testing)
+func NewSecretsManagerOperationsWithClient(client SecretsManagerClient) *DefaultSecretsManagerOperations {
+	return &DefaultSecretsManagerOperations{
+		client: client,
+	}
+}
+
+// GetSecretValue reads the current version of a secret's string value
+func (s *DefaultSecretsManagerOperations) GetSecretValue(ctx context.Context, secretID string) (string, error) {
+	result, err := s.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", SecretNotFoundError{SecretID: secretID}
+		}
+		if isAccessDeniedError(err) {
+			return "", fmt.Errorf("access denied reading secret %s: %w", secretID, err)
+		}
+		return "", fmt.Errorf("failed to get secret %s: %w", secretID, err)
+	}
+
+	if result.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", secretID)
+	}
+
+	return aws.ToString(result.SecretString), nil
+}
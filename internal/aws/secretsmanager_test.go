@@ -0,0 +1,82 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	smithy "github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsManagerGetSecretValue_Success(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSecretsManagerClient{}
+	ops := NewSecretsManagerOperationsWithClient(mockClient)
+
+	mockClient.On("GetSecretValue", ctx, mock.MatchedBy(func(input *secretsmanager.GetSecretValueInput) bool {
+		return aws.ToString(input.SecretId) == "prod/db/password"
+	})).Return(&secretsmanager.GetSecretValueOutput{
+		SecretString: aws.String("s3cr3t"),
+	}, nil)
+
+	value, err := ops.GetSecretValue(ctx, "prod/db/password")
+
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSecretsManagerGetSecretValue_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSecretsManagerClient{}
+	ops := NewSecretsManagerOperationsWithClient(mockClient)
+
+	mockClient.On("GetSecretValue", ctx, mock.Anything).Return(nil, &types.ResourceNotFoundException{Message: aws.String("not found")})
+
+	_, err := ops.GetSecretValue(ctx, "prod/db/missing")
+
+	require.Error(t, err)
+	var notFound SecretNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "secret prod/db/missing not found", err.Error())
+}
+
+func TestSecretsManagerGetSecretValue_AccessDenied(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSecretsManagerClient{}
+	ops := NewSecretsManagerOperationsWithClient(mockClient)
+
+	mockClient.On("GetSecretValue", ctx, mock.Anything).Return(nil, &smithy.GenericAPIError{
+		Code:    "AccessDeniedException",
+		Message: "User is not authorized to perform: secretsmanager:GetSecretValue",
+	})
+
+	_, err := ops.GetSecretValue(ctx, "prod/db/password")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied reading secret prod/db/password")
+}
+
+func TestSecretsManagerGetSecretValue_BinarySecret(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSecretsManagerClient{}
+	ops := NewSecretsManagerOperationsWithClient(mockClient)
+
+	mockClient.On("GetSecretValue", ctx, mock.Anything).Return(&secretsmanager.GetSecretValueOutput{
+		SecretBinary: []byte{0x01, 0x02},
+	}, nil)
+
+	_, err := ops.GetSecretValue(ctx, "prod/tls/key")
+
+	require.Error(t, err)
+	assert.Equal(t, "secret prod/tls/key has no string value", err.Error())
+}
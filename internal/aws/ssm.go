@@ -0,0 +1,98 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	smithy "github.com/aws/smithy-go"
+)
+
+// SSMClient defines the interface for SSM client operations
+// This allows for easier testing with mock implementations
+type SSMClient interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// Ensure that the actual SSM client implements our interface
+var _ SSMClient = (*ssm.Client)(nil)
+
+// Ensure that DefaultSSMOperations implements SSMOperations
+var _ SSMOperations = (*DefaultSSMOperations)(nil)
+
+// SSMOperations defines the interface for SSM Parameter Store operations
+type SSMOperations interface {
+	// GetParameter returns the decrypted value of a parameter. An empty version selects the latest.
+	GetParameter(ctx context.Context, name string, version string) (string, error)
+}
+
+// ParameterNotFoundError indicates that an SSM parameter (or the requested version of it) does not exist
+type ParameterNotFoundError struct {
+	Name    string
+	Version string
+}
+
+func (e ParameterNotFoundError) Error() string {
+	if e.Version != "" {
+		return fmt.Sprintf("SSM parameter %s version %s not found", e.Name, e.Version)
+	}
+	return fmt.Sprintf("SSM parameter %s not found", e.Name)
+}
+
+// DefaultSSMOperations provides SSM Parameter Store operations
+type DefaultSSMOperations struct {
+	client SSMClient
+}
+
+// NewSSMOperationsWithClient creates operations with a custom client (for testing)
+func NewSSMOperationsWithClient(client SSMClient) *DefaultSSMOperations {
+	return &DefaultSSMOperations{
+		client: client,
+	}
+}
+
+// GetParameter reads a parameter value, decrypting SecureString parameters
+func (s *DefaultSSMOperations) GetParameter(ctx context.Context, name string, version string) (string, error) {
+	selector := name
+	if version != "" {
+		selector = fmt.Sprintf("%s:%s", name, version)
+	}
+
+	result, err := s.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(selector),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		var notFound *types.ParameterNotFound
+		var versionNotFound *types.ParameterVersionNotFound
+		if errors.As(err, &notFound) || errors.As(err, &versionNotFound) {
+			return "", ParameterNotFoundError{Name: name, Version: version}
+		}
+		if isAccessDeniedError(err) {
+			return "", fmt.Errorf("access denied reading SSM parameter %s: %w", name, err)
+		}
+		return "", fmt.Errorf("failed to get SSM parameter %s: %w", name, err)
+	}
+
+	if result.Parameter == nil {
+		return "", ParameterNotFoundError{Name: name, Version: version}
+	}
+
+	return aws.ToString(result.Parameter.Value), nil
+}
+
+// isAccessDeniedError checks if the error indicates the caller lacks permission
+func isAccessDeniedError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode() == "AccessDeniedException"
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	smithy "github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSMGetParameter_Success(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSSMClient{}
+	ops := NewSSMOperationsWithClient(mockClient)
+
+	mockClient.On("GetParameter", ctx, mock.MatchedBy(func(input *ssm.GetParameterInput) bool {
+		return aws.ToString(input.Name) == "/myapp/dev/vpc-id" && aws.ToBool(input.WithDecryption)
+	})).Return(&ssm.GetParameterOutput{
+		Parameter: &types.Parameter{Value: aws.String("vpc-12345")},
+	}, nil)
+
+	value, err := ops.GetParameter(ctx, "/myapp/dev/vpc-id", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-12345", value)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSSMGetParameter_WithVersion(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSSMClient{}
+	ops := NewSSMOperationsWithClient(mockClient)
+
+	mockClient.On("GetParameter", ctx, mock.MatchedBy(func(input *ssm.GetParameterInput) bool {
+		return aws.ToString(input.Name) == "/myapp/dev/vpc-id:3"
+	})).Return(&ssm.GetParameterOutput{
+		Parameter: &types.Parameter{Value: aws.String("vpc-old")},
+	}, nil)
+
+	value, err := ops.GetParameter(ctx, "/myapp/dev/vpc-id", "3")
+
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-old", value)
+}
+
+func TestSSMGetParameter_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSSMClient{}
+	ops := NewSSMOperationsWithClient(mockClient)
+
+	mockClient.On("GetParameter", ctx, mock.Anything).Return(nil, &types.ParameterNotFound{Message: aws.String("not found")})
+
+	_, err := ops.GetParameter(ctx, "/myapp/dev/missing", "")
+
+	require.Error(t, err)
+	var notFound ParameterNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "/myapp/dev/missing", notFound.Name)
+	assert.Equal(t, "SSM parameter /myapp/dev/missing not found", err.Error())
+}
+
+func TestSSMGetParameter_VersionNotFound(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSSMClient{}
+	ops := NewSSMOperationsWithClient(mockClient)
+
+	mockClient.On("GetParameter", ctx, mock.Anything).Return(nil, &types.ParameterVersionNotFound{Message: aws.String("not found")})
+
+	_, err := ops.GetParameter(ctx, "/myapp/dev/vpc-id", "9")
+
+	require.Error(t, err)
+	assert.Equal(t, "SSM parameter /myapp/dev/vpc-id version 9 not found", err.Error())
+}
+
+func TestSSMGetParameter_AccessDenied(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSSMClient{}
+	ops := NewSSMOperationsWithClient(mockClient)
+
+	mockClient.On("GetParameter", ctx, mock.Anything).Return(nil, &smithy.GenericAPIError{
+		Code:    "AccessDeniedException",
+		Message: "User is not authorized to perform: ssm:GetParameter",
+	})
+
+	_, err := ops.GetParameter(ctx, "/myapp/prod/secret", "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied reading SSM parameter /myapp/prod/secret")
+	var notFound ParameterNotFoundError
+	assert.False(t, errors.As(err, &notFound))
+}
@@ -0,0 +1,57 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// STSClient defines the interface for STS client operations
+// This allows for easier testing with mock implementations
+type STSClient interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// Ensure that the actual STS client implements our interface
+var _ STSClient = (*sts.Client)(nil)
+
+// Ensure that DefaultSTSOperations implements STSOperations
+var _ STSOperations = (*DefaultSTSOperations)(nil)
+
+// STSOperations defines the interface for looking up the identity behind the current credentials
+type STSOperations interface {
+	// GetAccountID returns the ID of the AWS account the current credentials belong to
+	GetAccountID(ctx context.Context) (string, error)
+}
+
+// DefaultSTSOperations provides STS operations
+type DefaultSTSOperations struct {
+	client STSClient
+}
+
+// NewSTSOperationsWithClient creates operations with a custom client (for testing)
+func NewSTSOperationsWithClient(client STSClient) *DefaultSTSOperations {
+	return &DefaultSTSOperations{
+		client: client,
+	}
+}
+
+// GetAccountID returns the account of the caller identity
+func (s *DefaultSTSOperations) GetAccountID(ctx context.Context) (string, error) {
+	result, err := s.client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get caller identity: %w", err)
+	}
+
+	accountID := aws.ToString(result.Account)
+	if accountID == "" {
+		return "", fmt.Errorf("caller identity did not include an account ID")
+	}
+	return accountID, nil
+}
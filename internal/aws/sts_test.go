@@ -0,0 +1,58 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSTSGetAccountID_Success(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSTSClient{}
+	ops := NewSTSOperationsWithClient(mockClient)
+
+	mockClient.On("GetCallerIdentity", ctx, &sts.GetCallerIdentityInput{}).Return(&sts.GetCallerIdentityOutput{
+		Account: aws.String("123456789012"),
+		Arn:     aws.String("arn:aws:iam::123456789012:user/deployer"),
+	}, nil)
+
+	accountID, err := ops.GetAccountID(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, "123456789012", accountID)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSTSGetAccountID_Error(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSTSClient{}
+	ops := NewSTSOperationsWithClient(mockClient)
+
+	mockClient.On("GetCallerIdentity", ctx, &sts.GetCallerIdentityInput{}).Return(nil, errors.New("network unreachable"))
+
+	_, err := ops.GetAccountID(ctx)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get caller identity")
+}
+
+func TestSTSGetAccountID_MissingAccount(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockSTSClient{}
+	ops := NewSTSOperationsWithClient(mockClient)
+
+	mockClient.On("GetCallerIdentity", ctx, &sts.GetCallerIdentityInput{}).Return(&sts.GetCallerIdentityOutput{}, nil)
+
+	_, err := ops.GetAccountID(ctx)
+
+	require.EqualError(t, err, "caller identity did not include an account ID")
+}
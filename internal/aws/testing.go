@@ -12,6 +12,10 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -40,18 +44,31 @@ func SetupMockFactoryForMultiRegion(regions map[string]CloudFormationOperations)
 	return factory
 }
 
+// MockAccountID is the account reported for the current credentials by MockClientFactory unless
+// STS operations are configured for a region. It matches the account used by the model test contexts.
+const MockAccountID = "123456789012"
+
 // MockClientFactory provides a test implementation of ClientFactory
 type MockClientFactory struct {
-	operations map[string]CloudFormationOperations
-	baseConfig aws.Config
-	mutex      sync.RWMutex
+	operations    map[string]CloudFormationOperations
+	ssmOperations map[string]SSMOperations
+	s3Operations  map[string]S3Operations
+	secretsOps    map[string]SecretsManagerOperations
+	stsOperations map[string]STSOperations
+	waitConfig    WaitConfig
+	baseConfig    aws.Config
+	mutex         sync.RWMutex
 }
 
 // NewMockClientFactory creates a mock factory for testing
 func NewMockClientFactory() *MockClientFactory {
 	return &MockClientFactory{
-		operations: make(map[string]CloudFormationOperations),
-		baseConfig: aws.Config{}, // Empty config for testing
+		operations:    make(map[string]CloudFormationOperations),
+		ssmOperations: make(map[string]SSMOperations),
+		s3Operations:  make(map[string]S3Operations),
+		secretsOps:    make(map[string]SecretsManagerOperations),
+		stsOperations: make(map[string]STSOperations),
+		baseConfig:    aws.Config{}, // Empty config for testing
 	}
 }
 
@@ -75,6 +92,107 @@ func (m *MockClientFactory) GetCloudFormationOperations(ctx context.Context, reg
 	return ops, nil
 }
 
+// SetSSMOperations sets mock SSM operations for a specific region
+func (m *MockClientFactory) SetSSMOperations(region string, ops SSMOperations) {
+	m.mutex.Lock()
+	m.ssmOperations[region] = ops
+	m.mutex.Unlock()
+}
+
+// GetSSMOperations returns mock SSM operations for the specified region
+func (m *MockClientFactory) GetSSMOperations(ctx context.Context, region string) (SSMOperations, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	ops, exists := m.ssmOperations[region]
+	if !exists {
+		return nil, fmt.Errorf("no mock SSM operations configured for region %s", region)
+	}
+
+	return ops, nil
+}
+
+// SetS3Operations sets mock S3 operations for a specific region
+func (m *MockClientFactory) SetS3Operations(region string, ops S3Operations) {
+	m.mutex.Lock()
+	m.s3Operations[region] = ops
+	m.mutex.Unlock()
+}
+
+// GetS3Operations returns mock S3 operations for the specified region
+func (m *MockClientFactory) GetS3Operations(ctx context.Context, region string) (S3Operations, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	ops, exists := m.s3Operations[region]
+	if !exists {
+		return nil, fmt.Errorf("no mock S3 operations configured for region %s", region)
+	}
+
+	return ops, nil
+}
+
+// SetSecretsManagerOperations sets mock Secrets Manager operations for a specific region
+func (m *MockClientFactory) SetSecretsManagerOperations(region string, ops SecretsManagerOperations) {
+	m.mutex.Lock()
+	m.secretsOps[region] = ops
+	m.mutex.Unlock()
+}
+
+// GetSecretsManagerOperations returns mock Secrets Manager operations for the specified region
+func (m *MockClientFactory) GetSecretsManagerOperations(ctx context.Context, region string) (SecretsManagerOperations, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	ops, exists := m.secretsOps[region]
+	if !exists {
+		return nil, fmt.Errorf("no mock Secrets Manager operations configured for region %s", region)
+	}
+
+	return ops, nil
+}
+
+// SetSTSOperations sets mock STS operations for a specific region
+func (m *MockClientFactory) SetSTSOperations(region string, ops STSOperations) {
+	m.mutex.Lock()
+	m.stsOperations[region] = ops
+	m.mutex.Unlock()
+}
+
+// GetSTSOperations returns mock STS operations for the specified region, reporting MockAccountID when none are set
+func (m *MockClientFactory) GetSTSOperations(ctx context.Context, region string) (STSOperations, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	ops, exists := m.stsOperations[region]
+	if !exists {
+		return fixedAccountSTSOperations(MockAccountID), nil
+	}
+
+	return ops, nil
+}
+
+// fixedAccountSTSOperations reports the same account for every caller identity lookup
+type fixedAccountSTSOperations string
+
+func (f fixedAccountSTSOperations) GetAccountID(ctx context.Context) (string, error) {
+	return string(f), nil
+}
+
+// SetWaitConfig records the wait configuration so tests can check it, applying only non-zero fields
+func (m *MockClientFactory) SetWaitConfig(config WaitConfig) {
+	m.mutex.Lock()
+	m.waitConfig = m.waitConfig.merge(config)
+	m.mutex.Unlock()
+}
+
+// GetWaitConfig returns the wait configuration last set
+func (m *MockClientFactory) GetWaitConfig() WaitConfig {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.waitConfig
+}
+
 // GetBaseConfig returns the mock base configuration
 func (m *MockClientFactory) GetBaseConfig() aws.Config {
 	return m.baseConfig
@@ -113,6 +231,31 @@ func (m *MockCloudFormationOperations) DeleteStack(ctx context.Context, input De
 	return args.Error(0)
 }
 
+func (m *MockCloudFormationOperations) ContinueUpdateRollback(ctx context.Context, stackName string, resourcesToSkip []string) error {
+	args := m.Called(ctx, stackName, resourcesToSkip)
+	return args.Error(0)
+}
+
+func (m *MockCloudFormationOperations) CancelUpdateStack(ctx context.Context, stackName string) error {
+	args := m.Called(ctx, stackName)
+	return args.Error(0)
+}
+
+func (m *MockCloudFormationOperations) UpdateTerminationProtection(ctx context.Context, stackName string, enabled bool) error {
+	args := m.Called(ctx, stackName, enabled)
+	return args.Error(0)
+}
+
+func (m *MockCloudFormationOperations) SetStackPolicy(ctx context.Context, stackName string, policyBody string) error {
+	args := m.Called(ctx, stackName, policyBody)
+	return args.Error(0)
+}
+
+func (m *MockCloudFormationOperations) GetStackPolicy(ctx context.Context, stackName string) (string, error) {
+	args := m.Called(ctx, stackName)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockCloudFormationOperations) GetStack(ctx context.Context, stackName string) (*Stack, error) {
 	args := m.Called(ctx, stackName)
 	if args.Get(0) == nil {
@@ -152,11 +295,19 @@ func (m *MockCloudFormationOperations) DescribeStack(ctx context.Context, stackN
 	return args.Get(0).(*StackInfo), args.Error(1)
 }
 
-func (m *MockCloudFormationOperations) ExecuteChangeSet(ctx context.Context, changeSetID string) error {
-	args := m.Called(ctx, changeSetID)
+func (m *MockCloudFormationOperations) ExecuteChangeSet(ctx context.Context, changeSetID string, disableRollback bool) error {
+	args := m.Called(ctx, changeSetID, disableRollback)
 	return args.Error(0)
 }
 
+func (m *MockCloudFormationOperations) DescribeChangeSet(ctx context.Context, changeSetID string) (*ChangeSetInfo, error) {
+	args := m.Called(ctx, changeSetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ChangeSetInfo), args.Error(1)
+}
+
 func (m *MockCloudFormationOperations) DeleteChangeSet(ctx context.Context, changeSetID string) error {
 	args := m.Called(ctx, changeSetID)
 	return args.Error(0)
@@ -187,16 +338,16 @@ func (m *MockCloudFormationOperations) WaitForStackOperation(ctx context.Context
 	return args.Error(0)
 }
 
-func (m *MockCloudFormationOperations) CreateChangeSetPreview(ctx context.Context, stackName string, template string, parameters map[string]string, capabilities []string, tags map[string]string) (*ChangeSetInfo, error) {
-	args := m.Called(ctx, stackName, template, parameters, capabilities, tags)
+func (m *MockCloudFormationOperations) CreateChangeSetPreview(ctx context.Context, stackName string, template string, templateURL string, parameters map[string]string, capabilities []string, tags map[string]string) (*ChangeSetInfo, error) {
+	args := m.Called(ctx, stackName, template, templateURL, parameters, capabilities, tags)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*ChangeSetInfo), args.Error(1)
 }
 
-func (m *MockCloudFormationOperations) CreateChangeSetForDeployment(ctx context.Context, stackName string, template string, parameters map[string]string, capabilities []string, tags map[string]string) (*ChangeSetInfo, error) {
-	args := m.Called(ctx, stackName, template, parameters, capabilities, tags)
+func (m *MockCloudFormationOperations) CreateChangeSetForDeployment(ctx context.Context, input ChangeSetInput) (*ChangeSetInfo, error) {
+	args := m.Called(ctx, input)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -229,6 +380,22 @@ func (m *MockCloudFormationClient) DeleteStack(ctx context.Context, params *clou
 	return args.Get(0).(*cloudformation.DeleteStackOutput), args.Error(1)
 }
 
+func (m *MockCloudFormationClient) ContinueUpdateRollback(ctx context.Context, params *cloudformation.ContinueUpdateRollbackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ContinueUpdateRollbackOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.ContinueUpdateRollbackOutput), args.Error(1)
+}
+
+func (m *MockCloudFormationClient) CancelUpdateStack(ctx context.Context, params *cloudformation.CancelUpdateStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.CancelUpdateStackOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.CancelUpdateStackOutput), args.Error(1)
+}
+
 func (m *MockCloudFormationClient) DescribeStacks(ctx context.Context, params *cloudformation.DescribeStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
@@ -272,6 +439,30 @@ func (m *MockCloudFormationClient) DescribeChangeSet(ctx context.Context, params
 	return args.Get(0).(*cloudformation.DescribeChangeSetOutput), args.Error(1)
 }
 
+func (m *MockCloudFormationOperations) DetectStackDrift(ctx context.Context, stackName string) (*StackDriftResult, error) {
+	args := m.Called(ctx, stackName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*StackDriftResult), args.Error(1)
+}
+
+func (m *MockCloudFormationOperations) ListStackResources(ctx context.Context, stackName string) ([]StackResource, error) {
+	args := m.Called(ctx, stackName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]StackResource), args.Error(1)
+}
+
+func (m *MockCloudFormationOperations) ListExports(ctx context.Context) ([]Export, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Export), args.Error(1)
+}
+
 func (m *MockCloudFormationClient) DescribeStackEvents(ctx context.Context, params *cloudformation.DescribeStackEventsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackEventsOutput, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
@@ -279,3 +470,159 @@ func (m *MockCloudFormationClient) DescribeStackEvents(ctx context.Context, para
 	}
 	return args.Get(0).(*cloudformation.DescribeStackEventsOutput), args.Error(1)
 }
+
+func (m *MockCloudFormationClient) UpdateTerminationProtection(ctx context.Context, params *cloudformation.UpdateTerminationProtectionInput, optFns ...func(*cloudformation.Options)) (*cloudformation.UpdateTerminationProtectionOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.UpdateTerminationProtectionOutput), args.Error(1)
+}
+
+func (m *MockCloudFormationClient) SetStackPolicy(ctx context.Context, params *cloudformation.SetStackPolicyInput, optFns ...func(*cloudformation.Options)) (*cloudformation.SetStackPolicyOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.SetStackPolicyOutput), args.Error(1)
+}
+
+func (m *MockCloudFormationClient) GetStackPolicy(ctx context.Context, params *cloudformation.GetStackPolicyInput, optFns ...func(*cloudformation.Options)) (*cloudformation.GetStackPolicyOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.GetStackPolicyOutput), args.Error(1)
+}
+
+func (m *MockCloudFormationClient) DetectStackDrift(ctx context.Context, params *cloudformation.DetectStackDriftInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DetectStackDriftOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.DetectStackDriftOutput), args.Error(1)
+}
+
+func (m *MockCloudFormationClient) DescribeStackDriftDetectionStatus(ctx context.Context, params *cloudformation.DescribeStackDriftDetectionStatusInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.DescribeStackDriftDetectionStatusOutput), args.Error(1)
+}
+
+func (m *MockCloudFormationClient) DescribeStackResourceDrifts(ctx context.Context, params *cloudformation.DescribeStackResourceDriftsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackResourceDriftsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.DescribeStackResourceDriftsOutput), args.Error(1)
+}
+
+func (m *MockCloudFormationClient) ListStackResources(ctx context.Context, params *cloudformation.ListStackResourcesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.ListStackResourcesOutput), args.Error(1)
+}
+
+func (m *MockCloudFormationClient) ListExports(ctx context.Context, params *cloudformation.ListExportsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListExportsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.ListExportsOutput), args.Error(1)
+}
+
+// MockSSMOperations implements SSMOperations for testing
+type MockSSMOperations struct {
+	mock.Mock
+}
+
+func (m *MockSSMOperations) GetParameter(ctx context.Context, name string, version string) (string, error) {
+	args := m.Called(ctx, name, version)
+	return args.String(0), args.Error(1)
+}
+
+// MockSSMClient implements the AWS SSM service client interface for testing
+type MockSSMClient struct {
+	mock.Mock
+}
+
+func (m *MockSSMClient) GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ssm.GetParameterOutput), args.Error(1)
+}
+
+// MockS3Operations implements S3Operations for testing
+type MockS3Operations struct {
+	mock.Mock
+}
+
+func (m *MockS3Operations) GetObject(ctx context.Context, bucket string, key string) (string, error) {
+	args := m.Called(ctx, bucket, key)
+	return args.String(0), args.Error(1)
+}
+
+// MockS3Client implements the AWS S3 service client interface for testing
+type MockS3Client struct {
+	mock.Mock
+}
+
+func (m *MockS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.GetObjectOutput), args.Error(1)
+}
+
+// MockSTSOperations implements STSOperations for testing
+type MockSTSOperations struct {
+	mock.Mock
+}
+
+func (m *MockSTSOperations) GetAccountID(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+// MockSTSClient implements the AWS STS service client interface for testing
+type MockSTSClient struct {
+	mock.Mock
+}
+
+func (m *MockSTSClient) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sts.GetCallerIdentityOutput), args.Error(1)
+}
+
+// MockSecretsManagerOperations implements SecretsManagerOperations for testing
+type MockSecretsManagerOperations struct {
+	mock.Mock
+}
+
+func (m *MockSecretsManagerOperations) GetSecretValue(ctx context.Context, secretID string) (string, error) {
+	args := m.Called(ctx, secretID)
+	return args.String(0), args.Error(1)
+}
+
+// MockSecretsManagerClient implements the AWS Secrets Manager service client interface for testing
+type MockSecretsManagerClient struct {
+	mock.Mock
+}
+
+func (m *MockSecretsManagerClient) GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*secretsmanager.GetSecretValueOutput), args.Error(1)
+}
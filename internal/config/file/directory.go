@@ -0,0 +1,160 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package file
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Conventional configuration locations. When the default file does not exist, a directory of
+// the same name without the extension is loaded instead.
+const (
+	DefaultConfigFile      = "stackaroo.yaml"
+	DefaultConfigDirectory = "stackaroo"
+)
+
+// NewDirectoryProvider creates a ConfigProvider that loads and merges every *.yaml file in a
+// directory, so large projects can split their configuration, for example one file per team.
+// Template and values file paths are resolved relative to the directory containing it, as they
+// would be for a stackaroo.yaml beside it; context values files are read from the directory itself.
+func NewDirectoryProvider(dir string) *FileConfigProvider {
+	return &FileConfigProvider{
+		filename:  filepath.Clean(dir),
+		directory: true,
+	}
+}
+
+// discoverConfigDirectory returns the configuration directory to load in place of a local
+// configuration file, or an empty string to load the file. A directory given as the location
+// is used as it is; a missing stackaroo.yaml falls back to a stackaroo directory beside it.
+func discoverConfigDirectory(location string) string {
+	info, err := os.Stat(location)
+	if err == nil {
+		if info.IsDir() {
+			return location
+		}
+		return ""
+	}
+	if !os.IsNotExist(err) || filepath.Base(location) != DefaultConfigFile {
+		return ""
+	}
+
+	dir := filepath.Join(filepath.Dir(location), DefaultConfigDirectory)
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		return dir
+	}
+	return ""
+}
+
+// loadConfigDirectory loads every *.yaml file in a directory, in name order, and merges them.
+// Contexts, stacks, tags and mandatory tags are combined by name, and a name defined in more than one file is an
+// error naming both. Top-level settings such as project and region may be set by only one file.
+// Context values files, <context>.values.yaml, are not configuration and are skipped.
+func (fp *FileConfigProvider) loadConfigDirectory(dir string) (*Config, error) {
+	// Glob returns paths in name order
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config directory '%s': %w", dir, err)
+	}
+
+	merged := &Config{}
+	origins := make(map[string]string) // Kind and name of each setting, mapped to the file defining it
+	loaded := 0
+	for _, path := range paths {
+		if strings.HasSuffix(path, ".values.yaml") {
+			continue
+		}
+
+		rawConfig, err := fp.loadConfigFile(path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := mergeConfigFile(merged, rawConfig, path, origins); err != nil {
+			return nil, err
+		}
+		loaded++
+	}
+
+	if loaded == 0 {
+		return nil, fmt.Errorf("config directory '%s' contains no *.yaml files", dir)
+	}
+	return merged, nil
+}
+
+// mergeConfigFile merges one file of a configuration directory into the configuration loaded so
+// far, recording in origins which file defined each setting so that conflicts name both files
+func mergeConfigFile(merged, rawConfig *Config, path string, origins map[string]string) error {
+	claim := func(kind, name string) error {
+		key := fmt.Sprintf("%s '%s'", kind, name)
+		if previous, exists := origins[key]; exists {
+			return fmt.Errorf("%s is defined in both '%s' and '%s'", key, previous, path)
+		}
+		origins[key] = path
+		return nil
+	}
+
+	settings := []struct {
+		name string
+		set  bool
+	}{
+		{"project", rawConfig.Project != ""},
+		{"region", rawConfig.Region != ""},
+		{"templates", rawConfig.Templates != nil},
+		{"stack_name_prefix", rawConfig.StackNamePrefix != ""},
+		{"stack_name_suffix", rawConfig.StackNameSuffix != ""},
+		{"context_values_files", rawConfig.ContextValues},
+	}
+	for _, setting := range settings {
+		if setting.set {
+			if err := claim("setting", setting.name); err != nil {
+				return err
+			}
+		}
+	}
+	if rawConfig.Project != "" {
+		merged.Project = rawConfig.Project
+	}
+	if rawConfig.Region != "" {
+		merged.Region = rawConfig.Region
+	}
+	if rawConfig.Templates != nil {
+		merged.Templates = rawConfig.Templates
+	}
+	if rawConfig.StackNamePrefix != "" {
+		merged.StackNamePrefix = rawConfig.StackNamePrefix
+	}
+	if rawConfig.StackNameSuffix != "" {
+		merged.StackNameSuffix = rawConfig.StackNameSuffix
+	}
+	merged.ContextValues = merged.ContextValues || rawConfig.ContextValues
+
+	for _, name := range slices.Sorted(maps.Keys(rawConfig.Tags)) {
+		if err := claim("tag", name); err != nil {
+			return err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(rawConfig.MandatoryTags)) {
+		if err := claim("mandatory tag", name); err != nil {
+			return err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(rawConfig.Contexts)) {
+		if err := claim("context", name); err != nil {
+			return err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(rawConfig.Stacks)) {
+		if err := claim("stack", name); err != nil {
+			return err
+		}
+	}
+	mergeConfig(merged, rawConfig)
+	return nil
+}
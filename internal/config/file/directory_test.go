@@ -0,0 +1,190 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package file
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryProvider_MergesFiles(t *testing.T) {
+	configFile := writeConfigFiles(t, map[string]string{
+		"stackaroo/project.yaml": `
+project: test-project
+region: us-east-1
+tags:
+  Owner: platform
+contexts:
+  dev:
+    account: "123456789012"
+`,
+		"stackaroo/network.yaml": `
+tags:
+  CostCentre: "100"
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+`,
+		"stackaroo/payments.yaml": `
+contexts:
+  prod:
+    account: "210987654321"
+stacks:
+  payments:
+    template: templates/payments.yaml
+    depends_on:
+      - vpc
+`,
+		"stackaroo/dev.values.yaml": "Ignored: true\n",
+		"stackaroo/README.md":       "not configuration\n",
+	})
+	provider := NewDirectoryProvider(filepath.Join(filepath.Dir(configFile), "stackaroo"))
+
+	cfg, err := provider.LoadConfig(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "test-project", cfg.Project)
+	assert.Equal(t, map[string]string{"Owner": "platform", "CostCentre": "100"}, cfg.Tags)
+
+	contexts, err := provider.ListContexts()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"dev", "prod"}, contexts)
+
+	stacks, err := provider.ListStacks("dev")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"payments", "vpc"}, stacks)
+
+	// Paths are relative to the directory containing the configuration directory
+	payments, err := provider.GetStack("payments", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "file://"+filepath.Join(filepath.Dir(configFile), "templates/payments.yaml"), payments.Template)
+	assert.Equal(t, []string{"vpc"}, payments.Dependencies)
+}
+
+func TestDirectoryProvider_ContextValuesFile(t *testing.T) {
+	configFile := writeConfigFiles(t, map[string]string{
+		"stackaroo/project.yaml": `
+project: test-project
+context_values_files: true
+contexts:
+  dev:
+    region: us-east-1
+  prod:
+    region: us-east-1
+`,
+		"stackaroo/dev.values.yaml": "InstanceType: t3.small\n",
+		"prod.values.yaml":          "InstanceType: m5.large\n",
+	})
+	dir := filepath.Join(filepath.Dir(configFile), "stackaroo")
+	provider := NewDirectoryProvider(dir)
+
+	// Values files are read from the configuration directory itself
+	devConfig, err := provider.LoadConfig(context.Background(), "dev")
+	require.NoError(t, err)
+	realPath, err := filepath.EvalSymlinks(filepath.Join(dir, "dev.values.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "file://"+realPath, devConfig.Context.ValuesFile)
+
+	// A values file beside the directory is not the context's
+	prodConfig, err := provider.LoadConfig(context.Background(), "prod")
+	require.NoError(t, err)
+	assert.Empty(t, prodConfig.Context.ValuesFile)
+}
+
+func TestDirectoryProvider_Conflicts(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectedError string
+	}{
+		{
+			name: "duplicate stack",
+			files: map[string]string{
+				"stackaroo/a-network.yaml":  "project: test-project\nstacks:\n  vpc:\n    template: templates/vpc.yaml\n",
+				"stackaroo/b-payments.yaml": "stacks:\n  vpc:\n    template: templates/other-vpc.yaml\n",
+			},
+			expectedError: "stack 'vpc' is defined in both '%[1]s/a-network.yaml' and '%[1]s/b-payments.yaml'",
+		},
+		{
+			name: "duplicate context",
+			files: map[string]string{
+				"stackaroo/a.yaml": "project: test-project\ncontexts:\n  dev:\n    region: us-east-1\n",
+				"stackaroo/b.yaml": "contexts:\n  dev:\n    region: eu-west-1\n",
+			},
+			expectedError: "context 'dev' is defined in both '%[1]s/a.yaml' and '%[1]s/b.yaml'",
+		},
+		{
+			name: "top-level setting in two files",
+			files: map[string]string{
+				"stackaroo/a.yaml": "project: test-project\n",
+				"stackaroo/b.yaml": "project: other-project\n",
+			},
+			expectedError: "setting 'project' is defined in both '%[1]s/a.yaml' and '%[1]s/b.yaml'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(filepath.Dir(writeConfigFiles(t, tt.files)), "stackaroo")
+			provider := NewDirectoryProvider(dir)
+
+			_, err := provider.ListContexts()
+
+			require.Error(t, err)
+			assert.Equal(t, fmt.Sprintf(tt.expectedError, dir), err.Error())
+		})
+	}
+}
+
+func TestDirectoryProvider_Empty(t *testing.T) {
+	dir := filepath.Join(filepath.Dir(writeConfigFiles(t, map[string]string{"stackaroo/notes.txt": "none"})), "stackaroo")
+
+	_, err := NewDirectoryProvider(dir).ListContexts()
+
+	assert.EqualError(t, err, "config directory '"+dir+"' contains no *.yaml files")
+}
+
+func TestNewConfigProvider_DiscoversConfigDirectory(t *testing.T) {
+	t.Run("directory used when stackaroo.yaml is missing", func(t *testing.T) {
+		configFile := writeConfigFiles(t, map[string]string{
+			"stackaroo/project.yaml": "project: test-project\ncontexts:\n  dev:\n    region: us-east-1\n",
+		})
+
+		provider := NewConfigProvider(configFile)
+
+		assert.True(t, provider.directory)
+		contexts, err := provider.ListContexts()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dev"}, contexts)
+	})
+
+	t.Run("stackaroo.yaml preferred over the directory", func(t *testing.T) {
+		configFile := writeConfigFiles(t, map[string]string{
+			"stackaroo.yaml":         "project: test-project\ncontexts:\n  prod:\n    region: us-east-1\n",
+			"stackaroo/project.yaml": "project: test-project\ncontexts:\n  dev:\n    region: us-east-1\n",
+		})
+
+		provider := NewConfigProvider(configFile)
+
+		assert.False(t, provider.directory)
+		contexts, err := provider.ListContexts()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"prod"}, contexts)
+	})
+
+	t.Run("directory given explicitly", func(t *testing.T) {
+		configFile := writeConfigFiles(t, map[string]string{
+			"config/project.yaml": "project: test-project\ncontexts:\n  dev:\n    region: us-east-1\n",
+		})
+
+		provider := NewConfigProvider(filepath.Join(filepath.Dir(configFile), "config"))
+
+		assert.True(t, provider.directory)
+	})
+}
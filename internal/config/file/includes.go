@@ -0,0 +1,123 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfigFile reads and parses a configuration file together with the files it includes.
+// Included files are merged in the order listed, so later files override earlier ones, and the
+// including file overrides them all. chain holds the files currently being loaded, to detect cycles.
+func (fp *FileConfigProvider) loadConfigFile(location string, chain []string) (*Config, error) {
+	if fp.fetcher == nil {
+		location = filepath.Clean(location)
+	}
+	for _, loading := range chain {
+		if loading == location {
+			return nil, fmt.Errorf("include cycle detected: %s", strings.Join(append(chain, location), " -> "))
+		}
+	}
+	chain = append(chain, location)
+
+	// Read file, or fetch it when the configuration is remote
+	var data []byte
+	var err error
+	if fp.fetcher != nil {
+		data, err = fp.fetcher.fetch(location)
+	} else {
+		data, err = os.ReadFile(location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file '%s': %w", location, err)
+	}
+
+	var rawConfig Config
+	if err := yaml.Unmarshal(data, &rawConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML config file '%s': %w", location, err)
+	}
+	if len(rawConfig.Includes) == 0 {
+		return &rawConfig, nil
+	}
+
+	merged := &Config{}
+	for _, include := range rawConfig.Includes {
+		includeLocation, err := fp.resolveIncludeLocation(location, include)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include in config file '%s': %w", location, err)
+		}
+
+		included, err := fp.loadConfigFile(includeLocation, chain)
+		if err != nil {
+			return nil, err
+		}
+		if included.Project != "" || included.Region != "" || included.Templates != nil ||
+			included.StackNamePrefix != "" || included.StackNameSuffix != "" || included.ContextValues {
+			return nil, fmt.Errorf("included config file '%s' may only set includes, tags, contexts and stacks", includeLocation)
+		}
+		mergeConfig(merged, included)
+	}
+	mergeConfig(merged, &rawConfig)
+
+	rawConfig.Tags = merged.Tags
+	rawConfig.MandatoryTags = merged.MandatoryTags
+	rawConfig.Contexts = merged.Contexts
+	rawConfig.Stacks = merged.Stacks
+	return &rawConfig, nil
+}
+
+// resolveIncludeLocation resolves an include path relative to the directory of the file listing it
+func (fp *FileConfigProvider) resolveIncludeLocation(includingLocation, includePath string) (string, error) {
+	if fp.fetcher != nil {
+		includingDir, err := remoteConfigDir(includingLocation)
+		if err != nil {
+			return "", err
+		}
+		resolved, err := resolveRemoteURI(includingDir, includePath)
+		if err != nil {
+			return "", err
+		}
+		return resolved.String(), nil
+	}
+
+	if filepath.IsAbs(includePath) {
+		return "", fmt.Errorf("path must be relative: %s", includePath)
+	}
+	return filepath.Join(filepath.Dir(includingLocation), includePath), nil
+}
+
+// mergeConfig copies the tags, mandatory tags, contexts and stacks of override into base, replacing
+// entries with the same name
+func mergeConfig(base, override *Config) {
+	for key, value := range override.Tags {
+		if base.Tags == nil {
+			base.Tags = make(map[string]string)
+		}
+		base.Tags[key] = value
+	}
+	for key, value := range override.MandatoryTags {
+		if base.MandatoryTags == nil {
+			base.MandatoryTags = make(map[string]string)
+		}
+		base.MandatoryTags[key] = value
+	}
+	for name, context := range override.Contexts {
+		if base.Contexts == nil {
+			base.Contexts = make(map[string]*Context)
+		}
+		base.Contexts[name] = context
+	}
+	for name, stack := range override.Stacks {
+		if base.Stacks == nil {
+			base.Stacks = make(map[string]*Stack)
+		}
+		base.Stacks[name] = stack
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package file
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFiles writes files into a temporary directory and returns the path of stackaroo.yaml
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return filepath.Join(dir, "stackaroo.yaml")
+}
+
+func TestFileProvider_Includes_MergePrecedence(t *testing.T) {
+	configFile := writeConfigFiles(t, map[string]string{
+		"stackaroo.yaml": `
+project: test-project
+region: us-east-1
+includes:
+  - shared/network.yaml
+  - shared/apps.yaml
+tags:
+  Owner: platform
+stacks:
+  app:
+    template: templates/app-main.yaml
+`,
+		"shared/network.yaml": `
+tags:
+  Owner: network
+  CostCentre: "100"
+contexts:
+  dev:
+    region: us-west-2
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+  app:
+    template: templates/app-network.yaml
+`,
+		"shared/apps.yaml": `
+tags:
+  CostCentre: "200"
+contexts:
+  dev:
+    region: eu-west-1
+stacks:
+  queue:
+    template: templates/queue-apps.yaml
+  app:
+    template: templates/app-apps.yaml
+`,
+	})
+	provider := NewFileConfigProvider(configFile)
+
+	cfg, err := provider.LoadConfig(context.Background(), "dev")
+	require.NoError(t, err)
+
+	// The main file overrides every include, and later includes override earlier ones
+	assert.Equal(t, map[string]string{"Owner": "platform", "CostCentre": "200"}, cfg.Tags)
+	assert.Equal(t, "eu-west-1", cfg.Context.Region)
+
+	stacks, err := provider.ListStacks("dev")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"app", "queue", "vpc"}, stacks)
+
+	app, err := provider.GetStack("app", "dev")
+	require.NoError(t, err)
+	assert.Contains(t, app.Template, "templates/app-main.yaml")
+}
+
+func TestFileProvider_Includes_LaterIncludeOverridesEarlier(t *testing.T) {
+	configFile := writeConfigFiles(t, map[string]string{
+		"stackaroo.yaml": `
+project: test-project
+includes:
+  - first.yaml
+  - second.yaml
+contexts:
+  dev:
+    region: us-east-1
+`,
+		"first.yaml":  "stacks:\n  vpc:\n    template: templates/first.yaml\n",
+		"second.yaml": "stacks:\n  vpc:\n    template: templates/second.yaml\n",
+	})
+	provider := NewFileConfigProvider(configFile)
+
+	stack, err := provider.GetStack("vpc", "dev")
+
+	require.NoError(t, err)
+	assert.Contains(t, stack.Template, "templates/second.yaml")
+}
+
+func TestFileProvider_Includes_Nested(t *testing.T) {
+	// Include paths are relative to the file listing them, and the same file may be reached twice
+	configFile := writeConfigFiles(t, map[string]string{
+		"stackaroo.yaml": `
+project: test-project
+includes:
+  - shared/all.yaml
+  - shared/common/tags.yaml
+contexts:
+  dev:
+    region: us-east-1
+`,
+		"shared/all.yaml":         "includes:\n  - common/tags.yaml\nstacks:\n  vpc:\n    template: templates/vpc.yaml\n",
+		"shared/common/tags.yaml": "tags:\n  Owner: platform\n",
+	})
+	provider := NewFileConfigProvider(configFile)
+
+	cfg, err := provider.LoadConfig(context.Background(), "dev")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Owner": "platform"}, cfg.Tags)
+	require.Len(t, cfg.Stacks, 1)
+	assert.Equal(t, "vpc", cfg.Stacks[0].Name)
+}
+
+func TestFileProvider_Includes_CycleDetected(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name: "includes itself",
+			files: map[string]string{
+				"stackaroo.yaml": "project: test-project\nincludes:\n  - ./stackaroo.yaml\n",
+			},
+		},
+		{
+			name: "indirect cycle",
+			files: map[string]string{
+				"stackaroo.yaml": "project: test-project\nincludes:\n  - a.yaml\n",
+				"a.yaml":         "includes:\n  - shared/b.yaml\n",
+				"shared/b.yaml":  "includes:\n  - ../a.yaml\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewFileConfigProvider(writeConfigFiles(t, tt.files))
+
+			_, err := provider.ListContexts()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "include cycle detected")
+		})
+	}
+}
+
+func TestFileProvider_Includes_RejectsTopLevelSettings(t *testing.T) {
+	configFile := writeConfigFiles(t, map[string]string{
+		"stackaroo.yaml": "project: test-project\nincludes:\n  - other.yaml\n",
+		"other.yaml":     "project: other-project\n",
+	})
+	provider := NewFileConfigProvider(configFile)
+
+	_, err := provider.ListContexts()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "may only set includes, tags, contexts and stacks")
+}
+
+func TestFileProvider_Includes_MissingFile(t *testing.T) {
+	configFile := writeConfigFiles(t, map[string]string{
+		"stackaroo.yaml": "project: test-project\nincludes:\n  - missing.yaml\n",
+	})
+	provider := NewFileConfigProvider(configFile)
+
+	_, err := provider.ListContexts()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing.yaml")
+}
+
+func TestFileProvider_Includes_AbsolutePathRejected(t *testing.T) {
+	configFile := writeConfigFiles(t, map[string]string{
+		"stackaroo.yaml": "project: test-project\nincludes:\n  - /etc/stackaroo.yaml\n",
+	})
+	provider := NewFileConfigProvider(configFile)
+
+	_, err := provider.ListContexts()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "path must be relative")
+}
+
+func TestHTTPConfigProvider_Includes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/infra/stackaroo.yaml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("project: remote-project\nincludes:\n  - shared/stacks.yaml\ncontexts:\n  dev:\n    region: us-east-1\n"))
+	})
+	mux.HandleFunc("/infra/shared/stacks.yaml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("stacks:\n  vpc:\n    template: templates/vpc.yaml\n"))
+	})
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+	provider := NewHTTPConfigProvider(server.URL+"/infra/stackaroo.yaml", server.Client(), "")
+
+	stacks, err := provider.ListStacks("dev")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vpc"}, stacks)
+}
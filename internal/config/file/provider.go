@@ -13,14 +13,15 @@ import (
 	"strings"
 
 	"codeberg.org/orien/stackaroo/internal/config"
-	"gopkg.in/yaml.v3"
 )
 
 // FileConfigProvider implements config.ConfigProvider by reading from a YAML file
 // Based on ADR 0010 (File provider configuration structure)
 type FileConfigProvider struct {
-	filename  string
+	filename  string // Configuration file or directory; relative paths are resolved from the directory containing it
+	directory bool   // Set when filename is a directory whose *.yaml files are merged
 	rawConfig *Config
+	fetcher   *HTTPFetcher // Set when the configuration is read from an HTTPS URL
 }
 
 // NewFileConfigProvider creates a new file-based ConfigProvider for the given filename
@@ -30,6 +31,12 @@ func NewFileConfigProvider(filename string) *FileConfigProvider {
 	}
 }
 
+// RemoteFetcher returns the fetcher used for a remote configuration, or nil when it is a local file.
+// Templates and values files of a remote configuration must be read with it.
+func (fp *FileConfigProvider) RemoteFetcher() *HTTPFetcher {
+	return fp.fetcher
+}
+
 // LoadConfig loads and resolves configuration for the specified context
 func (fp *FileConfigProvider) LoadConfig(ctx context.Context, context string) (*config.Config, error) {
 	// Load raw config if not already loaded
@@ -45,6 +52,13 @@ func (fp *FileConfigProvider) LoadConfig(ctx context.Context, context string) (*
 
 	// Resolve context configuration with inheritance
 	resolvedContext := fp.resolveContext(context, rawContext)
+	if fp.rawConfig.ContextValues {
+		valuesFile, err := fp.contextValuesFile(context)
+		if err != nil {
+			return nil, err
+		}
+		resolvedContext.ValuesFile = valuesFile
+	}
 
 	// Resolve all stacks for this context
 	stacks, err := fp.resolveStacks(context)
@@ -54,11 +68,24 @@ func (fp *FileConfigProvider) LoadConfig(ctx context.Context, context string) (*
 
 	// Build final config
 	cfg := &config.Config{
-		Project: fp.rawConfig.Project,
-		Region:  fp.rawConfig.Region, // Global default
-		Tags:    fp.copyStringMap(fp.rawConfig.Tags),
-		Context: resolvedContext,
-		Stacks:  stacks,
+		Project:       fp.rawConfig.Project,
+		Region:        fp.rawConfig.Region, // Global default
+		Tags:          fp.copyStringMap(fp.rawConfig.Tags),
+		MandatoryTags: fp.copyStringMap(fp.rawConfig.MandatoryTags),
+		Context:       resolvedContext,
+		Stacks:        stacks,
+	}
+
+	// Expand ${...} tokens in global and context tags for this context
+	subs := fp.contextSubstitutions(context)
+	if err := subs.expandTags(cfg.Tags); err != nil {
+		return nil, fmt.Errorf("global tags: %w", err)
+	}
+	if err := subs.expandTags(cfg.MandatoryTags); err != nil {
+		return nil, fmt.Errorf("mandatory tags: %w", err)
+	}
+	if err := subs.expandTags(cfg.Context.Tags); err != nil {
+		return nil, fmt.Errorf("tags for context '%s': %w", context, err)
 	}
 
 	return cfg, nil
@@ -134,6 +161,11 @@ func (fp *FileConfigProvider) Validate() error {
 		}
 	}
 
+	// Remote templates can only be checked by fetching them, which happens when stacks are resolved
+	if fp.fetcher != nil {
+		return nil
+	}
+
 	// Check that global template directory exists if specified
 	if fp.rawConfig.Templates != nil && fp.rawConfig.Templates.Directory != "" {
 		templateDir := fp.rawConfig.Templates.Directory
@@ -148,7 +180,7 @@ func (fp *FileConfigProvider) Validate() error {
 
 	// Check that template files exist (basic validation)
 	for stackName, stack := range fp.rawConfig.Stacks {
-		if stack.Template != "" {
+		if stack.Template != "" && !isS3URI(stack.Template) {
 			templatePath, err := fp.resolveTemplatePath(stack.Template)
 			if err != nil {
 				return fmt.Errorf("invalid template path for stack '%s': %w", stackName, err)
@@ -157,6 +189,29 @@ func (fp *FileConfigProvider) Validate() error {
 				return fmt.Errorf("template file not found for stack '%s': %s", stackName, templatePath)
 			}
 		}
+
+		if stack.StackPolicy != "" {
+			policyPath, err := fp.resolveTemplatePath(stack.StackPolicy)
+			if err != nil {
+				return fmt.Errorf("invalid stack policy path for stack '%s': %w", stackName, err)
+			}
+			if _, err := os.Stat(policyPath); err != nil && os.IsNotExist(err) {
+				return fmt.Errorf("stack policy file not found for stack '%s': %s", stackName, policyPath)
+			}
+		}
+
+		for contextName, contextOverride := range stack.Contexts {
+			if contextOverride == nil || contextOverride.Template == "" || isS3URI(contextOverride.Template) {
+				continue
+			}
+			templatePath, err := fp.resolveTemplatePath(contextOverride.Template)
+			if err != nil {
+				return fmt.Errorf("invalid template path for stack '%s' in context '%s': %w", stackName, contextName, err)
+			}
+			if _, err := os.Stat(templatePath); err != nil && os.IsNotExist(err) {
+				return fmt.Errorf("template file not found for stack '%s' in context '%s': %s", stackName, contextName, templatePath)
+			}
+		}
 	}
 
 	return nil
@@ -168,35 +223,44 @@ func (fp *FileConfigProvider) ensureLoaded() error {
 		return nil // Already loaded
 	}
 
-	// Read file
-	data, err := os.ReadFile(fp.filename)
-	if err != nil {
-		return fmt.Errorf("failed to read config file '%s': %w", fp.filename, err)
+	var rawConfig *Config
+	var err error
+	if fp.directory {
+		rawConfig, err = fp.loadConfigDirectory(fp.filename)
+	} else {
+		rawConfig, err = fp.loadConfigFile(fp.filename, nil)
 	}
-
-	// Parse YAML
-	var rawConfig Config
-	if err := yaml.Unmarshal(data, &rawConfig); err != nil {
-		return fmt.Errorf("failed to parse YAML config file '%s': %w", fp.filename, err)
+	if err != nil {
+		return err
 	}
 
-	fp.rawConfig = &rawConfig
+	fp.rawConfig = rawConfig
 	return nil
 }
 
 // resolveContext creates a resolved context configuration with inheritance
 func (fp *FileConfigProvider) resolveContext(name string, rawContext *Context) *config.ContextConfig {
 	resolved := &config.ContextConfig{
-		Name:    name,
-		Account: rawContext.Account,
-		Region:  rawContext.Region,
-		Tags:    fp.copyStringMap(rawContext.Tags),
+		Name:            name,
+		Account:         rawContext.Account,
+		Region:          rawContext.Region,
+		Tags:            fp.copyStringMap(rawContext.Tags),
+		Exports:         rawContext.Exports,
+		Protected:       rawContext.Protected,
+		StackNamePrefix: rawContext.StackNamePrefix,
+		StackNameSuffix: rawContext.StackNameSuffix,
 	}
 
 	// Apply global defaults if not overridden
 	if resolved.Region == "" {
 		resolved.Region = fp.rawConfig.Region
 	}
+	if resolved.StackNamePrefix == "" {
+		resolved.StackNamePrefix = fp.rawConfig.StackNamePrefix
+	}
+	if resolved.StackNameSuffix == "" {
+		resolved.StackNameSuffix = fp.rawConfig.StackNameSuffix
+	}
 
 	// Merge global tags with context tags (context takes precedence)
 	if fp.rawConfig.Tags != nil {
@@ -213,6 +277,36 @@ func (fp *FileConfigProvider) resolveContext(name string, rawContext *Context) *
 	return resolved
 }
 
+// contextValuesFile returns the URI of the context's conventional values file, <context>.values.yaml
+// beside the configuration file or inside the configuration directory, or an empty string when a
+// local configuration has no such file.
+// A remote configuration cannot be checked without fetching, so its values file must exist.
+func (fp *FileConfigProvider) contextValuesFile(context string) (string, error) {
+	valuesPath := context + ".values.yaml"
+	if fp.directory {
+		valuesPath = filepath.Join(filepath.Base(fp.filename), valuesPath)
+	}
+	uri, err := fp.resolveValuesFileURI(valuesPath)
+	if err != nil {
+		return "", fmt.Errorf("values file for context '%s': %w", context, err)
+	}
+	if fp.fetcher != nil {
+		return uri, nil
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("values file for context '%s': %w", context, err)
+	}
+	if _, err := os.Stat(parsed.Path); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("values file for context '%s': %w", context, err)
+	}
+	return uri, nil
+}
+
 // resolveStacks resolves all stacks for the given context
 func (fp *FileConfigProvider) resolveStacks(context string) ([]*config.StackConfig, error) {
 	resolved := make([]*config.StackConfig, 0, len(fp.rawConfig.Stacks))
@@ -236,18 +330,46 @@ func (fp *FileConfigProvider) resolveStack(stackName string, rawStack *Stack, co
 		return nil, fmt.Errorf("failed to convert parameters for stack '%s': %w", stackName, err)
 	}
 
-	templateURI, err := fp.resolveTemplateURI(rawStack.Template)
-	if err != nil {
-		return nil, fmt.Errorf("invalid template path for stack '%s': %w", stackName, err)
+	// Use the context-specific template if one is set
+	template := rawStack.Template
+	if contextOverride, exists := rawStack.Contexts[context]; exists && contextOverride != nil && contextOverride.Template != "" {
+		template = contextOverride.Template
+	}
+
+	// A stack without a template is updated using its deployed template
+	var templateURI string
+	if template != "" {
+		templateURI, err = fp.resolveTemplateURI(template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template path for stack '%s': %w", stackName, err)
+		}
 	}
 
 	resolved := &config.StackConfig{
-		Name:         stackName,
-		Template:     templateURI,
-		Parameters:   parameters,
-		Tags:         fp.copyStringMap(rawStack.Tags),
-		Dependencies: fp.copyStringSlice(rawStack.Dependencies),
-		Capabilities: fp.copyStringSlice(rawStack.Capabilities),
+		Name:                  stackName,
+		Template:              templateURI,
+		Parameters:            parameters,
+		Tags:                  fp.copyStringMap(rawStack.Tags),
+		Dependencies:          fp.copyStringSlice(rawStack.Dependencies),
+		Capabilities:          fp.copyStringSlice(rawStack.Capabilities),
+		TerminationProtection: rawStack.TerminationProtection,
+		NotificationARNs:      fp.copyStringSlice(rawStack.NotificationARNs),
+		AllowedResourceTypes:  fp.copyStringSlice(rawStack.AllowedResourceTypes),
+		IgnoreProperties:      fp.copyStringSlice(rawStack.IgnoreProperties),
+		OnFailure:             rawStack.OnFailure,
+		TimeoutInMinutes:      rawStack.TimeoutMinutes,
+		Rollback:              fp.convertRollback(rawStack.Rollback),
+		AWSOptions:            rawStack.AWSOptions,
+		Priority:              rawStack.Priority,
+		RequiredApprovals:     rawStack.RequiredApprovals,
+	}
+
+	if rawStack.StackPolicy != "" {
+		policyURI, err := fp.resolveTemplateURI(rawStack.StackPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stack policy path for stack '%s': %w", stackName, err)
+		}
+		resolved.StackPolicy = policyURI
 	}
 
 	// Apply context-specific overrides if they exist
@@ -286,6 +408,35 @@ func (fp *FileConfigProvider) resolveStack(stackName string, rawStack *Stack, co
 		if contextOverride.Capabilities != nil {
 			resolved.Capabilities = fp.copyStringSlice(contextOverride.Capabilities)
 		}
+
+		// Override termination protection if specified
+		if contextOverride.TerminationProtection != nil {
+			resolved.TerminationProtection = contextOverride.TerminationProtection
+		}
+
+		// Override notification ARNs if specified
+		if contextOverride.NotificationARNs != nil {
+			resolved.NotificationARNs = fp.copyStringSlice(contextOverride.NotificationARNs)
+		}
+
+		// Override required approvals if specified
+		if contextOverride.RequiredApprovals != nil {
+			resolved.RequiredApprovals = *contextOverride.RequiredApprovals
+		}
+
+		// Turn the stack off in this context if requested
+		if contextOverride.Enabled != nil {
+			resolved.Disabled = !*contextOverride.Enabled
+		}
+	}
+
+	// Expand ${...} tokens in literal parameter values and tags for this context
+	subs := fp.contextSubstitutions(context)
+	if err := subs.expandParameters(resolved.Parameters); err != nil {
+		return nil, fmt.Errorf("stack '%s': %w", stackName, err)
+	}
+	if err := subs.expandTags(resolved.Tags); err != nil {
+		return nil, fmt.Errorf("stack '%s': %w", stackName, err)
 	}
 
 	return resolved, nil
@@ -356,8 +507,24 @@ func (fp *FileConfigProvider) resolveTemplatePath(templatePath string) (string,
 	return candidate, nil
 }
 
-// resolveTemplateURI resolves template path to file:// URI relative to the allowed root.
+// resolveTemplateURI resolves template path to file:// URI relative to the allowed root,
+// or to an https:// URI when the configuration is remote. s3:// URIs are used as given.
 func (fp *FileConfigProvider) resolveTemplateURI(templatePath string) (string, error) {
+	if isS3URI(templatePath) {
+		return templatePath, nil
+	}
+	if fp.fetcher != nil {
+		root, err := fp.remoteTemplateRoot()
+		if err != nil {
+			return "", err
+		}
+		resolved, err := resolveRemoteURI(root, templatePath)
+		if err != nil {
+			return "", fmt.Errorf("template %w", err)
+		}
+		return resolved.String(), nil
+	}
+
 	resolvedPath, err := fp.resolveTemplatePath(templatePath)
 	if err != nil {
 		return "", err
@@ -365,6 +532,11 @@ func (fp *FileConfigProvider) resolveTemplateURI(templatePath string) (string, e
 	return (&url.URL{Scheme: "file", Path: resolvedPath}).String(), nil
 }
 
+// isS3URI reports whether a template refers to an S3 object rather than a file
+func isS3URI(templatePath string) bool {
+	return strings.HasPrefix(templatePath, "s3://")
+}
+
 // Helper methods for copying maps and slices to avoid shared references
 
 func (fp *FileConfigProvider) copyStringMap(source map[string]string) map[string]string {
@@ -379,6 +551,17 @@ func (fp *FileConfigProvider) copyStringMap(source map[string]string) map[string
 	return result
 }
 
+// convertRollback converts YAML rollback triggers to config form
+func (fp *FileConfigProvider) convertRollback(rollback *Rollback) *config.RollbackConfig {
+	if rollback == nil {
+		return nil
+	}
+	return &config.RollbackConfig{
+		AlarmARNs:               fp.copyStringSlice(rollback.Alarms),
+		MonitoringTimeInMinutes: rollback.MonitoringMinutes,
+	}
+}
+
 // convertParameters converts yamlParameterValue map to config.ParameterValue map
 func (fp *FileConfigProvider) convertParameters(params map[string]*yamlParameterValue) (map[string]*config.ParameterValue, error) {
 	if params == nil {
@@ -397,6 +580,9 @@ func (fp *FileConfigProvider) convertParameters(params map[string]*yamlParameter
 		if configParam == nil {
 			return nil, fmt.Errorf("failed to convert parameter '%s' to config parameter value", key)
 		}
+		if err := fp.resolveValuesFilePaths(configParam); err != nil {
+			return nil, fmt.Errorf("parameter '%s': %w", key, err)
+		}
 
 		result[key] = configParam
 	}
@@ -404,6 +590,74 @@ func (fp *FileConfigProvider) convertParameters(params map[string]*yamlParameter
 	return result, nil
 }
 
+// resolveValuesFilePaths turns the paths of file parameters, including list items, into file:// URIs
+func (fp *FileConfigProvider) resolveValuesFilePaths(param *config.ParameterValue) error {
+	for _, item := range param.ListItems {
+		if item == nil {
+			continue
+		}
+		if err := fp.resolveValuesFilePaths(item); err != nil {
+			return err
+		}
+	}
+
+	if param.ResolutionType != "file" || param.ResolutionConfig["path"] == "" {
+		return nil
+	}
+	uri, err := fp.resolveValuesFileURI(param.ResolutionConfig["path"])
+	if err != nil {
+		return err
+	}
+	param.ResolutionConfig["path"] = uri
+	return nil
+}
+
+// resolveValuesFileURI resolves a values file path relative to the config file's directory to a file:// URI.
+// Absolute paths and traversal outside that directory are rejected.
+func (fp *FileConfigProvider) resolveValuesFileURI(valuesPath string) (string, error) {
+	if fp.fetcher != nil {
+		configDir, err := remoteConfigDir(fp.filename)
+		if err != nil {
+			return "", err
+		}
+		resolved, err := resolveRemoteURI(configDir, valuesPath)
+		if err != nil {
+			return "", fmt.Errorf("values file %w", err)
+		}
+		return resolved.String(), nil
+	}
+
+	if filepath.IsAbs(valuesPath) {
+		return "", fmt.Errorf("values file path must be relative: %s", valuesPath)
+	}
+
+	configDir, err := filepath.Abs(filepath.Dir(fp.filename))
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve config directory: %w", err)
+	}
+
+	candidate := filepath.Clean(filepath.Join(configDir, valuesPath))
+	rel, err := filepath.Rel(configDir, candidate)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("values file path escapes config directory: %s", valuesPath)
+	}
+
+	// If the file already exists, resolve symlinks and re-verify confinement
+	if real, err := filepath.EvalSymlinks(candidate); err == nil {
+		realConfigDir := configDir
+		if r, err := filepath.EvalSymlinks(configDir); err == nil {
+			realConfigDir = r
+		}
+		rel, err := filepath.Rel(realConfigDir, real)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("values file path escapes config directory via symlink: %s", valuesPath)
+		}
+		candidate = real
+	}
+
+	return (&url.URL{Scheme: "file", Path: candidate}).String(), nil
+}
+
 func (fp *FileConfigProvider) copyStringSlice(source []string) []string {
 	if source == nil {
 		return nil
@@ -6,6 +6,7 @@ package file
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -114,6 +115,218 @@ stacks:
 	assert.ElementsMatch(t, expected, contexts, "should return all defined contexts")
 }
 
+func TestFileProvider_LoadConfig_ContextExports(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-west-2
+    exports: false
+  prod:
+    region: us-east-1
+
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	devConfig, err := provider.LoadConfig(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.False(t, devConfig.Context.ExportsEnabled(), "exports should be disabled when set to false")
+
+	prodConfig, err := provider.LoadConfig(context.Background(), "prod")
+	require.NoError(t, err)
+	assert.True(t, prodConfig.Context.ExportsEnabled(), "exports should default to enabled")
+}
+
+func TestFileProvider_LoadConfig_ContextProtected(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-west-2
+  prod:
+    region: us-east-1
+    protected: true
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	devConfig, err := provider.LoadConfig(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.False(t, devConfig.Context.Protected, "contexts should not be protected by default")
+
+	prodConfig, err := provider.LoadConfig(context.Background(), "prod")
+	require.NoError(t, err)
+	assert.True(t, prodConfig.Context.Protected)
+}
+
+func TestFileProvider_LoadConfig_MandatoryTags(t *testing.T) {
+	configContent := `
+project: test-project
+
+tags:
+  Owner: platform-team
+
+mandatory_tags:
+  DataClassification: internal
+  Environment: ${context}
+
+contexts:
+  dev:
+    region: us-west-2
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	cfg, err := provider.LoadConfig(context.Background(), "dev")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Owner": "platform-team"}, cfg.Tags)
+	assert.Equal(t, map[string]string{"DataClassification": "internal", "Environment": "dev"}, cfg.MandatoryTags)
+}
+
+func TestFileProvider_LoadConfig_StackNamePrefixAndSuffix(t *testing.T) {
+	configContent := `
+project: test-project
+stack_name_prefix: acme-
+stack_name_suffix: -v1
+
+contexts:
+  dev:
+    region: us-west-2
+  prod:
+    region: us-east-1
+    stack_name_prefix: prod-
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	devConfig, err := provider.LoadConfig(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "acme-vpc-v1", devConfig.Context.DeployedStackName("vpc"), "contexts should inherit the global prefix and suffix")
+
+	prodConfig, err := provider.LoadConfig(context.Background(), "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "prod-vpc-v1", prodConfig.Context.DeployedStackName("vpc"))
+}
+
+func TestFileProvider_LoadConfig_ContextValuesFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		expected bool
+	}{
+		{name: "enabled picks up the context values file", enabled: true, expected: true},
+		{name: "disabled ignores the context values file", enabled: false, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configContent := fmt.Sprintf(`
+project: test-project
+context_values_files: %t
+
+contexts:
+  dev:
+    region: us-west-2
+  prod:
+    region: us-east-1
+`, tt.enabled)
+
+			tmpFile := createTempConfigFile(t, configContent)
+			valuesPath := filepath.Join(filepath.Dir(tmpFile), "dev.values.yaml")
+			require.NoError(t, os.WriteFile(valuesPath, []byte("InstanceType: t3.small\n"), 0644))
+			provider := NewFileConfigProvider(tmpFile)
+
+			devConfig, err := provider.LoadConfig(context.Background(), "dev")
+			require.NoError(t, err)
+			if tt.expected {
+				realPath, err := filepath.EvalSymlinks(valuesPath)
+				require.NoError(t, err)
+				assert.Equal(t, "file://"+realPath, devConfig.Context.ValuesFile)
+			} else {
+				assert.Empty(t, devConfig.Context.ValuesFile)
+			}
+
+			// A context without a values file has none to load
+			prodConfig, err := provider.LoadConfig(context.Background(), "prod")
+			require.NoError(t, err)
+			assert.Empty(t, prodConfig.Context.ValuesFile)
+		})
+	}
+}
+
+func TestFileProvider_GetStack_ResolvesValuesFilePaths(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-west-2
+
+stacks:
+  db:
+    template: templates/db.yaml
+    parameters:
+      InstanceClass:
+        type: file
+        path: values/dev.yaml
+        key: database.instance_class
+      Zones:
+        - type: file
+          path: values/dev.yaml
+          key: database.zone
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	stack, err := provider.GetStack("db", "dev")
+	require.NoError(t, err)
+
+	configDir, err := filepath.Abs(filepath.Dir(tmpFile))
+	require.NoError(t, err)
+	expectedURI := "file://" + filepath.Join(configDir, "values", "dev.yaml")
+	assert.Equal(t, map[string]string{"path": expectedURI, "key": "database.instance_class"}, stack.Parameters["InstanceClass"].ResolutionConfig)
+	assert.Equal(t, expectedURI, stack.Parameters["Zones"].ListItems[0].ResolutionConfig["path"])
+}
+
+func TestFileProvider_GetStack_RejectsValuesFileOutsideConfigDirectory(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-west-2
+
+stacks:
+  db:
+    template: templates/db.yaml
+    parameters:
+      InstanceClass:
+        type: file
+        path: ../secrets.yaml
+        key: database.instance_class
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	_, err := provider.GetStack("db", "dev")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "values file path escapes config directory: ../secrets.yaml")
+}
+
 func TestFileProvider_GetStack_ReturnsStackWithContextOverrides(t *testing.T) {
 	// Test that GetStack returns stack configuration with context-specific overrides applied
 	configContent := `
@@ -164,6 +377,481 @@ stacks:
 	assert.Equal(t, "production-database", prodStack.Tags["Component"])                               // Overridden
 }
 
+func TestFileProvider_GetStack_ContextTemplateOverride(t *testing.T) {
+	// Test that a context can select a different template, falling back to the stack template
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-west-2
+  prod:
+    region: us-east-1
+
+stacks:
+  database:
+    template: templates/rds.yaml
+    contexts:
+      prod:
+        template: templates/rds-multi-az.yaml
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	devStack, err := provider.GetStack("database", "dev")
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(devStack.Template, "templates/rds.yaml"))
+
+	prodStack, err := provider.GetStack("database", "prod")
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(prodStack.Template, "templates/rds-multi-az.yaml"))
+}
+
+func TestFileProvider_GetStack_RequiredApprovals(t *testing.T) {
+	// Test that required approvals can be set per stack and overridden per context
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-west-2
+  prod:
+    region: us-east-1
+
+stacks:
+  database:
+    template: templates/rds.yaml
+    required_approvals: 1
+    contexts:
+      prod:
+        required_approvals: 2
+  app:
+    template: templates/app.yaml
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	devStack, err := provider.GetStack("database", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, 1, devStack.RequiredApprovals)
+
+	prodStack, err := provider.GetStack("database", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, 2, prodStack.RequiredApprovals)
+
+	appStack, err := provider.GetStack("app", "prod")
+	require.NoError(t, err)
+	assert.Zero(t, appStack.RequiredApprovals, "stacks should not require approvals by default")
+}
+
+func TestFileProvider_GetStack_EnabledPerContext(t *testing.T) {
+	// Test that a stack can be turned off in one context and left on in others
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-west-2
+  prod:
+    region: us-east-1
+
+stacks:
+  monitoring:
+    template: templates/monitoring.yaml
+    contexts:
+      dev:
+        enabled: false
+      prod:
+        enabled: true
+  app:
+    template: templates/app.yaml
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	devStack, err := provider.GetStack("monitoring", "dev")
+	require.NoError(t, err)
+	assert.True(t, devStack.Disabled)
+
+	prodStack, err := provider.GetStack("monitoring", "prod")
+	require.NoError(t, err)
+	assert.False(t, prodStack.Disabled)
+
+	appStack, err := provider.GetStack("app", "dev")
+	require.NoError(t, err)
+	assert.False(t, appStack.Disabled, "stacks should be enabled by default")
+}
+
+func TestFileProvider_GetStack_TerminationProtection(t *testing.T) {
+	// Test that termination protection is optional and can be overridden per context
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-west-2
+  prod:
+    region: us-east-1
+
+stacks:
+  database:
+    template: templates/rds.yaml
+    termination_protection: false
+    contexts:
+      prod:
+        termination_protection: true
+  cache:
+    template: templates/cache.yaml
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	devStack, err := provider.GetStack("database", "dev")
+	require.NoError(t, err)
+	require.NotNil(t, devStack.TerminationProtection)
+	assert.False(t, *devStack.TerminationProtection)
+
+	prodStack, err := provider.GetStack("database", "prod")
+	require.NoError(t, err)
+	require.NotNil(t, prodStack.TerminationProtection)
+	assert.True(t, *prodStack.TerminationProtection)
+
+	cacheStack, err := provider.GetStack("cache", "prod")
+	require.NoError(t, err)
+	assert.Nil(t, cacheStack.TerminationProtection)
+}
+
+func TestFileProvider_GetStack_StackPolicy(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  prod:
+    region: us-east-1
+
+stacks:
+  database:
+    template: templates/rds.yaml
+    stack_policy: policies/rds.json
+    on_failure: DO_NOTHING
+    timeout_minutes: 30
+  cache:
+    template: templates/cache.yaml
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	database, err := provider.GetStack("database", "prod")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(database.StackPolicy, "file://"))
+	assert.True(t, strings.HasSuffix(database.StackPolicy, "policies/rds.json"))
+	assert.Equal(t, "DO_NOTHING", database.OnFailure)
+	require.NotNil(t, database.TimeoutInMinutes)
+	assert.Equal(t, int32(30), *database.TimeoutInMinutes)
+
+	cache, err := provider.GetStack("cache", "prod")
+	require.NoError(t, err)
+	assert.Empty(t, cache.StackPolicy)
+}
+
+func TestFileProvider_GetStack_NotificationARNs(t *testing.T) {
+	// Test that a context's notification ARNs replace the stack-level list
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-west-2
+  prod:
+    region: us-east-1
+
+stacks:
+  database:
+    template: templates/rds.yaml
+    notification_arns:
+      - arn:aws:sns:us-east-1:123456789012:stack-events
+    contexts:
+      prod:
+        notification_arns:
+          - arn:aws:sns:us-east-1:123456789012:prod-events
+          - arn:aws:sns:us-east-1:123456789012:pager
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	devStack, err := provider.GetStack("database", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"arn:aws:sns:us-east-1:123456789012:stack-events"}, devStack.NotificationARNs)
+
+	prodStack, err := provider.GetStack("database", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"arn:aws:sns:us-east-1:123456789012:prod-events",
+		"arn:aws:sns:us-east-1:123456789012:pager",
+	}, prodStack.NotificationARNs)
+}
+
+func TestFileProvider_GetStack_Rollback(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  prod:
+    region: us-east-1
+
+stacks:
+  api:
+    template: templates/api.yaml
+    rollback:
+      alarms:
+        - arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-5xx
+        - arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-latency
+      monitoring_minutes: 15
+  worker:
+    template: templates/worker.yaml
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	apiStack, err := provider.GetStack("api", "prod")
+	require.NoError(t, err)
+	require.NotNil(t, apiStack.Rollback)
+	assert.Equal(t, []string{
+		"arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-5xx",
+		"arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-latency",
+	}, apiStack.Rollback.AlarmARNs)
+	require.NotNil(t, apiStack.Rollback.MonitoringTimeInMinutes)
+	assert.Equal(t, int32(15), *apiStack.Rollback.MonitoringTimeInMinutes)
+
+	workerStack, err := provider.GetStack("worker", "prod")
+	require.NoError(t, err)
+	assert.Nil(t, workerStack.Rollback)
+}
+
+func TestFileProvider_GetStack_AllowedResourceTypes(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  prod:
+    region: us-east-1
+
+stacks:
+  queues:
+    template: templates/queues.yaml
+    allowed_resource_types:
+      - AWS::S3::*
+      - AWS::SQS::*
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	stack, err := provider.GetStack("queues", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AWS::S3::*", "AWS::SQS::*"}, stack.AllowedResourceTypes)
+}
+
+func TestFileProvider_GetStack_IgnoreProperties(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  prod:
+    region: us-east-1
+
+stacks:
+  assets:
+    template: templates/assets.yaml
+    ignore_properties:
+      - Resources.*.Metadata.BuildTime
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	stack, err := provider.GetStack("assets", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Resources.*.Metadata.BuildTime"}, stack.IgnoreProperties)
+}
+
+func TestFileProvider_GetStack_WithoutTemplate(t *testing.T) {
+	// Test that a stack may omit its template to reuse the deployed one
+	configContent := `
+project: test-project
+
+contexts:
+  prod:
+    region: us-east-1
+
+stacks:
+  legacy:
+    parameters:
+      InstanceType: t3.small
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	require.NoError(t, provider.Validate())
+
+	stack, err := provider.GetStack("legacy", "prod")
+	require.NoError(t, err)
+	assert.Empty(t, stack.Template)
+	assert.Equal(t, "t3.small", stack.Parameters["InstanceType"].ResolutionConfig["value"])
+}
+
+func TestFileProvider_GetStack_S3Template(t *testing.T) {
+	// Test that s3:// templates are passed through and not checked on disk
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-east-1
+  prod:
+    region: us-east-1
+
+stacks:
+  vpc:
+    template: s3://templates/network/vpc.yaml
+    contexts:
+      prod:
+        template: s3://templates/network/vpc-prod.yaml
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	require.NoError(t, provider.Validate())
+
+	stack, err := provider.GetStack("vpc", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "s3://templates/network/vpc.yaml", stack.Template)
+
+	stack, err = provider.GetStack("vpc", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "s3://templates/network/vpc-prod.yaml", stack.Template)
+}
+
+func TestFileProvider_GetStack_AWSOptions(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  prod:
+    region: us-east-1
+
+stacks:
+  database:
+    template: templates/rds.yaml
+    aws_options:
+      ResourceTypes:
+        - AWS::RDS::*
+      EnableTerminationProtection: true
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	stack, err := provider.GetStack("database", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"ResourceTypes":               []interface{}{"AWS::RDS::*"},
+		"EnableTerminationProtection": true,
+	}, stack.AWSOptions)
+}
+
+func TestFileProvider_GetStack_Priority(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  prod:
+    region: us-east-1
+
+stacks:
+  monitoring:
+    template: templates/monitoring.yaml
+    priority: 10
+  app:
+    template: templates/app.yaml
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	monitoring, err := provider.GetStack("monitoring", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, 10, monitoring.Priority)
+
+	app, err := provider.GetStack("app", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, 0, app.Priority)
+}
+
+func TestFileProvider_Validate_ChecksStackPolicyExists(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  prod:
+    region: us-east-1
+
+stacks:
+  database:
+    template: templates/rds.yaml
+    stack_policy: policies/missing.json
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	templateDir := filepath.Join(filepath.Dir(tmpFile), "templates")
+	require.NoError(t, os.MkdirAll(templateDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templateDir, "rds.yaml"), []byte("Resources: {}"), 0644))
+
+	provider := NewFileConfigProvider(tmpFile)
+	err := provider.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stack policy file not found for stack 'database'")
+}
+
+func TestFileProvider_Validate_ChecksContextTemplateExists(t *testing.T) {
+	// Test that validation reports a missing context-specific template
+	configContent := `
+project: test-project
+
+contexts:
+  prod:
+    region: us-east-1
+
+stacks:
+  database:
+    template: templates/rds.yaml
+    contexts:
+      prod:
+        template: templates/missing.yaml
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	templatesDir := filepath.Join(filepath.Dir(tmpFile), "templates")
+	require.NoError(t, os.MkdirAll(templatesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "rds.yaml"), []byte("Resources: {}"), 0644))
+
+	provider := NewFileConfigProvider(tmpFile)
+	err := provider.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "template file not found for stack 'database' in context 'prod'")
+}
+
 func TestFileProvider_Validate_DetectsInvalidConfiguration(t *testing.T) {
 	// Test that Validate catches common configuration errors
 	invalidConfigContent := `
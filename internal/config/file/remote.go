@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package file
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// ConfigAuthHeaderEnv names the environment variable holding an optional header, such as
+// "Authorization: Bearer <token>", sent with every request for a remote configuration
+const ConfigAuthHeaderEnv = "STACKAROO_CONFIG_AUTH_HEADER"
+
+// remoteFetchTimeout bounds each request for a remote configuration, template or values file
+const remoteFetchTimeout = 30 * time.Second
+
+// IsRemoteConfig reports whether a --config location is an HTTPS URL rather than a local file
+func IsRemoteConfig(location string) bool {
+	return strings.HasPrefix(strings.ToLower(location), "https://")
+}
+
+// NewConfigProvider creates a ConfigProvider for a local file, a local directory or an https:// URL.
+// Remote configurations send the header from STACKAROO_CONFIG_AUTH_HEADER when it is set. When
+// stackaroo.yaml does not exist but a stackaroo directory beside it does, the directory is loaded.
+func NewConfigProvider(location string) *FileConfigProvider {
+	if IsRemoteConfig(location) {
+		client := &http.Client{Timeout: remoteFetchTimeout}
+		return NewHTTPConfigProvider(location, client, os.Getenv(ConfigAuthHeaderEnv))
+	}
+	if dir := discoverConfigDirectory(location); dir != "" {
+		return NewDirectoryProvider(dir)
+	}
+	return NewFileConfigProvider(location)
+}
+
+// NewHTTPConfigProvider creates a ConfigProvider that reads its configuration from an HTTPS URL.
+// Templates and values files are resolved relative to the directory of that URL and fetched the same way.
+func NewHTTPConfigProvider(configURL string, client *http.Client, authHeader string) *FileConfigProvider {
+	return &FileConfigProvider{
+		filename: configURL,
+		fetcher:  &HTTPFetcher{client: client, authHeader: authHeader},
+	}
+}
+
+// HTTPFetcher reads remote configuration, templates and values files over HTTPS
+type HTTPFetcher struct {
+	client     *http.Client
+	authHeader string
+}
+
+// Resolve reads the content at an https:// URI, so the fetcher can stand in for the template file reader
+func (f *HTTPFetcher) Resolve(uri string) (string, error) {
+	content, err := f.fetch(uri)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// fetch reads the body at an https:// URI, sending the configured auth header
+func (f *HTTPFetcher) fetch(uri string) ([]byte, error) {
+	if !IsRemoteConfig(uri) {
+		return nil, fmt.Errorf("remote URI must start with https://, got: %s", uri)
+	}
+
+	request, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote URI %s: %w", uri, err)
+	}
+	if f.authHeader != "" {
+		name, value, found := strings.Cut(f.authHeader, ":")
+		if !found || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%s must have the form 'Header-Name: value'", ConfigAuthHeaderEnv)
+		}
+		request.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	response, err := f.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", uri, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to fetch %s: server returned %s", uri, response.Status)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", uri, err)
+	}
+	return body, nil
+}
+
+// remoteConfigDir returns the URL of the directory holding a remote configuration, with a trailing slash
+func remoteConfigDir(configURL string) (*url.URL, error) {
+	parsed, err := url.Parse(configURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config URL %s: %w", configURL, err)
+	}
+
+	dir := &url.URL{Scheme: parsed.Scheme, Host: parsed.Host, Path: path.Dir(parsed.Path)}
+	if !strings.HasSuffix(dir.Path, "/") {
+		dir.Path += "/"
+	}
+	return dir, nil
+}
+
+// resolveRemoteURI resolves a relative path against a remote directory.
+// Absolute paths, URLs and traversal outside the directory are rejected.
+func resolveRemoteURI(root *url.URL, relativePath string) (*url.URL, error) {
+	ref, err := url.Parse(relativePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %s: %w", relativePath, err)
+	}
+	if ref.Scheme != "" || ref.Host != "" || strings.HasPrefix(ref.Path, "/") {
+		return nil, fmt.Errorf("path must be relative: %s", relativePath)
+	}
+
+	resolved := root.ResolveReference(ref)
+	if !strings.HasPrefix(resolved.Path, root.Path) {
+		return nil, fmt.Errorf("path escapes config directory: %s", relativePath)
+	}
+	return resolved, nil
+}
+
+// remoteTemplateRoot returns the directory remote templates are resolved against:
+// templates.directory if set, otherwise the directory of the configuration
+func (fp *FileConfigProvider) remoteTemplateRoot() (*url.URL, error) {
+	configDir, err := remoteConfigDir(fp.filename)
+	if err != nil {
+		return nil, err
+	}
+	if fp.rawConfig == nil || fp.rawConfig.Templates == nil || fp.rawConfig.Templates.Directory == "" {
+		return configDir, nil
+	}
+
+	templateDir, err := resolveRemoteURI(configDir, fp.rawConfig.Templates.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("invalid templates directory: %w", err)
+	}
+	if !strings.HasSuffix(templateDir.Path, "/") {
+		templateDir.Path += "/"
+	}
+	return templateDir, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package file
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const remoteConfigYAML = `
+project: remote-project
+region: us-east-1
+contexts:
+  dev:
+    account: "123456789012"
+stacks:
+  vpc:
+    template: templates/vpc.yaml
+    parameters:
+      CidrBlock:
+        type: file
+        path: values/dev.yaml
+        key: vpc.cidr
+`
+
+// newConfigServer serves a configuration and its template under /infra, recording the auth header it receives
+func newConfigServer(t *testing.T) (*httptest.Server, *string) {
+	t.Helper()
+
+	var authorization string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/infra/stackaroo.yaml", func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(remoteConfigYAML))
+	})
+	mux.HandleFunc("/infra/templates/vpc.yaml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("Resources: {}\n"))
+	})
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+	return server, &authorization
+}
+
+func TestHTTPConfigProvider_LoadConfig_ResolvesRelativeToBaseURL(t *testing.T) {
+	server, authorization := newConfigServer(t)
+	provider := NewHTTPConfigProvider(server.URL+"/infra/stackaroo.yaml", server.Client(), "Authorization: Bearer secret-token")
+
+	cfg, err := provider.LoadConfig(context.Background(), "dev")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer secret-token", *authorization)
+	assert.Equal(t, "remote-project", cfg.Project)
+	assert.Equal(t, "123456789012", cfg.Context.Account)
+	require.Len(t, cfg.Stacks, 1)
+	assert.Equal(t, server.URL+"/infra/templates/vpc.yaml", cfg.Stacks[0].Template)
+	assert.Equal(t, server.URL+"/infra/values/dev.yaml", cfg.Stacks[0].Parameters["CidrBlock"].ResolutionConfig["path"])
+}
+
+func TestHTTPConfigProvider_RemoteFetcher_ReadsTemplates(t *testing.T) {
+	server, _ := newConfigServer(t)
+	provider := NewHTTPConfigProvider(server.URL+"/infra/stackaroo.yaml", server.Client(), "")
+
+	stack, err := provider.GetStack("vpc", "dev")
+	require.NoError(t, err)
+
+	fetcher := provider.RemoteFetcher()
+	require.NotNil(t, fetcher)
+	content, err := fetcher.Resolve(stack.Template)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Resources: {}\n", content)
+}
+
+func TestHTTPConfigProvider_LoadConfig_ServerError(t *testing.T) {
+	server, _ := newConfigServer(t)
+	provider := NewHTTPConfigProvider(server.URL+"/infra/missing.yaml", server.Client(), "")
+
+	_, err := provider.LoadConfig(context.Background(), "dev")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404 Not Found")
+}
+
+func TestHTTPConfigProvider_LoadConfig_InvalidAuthHeader(t *testing.T) {
+	server, _ := newConfigServer(t)
+	provider := NewHTTPConfigProvider(server.URL+"/infra/stackaroo.yaml", server.Client(), "secret-token")
+
+	_, err := provider.LoadConfig(context.Background(), "dev")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ConfigAuthHeaderEnv)
+}
+
+func TestFileConfigProvider_RemoteFetcher_NilForLocalFile(t *testing.T) {
+	assert.Nil(t, NewConfigProvider("stackaroo.yaml").RemoteFetcher())
+	assert.NotNil(t, NewConfigProvider("https://config.example.com/stackaroo.yaml").RemoteFetcher())
+}
+
+func TestResolveRemoteURI(t *testing.T) {
+	root, err := remoteConfigDir("https://config.example.com/infra/stackaroo.yaml")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		errorMsg string
+	}{
+		{name: "relative path", path: "templates/vpc.yaml", expected: "https://config.example.com/infra/templates/vpc.yaml"},
+		{name: "parent segment inside root", path: "templates/../vpc.yaml", expected: "https://config.example.com/infra/vpc.yaml"},
+		{name: "escapes root", path: "../secrets.yaml", errorMsg: "path escapes config directory"},
+		{name: "absolute path", path: "/etc/passwd", errorMsg: "path must be relative"},
+		{name: "other host", path: "https://attacker.example.com/vpc.yaml", errorMsg: "path must be relative"},
+		{name: "scheme-relative host", path: "//attacker.example.com/vpc.yaml", errorMsg: "path must be relative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := resolveRemoteURI(root, tt.path)
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved.String())
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package file
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/config"
+)
+
+// substitutions holds the values of the ${...} tokens that literal parameter values and tags may reference.
+// This is separate from template processing, which operates on CloudFormation templates.
+type substitutions struct {
+	context string
+	account string
+	region  string
+}
+
+// contextSubstitutions returns the substitutions for the named context, with its region inherited
+// from the global default when unset
+func (fp *FileConfigProvider) contextSubstitutions(context string) substitutions {
+	subs := substitutions{context: context, region: fp.rawConfig.Region}
+	if rawContext, exists := fp.rawConfig.Contexts[context]; exists && rawContext != nil {
+		subs.account = rawContext.Account
+		if rawContext.Region != "" {
+			subs.region = rawContext.Region
+		}
+	}
+	return subs
+}
+
+// expand replaces ${context}, ${account}, ${region} and ${env:VAR} tokens in value.
+// $$ produces a literal $, and a $ not followed by { or $ is left as it is.
+func (s substitutions) expand(value string) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+
+		switch value[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(value[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated substitution in '%s'", value)
+			}
+			token := value[i+2 : i+2+end]
+			replacement, err := s.lookup(token)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(replacement)
+			i += end + 2
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
+
+// lookup returns the value of a single token
+func (s substitutions) lookup(token string) (string, error) {
+	switch token {
+	case "context":
+		return s.context, nil
+	case "account":
+		if s.account == "" {
+			return "", fmt.Errorf("${account} is used but context '%s' has no account", s.context)
+		}
+		return s.account, nil
+	case "region":
+		if s.region == "" {
+			return "", fmt.Errorf("${region} is used but context '%s' has no region", s.context)
+		}
+		return s.region, nil
+	}
+
+	if name, ok := strings.CutPrefix(token, "env:"); ok {
+		value, set := os.LookupEnv(name)
+		if !set {
+			return "", fmt.Errorf("environment variable '%s' is not set", name)
+		}
+		return value, nil
+	}
+
+	return "", fmt.Errorf("unknown substitution '${%s}'", token)
+}
+
+// expandTags expands the tokens in each tag value in place
+func (s substitutions) expandTags(tags map[string]string) error {
+	for key, value := range tags {
+		expanded, err := s.expand(value)
+		if err != nil {
+			return fmt.Errorf("tag '%s': %w", key, err)
+		}
+		tags[key] = expanded
+	}
+	return nil
+}
+
+// expandParameters expands the tokens in literal parameter values, including list items, in place
+func (s substitutions) expandParameters(params map[string]*config.ParameterValue) error {
+	for key, param := range params {
+		if err := s.expandParameter(param); err != nil {
+			return fmt.Errorf("parameter '%s': %w", key, err)
+		}
+	}
+	return nil
+}
+
+// expandParameter expands the tokens in a literal parameter value or the literal items of a list
+func (s substitutions) expandParameter(param *config.ParameterValue) error {
+	if param == nil {
+		return nil
+	}
+
+	switch param.ResolutionType {
+	case "literal":
+		expanded, err := s.expand(param.ResolutionConfig["value"])
+		if err != nil {
+			return err
+		}
+		param.ResolutionConfig["value"] = expanded
+	case "list":
+		for _, item := range param.ListItems {
+			if err := s.expandParameter(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package file
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstitutions_Expand(t *testing.T) {
+	t.Setenv("STACKAROO_TEST_TEAM", "payments")
+	subs := substitutions{context: "dev", account: "123456789012", region: "us-west-2"}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "context", value: "myapp-${context}", expected: "myapp-dev"},
+		{name: "account", value: "${account}", expected: "123456789012"},
+		{name: "region", value: "logs-${region}", expected: "logs-us-west-2"},
+		{name: "environment variable", value: "team-${env:STACKAROO_TEST_TEAM}", expected: "team-payments"},
+		{name: "several tokens", value: "myapp-${context}-${account}-${region}", expected: "myapp-dev-123456789012-us-west-2"},
+		{name: "escaped dollar", value: "cost $$5", expected: "cost $5"},
+		{name: "escaped token", value: "$${context}", expected: "${context}"},
+		{name: "lone dollar", value: "price $5 or $", expected: "price $5 or $"},
+		{name: "no tokens", value: "plain", expected: "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded, err := subs.expand(tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expanded)
+		})
+	}
+}
+
+func TestSubstitutions_ExpandErrors(t *testing.T) {
+	subs := substitutions{context: "dev"}
+
+	tests := []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{name: "unknown token", value: "${stack}", err: "unknown substitution '${stack}'"},
+		{name: "unterminated", value: "myapp-${context", err: "unterminated substitution in 'myapp-${context'"},
+		{name: "missing account", value: "${account}", err: "${account} is used but context 'dev' has no account"},
+		{name: "missing region", value: "${region}", err: "${region} is used but context 'dev' has no region"},
+		{name: "unset environment variable", value: "${env:STACKAROO_TEST_UNSET}", err: "environment variable 'STACKAROO_TEST_UNSET' is not set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := subs.expand(tt.value)
+
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestFileProvider_LoadConfig_SubstitutesParametersAndTags(t *testing.T) {
+	// Test that tokens in literal parameter values and tags are expanded for the selected context
+	t.Setenv("STACKAROO_TEST_OWNER", "platform")
+	configContent := `
+project: test-project
+region: us-east-1
+tags:
+  Owner: ${env:STACKAROO_TEST_OWNER}
+
+contexts:
+  dev:
+    account: "123456789012"
+    tags:
+      Environment: ${context}
+
+stacks:
+  storage:
+    template: templates/storage.yaml
+    parameters:
+      BucketName: myapp-${context}-${account}
+      Regions:
+        - ${region}
+        - eu-west-1
+      Price: $$5
+      VpcId:
+        type: stack-output
+        stack: network-${context}
+        output: VpcId
+    tags:
+      Region: ${region}
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	cfg, err := provider.LoadConfig(context.Background(), "dev")
+	require.NoError(t, err)
+
+	assert.Equal(t, "platform", cfg.Tags["Owner"])
+	assert.Equal(t, "dev", cfg.Context.Tags["Environment"])
+	assert.Equal(t, "platform", cfg.Context.Tags["Owner"])
+
+	require.Len(t, cfg.Stacks, 1)
+	stack := cfg.Stacks[0]
+	assert.Equal(t, "myapp-dev-123456789012", stack.Parameters["BucketName"].ResolutionConfig["value"])
+	assert.Equal(t, "us-east-1", stack.Parameters["Regions"].ListItems[0].ResolutionConfig["value"])
+	assert.Equal(t, "$5", stack.Parameters["Price"].ResolutionConfig["value"])
+	assert.Equal(t, "network-${context}", stack.Parameters["VpcId"].ResolutionConfig["stack"], "resolver configuration is not substituted")
+	assert.Equal(t, "us-east-1", stack.Tags["Region"])
+}
+
+func TestFileProvider_GetStack_SubstitutionError(t *testing.T) {
+	configContent := `
+project: test-project
+
+contexts:
+  dev:
+    region: us-west-2
+
+stacks:
+  storage:
+    template: templates/storage.yaml
+    parameters:
+      BucketName: myapp-${account}
+`
+
+	tmpFile := createTempConfigFile(t, configContent)
+	provider := NewFileConfigProvider(tmpFile)
+
+	_, err := provider.GetStack("storage", "dev")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parameter 'BucketName': ${account} is used but context 'dev' has no account")
+}
@@ -17,12 +17,17 @@ import (
 // Config represents the raw YAML configuration file structure
 // Used for parsing the stackaroo.yaml file before context resolution
 type Config struct {
-	Project   string              `yaml:"project"`
-	Region    string              `yaml:"region"`
-	Tags      map[string]string   `yaml:"tags"`
-	Templates *Templates          `yaml:"templates"`
-	Contexts  map[string]*Context `yaml:"contexts"`
-	Stacks    map[string]*Stack   `yaml:"stacks"`
+	Includes        []string            `yaml:"includes"` // Files whose tags, contexts and stacks are merged in, relative to this file
+	Project         string              `yaml:"project"`
+	Region          string              `yaml:"region"`
+	Tags            map[string]string   `yaml:"tags"`
+	MandatoryTags   map[string]string   `yaml:"mandatory_tags"` // Applied to every stack last, so no other tag can override them
+	Templates       *Templates          `yaml:"templates"`
+	StackNamePrefix string              `yaml:"stack_name_prefix"`    // Prepended to every stack name deployed to AWS
+	StackNameSuffix string              `yaml:"stack_name_suffix"`    // Appended to every stack name deployed to AWS
+	ContextValues   bool                `yaml:"context_values_files"` // Load <context>.values.yaml beside this file as parameter defaults
+	Contexts        map[string]*Context `yaml:"contexts"`
+	Stacks          map[string]*Stack   `yaml:"stacks"`
 }
 
 // Templates represents global template configuration
@@ -32,27 +37,53 @@ type Templates struct {
 
 // Context represents context configuration as it appears in YAML
 type Context struct {
-	Account string            `yaml:"account"`
-	Region  string            `yaml:"region"`
-	Tags    map[string]string `yaml:"tags"`
+	Account         string            `yaml:"account"`
+	Region          string            `yaml:"region"`
+	Tags            map[string]string `yaml:"tags"`
+	Exports         *bool             `yaml:"exports"`
+	Protected       bool              `yaml:"protected"`
+	StackNamePrefix string            `yaml:"stack_name_prefix"` // Overrides the global stack name prefix
+	StackNameSuffix string            `yaml:"stack_name_suffix"` // Overrides the global stack name suffix
 }
 
 // Stack represents stack configuration as it appears in YAML before context resolution
 type Stack struct {
-	Template     string                         `yaml:"template"`
-	Parameters   map[string]*yamlParameterValue `yaml:"parameters"`
-	Tags         map[string]string              `yaml:"tags"`
-	Dependencies []string                       `yaml:"depends_on"`
-	Capabilities []string                       `yaml:"capabilities"`
-	Contexts     map[string]*ContextOverride    `yaml:"contexts"`
+	Template              string                         `yaml:"template"`
+	Parameters            map[string]*yamlParameterValue `yaml:"parameters"`
+	Tags                  map[string]string              `yaml:"tags"`
+	Dependencies          []string                       `yaml:"depends_on"`
+	Capabilities          []string                       `yaml:"capabilities"`
+	TerminationProtection *bool                          `yaml:"termination_protection"`
+	StackPolicy           string                         `yaml:"stack_policy"`
+	NotificationARNs      []string                       `yaml:"notification_arns"`
+	AllowedResourceTypes  []string                       `yaml:"allowed_resource_types"`
+	IgnoreProperties      []string                       `yaml:"ignore_properties"`
+	OnFailure             string                         `yaml:"on_failure"`
+	TimeoutMinutes        *int32                         `yaml:"timeout_minutes"`
+	Rollback              *Rollback                      `yaml:"rollback"`
+	AWSOptions            map[string]interface{}         `yaml:"aws_options"`
+	Priority              int                            `yaml:"priority"`
+	RequiredApprovals     int                            `yaml:"required_approvals"`
+	Contexts              map[string]*ContextOverride    `yaml:"contexts"`
+}
+
+// Rollback represents CloudWatch alarm rollback triggers as they appear in YAML
+type Rollback struct {
+	Alarms            []string `yaml:"alarms"`
+	MonitoringMinutes *int32   `yaml:"monitoring_minutes"`
 }
 
 // ContextOverride represents context-specific overrides for a stack
 type ContextOverride struct {
-	Parameters   map[string]*yamlParameterValue `yaml:"parameters"`
-	Tags         map[string]string              `yaml:"tags"`
-	Dependencies []string                       `yaml:"depends_on"`
-	Capabilities []string                       `yaml:"capabilities"`
+	Template              string                         `yaml:"template"`
+	Parameters            map[string]*yamlParameterValue `yaml:"parameters"`
+	Tags                  map[string]string              `yaml:"tags"`
+	Dependencies          []string                       `yaml:"depends_on"`
+	Capabilities          []string                       `yaml:"capabilities"`
+	TerminationProtection *bool                          `yaml:"termination_protection"`
+	NotificationARNs      []string                       `yaml:"notification_arns"`
+	RequiredApprovals     *int                           `yaml:"required_approvals"`
+	Enabled               *bool                          `yaml:"enabled"` // Set to false to leave the stack out of the context (nil keeps it)
 }
 
 // yamlParameterValue represents either a literal value, complex resolution object, or list (YAML-specific)
@@ -6,11 +6,12 @@ package config
 
 import (
 	"context"
+	"fmt"
 )
 
 // ParameterValue represents a parameter with unified resolution model
 type ParameterValue struct {
-	ResolutionType   string            // "literal", "stack-output", "list"
+	ResolutionType   string            // "literal", "stack-output", "export", "ssm", "secret", "env", "file", "git", "list"
 	ResolutionConfig map[string]string // Resolution-specific configuration
 
 	// For list parameters
@@ -36,30 +37,84 @@ type ConfigProvider interface {
 	Validate() error
 }
 
+// EnabledStacks returns the stacks of a context, leaving out those the context disables
+func EnabledStacks(provider ConfigProvider, context string) ([]string, error) {
+	stackNames, err := provider.ListStacks(context)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make([]string, 0, len(stackNames))
+	for _, stackName := range stackNames {
+		stackConfig, err := provider.GetStack(stackName, context)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stack config %s: %w", stackName, err)
+		}
+		if !stackConfig.Disabled {
+			enabled = append(enabled, stackName)
+		}
+	}
+	return enabled, nil
+}
+
 // Config represents the resolved configuration for a specific context
 // Based on ADR 0010 (File provider configuration structure)
 type Config struct {
-	Project string
-	Region  string
-	Tags    map[string]string
-	Context *ContextConfig // Resolved context
-	Stacks  []*StackConfig // Resolved stacks
+	Project       string
+	Region        string
+	Tags          map[string]string
+	MandatoryTags map[string]string // Merged after all other tags, so context and stack tags cannot override them
+	Context       *ContextConfig    // Resolved context
+	Stacks        []*StackConfig    // Resolved stacks
 }
 
 // ContextConfig represents resolved context-specific configuration
 type ContextConfig struct {
-	Name    string
-	Account string
-	Region  string
-	Tags    map[string]string
+	Name            string
+	Account         string
+	Region          string
+	Tags            map[string]string
+	Exports         *bool  // Whether template outputs keep their Export blocks (nil means they do)
+	Protected       bool   // Whether changing stacks requires --allow-protected
+	StackNamePrefix string // Prepended to stack names deployed to AWS
+	StackNameSuffix string // Appended to stack names deployed to AWS
+	ValuesFile      string // URI of the context's values file supplying parameter defaults, if any
+}
+
+// DeployedStackName returns the name a configured stack is given in CloudFormation
+func (c *ContextConfig) DeployedStackName(stackName string) string {
+	return c.StackNamePrefix + stackName + c.StackNameSuffix
+}
+
+// ExportsEnabled reports whether stacks in this context should export their outputs
+func (c *ContextConfig) ExportsEnabled() bool {
+	return c.Exports == nil || *c.Exports
+}
+
+// RollbackConfig lists CloudWatch alarms that roll a stack operation back when they go into ALARM
+type RollbackConfig struct {
+	AlarmARNs               []string
+	MonitoringTimeInMinutes *int32 // Minutes to keep monitoring once the operation completes (nil for none)
 }
 
 // StackConfig represents resolved stack configuration with context overrides applied
 type StackConfig struct {
-	Name         string
-	Template     string // URI to template (file://, s3://, git://, etc.)
-	Parameters   map[string]*ParameterValue
-	Tags         map[string]string
-	Dependencies []string
-	Capabilities []string
+	Name                  string
+	Template              string // URI to template (file://, s3://, git://, etc.)
+	Parameters            map[string]*ParameterValue
+	Tags                  map[string]string
+	Dependencies          []string
+	Capabilities          []string
+	TerminationProtection *bool                  // Desired termination protection (nil leaves it unchanged)
+	StackPolicy           string                 // URI to stack policy document (empty for none)
+	NotificationARNs      []string               // SNS topics that receive stack events
+	AllowedResourceTypes  []string               // Resource types the stack may create or update, such as AWS::S3::* (nil allows all)
+	IgnoreProperties      []string               // Dotted template paths, such as Resources.*.Metadata.BuildTime, left out of diffs
+	OnFailure             string                 // Action when stack creation fails: ROLLBACK, DELETE or DO_NOTHING (empty for the default)
+	TimeoutInMinutes      *int32                 // Time allowed for stack creation before it fails (nil for no limit)
+	Rollback              *RollbackConfig        // Alarms that roll stack operations back (nil for none)
+	AWSOptions            map[string]interface{} // Raw CreateStack/UpdateStack fields, validated by the resolver
+	Priority              int                    // Orders independent stacks; higher values deploy first (ties are alphabetical)
+	RequiredApprovals     int                    // Distinct approvals a change needs before it is deployed (zero for none)
+	Disabled              bool                   // Set when the context turns the stack off, so it is neither deployed nor deleted there
 }
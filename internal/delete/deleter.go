@@ -6,86 +6,252 @@ package delete
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
 	"time"
 
 	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/log"
 	"codeberg.org/orien/stackaroo/internal/model"
 	"codeberg.org/orien/stackaroo/internal/prompt"
 	"codeberg.org/orien/stackaroo/internal/resolve"
 )
 
+// Options configures how stacks are deleted
+type Options struct {
+	// Force deletes a stack even when other configured stacks depend on it, disabling its termination protection first
+	Force bool
+	// RequireStacks fails instead of doing nothing when a context has no stacks
+	RequireStacks bool
+	// JSONOutput prints a JSON report of each stack's outcome when finished
+	JSONOutput bool
+	// Retain lists logical IDs of resources to keep when retrying the deletion of a stack in DELETE_FAILED
+	Retain []string
+	// SkipAccountCheck deletes without confirming the credentials belong to the context's account
+	SkipAccountCheck bool
+	// AllowProtected permits deleting stacks in contexts marked as protected
+	AllowProtected bool
+	// Confirmed skips the confirmation prompt, for callers that have already asked the user
+	Confirmed bool
+}
+
+// StackOutcome describes how the deletion of a single stack ended
+type StackOutcome string
+
+const (
+	OutcomeDeleted   StackOutcome = "deleted"
+	OutcomeFailed    StackOutcome = "failed"
+	OutcomeNotFound  StackOutcome = "not-found"
+	OutcomeCancelled StackOutcome = "cancelled"
+)
+
+// StackResult records the outcome of deleting a single stack
+type StackResult struct {
+	StackName string
+	Outcome   StackOutcome
+	Status    aws.StackStatus
+	StackID   string
+	Err       error
+}
+
+// Report is the JSON document printed when JSON output is requested
+type Report struct {
+	Context string        `json:"context"`
+	Stacks  []StackReport `json:"stacks"`
+}
+
+// StackReport is the JSON representation of a StackResult
+type StackReport struct {
+	StackName string `json:"stack_name"`
+	Outcome   string `json:"outcome"`
+	Status    string `json:"status,omitempty"`
+	StackID   string `json:"stack_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DependentsError is returned when deleting a stack that other configured stacks depend on
+type DependentsError struct {
+	StackName  string
+	Dependents []string
+}
+
+func (e DependentsError) Error() string {
+	return fmt.Sprintf("stack %s is depended on by %s; delete those stacks first or use --force", e.StackName, strings.Join(e.Dependents, ", "))
+}
+
+// TerminationProtectionError is returned when deleting a stack that has termination protection enabled
+type TerminationProtectionError struct {
+	StackName string
+}
+
+func (e TerminationProtectionError) Error() string {
+	return fmt.Sprintf("stack %s has termination protection enabled; disable it or use --force to turn it off and delete the stack", e.StackName)
+}
+
 // Deleter defines the interface for stack deletion operations
 type Deleter interface {
 	DeleteStack(ctx context.Context, stack *model.Stack) error
-	DeleteSingleStack(ctx context.Context, stackName, contextName string) error
-	DeleteAllStacks(ctx context.Context, contextName string) error
+	DeleteSingleStack(ctx context.Context, stackName, contextName string, options Options) error
+	DeleteAllStacks(ctx context.Context, contextName string, options Options) error
 }
 
 // StackDeleter implements Deleter using AWS CloudFormation
 type StackDeleter struct {
-	clientFactory  aws.ClientFactory
-	configProvider config.ConfigProvider
-	resolver       resolve.Resolver
+	clientFactory   aws.ClientFactory
+	configProvider  config.ConfigProvider
+	resolver        resolve.Resolver
+	output          io.Writer           // Destination for the JSON report (injectable for testing)
+	accountVerifier aws.AccountVerifier // Confirms the credentials target the context's account (injectable for testing)
+	logger          *slog.Logger
 }
 
 // NewStackDeleter creates a new StackDeleter
 func NewStackDeleter(clientFactory aws.ClientFactory, configProvider config.ConfigProvider, resolver resolve.Resolver) *StackDeleter {
 	return &StackDeleter{
-		clientFactory:  clientFactory,
-		configProvider: configProvider,
-		resolver:       resolver,
+		clientFactory:   clientFactory,
+		configProvider:  configProvider,
+		resolver:        resolver,
+		output:          os.Stdout,
+		accountVerifier: aws.NewAccountVerifier(clientFactory),
+		logger:          log.Default(),
 	}
 }
 
+// SetOutput allows injection of the JSON report destination for testing
+func (d *StackDeleter) SetOutput(w io.Writer) {
+	d.output = w
+}
+
+// SetAccountVerifier allows injection of a custom account verifier for testing
+func (d *StackDeleter) SetAccountVerifier(v aws.AccountVerifier) {
+	d.accountVerifier = v
+}
+
+// SetLogger sets the logger for diagnostic records
+func (d *StackDeleter) SetLogger(logger *slog.Logger) {
+	d.logger = logger
+}
+
 // DeleteStack deletes a CloudFormation stack with confirmation
 func (d *StackDeleter) DeleteStack(ctx context.Context, stack *model.Stack) error {
+	return d.deleteStackWithResult(ctx, stack, Options{}).Err
+}
+
+// deleteStackWithResult deletes a stack with confirmation and reports how the deletion ended.
+// Resources named in options.Retain are kept only when the stack is in DELETE_FAILED, as CloudFormation requires.
+func (d *StackDeleter) deleteStackWithResult(ctx context.Context, stack *model.Stack, options Options) StackResult {
+	result := StackResult{StackName: stack.Name, Outcome: OutcomeFailed}
+	retain := options.Retain
+
+	if stack.Context.Protected && !options.AllowProtected {
+		result.Err = model.ProtectedContextError{Context: stack.Context.Name}
+		return result
+	}
+
+	// Refuse to touch stacks in an account the context does not target
+	if options.SkipAccountCheck {
+		d.logger.DebugContext(ctx, "skipping account check", "stack", stack.Name, "context", stack.Context.Name)
+	} else {
+		if err := d.accountVerifier.VerifyAccount(ctx, stack.Context.Name, stack.Context.Region, stack.Context.Account); err != nil {
+			result.Err = err
+			return result
+		}
+	}
+
 	// Get region-specific CloudFormation operations
 	cfnOps, err := d.clientFactory.GetCloudFormationOperations(ctx, stack.Context.Region)
 	if err != nil {
-		return fmt.Errorf("failed to get CloudFormation operations for region %s: %w", stack.Context.Region, err)
+		result.Err = fmt.Errorf("failed to get CloudFormation operations for region %s: %w", stack.Context.Region, err)
+		return result
 	}
 
 	// Check if stack exists
-	exists, err := cfnOps.StackExists(ctx, stack.Name)
+	exists, err := cfnOps.StackExists(ctx, stack.CloudFormationName())
 	if err != nil {
-		return fmt.Errorf("failed to check if stack exists: %w", err)
+		result.Err = fmt.Errorf("failed to check if stack exists: %w", err)
+		return result
 	}
 
 	if !exists {
+		d.logger.DebugContext(ctx, "stack does not exist; skipping", "stack", stack.Name)
 		fmt.Printf("Stack %s does not exist, skipping deletion\n", stack.Name)
-		return nil
+		result.Outcome = OutcomeNotFound
+		return result
 	}
 
 	// Get stack information to show what will be deleted
-	stackInfo, err := cfnOps.DescribeStack(ctx, stack.Name)
+	stackInfo, err := cfnOps.DescribeStack(ctx, stack.CloudFormationName())
 	if err != nil {
-		return fmt.Errorf("failed to describe stack %s: %w", stack.Name, err)
+		result.Err = fmt.Errorf("failed to describe stack %s: %w", stack.Name, err)
+		return result
+	}
+	result.Status = stackInfo.Status
+	result.StackID = stackInfo.ID
+
+	// CloudFormation rejects deleting a protected stack, so refuse before asking for confirmation
+	if stackInfo.TerminationProtection && !options.Force {
+		result.Err = TerminationProtectionError{StackName: stack.Name}
+		return result
 	}
 
 	// Show what will be deleted
 	fmt.Printf("\n=== Stack Deletion Preview ===\n")
-	fmt.Printf("Stack Name: %s\n", stack.Name)
+	fmt.Printf("Stack Name: %s\n", stack.CloudFormationName())
 	fmt.Printf("Context: %s\n", stack.Context.Name)
 	fmt.Printf("Status: %s\n", stackInfo.Status)
 	if stackInfo.Description != "" {
 		fmt.Printf("Description: %s\n", stackInfo.Description)
 	}
+	if stackInfo.TerminationProtection {
+		fmt.Printf("Termination Protection: enabled (will be disabled by --force)\n")
+	}
 
-	fmt.Printf("\nThis will permanently delete the CloudFormation stack and all its resources.\n")
-	fmt.Printf("WARNING: This operation cannot be undone!\n")
+	// CloudFormation only accepts retained resources when retrying a failed deletion
+	if len(retain) > 0 && stackInfo.Status != aws.StackStatusDeleteFailed {
+		d.logger.DebugContext(ctx, "ignoring retained resources", "stack", stack.Name, "status", stackInfo.Status)
+		fmt.Printf("Ignoring --retain: stack %s is in %s, and resources can only be retained for stacks in %s\n",
+			stack.Name, stackInfo.Status, aws.StackStatusDeleteFailed)
+		retain = nil
+	}
 
-	// Prompt for confirmation
 	message := fmt.Sprintf("Do you want to delete stack %s? This cannot be undone.", stack.Name)
-	confirmed, err := prompt.Confirm(message)
-	if err != nil {
-		return fmt.Errorf("failed to get user confirmation: %w", err)
+	if len(retain) > 0 {
+		fmt.Printf("\nThis will delete the CloudFormation stack and all its resources except: %s\n", strings.Join(retain, ", "))
+		fmt.Printf("Retained resources are left in AWS and are no longer managed by CloudFormation.\n")
+		fmt.Printf("WARNING: This operation cannot be undone!\n")
+		message = fmt.Sprintf("Do you want to delete stack %s, retaining %s? This cannot be undone.", stack.Name, strings.Join(retain, ", "))
+	} else {
+		fmt.Printf("\nThis will permanently delete the CloudFormation stack and all its resources.\n")
+		fmt.Printf("WARNING: This operation cannot be undone!\n")
+	}
+
+	// Prompt for confirmation unless the caller has already asked
+	confirmed := options.Confirmed
+	if !confirmed {
+		confirmed, err = confirmDeletion(stack, message)
+		if err != nil {
+			result.Err = fmt.Errorf("failed to get user confirmation: %w", err)
+			return result
+		}
 	}
 
 	if !confirmed {
 		fmt.Printf("Deletion of stack %s cancelled by user\n", stack.Name)
-		return nil
+		result.Outcome = OutcomeCancelled
+		return result
+	}
+
+	if stackInfo.TerminationProtection {
+		fmt.Printf("Disabling termination protection on stack %s...\n", stack.Name)
+		if err := cfnOps.UpdateTerminationProtection(ctx, stack.CloudFormationName(), false); err != nil {
+			result.Err = fmt.Errorf("failed to disable termination protection on stack %s: %w", stack.Name, err)
+			return result
+		}
 	}
 
 	// Perform the deletion
@@ -95,49 +261,124 @@ func (d *StackDeleter) DeleteStack(ctx context.Context, stack *model.Stack) erro
 	startTime := time.Now()
 
 	deleteInput := aws.DeleteStackInput{
-		StackName: stack.Name,
+		StackName:       stack.CloudFormationName(),
+		RetainResources: retain,
 	}
 
+	d.logger.DebugContext(ctx, "deleting stack", "stack", stack.Name, "status", stackInfo.Status, "retain", retain)
 	err = cfnOps.DeleteStack(ctx, deleteInput)
 	if err != nil {
-		return fmt.Errorf("failed to delete stack %s: %w", stack.Name, err)
+		result.Err = fmt.Errorf("failed to delete stack %s: %w", stack.Name, err)
+		return result
 	}
 
 	// Wait for deletion to complete
 	fmt.Printf("Waiting for stack deletion to complete...\n")
-	err = cfnOps.WaitForStackOperation(ctx, stack.Name, startTime, func(event aws.StackEvent) {
+	err = cfnOps.WaitForStackOperation(ctx, stack.CloudFormationName(), startTime, func(event aws.StackEvent) {
 		fmt.Printf("  %s: %s - %s\n", event.Timestamp.Format("15:04:05"), event.ResourceType, event.ResourceStatus)
 		if event.ResourceStatusReason != "" {
 			fmt.Printf("    Reason: %s\n", event.ResourceStatusReason)
 		}
 	})
 	if err != nil {
-		return fmt.Errorf("failed to wait for stack deletion: %w", err)
+		result.Err = fmt.Errorf("failed to wait for stack deletion: %w", err)
+		return result
 	}
 
-	return nil
+	result.Outcome = OutcomeDeleted
+	result.Status = aws.StackStatusDeleteComplete
+	return result
 }
 
 // DeleteSingleStack handles deletion of a single stack
-func (d *StackDeleter) DeleteSingleStack(ctx context.Context, stackName, contextName string) error {
+func (d *StackDeleter) DeleteSingleStack(ctx context.Context, stackName, contextName string, options Options) error {
+	result := d.resolveAndDelete(ctx, stackName, contextName, options)
+	if options.JSONOutput {
+		if err := d.writeReport(contextName, []StackResult{result}); err != nil {
+			return err
+		}
+	}
+	return result.Err
+}
+
+// resolveAndDelete resolves a single stack, checks for dependents and deletes it
+func (d *StackDeleter) resolveAndDelete(ctx context.Context, stackName, contextName string, options Options) StackResult {
 	// Resolve single stack
 	stack, err := d.resolver.ResolveStack(ctx, contextName, stackName)
 	if err != nil {
-		return err
+		return StackResult{StackName: stackName, Outcome: OutcomeFailed, Err: err}
 	}
 
-	return d.deleteStackWithFeedback(ctx, stack, contextName)
+	// Deleting a stack that others depend on can break them
+	dependents, err := d.findDependents(stackName, contextName)
+	if err != nil {
+		return StackResult{StackName: stackName, Outcome: OutcomeFailed, Err: err}
+	}
+	if len(dependents) > 0 {
+		if !options.Force {
+			return StackResult{StackName: stackName, Outcome: OutcomeFailed, Err: DependentsError{StackName: stackName, Dependents: dependents}}
+		}
+		fmt.Printf("WARNING: Stack %s is depended on by %s\n", stackName, strings.Join(dependents, ", "))
+	}
+
+	return d.deleteStackWithFeedback(ctx, stack, contextName, options)
+}
+
+// confirmDeletion asks the user to confirm deleting a stack. Stacks in a protected context are
+// confirmed by typing the context name rather than y/N.
+func confirmDeletion(stack *model.Stack, message string) (bool, error) {
+	if stack.Context.Protected {
+		return prompt.ConfirmWithPhrase(fmt.Sprintf("%s\nStack %s is in protected %s.", message, stack.Name, stack.Context.Describe()), stack.Context.Name)
+	}
+	return prompt.Confirm(message)
+}
+
+// findDependents returns the configured stacks in a context that depend directly on the given stack
+func (d *StackDeleter) findDependents(stackName, contextName string) ([]string, error) {
+	stackNames, err := d.configProvider.ListStacks(contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []string
+	for _, name := range stackNames {
+		if name == stackName {
+			continue
+		}
+
+		stackConfig, err := d.configProvider.GetStack(name, contextName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stack config %s: %w", name, err)
+		}
+		if stackConfig.Disabled {
+			continue
+		}
+
+		for _, dep := range stackConfig.Dependencies {
+			if dep == stackName {
+				dependents = append(dependents, name)
+				break
+			}
+		}
+	}
+	return dependents, nil
 }
 
 // DeleteAllStacks handles deletion of all stacks in a context
-func (d *StackDeleter) DeleteAllStacks(ctx context.Context, contextName string) error {
+func (d *StackDeleter) DeleteAllStacks(ctx context.Context, contextName string, options Options) error {
 	// Get list of stacks to delete
 	stackNames, err := d.configProvider.ListStacks(contextName)
 	if err != nil {
 		return err
 	}
 	if len(stackNames) == 0 {
+		if options.RequireStacks {
+			return fmt.Errorf("no stacks found in context %s", contextName)
+		}
 		fmt.Printf("No stacks found in context %s\n", contextName)
+		if options.JSONOutput {
+			return d.writeReport(contextName, nil)
+		}
 		return nil
 	}
 
@@ -155,29 +396,86 @@ func (d *StackDeleter) DeleteAllStacks(ctx context.Context, contextName string)
 	}
 
 	// Delete each stack in reverse dependency order, resolving individually
+	results := make([]StackResult, 0, len(deletionOrder))
 	for _, stackName := range deletionOrder {
 		// Resolve this specific stack
+		var result StackResult
 		stack, err := d.resolver.ResolveStack(ctx, contextName, stackName)
 		if err != nil {
-			return err
+			result = StackResult{StackName: stackName, Outcome: OutcomeFailed, Err: err}
+		} else {
+			result = d.deleteStackWithFeedback(ctx, stack, contextName, options)
 		}
+		results = append(results, result)
 
-		err = d.deleteStackWithFeedback(ctx, stack, contextName)
-		if err != nil {
-			return err
+		if result.Err != nil {
+			if options.JSONOutput {
+				if err := d.writeReport(contextName, results); err != nil {
+					return err
+				}
+			}
+			return result.Err
 		}
 	}
 
+	if options.JSONOutput {
+		return d.writeReport(contextName, results)
+	}
 	return nil
 }
 
 // deleteStackWithFeedback deletes a stack and provides feedback
-func (d *StackDeleter) deleteStackWithFeedback(ctx context.Context, stack *model.Stack, contextName string) error {
-	err := d.DeleteStack(ctx, stack)
-	if err != nil {
-		return fmt.Errorf("error deleting stack %s: %w", stack.Name, err)
+func (d *StackDeleter) deleteStackWithFeedback(ctx context.Context, stack *model.Stack, contextName string, options Options) StackResult {
+	result := d.deleteStackWithResult(ctx, stack, options)
+	if result.Err != nil {
+		result.Err = fmt.Errorf("error deleting stack %s: %w", stack.Name, result.Err)
+		if options.JSONOutput {
+			d.refreshStatus(ctx, stack, &result)
+		}
+		return result
 	}
 
 	fmt.Printf("Successfully deleted stack %s in context %s\n", stack.Name, contextName)
+	return result
+}
+
+// refreshStatus updates a failed result with the stack's current status, where the stack still exists
+func (d *StackDeleter) refreshStatus(ctx context.Context, stack *model.Stack, result *StackResult) {
+	if result.StackID == "" {
+		return // The deletion failed before the stack was inspected
+	}
+
+	cfnOps, err := d.clientFactory.GetCloudFormationOperations(ctx, stack.Context.Region)
+	if err != nil {
+		return
+	}
+	current, err := cfnOps.GetStack(ctx, result.StackID)
+	if err != nil {
+		return
+	}
+	result.Status = current.Status
+}
+
+// writeReport prints the JSON report of a deletion
+func (d *StackDeleter) writeReport(contextName string, results []StackResult) error {
+	report := Report{Context: contextName, Stacks: make([]StackReport, 0, len(results))}
+	for _, result := range results {
+		stackReport := StackReport{
+			StackName: result.StackName,
+			Outcome:   string(result.Outcome),
+			Status:    string(result.Status),
+			StackID:   result.StackID,
+		}
+		if result.Err != nil {
+			stackReport.Error = result.Err.Error()
+		}
+		report.Stacks = append(report.Stacks, stackReport)
+	}
+
+	encoder := json.NewEncoder(d.output)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write deletion report: %w", err)
+	}
 	return nil
 }
@@ -5,7 +5,9 @@ SPDX-License-Identifier: BSD-3-Clause
 package delete
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -111,6 +113,34 @@ func TestDeleteStack_StackExists_UserCancels(t *testing.T) {
 	mockPrompter.AssertExpectations(t)
 }
 
+func TestDeleteStack_AutoApprove_DeletesWithoutPrompting(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockPrompter := &prompt.MockPrompter{}
+
+	mockCfnOps.On("StackExists", ctx, "test-stack").Return(true, nil)
+	mockCfnOps.On("DescribeStack", ctx, "test-stack").Return(&aws.StackInfo{Name: "test-stack", Status: aws.StackStatusCreateComplete}, nil)
+	mockCfnOps.On("DeleteStack", ctx, aws.DeleteStackInput{StackName: "test-stack"}).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", ctx, "test-stack", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).Return(nil)
+
+	prompt.SetPrompter(mockPrompter)
+	defer prompt.SetPrompter(nil)
+	prompt.SetAutoApprove(true)
+	defer prompt.SetAutoApprove(false)
+
+	deleter := NewStackDeleter(mockFactory, nil, nil)
+	stack := &model.Stack{
+		Name:    "test-stack",
+		Context: model.NewTestContext("dev", "us-east-1", "123456789012"),
+	}
+
+	err := deleter.DeleteStack(ctx, stack)
+
+	assert.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+}
+
 func TestDeleteStack_StackDoesNotExist(t *testing.T) {
 	ctx := context.Background()
 	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
@@ -131,6 +161,140 @@ func TestDeleteStack_StackDoesNotExist(t *testing.T) {
 	mockCfnOps.AssertExpectations(t)
 }
 
+func TestDeleteStack_AccountMismatch(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockSTS := &aws.MockSTSOperations{}
+	mockSTS.On("GetAccountID", ctx).Return("999999999999", nil)
+	mockFactory.SetSTSOperations("us-east-1", mockSTS)
+
+	deleter := NewStackDeleter(mockFactory, nil, nil)
+	stack := &model.Stack{
+		Name:    "test-stack",
+		Context: model.NewTestContext("dev", "us-east-1", "123456789012"),
+	}
+
+	err := deleter.DeleteStack(ctx, stack)
+
+	var mismatch aws.AccountMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "123456789012", mismatch.Expected)
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
+
+func TestDeleteStack_ProtectedContext(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	protected := model.NewTestContext("prod", "us-east-1", "123456789012")
+	protected.Protected = true
+	deleter := NewStackDeleter(mockFactory, nil, nil)
+
+	err := deleter.DeleteStack(ctx, &model.Stack{Name: "test-stack", Context: protected})
+
+	require.ErrorAs(t, err, &model.ProtectedContextError{})
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
+
+func TestDeleteSingleStack_AllowProtected(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	protected := model.NewTestContext("prod", "us-east-1", "123456789012")
+	protected.Protected = true
+	mockResolver.On("ResolveStack", ctx, "prod", "test-stack").Return(&model.Stack{Name: "test-stack", Context: protected}, nil)
+	mockConfigProvider.On("ListStacks", "prod").Return([]string{"test-stack"}, nil)
+	mockCfnOps.On("StackExists", ctx, "test-stack").Return(false, nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	err := deleter.DeleteSingleStack(ctx, "test-stack", "prod", Options{AllowProtected: true})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeleteSingleStack_ProtectedContext_RequiresPhrase(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	protected := model.NewTestContext("prod", "us-east-1", "123456789012")
+	protected.Protected = true
+	mockResolver.On("ResolveStack", ctx, "prod", "test-stack").Return(&model.Stack{Name: "test-stack", Context: protected}, nil)
+	mockConfigProvider.On("ListStacks", "prod").Return([]string{"test-stack"}, nil)
+	mockCfnOps.On("StackExists", ctx, "test-stack").Return(true, nil)
+	mockCfnOps.On("DescribeStack", ctx, "test-stack").Return(&aws.StackInfo{Name: "test-stack", Status: aws.StackStatusCreateComplete}, nil)
+
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("ConfirmWithPhrase", "Do you want to delete stack test-stack? This cannot be undone.\nStack test-stack is in protected context prod (account 123456789012, region us-east-1).", "prod").Return(false, nil)
+	prompt.SetPrompter(mockPrompter)
+	defer prompt.SetPrompter(nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	err := deleter.DeleteSingleStack(ctx, "test-stack", "prod", Options{AllowProtected: true, SkipAccountCheck: true})
+
+	require.NoError(t, err)
+	mockPrompter.AssertExpectations(t)
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "DeleteStack", mock.Anything, mock.Anything)
+}
+
+func TestDeleteSingleStack_SkipAccountCheck(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockSTS := &aws.MockSTSOperations{}
+	mockFactory.SetSTSOperations("us-east-1", mockSTS)
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	testStack := &model.Stack{
+		Name:    "test-stack",
+		Context: model.NewTestContext("dev", "us-east-1", "123456789012"),
+	}
+	mockResolver.On("ResolveStack", ctx, "dev", "test-stack").Return(testStack, nil)
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"test-stack"}, nil)
+	mockCfnOps.On("StackExists", ctx, "test-stack").Return(false, nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	err := deleter.DeleteSingleStack(ctx, "test-stack", "dev", Options{SkipAccountCheck: true})
+
+	require.NoError(t, err)
+	mockSTS.AssertNotCalled(t, "GetAccountID", mock.Anything)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeleteSingleStack_ConfirmedSkipsPrompt(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+	mockPrompter := &prompt.MockPrompter{}
+
+	testStack := &model.Stack{
+		Name:    "test-stack",
+		Context: model.NewTestContext("dev", "us-east-1", "123456789012"),
+	}
+	mockResolver.On("ResolveStack", ctx, "dev", "test-stack").Return(testStack, nil)
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"test-stack"}, nil)
+	mockCfnOps.On("StackExists", ctx, "test-stack").Return(true, nil)
+	mockCfnOps.On("DescribeStack", ctx, "test-stack").Return(&aws.StackInfo{Name: "test-stack", Status: aws.StackStatusCreateComplete}, nil)
+	mockCfnOps.On("DeleteStack", ctx, aws.DeleteStackInput{StackName: "test-stack"}).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", ctx, "test-stack", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).Return(nil)
+
+	prompt.SetPrompter(mockPrompter)
+	defer prompt.SetPrompter(nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	err := deleter.DeleteSingleStack(ctx, "test-stack", "dev", Options{SkipAccountCheck: true, Confirmed: true})
+
+	require.NoError(t, err)
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+	mockCfnOps.AssertExpectations(t)
+}
+
 func TestDeleteStack_StackExistsCheckFails(t *testing.T) {
 	ctx := context.Background()
 	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
@@ -190,6 +354,7 @@ func TestDeleteSingleStack_Success(t *testing.T) {
 
 	// Mock resolver to return the test stack
 	mockResolver.On("ResolveStack", ctx, "dev", "test-stack").Return(testStack, nil)
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"test-stack"}, nil)
 
 	// Mock CloudFormation operations for successful deletion
 	mockCfnOps.On("StackExists", ctx, "test-stack").Return(true, nil)
@@ -208,7 +373,7 @@ func TestDeleteSingleStack_Success(t *testing.T) {
 
 	// Create deleter and test
 	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
-	err := deleter.DeleteSingleStack(ctx, "test-stack", "dev")
+	err := deleter.DeleteSingleStack(ctx, "test-stack", "dev", Options{})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -231,6 +396,7 @@ func TestDeleteSingleStack_DeleteStackFailure(t *testing.T) {
 
 	// Mock resolver to return the test stack
 	mockResolver.On("ResolveStack", ctx, "dev", "test-stack").Return(testStack, nil)
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"test-stack"}, nil)
 
 	// Mock CloudFormation operations for existence check
 	mockCfnOps.On("StackExists", ctx, "test-stack").Return(true, nil)
@@ -251,7 +417,7 @@ func TestDeleteSingleStack_DeleteStackFailure(t *testing.T) {
 
 	// Create deleter and test
 	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
-	err := deleter.DeleteSingleStack(ctx, "test-stack", "dev")
+	err := deleter.DeleteSingleStack(ctx, "test-stack", "dev", Options{})
 
 	// Assertions
 	require.Error(t, err)
@@ -272,7 +438,7 @@ func TestDeleteSingleStack_ResolverFailure(t *testing.T) {
 
 	// Create deleter and test
 	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
-	err := deleter.DeleteSingleStack(ctx, "test-stack", "dev")
+	err := deleter.DeleteSingleStack(ctx, "test-stack", "dev", Options{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -294,6 +460,7 @@ func TestDeleteSingleStack_DeploymentFailure(t *testing.T) {
 
 	// Mock resolver to return our test stack
 	mockResolver.On("ResolveStack", ctx, "dev", "test-stack").Return(testStack, nil)
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"test-stack"}, nil)
 
 	// Mock CloudFormation operations for failed deletion
 	mockCfnOps.On("StackExists", ctx, "test-stack").Return(true, nil)
@@ -312,7 +479,7 @@ func TestDeleteSingleStack_DeploymentFailure(t *testing.T) {
 
 	// Create deleter and test
 	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
-	err := deleter.DeleteSingleStack(ctx, "test-stack", "dev")
+	err := deleter.DeleteSingleStack(ctx, "test-stack", "dev", Options{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -364,7 +531,7 @@ func TestDeleteAllStacks_Success(t *testing.T) {
 
 	// Create deleter and test
 	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
-	err := deleter.DeleteAllStacks(ctx, "dev")
+	err := deleter.DeleteAllStacks(ctx, "dev", Options{})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -385,7 +552,7 @@ func TestDeleteAllStacks_NoStacksFound(t *testing.T) {
 
 	// Create deleter and test
 	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
-	err := deleter.DeleteAllStacks(ctx, "dev")
+	err := deleter.DeleteAllStacks(ctx, "dev", Options{})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -393,6 +560,22 @@ func TestDeleteAllStacks_NoStacksFound(t *testing.T) {
 	// No other mocks should be called
 }
 
+func TestDeleteAllStacks_NoStacksFound_RequireStacks(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{}, nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	err := deleter.DeleteAllStacks(ctx, "dev", Options{RequireStacks: true})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no stacks found in context dev")
+	mockConfigProvider.AssertExpectations(t)
+}
+
 func TestDeleteAllStacks_ListStacksFailure(t *testing.T) {
 	ctx := context.Background()
 	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
@@ -404,7 +587,7 @@ func TestDeleteAllStacks_ListStacksFailure(t *testing.T) {
 
 	// Create deleter and test
 	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
-	err := deleter.DeleteAllStacks(ctx, "dev")
+	err := deleter.DeleteAllStacks(ctx, "dev", Options{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -427,7 +610,7 @@ func TestDeleteAllStacks_GetDependencyOrderFailure(t *testing.T) {
 
 	// Create deleter and test
 	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
-	err := deleter.DeleteAllStacks(ctx, "dev")
+	err := deleter.DeleteAllStacks(ctx, "dev", Options{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -454,7 +637,7 @@ func TestDeleteAllStacks_ResolveStackFailure(t *testing.T) {
 
 	// Create deleter and test
 	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
-	err := deleter.DeleteAllStacks(ctx, "dev")
+	err := deleter.DeleteAllStacks(ctx, "dev", Options{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -498,7 +681,7 @@ func TestDeleteAllStacks_DeleteStackFailureStopsExecution(t *testing.T) {
 
 	// Create deleter and test
 	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
-	err := deleter.DeleteAllStacks(ctx, "dev")
+	err := deleter.DeleteAllStacks(ctx, "dev", Options{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -645,3 +828,326 @@ func TestDeleteStack_WaitForOperationFails(t *testing.T) {
 	mockCfnOps.AssertExpectations(t)
 	mockPrompter.AssertExpectations(t)
 }
+
+func TestDeleteSingleStack_WithDependents_BlocksWithoutForce(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	testStack := &model.Stack{
+		Name:    "vpc",
+		Context: model.NewTestContext("dev", "us-east-1", "123456789012"),
+	}
+	mockResolver.On("ResolveStack", ctx, "dev", "vpc").Return(testStack, nil)
+
+	// app and database both depend on vpc; monitoring does not
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"app", "database", "monitoring", "vpc"}, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app", Dependencies: []string{"database", "vpc"}}, nil)
+	mockConfigProvider.On("GetStack", "database", "dev").Return(&config.StackConfig{Name: "database", Dependencies: []string{"vpc"}}, nil)
+	mockConfigProvider.On("GetStack", "monitoring", "dev").Return(&config.StackConfig{Name: "monitoring"}, nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	err := deleter.DeleteSingleStack(ctx, "vpc", "dev", Options{})
+
+	require.Error(t, err)
+	var dependentsErr DependentsError
+	require.ErrorAs(t, err, &dependentsErr)
+	assert.Equal(t, []string{"app", "database"}, dependentsErr.Dependents)
+	assert.Contains(t, err.Error(), "--force")
+	mockConfigProvider.AssertExpectations(t)
+	mockCfnOps.AssertNotCalled(t, "DeleteStack", mock.Anything, mock.Anything)
+}
+
+func TestDeleteSingleStack_WithDependents_IgnoresStacksDisabledInContext(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	testStack := &model.Stack{
+		Name:    "vpc",
+		Context: model.NewTestContext("dev", "us-east-1", "123456789012"),
+	}
+	mockResolver.On("ResolveStack", ctx, "dev", "vpc").Return(testStack, nil)
+
+	// monitoring depends on vpc but is not deployed in dev
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"app", "monitoring", "vpc"}, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app", Dependencies: []string{"vpc"}}, nil)
+	mockConfigProvider.On("GetStack", "monitoring", "dev").Return(&config.StackConfig{Name: "monitoring", Dependencies: []string{"vpc"}, Disabled: true}, nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	err := deleter.DeleteSingleStack(ctx, "vpc", "dev", Options{})
+
+	var dependentsErr DependentsError
+	require.ErrorAs(t, err, &dependentsErr)
+	assert.Equal(t, []string{"app"}, dependentsErr.Dependents)
+}
+
+func TestDeleteSingleStack_WithDependents_ForceDeletes(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	testStack := &model.Stack{
+		Name:    "vpc",
+		Context: model.NewTestContext("dev", "us-east-1", "123456789012"),
+	}
+	mockResolver.On("ResolveStack", ctx, "dev", "vpc").Return(testStack, nil)
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"app", "vpc"}, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app", Dependencies: []string{"vpc"}}, nil)
+
+	mockCfnOps.On("StackExists", ctx, "vpc").Return(true, nil)
+	mockCfnOps.On("DescribeStack", ctx, "vpc").Return(&aws.StackInfo{Status: "CREATE_COMPLETE"}, nil)
+	mockCfnOps.On("DeleteStack", ctx, aws.DeleteStackInput{StackName: "vpc"}).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", ctx, "vpc", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).Return(nil)
+
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", "Do you want to delete stack vpc? This cannot be undone.").Return(true, nil)
+	prompt.SetPrompter(mockPrompter)
+	defer prompt.SetPrompter(nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	err := deleter.DeleteSingleStack(ctx, "vpc", "dev", Options{Force: true})
+
+	assert.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+	mockPrompter.AssertExpectations(t)
+}
+
+func TestDeleteStack_TerminationProtection_BlocksWithoutForce(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	testStack := &model.Stack{
+		Name:    "database",
+		Context: model.NewTestContext("prod", "us-east-1", "123456789012"),
+	}
+	mockResolver.On("ResolveStack", ctx, "prod", "database").Return(testStack, nil)
+	mockConfigProvider.On("ListStacks", "prod").Return([]string{"database"}, nil)
+
+	mockCfnOps.On("StackExists", ctx, "database").Return(true, nil)
+	mockCfnOps.On("DescribeStack", ctx, "database").Return(&aws.StackInfo{Status: "CREATE_COMPLETE", TerminationProtection: true}, nil)
+
+	mockPrompter := &prompt.MockPrompter{}
+	prompt.SetPrompter(mockPrompter)
+	defer prompt.SetPrompter(nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	err := deleter.DeleteSingleStack(ctx, "database", "prod", Options{})
+
+	require.Error(t, err)
+	var protectionErr TerminationProtectionError
+	require.ErrorAs(t, err, &protectionErr)
+	assert.Equal(t, "database", protectionErr.StackName)
+	assert.Contains(t, err.Error(), "termination protection enabled")
+	assert.Contains(t, err.Error(), "--force")
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "UpdateTerminationProtection", mock.Anything, mock.Anything, mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "DeleteStack", mock.Anything, mock.Anything)
+}
+
+func TestDeleteStack_TerminationProtection_ForceDisablesThenDeletes(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	testStack := &model.Stack{
+		Name:    "database",
+		Context: model.NewTestContext("prod", "us-east-1", "123456789012"),
+	}
+	mockResolver.On("ResolveStack", ctx, "prod", "database").Return(testStack, nil)
+	mockConfigProvider.On("ListStacks", "prod").Return([]string{"database"}, nil)
+
+	var calls []string
+	mockCfnOps.On("StackExists", ctx, "database").Return(true, nil)
+	mockCfnOps.On("DescribeStack", ctx, "database").Return(&aws.StackInfo{Status: "CREATE_COMPLETE", TerminationProtection: true}, nil)
+	mockCfnOps.On("UpdateTerminationProtection", ctx, "database", false).Return(nil).
+		Run(func(mock.Arguments) { calls = append(calls, "UpdateTerminationProtection") })
+	mockCfnOps.On("DeleteStack", ctx, aws.DeleteStackInput{StackName: "database"}).Return(nil).
+		Run(func(mock.Arguments) { calls = append(calls, "DeleteStack") })
+	mockCfnOps.On("WaitForStackOperation", ctx, "database", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).Return(nil)
+
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", "Do you want to delete stack database? This cannot be undone.").Return(true, nil)
+	prompt.SetPrompter(mockPrompter)
+	defer prompt.SetPrompter(nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	err := deleter.DeleteSingleStack(ctx, "database", "prod", Options{Force: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"UpdateTerminationProtection", "DeleteStack"}, calls)
+	mockCfnOps.AssertExpectations(t)
+	mockPrompter.AssertExpectations(t)
+}
+
+func TestDeleteStack_TerminationProtection_ForceKeepsProtectionWhenCancelled(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	testStack := &model.Stack{
+		Name:    "database",
+		Context: model.NewTestContext("prod", "us-east-1", "123456789012"),
+	}
+
+	mockCfnOps.On("StackExists", ctx, "database").Return(true, nil)
+	mockCfnOps.On("DescribeStack", ctx, "database").Return(&aws.StackInfo{Status: "CREATE_COMPLETE", TerminationProtection: true}, nil)
+
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", "Do you want to delete stack database? This cannot be undone.").Return(false, nil)
+	prompt.SetPrompter(mockPrompter)
+	defer prompt.SetPrompter(nil)
+
+	deleter := NewStackDeleter(mockFactory, &config.MockConfigProvider{}, &resolve.MockResolver{})
+	result := deleter.deleteStackWithResult(ctx, testStack, Options{Force: true})
+
+	require.NoError(t, result.Err)
+	assert.Equal(t, OutcomeCancelled, result.Outcome)
+	mockCfnOps.AssertNotCalled(t, "UpdateTerminationProtection", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteSingleStack_Retain(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         aws.StackStatus
+		expectedInput  aws.DeleteStackInput
+		expectedPrompt string
+	}{
+		{
+			name:           "delete failed stack retains resources",
+			status:         aws.StackStatusDeleteFailed,
+			expectedInput:  aws.DeleteStackInput{StackName: "app", RetainResources: []string{"LogsBucket"}},
+			expectedPrompt: "Do you want to delete stack app, retaining LogsBucket? This cannot be undone.",
+		},
+		{
+			name:           "healthy stack ignores retain",
+			status:         aws.StackStatusUpdateComplete,
+			expectedInput:  aws.DeleteStackInput{StackName: "app"},
+			expectedPrompt: "Do you want to delete stack app? This cannot be undone.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockResolver := &resolve.MockResolver{}
+
+			testStack := &model.Stack{
+				Name:    "app",
+				Context: model.NewTestContext("dev", "us-east-1", "123456789012"),
+			}
+			mockResolver.On("ResolveStack", ctx, "dev", "app").Return(testStack, nil)
+			mockConfigProvider.On("ListStacks", "dev").Return([]string{"app"}, nil)
+
+			mockCfnOps.On("StackExists", ctx, "app").Return(true, nil)
+			mockCfnOps.On("DescribeStack", ctx, "app").Return(&aws.StackInfo{Status: tt.status}, nil)
+			mockCfnOps.On("DeleteStack", ctx, tt.expectedInput).Return(nil)
+			mockCfnOps.On("WaitForStackOperation", ctx, "app", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).Return(nil)
+
+			mockPrompter := &prompt.MockPrompter{}
+			mockPrompter.On("Confirm", tt.expectedPrompt).Return(true, nil)
+			prompt.SetPrompter(mockPrompter)
+			defer prompt.SetPrompter(nil)
+
+			deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+			err := deleter.DeleteSingleStack(ctx, "app", "dev", Options{Retain: []string{"LogsBucket"}})
+
+			assert.NoError(t, err)
+			mockCfnOps.AssertExpectations(t)
+			mockPrompter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteSingleStack_JSONOutput_ReportsOutcome(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	testStack := &model.Stack{
+		Name:    "vpc",
+		Context: model.NewTestContext("dev", "us-east-1", "123456789012"),
+	}
+	mockResolver.On("ResolveStack", ctx, "dev", "vpc").Return(testStack, nil)
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"vpc"}, nil)
+
+	mockCfnOps.On("StackExists", ctx, "vpc").Return(true, nil)
+	mockCfnOps.On("DescribeStack", ctx, "vpc").Return(&aws.StackInfo{
+		ID:     "arn:aws:cloudformation:us-east-1:123456789012:stack/vpc/abc",
+		Status: "CREATE_COMPLETE",
+	}, nil)
+	mockCfnOps.On("DeleteStack", ctx, aws.DeleteStackInput{StackName: "vpc"}).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", ctx, "vpc", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).Return(nil)
+
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	prompt.SetPrompter(mockPrompter)
+	defer prompt.SetPrompter(nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	var output bytes.Buffer
+	deleter.SetOutput(&output)
+
+	err := deleter.DeleteSingleStack(ctx, "vpc", "dev", Options{JSONOutput: true})
+	require.NoError(t, err)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	assert.Equal(t, Report{
+		Context: "dev",
+		Stacks: []StackReport{{
+			StackName: "vpc",
+			Outcome:   "deleted",
+			Status:    "DELETE_COMPLETE",
+			StackID:   "arn:aws:cloudformation:us-east-1:123456789012:stack/vpc/abc",
+		}},
+	}, report)
+}
+
+func TestDeleteSingleStack_JSONOutput_ReportsFailure(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	stackID := "arn:aws:cloudformation:us-east-1:123456789012:stack/vpc/abc"
+	testStack := &model.Stack{
+		Name:    "vpc",
+		Context: model.NewTestContext("dev", "us-east-1", "123456789012"),
+	}
+	mockResolver.On("ResolveStack", ctx, "dev", "vpc").Return(testStack, nil)
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"vpc"}, nil)
+
+	mockCfnOps.On("StackExists", ctx, "vpc").Return(true, nil)
+	mockCfnOps.On("DescribeStack", ctx, "vpc").Return(&aws.StackInfo{ID: stackID, Status: "CREATE_COMPLETE"}, nil)
+	mockCfnOps.On("DeleteStack", ctx, aws.DeleteStackInput{StackName: "vpc"}).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", ctx, "vpc", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).Return(errors.New("stack operation failed"))
+	mockCfnOps.On("GetStack", ctx, stackID).Return(&aws.Stack{ID: stackID, Name: "vpc", Status: aws.StackStatusDeleteFailed}, nil)
+
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	prompt.SetPrompter(mockPrompter)
+	defer prompt.SetPrompter(nil)
+
+	deleter := NewStackDeleter(mockFactory, mockConfigProvider, mockResolver)
+	var output bytes.Buffer
+	deleter.SetOutput(&output)
+
+	err := deleter.DeleteSingleStack(ctx, "vpc", "dev", Options{JSONOutput: true})
+	require.Error(t, err)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	require.Len(t, report.Stacks, 1)
+	assert.Equal(t, "failed", report.Stacks[0].Outcome)
+	assert.Equal(t, "DELETE_FAILED", report.Stacks[0].Status)
+	assert.Contains(t, report.Stacks[0].Error, "stack operation failed")
+}
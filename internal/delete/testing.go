@@ -21,12 +21,12 @@ func (m *MockDeleter) DeleteStack(ctx context.Context, stack *model.Stack) error
 	return args.Error(0)
 }
 
-func (m *MockDeleter) DeleteSingleStack(ctx context.Context, stackName, contextName string) error {
-	args := m.Called(ctx, stackName, contextName)
+func (m *MockDeleter) DeleteSingleStack(ctx context.Context, stackName, contextName string, options Options) error {
+	args := m.Called(ctx, stackName, contextName, options)
 	return args.Error(0)
 }
 
-func (m *MockDeleter) DeleteAllStacks(ctx context.Context, contextName string) error {
-	args := m.Called(ctx, contextName)
+func (m *MockDeleter) DeleteAllStacks(ctx context.Context, contextName string, options Options) error {
+	args := m.Called(ctx, contextName, options)
 	return args.Error(0)
 }
@@ -0,0 +1,57 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/approval"
+	"codeberg.org/orien/stackaroo/internal/model"
+)
+
+// ApprovalsRequiredError indicates that a stack's change has fewer approvals than its configuration requires
+type ApprovalsRequiredError struct {
+	StackName string
+	Context   string
+	Required  int
+	Approvers []string
+}
+
+func (e ApprovalsRequiredError) Error() string {
+	approvedBy := ""
+	if len(e.Approvers) > 0 {
+		approvedBy = fmt.Sprintf(" (%s)", strings.Join(e.Approvers, ", "))
+	}
+	return fmt.Sprintf("stack %s requires %d approvals of this change but has %d%s; record approvals with 'stackaroo approve %s %s --as <name>' or pass --force",
+		e.StackName, e.Required, len(e.Approvers), approvedBy, e.Context, e.StackName)
+}
+
+// checkApprovals returns an ApprovalsRequiredError when the stack's current change has not been
+// approved by as many people as it requires. An empty path reads the default approvals file.
+func checkApprovals(path string, stack *model.Stack) error {
+	if stack.RequiredApprovals <= 0 {
+		return nil
+	}
+	if path == "" {
+		path = approval.DefaultPath
+	}
+
+	record, err := approval.Load(path)
+	if err != nil {
+		return err
+	}
+
+	approvers := record.Approvers(stack.Context.Name, stack.Name, approval.ChangeHash(stack))
+	if len(approvers) < stack.RequiredApprovals {
+		return ApprovalsRequiredError{
+			StackName: stack.Name,
+			Context:   stack.Context.Name,
+			Required:  stack.RequiredApprovals,
+			Approvers: approvers,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/approval"
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/prompt"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// approvedStack returns a stack requiring two approvals and an approvals file where the given
+// people approved its current change
+func approvedStack(t *testing.T, approvers ...string) (*model.Stack, string) {
+	t.Helper()
+
+	stack := model.NewTestStack("app", model.NewTestContext("prod", "us-east-1", "123456789012"))
+	stack.RequiredApprovals = 2
+
+	path := filepath.Join(t.TempDir(), "approvals.json")
+	record := &approval.Record{}
+	for _, approver := range approvers {
+		record.Approve(approval.Approval{StackName: "app", Context: "prod", ChangeHash: approval.ChangeHash(stack), Approver: approver})
+	}
+	require.NoError(t, record.Save(path))
+	return stack, path
+}
+
+func TestCheckApprovals_RequiredApprovalsMet(t *testing.T) {
+	stack, path := approvedStack(t, "alice", "bob")
+
+	assert.NoError(t, checkApprovals(path, stack))
+}
+
+func TestCheckApprovals_TooFewApprovals(t *testing.T) {
+	stack, path := approvedStack(t, "alice")
+
+	err := checkApprovals(path, stack)
+
+	var approvalsErr ApprovalsRequiredError
+	require.ErrorAs(t, err, &approvalsErr)
+	assert.Equal(t, []string{"alice"}, approvalsErr.Approvers)
+	assert.EqualError(t, err, "stack app requires 2 approvals of this change but has 1 (alice); record approvals with 'stackaroo approve prod app --as <name>' or pass --force")
+}
+
+func TestCheckApprovals_ChangedStackInvalidatesApprovals(t *testing.T) {
+	stack, path := approvedStack(t, "alice", "bob")
+	stack.Parameters = map[string]string{"InstanceType": "t3.large"}
+
+	err := checkApprovals(path, stack)
+
+	var approvalsErr ApprovalsRequiredError
+	require.ErrorAs(t, err, &approvalsErr)
+	assert.Empty(t, approvalsErr.Approvers)
+}
+
+func TestCheckApprovals_NotRequired(t *testing.T) {
+	stack := model.NewTestStackWithDefaults("app")
+
+	assert.NoError(t, checkApprovals(filepath.Join(t.TempDir(), "missing.json"), stack))
+}
+
+func TestDeploySingleStack_RequiredApprovals(t *testing.T) {
+	tests := []struct {
+		name      string
+		approvers []string
+		force     bool
+		wantErr   bool
+	}{
+		{name: "approved", approvers: []string{"alice", "bob"}},
+		{name: "not approved", approvers: []string{"alice"}, wantErr: true},
+		{name: "forced", approvers: []string{"alice"}, force: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			stack, path := approvedStack(t, tt.approvers...)
+
+			mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+			mockResolver := &resolve.MockResolver{}
+			mockResolver.On("ResolveStack", mock.Anything, "prod", "app").Return(stack, nil)
+			mockCfnOps.On("StackExists", mock.Anything, "app").Return(false, nil)
+			mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+			mockPrompter := &prompt.MockPrompter{}
+			mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+			deployer.SetPrompter(mockPrompter)
+
+			err := deployer.DeploySingleStack(ctx, "app", "prod", Options{ApprovalsFile: path, Force: tt.force})
+
+			if tt.wantErr {
+				var approvalsErr ApprovalsRequiredError
+				require.ErrorAs(t, err, &approvalsErr)
+				mockCfnOps.AssertNotCalled(t, "DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			mockCfnOps.AssertCalled(t, "DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
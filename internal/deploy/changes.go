@@ -0,0 +1,52 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+)
+
+// changeCounts tallies the resource changes in a changeset by action
+type changeCounts struct {
+	Add          int
+	Modify       int
+	Remove       int
+	Replacements int // Modified resources CloudFormation will replace
+}
+
+// countChanges tallies the actions and replacements of a changeset's resource changes
+func countChanges(changes []aws.ResourceChange) changeCounts {
+	var counts changeCounts
+	for _, change := range changes {
+		switch change.Action {
+		case "Add":
+			counts.Add++
+		case "Modify":
+			counts.Modify++
+			if change.Replacement == "True" {
+				counts.Replacements++
+			}
+		case "Remove":
+			counts.Remove++
+		}
+	}
+	return counts
+}
+
+// String summarises the counts, e.g. "3 to add, 1 to modify (1 replacement), 2 to remove"
+func (c changeCounts) String() string {
+	modify := fmt.Sprintf("%d to modify", c.Modify)
+	switch c.Replacements {
+	case 0:
+	case 1:
+		modify += " (1 replacement)"
+	default:
+		modify += fmt.Sprintf(" (%d replacements)", c.Replacements)
+	}
+	return strings.Join([]string{fmt.Sprintf("%d to add", c.Add), modify, fmt.Sprintf("%d to remove", c.Remove)}, ", ")
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		changes  []aws.ResourceChange
+		expected changeCounts
+		summary  string
+	}{
+		{
+			name:     "no changes",
+			expected: changeCounts{},
+			summary:  "0 to add, 0 to modify, 0 to remove",
+		},
+		{
+			name: "additions only",
+			changes: []aws.ResourceChange{
+				{Action: "Add", LogicalID: "Bucket"},
+				{Action: "Add", LogicalID: "Queue"},
+			},
+			expected: changeCounts{Add: 2},
+			summary:  "2 to add, 0 to modify, 0 to remove",
+		},
+		{
+			name: "mix of actions with a replacement",
+			changes: []aws.ResourceChange{
+				{Action: "Add", LogicalID: "Bucket"},
+				{Action: "Add", LogicalID: "Queue"},
+				{Action: "Add", LogicalID: "Topic"},
+				{Action: "Modify", LogicalID: "Database", Replacement: "True"},
+				{Action: "Remove", LogicalID: "OldBucket"},
+				{Action: "Remove", LogicalID: "OldQueue"},
+			},
+			expected: changeCounts{Add: 3, Modify: 1, Remove: 2, Replacements: 1},
+			summary:  "3 to add, 1 to modify (1 replacement), 2 to remove",
+		},
+		{
+			name: "conditional replacements are not counted",
+			changes: []aws.ResourceChange{
+				{Action: "Modify", LogicalID: "Database", Replacement: "True"},
+				{Action: "Modify", LogicalID: "Instance", Replacement: "True"},
+				{Action: "Modify", LogicalID: "Function", Replacement: "Conditional"},
+				{Action: "Modify", LogicalID: "Queue", Replacement: "False"},
+			},
+			expected: changeCounts{Modify: 4, Replacements: 2},
+			summary:  "0 to add, 4 to modify (2 replacements), 0 to remove",
+		},
+		{
+			name: "removals only",
+			changes: []aws.ResourceChange{
+				{Action: "Remove", LogicalID: "Bucket"},
+			},
+			expected: changeCounts{Remove: 1},
+			summary:  "0 to add, 0 to modify, 1 to remove",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts := countChanges(tt.changes)
+
+			assert.Equal(t, tt.expected, counts)
+			assert.Equal(t, tt.summary, counts.String())
+		})
+	}
+}
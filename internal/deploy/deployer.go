@@ -6,17 +6,22 @@ package deploy
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"time"
 
 	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/config"
 	"codeberg.org/orien/stackaroo/internal/diff"
+	"codeberg.org/orien/stackaroo/internal/log"
 	"codeberg.org/orien/stackaroo/internal/model"
 	"codeberg.org/orien/stackaroo/internal/prompt"
 	"codeberg.org/orien/stackaroo/internal/resolve"
+	"codeberg.org/orien/stackaroo/internal/snapshot"
 )
 
 // CancellationError indicates that a stack operation was cancelled by the user
@@ -37,39 +42,208 @@ func (e NoChangesError) Error() string {
 	return fmt.Sprintf("no changes detected for stack %s", e.StackName)
 }
 
+// TimeoutError indicates that a stack operation exceeded its per-stack timeout
+type TimeoutError struct {
+	StackName string
+	Timeout   time.Duration
+	Status    aws.StackStatus // Last status seen, when the timeout struck while waiting on CloudFormation
+}
+
+func (e TimeoutError) Error() string {
+	if e.Status != "" {
+		return fmt.Sprintf("deployment of stack %s timed out after %s (last status: %s)", e.StackName, e.Timeout, e.Status)
+	}
+	return fmt.Sprintf("deployment of stack %s timed out after %s", e.StackName, e.Timeout)
+}
+
+// RollbackFailedError indicates that a stack cannot be deployed until a failed rollback is resolved
+type RollbackFailedError struct {
+	StackName string
+	Context   string
+	Status    aws.StackStatus
+}
+
+func (e RollbackFailedError) Error() string {
+	if e.Status == aws.StackStatusUpdateRollbackFailed {
+		return fmt.Sprintf("stack %s is in %s and cannot be deployed; run 'stackaroo recover %s %s' to continue the rollback, then deploy again",
+			e.StackName, e.Status, e.Context, e.StackName)
+	}
+	return fmt.Sprintf("stack %s is in %s and cannot be deployed; delete it with 'stackaroo delete %s %s' and deploy it again",
+		e.StackName, e.Status, e.Context, e.StackName)
+}
+
+// TemplateChangedError indicates that a stack's configured template differs from the deployed one,
+// so the change needs a full deployment rather than a refresh
+type TemplateChangedError struct {
+	StackName string
+}
+
+func (e TemplateChangedError) Error() string {
+	return fmt.Sprintf("the template of stack %s has changed; use 'stackaroo deploy' to apply template changes", e.StackName)
+}
+
+// NotInProgressError indicates that a stack to watch has no operation under way
+type NotInProgressError struct {
+	StackName string
+	Status    aws.StackStatus
+}
+
+func (e NotInProgressError) Error() string {
+	return fmt.Sprintf("stack %s is in %s with no operation in progress; nothing to watch", e.StackName, e.Status)
+}
+
+// Options configures how stacks are deployed
+type Options struct {
+	StackTimeout       time.Duration         // Maximum time allowed for each stack (zero means no limit)
+	PollInterval       time.Duration         // Time between status checks while waiting for a stack (zero uses the default)
+	ContinueOnError    bool                  // Continue deploying independent stacks after a failure
+	SummaryFile        string                // Record deployed parameters and tags to this file (empty disables)
+	RequireStacks      bool                  // Fail instead of doing nothing when a context has no stacks
+	JSONOutput         bool                  // Print a JSON report of each stack's outcome when finished
+	Explain            bool                  // Print how each parameter value was resolved before deploying
+	ChangeSetMetadata  aws.ChangeSetMetadata // Recorded in the description of each deployment changeset
+	PruneParameters    bool                  // Fail when configured parameters are not declared by the template
+	SkipAccountCheck   bool                  // Deploy without confirming the credentials belong to the context's account
+	AllowProtected     bool                  // Permit deploying to contexts marked as protected
+	JSONEvents         bool                  // Print stack events as JSON lines instead of text
+	Watch              bool                  // Redraw a live view of resource statuses in place of event lines on a terminal
+	DryRun             bool                  // Create and show a changeset, then delete it without executing or prompting
+	Force              bool                  // Deploy stacks whose changes lack their required approvals
+	ApprovalsFile      string                // File of recorded approvals (empty uses the default file)
+	ChangeSetID        string                // Execute this changeset saved by diff instead of creating one (single stack only)
+	OnFailure          string                // Overrides each stack's on_failure when creating stacks (empty keeps the configuration)
+	DisableRollback    bool                  // Keep the resources of failed stack creations, overriding each stack's on_failure
+	NoRollback         bool                  // Leave stacks whose update fails in their failed state instead of rolling back
+	ParameterOverrides map[string]string     // Replace the resolved values of these parameters in every stack that has them
+	Timings            bool                  // Print how long each phase of each stack took when the run ends
+	Confirmed          bool                  // Skip confirmation prompts, for callers that have already asked the user
+	FailFastOnRollback bool                  // Fail a stack as soon as it starts rolling back instead of waiting for the rollback
+}
+
+// waitConfig returns how stack operations are waited on for these options
+func (o Options) waitConfig() aws.WaitConfig {
+	return aws.WaitConfig{PollInterval: o.PollInterval, FailFastOnRollback: o.FailFastOnRollback}
+}
+
+// eventSink chooses where stack events go. The watch view needs a terminal, so elsewhere,
+// such as in CI, events are printed line by line as without --watch.
+func (o Options) eventSink(w io.Writer) EventSink {
+	if o.Watch && !o.JSONEvents && diff.IsInteractiveTerminal(w) {
+		return NewWatchEventSink(w, diff.ShouldUseColour())
+	}
+	return NewEventSink(o.JSONEvents, w)
+}
+
+// StackOutcome describes how the deployment of a single stack ended
+type StackOutcome string
+
+const (
+	OutcomeDeployed  StackOutcome = "deployed"
+	OutcomeFailed    StackOutcome = "failed"
+	OutcomeTimedOut  StackOutcome = "timed-out"
+	OutcomeSkipped   StackOutcome = "skipped"
+	OutcomeNoChanges StackOutcome = "no-changes"
+	OutcomeCancelled StackOutcome = "cancelled"
+	OutcomePlanned   StackOutcome = "planned"
+)
+
+// StackResult records the outcome of deploying a single stack
+type StackResult struct {
+	StackName string
+	Outcome   StackOutcome
+	Status    aws.StackStatus // Stack status after the operation (only looked up for JSON output)
+	StackID   string          // Stack ID (only looked up for JSON output)
+	Err       error
+}
+
+// Report is the JSON document printed when JSON output is requested
+type Report struct {
+	Context string        `json:"context"`
+	Stacks  []StackReport `json:"stacks"`
+}
+
+// StackReport is the JSON representation of a StackResult
+type StackReport struct {
+	StackName string `json:"stack_name"`
+	Outcome   string `json:"outcome"`
+	Status    string `json:"status,omitempty"`
+	StackID   string `json:"stack_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
 // Deployer defines the interface for stack deployment operations
 type Deployer interface {
 	DeployStack(ctx context.Context, stack *model.Stack) error
-	DeploySingleStack(ctx context.Context, stackName, contextName string) error
-	DeployAllStacks(ctx context.Context, contextName string) error
+	DeploySingleStack(ctx context.Context, stackName, contextName string, options Options) error
+	DeployAllStacks(ctx context.Context, contextName string, options Options) error
+	WatchStack(ctx context.Context, stackName, contextName string, options Options) error
+	PlanAllStacks(ctx context.Context, contextName string, options Options) error
+	RefreshSingleStack(ctx context.Context, stackName, contextName string, options Options) error
 	ValidateTemplate(ctx context.Context, templateFile string) error
 }
 
 // StackDeployer implements Deployer using AWS CloudFormation
 type StackDeployer struct {
-	clientFactory aws.ClientFactory
-	provider      config.ConfigProvider
-	resolver      resolve.Resolver
-	prompter      prompt.Prompter // Prompter for user confirmation (injectable for testing)
+	clientFactory     aws.ClientFactory
+	provider          config.ConfigProvider
+	resolver          resolve.Resolver
+	prompter          prompt.Prompter       // Prompter for user confirmation (injectable for testing)
+	output            io.Writer             // Destination for the JSON report and stack events (injectable for testing)
+	changeSetMetadata aws.ChangeSetMetadata // Recorded on deployment changesets (set from Options)
+	accountVerifier   aws.AccountVerifier   // Confirms the credentials target the context's account (injectable for testing)
+	skipAccountCheck  bool                  // Bypasses the account verifier (set from Options)
+	allowProtected    bool                  // Permits changes to protected contexts (set from Options)
+	events            EventSink             // Receives stack events during operations (chosen from Options)
+	dryRun            bool                  // Previews changes without modifying stacks (set from Options)
+	changeSetID       string                // Existing changeset to execute instead of creating one (set from Options)
+	onFailure         string                // Overrides the on_failure of stacks created in this run (set from Options)
+	disableRollback   bool                  // Keeps the resources of failed stack creations (set from Options)
+	noRollback        bool                  // Leaves stacks whose update fails in their failed state (set from Options)
+	timings           *Timings              // Phase durations of this run (nil unless requested in Options)
+	confirmed         bool                  // Skips confirmation prompts the caller has already covered (set from Options)
+	logger            *slog.Logger          // Records deployment decisions for debugging
 }
 
 // NewStackDeployer creates a new StackDeployer
 func NewStackDeployer(clientFactory aws.ClientFactory, provider config.ConfigProvider, resolver resolve.Resolver) *StackDeployer {
 	return &StackDeployer{
-		clientFactory: clientFactory,
-		provider:      provider,
-		resolver:      resolver,
-		prompter:      prompt.NewStdinPrompter(),
+		clientFactory:   clientFactory,
+		provider:        provider,
+		resolver:        resolver,
+		prompter:        prompt.NewStdinPrompter(),
+		output:          os.Stdout,
+		accountVerifier: aws.NewAccountVerifier(clientFactory),
+		events:          &TextEventSink{w: os.Stdout},
+		logger:          log.Default(),
 	}
 }
 
+// SetLogger replaces the logger that records deployment decisions
+func (d *StackDeployer) SetLogger(logger *slog.Logger) {
+	d.logger = logger
+}
+
 // SetPrompter allows injection of a custom prompter for testing
 func (d *StackDeployer) SetPrompter(p prompt.Prompter) {
 	d.prompter = p
 }
 
+// SetOutput allows injection of the JSON report destination for testing
+func (d *StackDeployer) SetOutput(w io.Writer) {
+	d.output = w
+}
+
+// SetAccountVerifier allows injection of a custom account verifier for testing
+func (d *StackDeployer) SetAccountVerifier(v aws.AccountVerifier) {
+	d.accountVerifier = v
+}
+
 // DeployStack deploys a CloudFormation stack using changesets for preview and deployment
 func (d *StackDeployer) DeployStack(ctx context.Context, stack *model.Stack) error {
+	if err := d.checkTarget(ctx, stack); err != nil {
+		return err
+	}
+
 	// Get region-specific CloudFormation operations
 	cfnOps, err := d.clientFactory.GetCloudFormationOperations(ctx, stack.Context.Region)
 	if err != nil {
@@ -77,18 +251,97 @@ func (d *StackDeployer) DeployStack(ctx context.Context, stack *model.Stack) err
 	}
 
 	// Check if stack exists to determine deployment approach
-	exists, err := cfnOps.StackExists(ctx, stack.Name)
+	exists, err := cfnOps.StackExists(ctx, stack.CloudFormationName())
 	if err != nil {
 		return err
 	}
 
 	if !exists {
+		if d.changeSetID != "" {
+			return fmt.Errorf("stack %s does not exist; a saved changeset can only update an existing stack", stack.Name)
+		}
 		// For new stacks, use direct creation (changesets are less useful)
+		d.logger.DebugContext(ctx, "stack does not exist; creating it directly", "stack", stack.Name, "cloudformation_name", stack.CloudFormationName(), "region", stack.Context.Region)
 		return d.deployNewStack(ctx, stack, cfnOps)
 	}
 
-	// For existing stacks, use changeset approach for preview + deployment
-	return d.deployWithChangeSet(ctx, stack, cfnOps)
+	// A stack stuck after a failed rollback rejects every update, so stop before building a changeset
+	current, err := cfnOps.DescribeStack(ctx, stack.CloudFormationName())
+	if err != nil {
+		return err
+	}
+	if aws.IsRollbackFailed(current.Status) {
+		return RollbackFailedError{StackName: stack.Name, Context: stack.Context.Name, Status: current.Status}
+	}
+
+	// For existing stacks, use changeset approach for preview + deployment. A changeset saved
+	// by diff is executed as it is, so what was reviewed is exactly what is deployed.
+	if d.changeSetID != "" {
+		d.logger.DebugContext(ctx, "stack exists; executing saved changeset", "stack", stack.Name, "changeset", d.changeSetID)
+		err = d.deploySavedChangeSet(ctx, stack, cfnOps)
+	} else {
+		d.logger.DebugContext(ctx, "stack exists; updating through a changeset", "stack", stack.Name, "status", current.Status)
+		err = d.deployWithChangeSet(ctx, stack, cfnOps)
+	}
+
+	// Changesets do not cover stack settings, so apply them once the stack is up to date
+	var noChangesErr NoChangesError
+	if !d.dryRun && (err == nil || errors.As(err, &noChangesErr)) {
+		if protectionErr := d.applyTerminationProtection(ctx, stack, cfnOps); protectionErr != nil {
+			return protectionErr
+		}
+		if stack.StackPolicyBody != "" {
+			if policyErr := cfnOps.SetStackPolicy(ctx, stack.CloudFormationName(), stack.StackPolicyBody); policyErr != nil {
+				return policyErr
+			}
+		}
+	}
+	return err
+}
+
+// checkTarget refuses stacks in protected contexts unless allowed, and stacks in an account the
+// context does not target
+func (d *StackDeployer) checkTarget(ctx context.Context, stack *model.Stack) error {
+	// A dry run changes nothing, so protected contexts may be previewed
+	if stack.Context.Protected && !d.allowProtected && !d.dryRun {
+		return model.ProtectedContextError{Context: stack.Context.Name}
+	}
+
+	// Refuse to touch stacks in an account the context does not target
+	if !d.skipAccountCheck {
+		if err := d.accountVerifier.VerifyAccount(ctx, stack.Context.Name, stack.Context.Region, stack.Context.Account); err != nil {
+			return err
+		}
+	} else {
+		d.logger.DebugContext(ctx, "skipping account check", "stack", stack.Name, "context", stack.Context.Name)
+	}
+	return nil
+}
+
+// applyTerminationProtection updates an existing stack's termination protection to match its configuration
+func (d *StackDeployer) applyTerminationProtection(ctx context.Context, stack *model.Stack, cfnOps aws.CloudFormationOperations) error {
+	if stack.TerminationProtection == nil {
+		return nil
+	}
+
+	current, err := cfnOps.GetStack(ctx, stack.CloudFormationName())
+	if err != nil {
+		return err
+	}
+	if current.TerminationProtection == *stack.TerminationProtection {
+		return nil
+	}
+
+	if err := cfnOps.UpdateTerminationProtection(ctx, stack.CloudFormationName(), *stack.TerminationProtection); err != nil {
+		return err
+	}
+
+	state := "disabled"
+	if *stack.TerminationProtection {
+		state = "enabled"
+	}
+	fmt.Printf("Termination protection %s for stack %s\n", state, diff.Highlight(stack.Name))
+	return nil
 }
 
 // deployNewStack handles deployment of new stacks using direct creation
@@ -106,6 +359,7 @@ func (d *StackDeployer) deployNewStack(ctx context.Context, stack *model.Stack,
 			Key:           key,
 			ProposedValue: value,
 			ChangeType:    diff.ChangeTypeAdd,
+			Sensitive:     stack.SensitiveParameters[key],
 		})
 	}
 
@@ -122,8 +376,20 @@ func (d *StackDeployer) deployNewStack(ctx context.Context, stack *model.Stack,
 	fmt.Print(diffResult.String())
 	fmt.Println()
 
+	// A changeset for a new stack would leave an empty stack behind once deleted, so a dry run
+	// validates the template instead
+	if d.dryRun {
+		if stack.TemplateBody != "" {
+			if err := cfnOps.ValidateTemplate(ctx, stack.TemplateBody); err != nil {
+				return fmt.Errorf("template for stack %s is invalid: %w", stack.Name, err)
+			}
+		}
+		fmt.Printf("Dry run: stack %s was not created\n", diff.Highlight(stack.Name))
+		return nil
+	}
+
 	message := fmt.Sprintf("Do you want to create stack %s?", stack.Name)
-	confirmed, err := d.prompter.Confirm(message)
+	confirmed, err := d.confirm(stack, message)
 	if err != nil {
 		return err
 	}
@@ -150,27 +416,39 @@ func (d *StackDeployer) deployNewStack(ctx context.Context, stack *model.Stack,
 	}
 
 	// Set up event callback for user feedback
-	eventCallback := func(event aws.StackEvent) {
-		timestamp := event.Timestamp.Format("2006-01-02 15:04:05")
-		fmt.Printf("[%s] %-20s %-40s %s %s\n",
-			timestamp,
-			event.ResourceStatus,
-			event.ResourceType,
-			event.LogicalResourceId,
-			event.ResourceStatusReason,
-		)
-	}
+	eventCallback := d.events.WriteEvent
 
 	deployInput := aws.DeployStackInput{
-		StackName:    stack.Name,
-		TemplateBody: stack.TemplateBody,
-		Parameters:   awsParams,
-		Tags:         stack.Tags,
-		Capabilities: capabilities,
+		StackName:             stack.CloudFormationName(),
+		TemplateBody:          stack.TemplateBody,
+		TemplateURL:           stack.TemplateURL,
+		Parameters:            awsParams,
+		Tags:                  stack.Tags,
+		Capabilities:          capabilities,
+		TerminationProtection: stack.TerminationProtection,
+		StackPolicyBody:       stack.StackPolicyBody,
+		NotificationARNs:      stack.NotificationARNs,
+		UsePreviousTemplate:   stack.UsePreviousTemplate,
+		OnFailure:             stack.OnFailure,
+		TimeoutInMinutes:      stack.TimeoutInMinutes,
+		RollbackConfiguration: (*aws.RollbackConfiguration)(stack.Rollback),
+		ResourceTypes:         stack.ResourceTypes,
+		ClientRequestToken:    stack.ClientRequestToken,
+	}
+
+	// Overrides given for this run take precedence over the stack's configuration
+	if d.onFailure != "" {
+		deployInput.OnFailure = d.onFailure
+	}
+	if d.disableRollback {
+		deployInput.OnFailure = ""
+		deployInput.DisableRollback = true
 	}
 
 	// Deploy the stack with event streaming
+	stopTiming := d.timings.start(stack.Name, PhaseWait)
 	err = cfnOps.DeployStackWithCallback(ctx, deployInput, eventCallback)
+	stopTiming()
 	if err != nil {
 		return err
 	}
@@ -186,12 +464,24 @@ func (d *StackDeployer) deployWithChangeSet(ctx context.Context, stack *model.St
 
 	// Generate diff result using the same system as 'stackaroo diff'
 	// Keep changeset alive for deployment use
-	diffOptions := diff.Options{KeepChangeSet: true}
+	diffOptions := diff.Options{KeepChangeSet: true, ChangeSetMetadata: d.changeSetMetadata}
+	stopTiming := d.timings.start(stack.Name, PhaseChangeSetCreate)
 	diffResult, err := differ.DiffStack(ctx, stack, diffOptions)
+	stopTiming()
 	if err != nil {
 		return err
 	}
 
+	// A dry run only needs the changeset for its preview, so remove it however the run ends
+	if d.dryRun && diffResult.ChangeSet != nil {
+		changeSetID := diffResult.ChangeSet.ChangeSetID
+		defer func() {
+			if deleteErr := cfnOps.DeleteChangeSet(ctx, changeSetID); deleteErr != nil {
+				fmt.Printf("Warning: failed to delete changeset %s: %v\n", changeSetID, deleteErr)
+			}
+		}()
+	}
+
 	// Show preview
 	fmt.Print(diffResult.String())
 	fmt.Println()
@@ -201,8 +491,12 @@ func (d *StackDeployer) deployWithChangeSet(ctx context.Context, stack *model.St
 		// Check if this is a "no infrastructure changes" scenario (metadata-only changes)
 		var noChangesErr aws.NoChangesError
 		if errors.As(diffResult.ChangeSetError, &noChangesErr) {
-			// Treat metadata-only changes the same as no changes - no deployment needed
-			fmt.Printf("No infrastructure changes for stack %s (metadata-only changes detected)\n", diff.Highlight(stack.Name))
+			// CloudFormation rejects identical and metadata-only updates alike; neither needs a deployment
+			if diffResult.HasChanges() {
+				fmt.Printf("No infrastructure changes for stack %s (metadata-only changes detected)\n", diff.Highlight(stack.Name))
+			} else {
+				fmt.Printf("Stack %s is already up to date\n", diff.Highlight(stack.Name))
+			}
 			return NoChangesError{StackName: stack.Name}
 		}
 		return diffResult.ChangeSetError
@@ -214,9 +508,17 @@ func (d *StackDeployer) deployWithChangeSet(ctx context.Context, stack *model.St
 		return NoChangesError{StackName: stack.Name}
 	}
 
-	// Prompt for confirmation
+	if d.dryRun {
+		fmt.Printf("Dry run: changes to stack %s were not applied\n", diff.Highlight(stack.Name))
+		return nil
+	}
+
+	// Prompt for confirmation, summarising the changeset so the decision is an informed one
 	message := fmt.Sprintf("Do you want to apply these changes to stack %s?", stack.Name)
-	confirmed, err := d.prompter.Confirm(message)
+	if diffResult.ChangeSet != nil {
+		message = fmt.Sprintf("Changeset for stack %s: %s\n%s", stack.Name, countChanges(diffResult.ChangeSet.Changes), message)
+	}
+	confirmed, err := d.confirm(stack, message)
 	if err != nil {
 		// Clean up changeset on error
 		if diffResult.ChangeSet != nil {
@@ -244,8 +546,10 @@ func (d *StackDeployer) deployWithChangeSet(ctx context.Context, stack *model.St
 	// Execute the changeset
 	// Capture start time to filter events to only this deployment
 	startTime := time.Now()
+	stopTiming = d.timings.start(stack.Name, PhaseWait)
+	defer stopTiming()
 
-	err = cfnOps.ExecuteChangeSet(ctx, changeSetInfo.ChangeSetID)
+	err = cfnOps.ExecuteChangeSet(ctx, changeSetInfo.ChangeSetID, d.noRollback)
 	if err != nil {
 		// Clean up changeset on failure
 		_ = cfnOps.DeleteChangeSet(ctx, changeSetInfo.ChangeSetID)
@@ -253,18 +557,9 @@ func (d *StackDeployer) deployWithChangeSet(ctx context.Context, stack *model.St
 	}
 
 	// Wait for deployment to complete with progress updates
-	eventCallback := func(event aws.StackEvent) {
-		timestamp := event.Timestamp.Format("2006-01-02 15:04:05")
-		fmt.Printf("[%s] %-20s %-40s %s %s\n",
-			timestamp,
-			event.ResourceStatus,
-			event.ResourceType,
-			event.LogicalResourceId,
-			event.ResourceStatusReason,
-		)
-	}
-
-	err = cfnOps.WaitForStackOperation(ctx, stack.Name, startTime, eventCallback)
+	eventCallback := d.events.WriteEvent
+
+	err = cfnOps.WaitForStackOperation(ctx, stack.CloudFormationName(), startTime, eventCallback)
 	if err != nil {
 		return err
 	}
@@ -276,6 +571,59 @@ func (d *StackDeployer) deployWithChangeSet(ctx context.Context, stack *model.St
 	return nil
 }
 
+// deploySavedChangeSet executes a changeset saved by 'stackaroo diff --save-changeset' after
+// checking it still exists, belongs to the stack and can be executed
+func (d *StackDeployer) deploySavedChangeSet(ctx context.Context, stack *model.Stack, cfnOps aws.CloudFormationOperations) error {
+	changeSetInfo, err := cfnOps.DescribeChangeSet(ctx, d.changeSetID)
+	if err != nil {
+		return fmt.Errorf("changeset %s is not available: %w", d.changeSetID, err)
+	}
+	if changeSetInfo.StackName != stack.CloudFormationName() {
+		return fmt.Errorf("changeset %s belongs to stack %s, not %s", d.changeSetID, changeSetInfo.StackName, stack.CloudFormationName())
+	}
+	if changeSetInfo.ExecutionStatus != "AVAILABLE" {
+		return fmt.Errorf("changeset %s cannot be executed: its execution status is %s", d.changeSetID, changeSetInfo.ExecutionStatus)
+	}
+
+	// Show the changes being applied
+	fmt.Printf("Changeset %s for stack %s contains %d resource changes:\n", d.changeSetID, diff.Highlight(stack.Name), len(changeSetInfo.Changes))
+	for _, change := range changeSetInfo.Changes {
+		fmt.Printf("  %-8s %-40s %s\n", change.Action, change.LogicalID, change.ResourceType)
+	}
+	fmt.Println()
+
+	if d.dryRun {
+		fmt.Printf("Dry run: changeset %s was not executed\n", d.changeSetID)
+		return nil
+	}
+
+	message := fmt.Sprintf("Do you want to execute changeset %s on stack %s?", d.changeSetID, stack.Name)
+	confirmed, err := d.confirm(stack, message)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Printf("\nDeployment cancelled for stack %s\n", diff.Highlight(stack.Name))
+		return CancellationError{StackName: stack.Name}
+	}
+
+	fmt.Println() // Add spacing before deployment starts
+
+	// Capture start time to filter events to only this deployment
+	startTime := time.Now()
+	defer d.timings.start(stack.Name, PhaseWait)()
+	if err := cfnOps.ExecuteChangeSet(ctx, d.changeSetID, d.noRollback); err != nil {
+		return err
+	}
+
+	if err := cfnOps.WaitForStackOperation(ctx, stack.CloudFormationName(), startTime, d.events.WriteEvent); err != nil {
+		return err
+	}
+
+	fmt.Printf("Stack %s update completed successfully\n", diff.Highlight(stack.Name))
+	return nil
+}
+
 // ValidateTemplate validates a CloudFormation template
 // Note: This method requires region information - consider updating interface to accept region
 func (d *StackDeployer) ValidateTemplate(ctx context.Context, templateFile string) error {
@@ -317,45 +665,247 @@ func (d *StackDeployer) readTemplateFile(filename string) (string, error) {
 
 // deployStackWithFeedback deploys a stack and provides feedback
 func (d *StackDeployer) deployStackWithFeedback(ctx context.Context, stack *model.Stack, contextName string) error {
+	_, err := d.deployStackWithOutcome(ctx, stack, contextName)
+	return err
+}
+
+// deployStackWithOutcome deploys a stack, provides feedback and reports how the deployment ended
+func (d *StackDeployer) deployStackWithOutcome(ctx context.Context, stack *model.Stack, contextName string) (StackOutcome, error) {
 	err := d.DeployStack(ctx, stack)
 	if err != nil {
 		// Handle no changes - don't treat it as an error for the caller
 		var noChangesErr NoChangesError
 		if errors.As(err, &noChangesErr) {
-			return nil
+			return OutcomeNoChanges, nil
 		}
 		// Handle cancellation - don't treat it as an error for the caller
 		var cancellationErr CancellationError
 		if errors.As(err, &cancellationErr) {
-			return nil
+			return OutcomeCancelled, nil
 		}
-		return err
+		return OutcomeFailed, err
+	}
+
+	if d.dryRun {
+		return OutcomePlanned, nil
 	}
 
 	fmt.Printf("Successfully deployed stack %s in context %s\n", diff.Highlight(stack.Name), diff.Highlight(contextName))
+	return OutcomeDeployed, nil
+}
+
+// resolveAndDeploy resolves and deploys a single stack, applying the per-stack timeout if configured.
+// When shareOutputs is set, the outputs of the deployed stack are passed to the resolver for its dependents.
+func (d *StackDeployer) resolveAndDeploy(ctx context.Context, stackName, contextName string, options Options, shareOutputs bool) StackResult {
+	stackCtx := ctx
+	if options.StackTimeout > 0 {
+		var cancel context.CancelFunc
+		stackCtx, cancel = context.WithTimeout(ctx, options.StackTimeout)
+		defer cancel()
+	}
+
+	// Loading the configuration ahead of the resolver times it separately; the provider keeps the
+	// parsed file, so resolving does not load it again
+	if d.timings != nil {
+		stopTiming := d.timings.start(stackName, PhaseConfigLoad)
+		_, err := d.provider.LoadConfig(stackCtx, contextName)
+		if err == nil {
+			_, err = d.provider.GetStack(stackName, contextName)
+		}
+		stopTiming()
+		if err != nil {
+			return d.failedResult(stackCtx, stackName, err, options)
+		}
+	}
+
+	// Resolve this specific stack to get fresh parameter values
+	stopTiming := d.timings.start(stackName, PhaseResolve)
+	stack, err := d.resolver.ResolveStack(stackCtx, contextName, stackName)
+	stopTiming()
+	if err != nil {
+		return d.failedResult(stackCtx, stackName, err, options)
+	}
+	if err := resolve.ApplyParameterOverrides(stack, options.ParameterOverrides); err != nil {
+		return d.failedResult(stackCtx, stackName, err, options)
+	}
+
+	if options.Explain {
+		fmt.Print(resolve.FormatExplanation(stack))
+	}
+
+	if options.PruneParameters {
+		if err := checkDeclaredParameters(stack); err != nil {
+			return d.failedResult(stackCtx, stackName, err, options)
+		}
+	}
+
+	// A dry run changes nothing, so it needs no approvals
+	if !options.Force && !options.DryRun {
+		if err := checkApprovals(options.ApprovalsFile, stack); err != nil {
+			return d.failedResult(stackCtx, stackName, err, options)
+		}
+	}
+
+	result := StackResult{StackName: stackName}
+	outcome, err := d.deployStackWithOutcome(stackCtx, stack, contextName)
+	d.logger.DebugContext(ctx, "finished deploying stack", "stack", stackName, "context", contextName, "outcome", outcome, "error", err)
+	succeeded := err == nil && (outcome == OutcomeDeployed || outcome == OutcomeNoChanges)
+	if err != nil {
+		result = d.failedResult(stackCtx, stackName, err, options)
+	} else {
+		result.Outcome = outcome
+
+		// Record what is now deployed so later diffs can compare against it; a stack whose
+		// record cannot be written is reported as failed so the run does not claim success
+		if options.SummaryFile != "" && succeeded {
+			if err := recordSnapshot(options.SummaryFile, stack); err != nil {
+				result.Outcome = OutcomeFailed
+				result.Err = fmt.Errorf("stack %s was deployed but its summary was not recorded: %w", stackName, err)
+			}
+		}
+	}
+
+	if options.JSONOutput || (shareOutputs && succeeded) {
+		// Use the parent context so the lookup still works after a stack timeout
+		current := d.lookupStackState(ctx, stack, &result)
+
+		// Dependents read these outputs from memory rather than describing the stack again
+		if shareOutputs && succeeded && current != nil {
+			d.resolver.RecordStackOutputs(stack.Context.Region, stack.CloudFormationName(), current.Outputs)
+		}
+	}
+
+	return result
+}
+
+// lookupStackState fills in the stack's status and ID after an operation, where the stack exists,
+// and returns the stack as CloudFormation now reports it
+func (d *StackDeployer) lookupStackState(ctx context.Context, stack *model.Stack, result *StackResult) *aws.Stack {
+	cfnOps, err := d.clientFactory.GetCloudFormationOperations(ctx, stack.Context.Region)
+	if err != nil {
+		return nil
+	}
+
+	// A stack that failed to create may have been rolled back and removed, so errors are not reported
+	current, err := cfnOps.GetStack(ctx, stack.CloudFormationName())
+	if err != nil {
+		return nil
+	}
+	result.Status = current.Status
+	result.StackID = current.ID
+	return current
+}
+
+// writeReport prints the JSON report of a deployment
+func (d *StackDeployer) writeReport(contextName string, results []StackResult) error {
+	report := Report{Context: contextName, Stacks: make([]StackReport, 0, len(results))}
+	for _, result := range results {
+		stackReport := StackReport{
+			StackName: result.StackName,
+			Outcome:   string(result.Outcome),
+			Status:    string(result.Status),
+			StackID:   result.StackID,
+		}
+		if result.Err != nil {
+			stackReport.Error = result.Err.Error()
+		}
+		report.Stacks = append(report.Stacks, stackReport)
+	}
+
+	encoder := json.NewEncoder(d.output)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write deployment report: %w", err)
+	}
 	return nil
 }
 
-// DeploySingleStack handles deployment of a single stack
-func (d *StackDeployer) DeploySingleStack(ctx context.Context, stackName, contextName string) error {
-	// Resolve single stack
-	stack, err := d.resolver.ResolveStack(ctx, contextName, stackName)
+// recordSnapshot saves the deployed parameters and tags of a stack to the summary file
+func recordSnapshot(summaryFile string, stack *model.Stack) error {
+	summary, err := snapshot.Load(summaryFile)
 	if err != nil {
 		return err
 	}
 
-	return d.deployStackWithFeedback(ctx, stack, contextName)
+	summary.Record(snapshot.StackSnapshot{
+		StackName:  stack.Name,
+		Context:    stack.Context.Name,
+		DeployedAt: time.Now().UTC(),
+		Parameters: diff.MaskSensitive(stack.Parameters, stack.SensitiveParameters),
+		Tags:       stack.Tags,
+	})
+
+	return summary.Save(summaryFile)
+}
+
+// failedResult builds a failure result, distinguishing per-stack timeouts from other errors
+func (d *StackDeployer) failedResult(stackCtx context.Context, stackName string, err error, options Options) StackResult {
+	if options.StackTimeout > 0 && errors.Is(stackCtx.Err(), context.DeadlineExceeded) && errors.Is(err, context.DeadlineExceeded) {
+		timeoutErr := TimeoutError{StackName: stackName, Timeout: options.StackTimeout}
+		var waitErr aws.WaitTimeoutError
+		if errors.As(err, &waitErr) {
+			timeoutErr.Status = waitErr.Status
+		}
+		return StackResult{StackName: stackName, Outcome: OutcomeTimedOut, Err: timeoutErr}
+	}
+	return StackResult{StackName: stackName, Outcome: OutcomeFailed, Err: err}
+}
+
+// DeploySingleStack handles deployment of a single stack
+func (d *StackDeployer) DeploySingleStack(ctx context.Context, stackName, contextName string, options Options) error {
+	d.changeSetMetadata = options.ChangeSetMetadata
+	d.onFailure = options.OnFailure
+	d.disableRollback = options.DisableRollback
+	d.noRollback = options.NoRollback
+	d.changeSetID = options.ChangeSetID
+	d.skipAccountCheck = options.SkipAccountCheck
+	d.allowProtected = options.AllowProtected
+	d.dryRun = options.DryRun
+	d.confirmed = options.Confirmed
+	d.events = options.eventSink(d.output)
+	d.clientFactory.SetWaitConfig(options.waitConfig())
+	d.startTimings(options)
+	defer d.printTimings()
+	result := d.resolveAndDeploy(ctx, stackName, contextName, options, false)
+	if options.JSONOutput {
+		if err := d.writeReport(contextName, []StackResult{result}); err != nil {
+			return err
+		}
+	}
+	return result.Err
 }
 
 // DeployAllStacks handles deployment of all stacks in a context
-func (d *StackDeployer) DeployAllStacks(ctx context.Context, contextName string) error {
+func (d *StackDeployer) DeployAllStacks(ctx context.Context, contextName string, options Options) error {
+	if options.ChangeSetID != "" {
+		return fmt.Errorf("a saved changeset can only be deployed to a single stack")
+	}
+	d.changeSetMetadata = options.ChangeSetMetadata
+	d.onFailure = options.OnFailure
+	d.disableRollback = options.DisableRollback
+	d.noRollback = options.NoRollback
+	d.skipAccountCheck = options.SkipAccountCheck
+	d.allowProtected = options.AllowProtected
+	d.dryRun = options.DryRun
+	d.confirmed = options.Confirmed
+	d.events = options.eventSink(d.output)
+	d.clientFactory.SetWaitConfig(options.waitConfig())
+	d.startTimings(options)
+	defer d.printTimings()
+
 	// Get list of stacks to deploy
 	stackNames, err := d.provider.ListStacks(contextName)
 	if err != nil {
 		return err
 	}
 	if len(stackNames) == 0 {
+		if options.RequireStacks {
+			return fmt.Errorf("no stacks found in context %s", contextName)
+		}
 		fmt.Printf("No stacks found in context %s\n", diff.Highlight(contextName))
+		if options.JSONOutput {
+			return d.writeReport(contextName, nil)
+		}
 		return nil
 	}
 
@@ -365,19 +915,191 @@ func (d *StackDeployer) DeployAllStacks(ctx context.Context, contextName string)
 		return err
 	}
 
+	dependedOn, err := d.dependedOnStacks(contextName, deploymentOrder)
+	if err != nil {
+		return err
+	}
+
+	results := make([]StackResult, 0, len(deploymentOrder))
+	unsuccessful := make(map[string]bool)
+
 	// Deploy each stack in dependency order, resolving individually to get fresh parameters
 	for _, stackName := range deploymentOrder {
-		// Resolve this specific stack to get fresh parameter values
-		stack, err := d.resolver.ResolveStack(ctx, contextName, stackName)
-		if err != nil {
-			return err
+		// Skip stacks whose dependencies did not deploy
+		if len(unsuccessful) > 0 {
+			blockedBy, err := d.unsuccessfulDependency(stackName, contextName, unsuccessful)
+			if err != nil {
+				return err
+			}
+			if blockedBy != "" {
+				fmt.Printf("Skipping stack %s because dependency %s did not deploy\n", diff.Highlight(stackName), diff.Highlight(blockedBy))
+				unsuccessful[stackName] = true
+				results = append(results, StackResult{StackName: stackName, Outcome: OutcomeSkipped})
+				continue
+			}
 		}
 
-		err = d.deployStackWithFeedback(ctx, stack, contextName)
-		if err != nil {
+		result := d.resolveAndDeploy(ctx, stackName, contextName, options, dependedOn[stackName])
+		results = append(results, result)
+
+		if result.Err != nil {
+			if result.Outcome == OutcomeTimedOut {
+				fmt.Printf("Stack %s timed out after %s\n", diff.Highlight(stackName), options.StackTimeout)
+			}
+			// Credential, account and protection failures would repeat for every remaining stack, so always stop at the first
+			var credentialsErr aws.CredentialsError
+			credentialsFailed := errors.As(result.Err, &credentialsErr)
+			if !options.ContinueOnError || credentialsFailed || blocksEveryStack(result.Err) {
+				if options.JSONOutput {
+					if err := d.writeReport(contextName, results); err != nil {
+						return err
+					}
+				}
+				if credentialsFailed {
+					return credentialsErr
+				}
+				return result.Err
+			}
+			fmt.Printf("Stack %s failed: %v\n", diff.Highlight(stackName), result.Err)
+			unsuccessful[stackName] = true
+		}
+	}
+
+	if options.ContinueOnError {
+		printSummary("Deployment summary", results)
+	}
+
+	if options.JSONOutput {
+		if err := d.writeReport(contextName, results); err != nil {
 			return err
 		}
 	}
 
+	if len(unsuccessful) > 0 {
+		return fmt.Errorf("deployment did not complete for %d of %d stacks in context %s", len(unsuccessful), len(results), contextName)
+	}
+	return nil
+}
+
+// WatchStack streams the events of an operation already in progress on a stack until it finishes,
+// without changing the stack. It fails if no operation is in progress.
+func (d *StackDeployer) WatchStack(ctx context.Context, stackName, contextName string, options Options) error {
+	d.events = options.eventSink(d.output)
+	d.clientFactory.SetWaitConfig(options.waitConfig())
+
+	cfg, err := d.provider.LoadConfig(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if _, err := d.provider.GetStack(stackName, contextName); err != nil {
+		return err
+	}
+
+	region := cfg.Context.Region
+	cfnOps, err := d.clientFactory.GetCloudFormationOperations(ctx, region)
+	if err != nil {
+		return fmt.Errorf("failed to get CloudFormation operations for region %s: %w", region, err)
+	}
+
+	deployedName := cfg.Context.DeployedStackName(stackName)
+	current, err := cfnOps.GetStack(ctx, deployedName)
+	if err != nil {
+		return err
+	}
+	if !aws.IsOperationInProgress(current.Status) {
+		return NotInProgressError{StackName: stackName, Status: current.Status}
+	}
+
+	// Show the events of the operation under way, which began at the stack's last update or its creation
+	startTime := time.Now()
+	if current.UpdatedTime != nil {
+		startTime = *current.UpdatedTime
+	} else if current.CreatedTime != nil {
+		startTime = *current.CreatedTime
+	}
+
+	fmt.Printf("Watching stack %s (%s)...\n", diff.Highlight(stackName), current.Status)
+	if err := cfnOps.WaitForStackOperation(ctx, deployedName, startTime, d.events.WriteEvent); err != nil {
+		return err
+	}
+
+	fmt.Printf("Operation on stack %s completed successfully\n", diff.Highlight(stackName))
 	return nil
 }
+
+// confirm asks the user to confirm a change, unless the run was confirmed up front or with --yes.
+// Changes in a protected context are confirmed by typing the context name rather than y/N.
+func (d *StackDeployer) confirm(stack *model.Stack, message string) (bool, error) {
+	if d.confirmed || prompt.AutoApprove() {
+		return true, nil
+	}
+	if stack.Context.Protected {
+		return d.prompter.ConfirmWithPhrase(fmt.Sprintf("%s\nStack %s is in protected %s.", message, stack.Name, stack.Context.Describe()), stack.Context.Name)
+	}
+	return d.prompter.Confirm(message)
+}
+
+// startTimings begins a fresh record of phase durations when the options ask for timings
+func (d *StackDeployer) startTimings(options Options) {
+	d.timings = nil
+	if options.Timings {
+		d.timings = NewTimings()
+	}
+}
+
+// printTimings prints the recorded phase durations to stderr, keeping them out of any JSON
+// report on standard output
+func (d *StackDeployer) printTimings() {
+	if len(d.timings.Stacks()) > 0 {
+		fmt.Fprint(os.Stderr, d.timings.Format())
+	}
+}
+
+// blocksEveryStack reports whether an error comes from a check that every stack in the context would fail
+func blocksEveryStack(err error) bool {
+	var mismatchErr aws.AccountMismatchError
+	var protectedErr model.ProtectedContextError
+	return errors.As(err, &mismatchErr) || errors.As(err, &protectedErr)
+}
+
+// dependedOnStacks returns the stacks that other stacks in the deployment depend on
+func (d *StackDeployer) dependedOnStacks(contextName string, stackNames []string) (map[string]bool, error) {
+	dependedOn := make(map[string]bool)
+	for _, stackName := range stackNames {
+		stackConfig, err := d.provider.GetStack(stackName, contextName)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range stackConfig.Dependencies {
+			dependedOn[dep] = true
+		}
+	}
+	return dependedOn, nil
+}
+
+// unsuccessfulDependency returns the first dependency of a stack that did not deploy, if any
+func (d *StackDeployer) unsuccessfulDependency(stackName, contextName string, unsuccessful map[string]bool) (string, error) {
+	stackConfig, err := d.provider.GetStack(stackName, contextName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, dep := range stackConfig.Dependencies {
+		if unsuccessful[dep] {
+			return dep, nil
+		}
+	}
+	return "", nil
+}
+
+// printSummary prints the outcome of each stack in a multi-stack deployment or plan under a title
+func printSummary(title string, results []StackResult) {
+	fmt.Printf("\n%s:\n", title)
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Printf("  %-30s %s (%v)\n", result.StackName, result.Outcome, result.Err)
+		} else {
+			fmt.Printf("  %-30s %s\n", result.StackName, result.Outcome)
+		}
+	}
+}
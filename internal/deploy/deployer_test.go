@@ -5,17 +5,23 @@ SPDX-License-Identifier: BSD-3-Clause
 package deploy
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/config"
 	"codeberg.org/orien/stackaroo/internal/model"
 	"codeberg.org/orien/stackaroo/internal/prompt"
 	"codeberg.org/orien/stackaroo/internal/resolve"
+	"codeberg.org/orien/stackaroo/internal/snapshot"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -160,7 +166,7 @@ func TestDeploySingleStack_ResolverError(t *testing.T) {
 	mockProvider.On("LoadConfig", ctx, "test-context").Return((*config.Config)(nil), expectedError)
 
 	// Test execution - should propagate resolver error
-	err := deployer.DeploySingleStack(ctx, "test-stack", "test-context")
+	err := deployer.DeploySingleStack(ctx, "test-stack", "test-context", Options{})
 
 	// Verify error is propagated correctly
 	assert.Error(t, err)
@@ -196,7 +202,7 @@ func TestDeployAllStacks_ConfigLoadError(t *testing.T) {
 	mockProvider.On("LoadConfig", ctx, "test-context").Return((*config.Config)(nil), expectedError)
 
 	// Test execution - will fail when resolver tries to load config for individual stack resolution
-	err := deployer.DeployAllStacks(ctx, "test-context")
+	err := deployer.DeployAllStacks(ctx, "test-context", Options{})
 
 	// Should fail during config loading for individual stack resolution
 	assert.Error(t, err)
@@ -221,12 +227,30 @@ func TestDeployAllStacks_EmptyContext(t *testing.T) {
 	mockProvider.On("ListStacks", "empty-context").Return([]string{}, nil)
 
 	// Execute - should handle empty context gracefully
-	err := deployer.DeployAllStacks(ctx, "empty-context")
+	err := deployer.DeployAllStacks(ctx, "empty-context", Options{})
 	assert.NoError(t, err, "Should handle empty context without error")
 
 	mockProvider.AssertExpectations(t)
 }
 
+// TestDeployAllStacks_EmptyContext_RequireStacks tests that an empty context fails when stacks are required
+func TestDeployAllStacks_EmptyContext_RequireStacks(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	mockResolver := resolve.NewStackResolver(mockProvider, mockFactory)
+	deployer := NewStackDeployer(mockFactory, mockProvider, mockResolver)
+
+	mockProvider.On("ListStacks", "empty-context").Return([]string{}, nil)
+
+	err := deployer.DeployAllStacks(ctx, "empty-context", Options{RequireStacks: true})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no stacks found in context empty-context")
+	mockProvider.AssertExpectations(t)
+}
+
 // TestDeployAllStacks_ProviderError tests error handling when provider fails
 func TestDeployAllStacks_ProviderError(t *testing.T) {
 	ctx := context.Background()
@@ -244,7 +268,7 @@ func TestDeployAllStacks_ProviderError(t *testing.T) {
 	mockProvider.On("ListStacks", "error-context").Return([]string(nil), expectedError)
 
 	// Execute - should propagate provider error
-	err := deployer.DeployAllStacks(ctx, "error-context")
+	err := deployer.DeployAllStacks(ctx, "error-context", Options{})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to list stacks")
 
@@ -346,6 +370,381 @@ func TestStackDeployer_DeployStack_NoChanges(t *testing.T) {
 	mockCfnOps.AssertExpectations(t)
 }
 
+func TestStackDeployer_DeployStack_RollbackFailed(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     aws.StackStatus
+		suggestion string
+	}{
+		{name: "update rollback failed", status: aws.StackStatusUpdateRollbackFailed, suggestion: "stackaroo recover dev test-stack"},
+		{name: "create rollback failed", status: aws.StackStatusRollbackFailed, suggestion: "stackaroo delete dev test-stack"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(true, nil)
+			mockCfnOps.On("DescribeStack", mock.Anything, "test-stack").Return(&aws.StackInfo{
+				Name:   "test-stack",
+				Status: tt.status,
+			}, nil)
+
+			deployer := createMockDeployer(mockFactory)
+			stack := &model.Stack{
+				Name:         "test-stack",
+				Context:      model.NewTestContext("dev", "us-east-1", "123456789012"),
+				TemplateBody: `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+			}
+
+			err := deployer.DeployStack(ctx, stack)
+
+			var rollbackErr RollbackFailedError
+			require.ErrorAs(t, err, &rollbackErr)
+			assert.Equal(t, tt.status, rollbackErr.Status)
+			assert.Contains(t, err.Error(), tt.suggestion)
+			mockCfnOps.AssertNotCalled(t, "GetTemplate", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestStackDeployer_DeployStack_AccountMismatch(t *testing.T) {
+	// Credentials for another account stop the deployment before CloudFormation is touched
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockSTS := &aws.MockSTSOperations{}
+	mockSTS.On("GetAccountID", mock.Anything).Return("999999999999", nil)
+	mockFactory.SetSTSOperations("us-east-1", mockSTS)
+
+	deployer := createMockDeployer(mockFactory)
+	stack := &model.Stack{
+		Name:         "test-stack",
+		Context:      model.NewTestContext("dev", "us-east-1", "123456789012"),
+		TemplateBody: `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+	}
+
+	err := deployer.DeployStack(ctx, stack)
+
+	var mismatch aws.AccountMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "999999999999", mismatch.Actual)
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
+
+func TestStackDeployer_DeployStack_ProtectedContext(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	deployer := createMockDeployer(mockFactory)
+	protected := model.NewTestContext("prod", "us-east-1", "123456789012")
+	protected.Protected = true
+	stack := &model.Stack{
+		Name:         "test-stack",
+		Context:      protected,
+		TemplateBody: `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+	}
+
+	err := deployer.DeployStack(ctx, stack)
+
+	require.ErrorAs(t, err, &model.ProtectedContextError{})
+	assert.Contains(t, err.Error(), "--allow-protected")
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
+
+func TestDeployAllStacks_ProtectedContext_StopsDespiteContinueOnError(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	protected := model.NewTestContext("prod", "us-east-1", "123456789012")
+	protected.Protected = true
+	mockProvider.On("ListStacks", "prod").Return([]string{"app", "vpc"}, nil)
+	mockResolver.On("GetDependencyOrder", "prod", []string{"app", "vpc"}).Return([]string{"app", "vpc"}, nil)
+	mockResolver.On("ResolveStack", mock.Anything, "prod", "app").Return(model.NewTestStack("app", protected), nil)
+	mockProvider.On("GetStack", "app", "prod").Return(&config.StackConfig{Name: "app"}, nil)
+	mockProvider.On("GetStack", "vpc", "prod").Return(&config.StackConfig{Name: "vpc"}, nil)
+
+	deployer := NewStackDeployer(mockFactory, mockProvider, mockResolver)
+	err := deployer.DeployAllStacks(ctx, "prod", Options{ContinueOnError: true})
+
+	require.EqualError(t, err, "context prod is protected; pass --allow-protected to change its stacks")
+	mockResolver.AssertNotCalled(t, "ResolveStack", mock.Anything, "prod", "vpc")
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
+
+func TestDeploySingleStack_AllowProtected(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+
+	protected := model.NewTestContext("prod", "us-east-1", "123456789012")
+	protected.Protected = true
+	mockResolver.On("ResolveStack", mock.Anything, "prod", "vpc").Return(model.NewTestStack("vpc", protected), nil)
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("ConfirmWithPhrase", mock.Anything, "prod").Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "vpc", "prod", Options{AllowProtected: true})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+}
+
+func TestDeploySingleStack_ProtectedContext_RefusesWithoutPhrase(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+
+	protected := model.NewTestContext("prod", "us-east-1", "123456789012")
+	protected.Protected = true
+	stack := model.NewTestStack("vpc", protected)
+	mockResolver.On("ResolveStack", mock.Anything, "prod", "vpc").Return(stack, nil)
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("ConfirmWithPhrase", mock.MatchedBy(func(message string) bool {
+		return strings.Contains(message, "protected context prod (account 123456789012, region us-east-1)")
+	}), "prod").Return(false, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "vpc", "prod", Options{AllowProtected: true})
+
+	require.NoError(t, err, "a declined deployment is cancelled rather than failed")
+	mockPrompter.AssertExpectations(t)
+	mockCfnOps.AssertNotCalled(t, "DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeploySingleStack_ProtectedContext_ConfirmedSkipsPhrase(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+
+	protected := model.NewTestContext("prod", "us-east-1", "123456789012")
+	protected.Protected = true
+	mockResolver.On("ResolveStack", mock.Anything, "prod", "vpc").Return(model.NewTestStack("vpc", protected), nil)
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "vpc", "prod", Options{AllowProtected: true, Confirmed: true})
+
+	require.NoError(t, err)
+	mockPrompter.AssertNotCalled(t, "ConfirmWithPhrase", mock.Anything, mock.Anything)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeploySingleStack_SkipAccountCheck(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockSTS := &aws.MockSTSOperations{}
+	mockFactory.SetSTSOperations("us-east-1", mockSTS)
+	mockResolver := &resolve.MockResolver{}
+
+	stack := model.NewTestStack("vpc", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "vpc").Return(stack, nil)
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "vpc", "dev", Options{SkipAccountCheck: true})
+
+	require.NoError(t, err)
+	mockSTS.AssertNotCalled(t, "GetAccountID", mock.Anything)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestStackDeployer_DeployStack_NoChanges_AppliesTerminationProtection(t *testing.T) {
+	// Termination protection is applied even when the template and parameters are unchanged
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(true, nil)
+	mockCfnOps.On("DescribeStack", mock.Anything, "test-stack").Return(&aws.StackInfo{
+		Name:       "test-stack",
+		Status:     "UPDATE_COMPLETE",
+		Parameters: map[string]string{},
+		Tags:       map[string]string{},
+	}, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "test-stack").Return(`{"AWSTemplateFormatVersion": "2010-09-09"}`, nil)
+	mockCfnOps.On("GetStack", mock.Anything, "test-stack").Return(&aws.Stack{Name: "test-stack", TerminationProtection: false}, nil)
+	mockCfnOps.On("UpdateTerminationProtection", mock.Anything, "test-stack", true).Return(nil)
+
+	deployer := createMockDeployer(mockFactory)
+
+	enabled := true
+	stack := &model.Stack{
+		Name:                  "test-stack",
+		Context:               model.NewTestContext("dev", "us-east-1", "123456789012"),
+		TemplateBody:          `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+		Parameters:            map[string]string{},
+		Tags:                  map[string]string{},
+		TerminationProtection: &enabled,
+	}
+
+	err := deployer.DeployStack(ctx, stack)
+
+	var noChangesErr NoChangesError
+	require.ErrorAs(t, err, &noChangesErr)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestStackDeployer_DeployStack_NoChanges_SetsStackPolicy(t *testing.T) {
+	// Changesets cannot carry a stack policy, so it is set separately on existing stacks
+	ctx := context.Background()
+	policy := `{"Statement":[{"Effect":"Allow","Action":"Update:*","Principal":"*","Resource":"*"}]}`
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(true, nil)
+	mockCfnOps.On("DescribeStack", mock.Anything, "test-stack").Return(&aws.StackInfo{
+		Name:       "test-stack",
+		Status:     "UPDATE_COMPLETE",
+		Parameters: map[string]string{},
+		Tags:       map[string]string{},
+	}, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "test-stack").Return(`{"AWSTemplateFormatVersion": "2010-09-09"}`, nil)
+	mockCfnOps.On("SetStackPolicy", mock.Anything, "test-stack", policy).Return(nil)
+
+	deployer := createMockDeployer(mockFactory)
+
+	stack := &model.Stack{
+		Name:            "test-stack",
+		Context:         model.NewTestContext("dev", "us-east-1", "123456789012"),
+		TemplateBody:    `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+		Parameters:      map[string]string{},
+		Tags:            map[string]string{},
+		StackPolicyBody: policy,
+	}
+
+	err := deployer.DeployStack(ctx, stack)
+
+	var noChangesErr NoChangesError
+	require.ErrorAs(t, err, &noChangesErr)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestStackDeployer_DeployStack_NewStack_PassesTerminationProtection(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.TerminationProtection != nil && *input.TerminationProtection
+	}), mock.Anything).Return(nil)
+
+	deployer := createMockDeployerWithConfirm(mockFactory, true)
+
+	enabled := true
+	stack := model.NewTestStack("test-stack", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.TerminationProtection = &enabled
+
+	err := deployer.DeployStack(ctx, stack)
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+	mockCfnOps.AssertNotCalled(t, "UpdateTerminationProtection", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStackDeployer_DeployStack_NewStack_PassesNotificationARNs(t *testing.T) {
+	ctx := context.Background()
+	topics := []string{"arn:aws:sns:us-east-1:123456789012:stack-events"}
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return assert.ObjectsAreEqual(topics, input.NotificationARNs)
+	}), mock.Anything).Return(nil)
+
+	deployer := createMockDeployerWithConfirm(mockFactory, true)
+
+	stack := model.NewTestStack("test-stack", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.NotificationARNs = topics
+
+	err := deployer.DeployStack(ctx, stack)
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestStackDeployer_DeployStack_NewStack_PassesTimeoutInMinutes(t *testing.T) {
+	ctx := context.Background()
+	timeout := int32(15)
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.TimeoutInMinutes != nil && *input.TimeoutInMinutes == timeout
+	}), mock.Anything).Return(nil)
+
+	deployer := createMockDeployerWithConfirm(mockFactory, true)
+
+	stack := model.NewTestStack("test-stack", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.TimeoutInMinutes = &timeout
+
+	err := deployer.DeployStack(ctx, stack)
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestStackDeployer_DeployStack_NewStack_PassesRollbackConfiguration(t *testing.T) {
+	ctx := context.Background()
+	alarms := []string{"arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-5xx"}
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.RollbackConfiguration != nil && assert.ObjectsAreEqual(alarms, input.RollbackConfiguration.AlarmARNs)
+	}), mock.Anything).Return(nil)
+
+	deployer := createMockDeployerWithConfirm(mockFactory, true)
+
+	stack := model.NewTestStack("test-stack", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.Rollback = &model.RollbackConfiguration{AlarmARNs: alarms}
+
+	err := deployer.DeployStack(ctx, stack)
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestStackDeployer_DeployStack_NewStack_PassesTemplateURL(t *testing.T) {
+	ctx := context.Background()
+	templateURL := "https://templates.s3.us-east-1.amazonaws.com/vpc.yaml"
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.TemplateURL == templateURL
+	}), mock.Anything).Return(nil)
+
+	deployer := createMockDeployerWithConfirm(mockFactory, true)
+
+	stack := model.NewTestStack("test-stack", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.TemplateURL = templateURL
+
+	err := deployer.DeployStack(ctx, stack)
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+}
+
 func TestStackDeployer_DeployStack_WithChanges(t *testing.T) {
 	// Test successful deployment with changes
 	ctx := context.Background()
@@ -383,10 +782,10 @@ func TestStackDeployer_DeployStack_WithChanges(t *testing.T) {
 			},
 		},
 	}
-	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, "test-stack", templateContent, map[string]string{}, []string{"CAPABILITY_IAM"}, map[string]string{}).Return(changeSetInfo, nil)
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, aws.ChangeSetInput{StackName: "test-stack", TemplateBody: templateContent, Parameters: map[string]string{}, Capabilities: []string{"CAPABILITY_IAM"}, Tags: map[string]string{}}).Return(changeSetInfo, nil)
 
 	// Mock execute changeset using abstracted method
-	mockCfnOps.On("ExecuteChangeSet", mock.Anything, "test-changeset-id").Return(nil)
+	mockCfnOps.On("ExecuteChangeSet", mock.Anything, "test-changeset-id", false).Return(nil)
 
 	// Mock wait for stack operation
 	mockCfnOps.On("WaitForStackOperation", mock.Anything, "test-stack", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).Return(nil)
@@ -684,7 +1083,7 @@ func TestDeployStack_ExistingStack_UserCancelsChangeset(t *testing.T) {
 			},
 		},
 	}
-	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, "test-stack", `{"AWSTemplateFormatVersion": "2010-09-09", "Resources": {"NewBucket": {"Type": "AWS::S3::Bucket"}}}`, map[string]string{"Environment": "test"}, []string{"CAPABILITY_IAM"}, map[string]string{"Project": "stackaroo"}).Return(changeSetInfo, nil)
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, aws.ChangeSetInput{StackName: "test-stack", TemplateBody: `{"AWSTemplateFormatVersion": "2010-09-09", "Resources": {"NewBucket": {"Type": "AWS::S3::Bucket"}}}`, Parameters: map[string]string{"Environment": "test"}, Capabilities: []string{"CAPABILITY_IAM"}, Tags: map[string]string{"Project": "stackaroo"}}).Return(changeSetInfo, nil)
 
 	// Mock changeset deletion (cleanup after cancellation)
 	mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-123").Return(nil)
@@ -712,52 +1111,139 @@ func TestDeployStack_ExistingStack_UserCancelsChangeset(t *testing.T) {
 	assert.ErrorAs(t, err, &cancellationErr)
 	assert.Equal(t, "test-stack", cancellationErr.StackName)
 	mockCfnOps.AssertExpectations(t)
+	deployer.prompter.(*prompt.MockPrompter).AssertCalled(t, "Confirm",
+		"Changeset for stack test-stack: 1 to add, 0 to modify, 0 to remove\nDo you want to apply these changes to stack test-stack?")
 }
 
-func TestDeployStack_ExistingStack_ChangeSetGenerationFails(t *testing.T) {
-	// Test that deployment fails early when changeset generation fails (e.g., invalid parameter)
+func TestDeployStack_ExistingStack_PassesChangeSetMetadata(t *testing.T) {
+	// Test that changeset metadata from the deploy options reaches changeset creation
 	ctx := context.Background()
-
-	// Set up mocks
 	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
 
-	// Mock StackExists call (existing stack)
 	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(true, nil)
-
-	// Mock differ operations
-	currentStackInfo := &aws.StackInfo{
+	mockCfnOps.On("DescribeStack", mock.Anything, "test-stack").Return(&aws.StackInfo{
 		Name:       "test-stack",
 		Status:     "UPDATE_COMPLETE",
-		Parameters: map[string]string{"Environment": "test"},
-		Tags:       map[string]string{"Project": "stackaroo"},
-	}
-	mockCfnOps.On("DescribeStack", mock.Anything, "test-stack").Return(currentStackInfo, nil)
-	mockCfnOps.On("GetTemplate", mock.Anything, "test-stack").Return(`{"AWSTemplateFormatVersion": "2010-09-09", "Resources": {"Bucket": {"Type": "AWS::S3::Bucket"}}}`, nil)
+		Parameters: map[string]string{},
+		Tags:       map[string]string{},
+	}, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "test-stack").Return(`{"Resources": {}}`, nil)
 
-	// Mock changeset creation failure (e.g., invalid parameter)
-	changeSetError := errors.New("operation error CloudFormation: CreateChangeSet, api error ValidationError: Parameter values specified for a template which does not require them")
-	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, "test-stack", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return((*aws.ChangeSetInfo)(nil), changeSetError)
+	metadata := aws.ChangeSetMetadata{Commit: "a1b2c3d", User: "alice"}
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool {
+		return input.StackName == "test-stack" && input.Metadata == metadata
+	})).Return(&aws.ChangeSetInfo{
+		ChangeSetID: "changeset-123",
+		Status:      "CREATE_COMPLETE",
+		Changes:     []aws.ResourceChange{{Action: "Add", ResourceType: "AWS::S3::Bucket", LogicalID: "Bucket"}},
+	}, nil)
+	mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-123").Return(nil)
 
-	// Create deployer - we should never reach the confirm prompt
-	deployer := createMockDeployer(mockFactory)
+	deployer := createMockDeployerWithConfirm(mockFactory, false)
+	deployer.changeSetMetadata = metadata
 
-	// Create resolved stack with invalid parameter
 	stack := &model.Stack{
 		Name:         "test-stack",
 		Context:      model.NewTestContext("dev", "us-east-1", "123456789012"),
-		TemplateBody: `{"AWSTemplateFormatVersion": "2010-09-09", "Resources": {"Bucket": {"Type": "AWS::S3::Bucket"}}}`,
-		Parameters:   map[string]string{"InvalidParam": "value"}, // This parameter doesn't exist in template
-		Tags:         map[string]string{"Project": "stackaroo"},
-		Dependencies: []string{},
-		Capabilities: []string{"CAPABILITY_IAM"},
+		TemplateBody: `{"Resources": {"Bucket": {"Type": "AWS::S3::Bucket"}}}`,
+		Parameters:   map[string]string{},
+		Tags:         map[string]string{},
 	}
 
-	// Execute
 	err := deployer.DeployStack(ctx, stack)
 
-	// Verify that deployment fails with changeset error
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "Parameter values specified for a template which does not require them")
+	var cancellationErr CancellationError
+	assert.ErrorAs(t, err, &cancellationErr)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeployStack_ExistingStack_S3Template(t *testing.T) {
+	// Test that an existing stack is updated from its S3 template URL rather than an inline body
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	templateURL := "https://templates.s3.us-east-1.amazonaws.com/app.yaml"
+	templateBody := `{"Resources": {"Bucket": {"Type": "AWS::S3::Bucket"}}}`
+
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(true, nil)
+	mockCfnOps.On("DescribeStack", mock.Anything, "test-stack").Return(&aws.StackInfo{
+		Name:       "test-stack",
+		Status:     "UPDATE_COMPLETE",
+		Parameters: map[string]string{},
+		Tags:       map[string]string{},
+	}, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "test-stack").Return(`{"Resources": {}}`, nil)
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool {
+		return input.StackName == "test-stack" && input.TemplateBody == templateBody && input.TemplateURL == templateURL
+	})).Return(&aws.ChangeSetInfo{
+		ChangeSetID: "changeset-123",
+		Status:      "CREATE_COMPLETE",
+		Changes:     []aws.ResourceChange{{Action: "Add", ResourceType: "AWS::S3::Bucket", LogicalID: "Bucket"}},
+	}, nil)
+	mockCfnOps.On("ExecuteChangeSet", mock.Anything, "changeset-123", false).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", mock.Anything, "test-stack", mock.Anything, mock.Anything).Return(nil)
+	mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-123").Return(nil)
+
+	deployer := createMockDeployerWithConfirm(mockFactory, true)
+
+	stack := &model.Stack{
+		Name:         "test-stack",
+		Context:      model.NewTestContext("dev", "us-east-1", "123456789012"),
+		TemplateBody: templateBody,
+		TemplateURL:  templateURL,
+		Parameters:   map[string]string{},
+		Tags:         map[string]string{},
+	}
+
+	err := deployer.DeployStack(ctx, stack)
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeployStack_ExistingStack_ChangeSetGenerationFails(t *testing.T) {
+	// Test that deployment fails early when changeset generation fails (e.g., invalid parameter)
+	ctx := context.Background()
+
+	// Set up mocks
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	// Mock StackExists call (existing stack)
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(true, nil)
+
+	// Mock differ operations
+	currentStackInfo := &aws.StackInfo{
+		Name:       "test-stack",
+		Status:     "UPDATE_COMPLETE",
+		Parameters: map[string]string{"Environment": "test"},
+		Tags:       map[string]string{"Project": "stackaroo"},
+	}
+	mockCfnOps.On("DescribeStack", mock.Anything, "test-stack").Return(currentStackInfo, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "test-stack").Return(`{"AWSTemplateFormatVersion": "2010-09-09", "Resources": {"Bucket": {"Type": "AWS::S3::Bucket"}}}`, nil)
+
+	// Mock changeset creation failure (e.g., invalid parameter)
+	changeSetError := errors.New("operation error CloudFormation: CreateChangeSet, api error ValidationError: Parameter values specified for a template which does not require them")
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == "test-stack" })).Return((*aws.ChangeSetInfo)(nil), changeSetError)
+
+	// Create deployer - we should never reach the confirm prompt
+	deployer := createMockDeployer(mockFactory)
+
+	// Create resolved stack with invalid parameter
+	stack := &model.Stack{
+		Name:         "test-stack",
+		Context:      model.NewTestContext("dev", "us-east-1", "123456789012"),
+		TemplateBody: `{"AWSTemplateFormatVersion": "2010-09-09", "Resources": {"Bucket": {"Type": "AWS::S3::Bucket"}}}`,
+		Parameters:   map[string]string{"InvalidParam": "value"}, // This parameter doesn't exist in template
+		Tags:         map[string]string{"Project": "stackaroo"},
+		Dependencies: []string{},
+		Capabilities: []string{"CAPABILITY_IAM"},
+	}
+
+	// Execute
+	err := deployer.DeployStack(ctx, stack)
+
+	// Verify that deployment fails with changeset error
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Parameter values specified for a template which does not require them")
 
 	// Verify that we never prompted the user (the prompter should not have been called)
 	mockCfnOps.AssertExpectations(t)
@@ -785,7 +1271,7 @@ func TestDeployStack_ExistingStack_MetadataOnlyChanges(t *testing.T) {
 
 	// Mock changeset creation failure with "no changes" error (metadata-only changes)
 	noChangesError := aws.NoChangesError{StackName: "test-stack"}
-	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, "test-stack", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return((*aws.ChangeSetInfo)(nil), noChangesError)
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == "test-stack" })).Return((*aws.ChangeSetInfo)(nil), noChangesError)
 
 	// Create deployer - we should never reach the confirm prompt
 	deployer := createMockDeployer(mockFactory)
@@ -813,3 +1299,899 @@ func TestDeployStack_ExistingStack_MetadataOnlyChanges(t *testing.T) {
 	// Verify mocks were called as expected
 	mockCfnOps.AssertExpectations(t)
 }
+
+func TestDeployStack_ExistingStack_ChangeSetValidationFailure(t *testing.T) {
+	// A changeset rejected by validation is a failure, not an up-to-date stack
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(true, nil)
+	mockCfnOps.On("DescribeStack", mock.Anything, "test-stack").Return(&aws.StackInfo{Name: "test-stack", Status: "UPDATE_COMPLETE"}, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "test-stack").Return(`{"Resources": {"Bucket": {"Type": "AWS::S3::Bucket"}}}`, nil)
+	validationErr := errors.New("changeset creation failed: Template format error: Unresolved resource dependencies [Topic] in the Resources block of the template")
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == "test-stack" })).Return((*aws.ChangeSetInfo)(nil), validationErr)
+
+	deployer := createMockDeployer(mockFactory)
+	stack := &model.Stack{
+		Name:         "test-stack",
+		Context:      model.NewTestContext("dev", "us-east-1", "123456789012"),
+		TemplateBody: `{"Resources": {"Queue": {"Type": "AWS::SQS::Queue", "DependsOn": "Topic"}}}`,
+	}
+
+	outcome, err := deployer.deployStackWithOutcome(ctx, stack, "dev")
+
+	assert.Equal(t, OutcomeFailed, outcome)
+	require.EqualError(t, err, validationErr.Error())
+	assert.False(t, errors.As(err, &NoChangesError{}))
+}
+
+// setupContinueOnErrorDeployment creates a deployer for three stacks where "dependent" depends on "slow"
+func setupContinueOnErrorDeployment(t *testing.T) (*StackDeployer, *aws.MockCloudFormationOperations, *config.MockConfigProvider) {
+	t.Helper()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	stackNames := []string{"slow", "dependent", "independent"}
+	mockProvider.On("ListStacks", "dev").Return(stackNames, nil)
+	mockResolver.On("GetDependencyOrder", "dev", stackNames).Return(stackNames, nil)
+
+	for _, name := range []string{"slow", "independent"} {
+		stack := model.NewTestStack(name, model.NewTestContext("dev", "us-east-1", "123456789012"))
+		mockResolver.On("ResolveStack", mock.Anything, "dev", name).Return(stack, nil)
+		mockCfnOps.On("StackExists", mock.Anything, name).Return(false, nil)
+	}
+
+	mockProvider.On("GetStack", "slow", "dev").Return(&config.StackConfig{Name: "slow"}, nil)
+	mockProvider.On("GetStack", "dependent", "dev").Return(&config.StackConfig{Name: "dependent", Dependencies: []string{"slow"}}, nil)
+	mockProvider.On("GetStack", "independent", "dev").Return(&config.StackConfig{Name: "independent"}, nil)
+
+	deployer := NewStackDeployer(mockFactory, mockProvider, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	return deployer, mockCfnOps, mockProvider
+}
+
+func TestDeployAllStacks_StackTimeout_ContinuesWithIndependentStacks(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps, mockProvider := setupContinueOnErrorDeployment(t)
+
+	// The slow stack blocks until its per-stack context expires
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.StackName == "slow"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.DeadlineExceeded)
+
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.StackName == "independent"
+	}), mock.Anything).Return(nil)
+
+	err := deployer.DeployAllStacks(ctx, "dev", Options{StackTimeout: 10 * time.Millisecond, ContinueOnError: true})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deployment did not complete for 2 of 3 stacks")
+	mockCfnOps.AssertCalled(t, "DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.StackName == "independent"
+	}), mock.Anything)
+	mockCfnOps.AssertExpectations(t)
+	mockProvider.AssertExpectations(t)
+}
+
+func TestDeployAllStacks_StackTimeout_StopsWithoutContinueOnError(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps, _ := setupContinueOnErrorDeployment(t)
+
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.StackName == "slow"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.DeadlineExceeded)
+
+	err := deployer.DeployAllStacks(ctx, "dev", Options{StackTimeout: 10 * time.Millisecond})
+
+	require.Error(t, err)
+	var timeoutErr TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "slow", timeoutErr.StackName)
+	assert.Equal(t, 10*time.Millisecond, timeoutErr.Timeout)
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, "independent")
+}
+
+func TestDeployAllStacks_StackTimeout_ReportsLastStatus(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps, _ := setupContinueOnErrorDeployment(t)
+
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.StackName == "slow"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(aws.WaitTimeoutError{StackName: "slow", Status: aws.StackStatusCreateInProgress})
+
+	err := deployer.DeployAllStacks(ctx, "dev", Options{StackTimeout: 10 * time.Millisecond})
+
+	var timeoutErr TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, aws.StackStatusCreateInProgress, timeoutErr.Status)
+	assert.Equal(t, "deployment of stack slow timed out after 10ms (last status: CREATE_IN_PROGRESS)", err.Error())
+}
+
+func TestDeploySingleStack_PollInterval_ConfiguresWaits(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "vpc").Return(nil, errors.New("resolution failed"))
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	err := deployer.DeploySingleStack(ctx, "vpc", "dev", Options{PollInterval: 15 * time.Second})
+
+	require.Error(t, err)
+	assert.Equal(t, aws.WaitConfig{PollInterval: 15 * time.Second}, mockFactory.GetWaitConfig())
+}
+
+func TestDeployAllStacks_FailFastOnRollback_ConfiguresWaits(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	mockProvider.On("ListStacks", "dev").Return([]string{}, nil)
+
+	deployer := NewStackDeployer(mockFactory, mockProvider, &resolve.MockResolver{})
+	err := deployer.DeployAllStacks(ctx, "dev", Options{FailFastOnRollback: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, aws.WaitConfig{FailFastOnRollback: true}, mockFactory.GetWaitConfig())
+}
+
+func TestDeployAllStacks_ContinueOnError_SkipsDependents(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps, mockProvider := setupContinueOnErrorDeployment(t)
+
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.StackName == "slow"
+	}), mock.Anything).Return(errors.New("resource creation failed"))
+
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.StackName == "independent"
+	}), mock.Anything).Return(nil)
+
+	err := deployer.DeployAllStacks(ctx, "dev", Options{ContinueOnError: true})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deployment did not complete for 2 of 3 stacks")
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, "dependent")
+	mockCfnOps.AssertExpectations(t)
+	mockProvider.AssertExpectations(t)
+}
+
+func TestDeployAllStacks_CredentialsError_StopsDespiteContinueOnError(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps, _ := setupContinueOnErrorDeployment(t)
+
+	expired := aws.CredentialsError{Err: errors.New("ExpiredToken: The security token included in the request is expired")}
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.StackName == "slow"
+	}), mock.Anything).Return(fmt.Errorf("failed to check if stack exists: %w", expired))
+
+	err := deployer.DeployAllStacks(ctx, "dev", Options{ContinueOnError: true})
+
+	require.Error(t, err)
+	assert.Equal(t, expired, err)
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, "independent")
+}
+
+func TestDeploySingleStack_SummaryFile_RecordsDeployedValues(t *testing.T) {
+	ctx := context.Background()
+	summaryFile := filepath.Join(t.TempDir(), "summary.json")
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+
+	stack := model.NewTestStack("vpc", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.Parameters["CidrBlock"] = "10.0.0.0/16"
+	stack.Tags["Team"] = "platform"
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "vpc").Return(stack, nil)
+
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "vpc", "dev", Options{SummaryFile: summaryFile})
+	require.NoError(t, err)
+
+	summary, err := snapshot.Load(summaryFile)
+	require.NoError(t, err)
+	recorded, ok := summary.Find("dev", "vpc")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"CidrBlock": "10.0.0.0/16"}, recorded.Parameters)
+	assert.Equal(t, map[string]string{"Team": "platform"}, recorded.Tags)
+	assert.False(t, recorded.DeployedAt.IsZero())
+}
+
+func TestResolveAndDeploy_SummaryFileError_ReportsFailure(t *testing.T) {
+	ctx := context.Background()
+	// A directory cannot be read as a summary file
+	summaryFile := t.TempDir()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+
+	stack := model.NewTestStack("vpc", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "vpc").Return(stack, nil)
+
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	result := deployer.resolveAndDeploy(ctx, "vpc", "dev", Options{SummaryFile: summaryFile}, false)
+
+	assert.Equal(t, OutcomeFailed, result.Outcome)
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "stack vpc was deployed but its summary was not recorded")
+}
+
+func TestDeploySingleStack_SummaryFile_MasksSensitiveParameters(t *testing.T) {
+	ctx := context.Background()
+	summaryFile := filepath.Join(t.TempDir(), "summary.json")
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+
+	stack := model.NewTestStack("db", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.Parameters["DBPassword"] = "s3cr3t"
+	stack.Parameters["InstanceClass"] = "db.t3.micro"
+	stack.SensitiveParameters = map[string]bool{"DBPassword": true}
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "db").Return(stack, nil)
+
+	mockCfnOps.On("StackExists", mock.Anything, "db").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		for _, p := range input.Parameters {
+			if p.Key == "DBPassword" {
+				return p.Value == "s3cr3t"
+			}
+		}
+		return false
+	}), mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "db", "dev", Options{SummaryFile: summaryFile})
+	require.NoError(t, err)
+
+	summary, err := snapshot.Load(summaryFile)
+	require.NoError(t, err)
+	recorded, ok := summary.Find("dev", "db")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"DBPassword": "****", "InstanceClass": "db.t3.micro"}, recorded.Parameters)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeploySingleStack_ParameterOverridesReplaceResolvedValues(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+
+	stack := model.NewTestStack("app", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.Parameters["ImageTag"] = "v1.2.0"
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "app").Return(stack, nil)
+
+	mockCfnOps.On("StackExists", mock.Anything, "app").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		for _, p := range input.Parameters {
+			if p.Key == "ImageTag" {
+				return p.Value == "abc123"
+			}
+		}
+		return false
+	}), mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "app", "dev", Options{ParameterOverrides: map[string]string{"ImageTag": "abc123"}})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeploySingleStack_ConfirmedSkipsPrompt(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	stack := model.NewTestStack("queue", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "queue").Return(stack, nil)
+	mockCfnOps.On("StackExists", mock.Anything, "queue").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "queue", "dev", Options{Confirmed: true})
+
+	require.NoError(t, err)
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeployStack_AutoApprove_ExecutesChangeSetWithoutPrompting(t *testing.T) {
+	ctx := context.Background()
+	prompt.SetAutoApprove(true)
+	defer prompt.SetAutoApprove(false)
+
+	templateContent := `{"Resources": {"NewBucket": {"Type": "AWS::S3::Bucket"}}}`
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockCfnOps.On("StackExists", mock.Anything, "app").Return(true, nil)
+	mockCfnOps.On("DescribeStack", mock.Anything, "app").Return(&aws.StackInfo{Name: "app", Status: "UPDATE_COMPLETE"}, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "app").Return(`{"Resources": {"OldBucket": {"Type": "AWS::S3::Bucket"}}}`, nil)
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool {
+		return input.StackName == "app" && input.TemplateBody == templateContent && input.TemplateURL == ""
+	})).
+		Return(&aws.ChangeSetInfo{ChangeSetID: "changeset-1", Status: "CREATE_COMPLETE"}, nil)
+	mockCfnOps.On("ExecuteChangeSet", mock.Anything, "changeset-1", false).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", mock.Anything, "app", mock.AnythingOfType("time.Time"), mock.Anything).Return(nil)
+	mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-1").Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, &resolve.MockResolver{})
+	mockPrompter := &prompt.MockPrompter{}
+	deployer.SetPrompter(mockPrompter)
+
+	stack := model.NewTestStack("app", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.TemplateBody = templateContent
+
+	err := deployer.DeployStack(ctx, stack)
+
+	require.NoError(t, err)
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+	mockCfnOps.AssertCalled(t, "ExecuteChangeSet", mock.Anything, "changeset-1", false)
+}
+
+func TestDeploySingleStack_NoRollback_PassedToExecuteChangeSet(t *testing.T) {
+	ctx := context.Background()
+
+	templateContent := `{"Resources": {"NewBucket": {"Type": "AWS::S3::Bucket"}}}`
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	stack := model.NewTestStack("app", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.TemplateBody = templateContent
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "app").Return(stack, nil)
+	mockCfnOps.On("StackExists", mock.Anything, "app").Return(true, nil)
+	mockCfnOps.On("DescribeStack", mock.Anything, "app").Return(&aws.StackInfo{Name: "app", Status: "UPDATE_COMPLETE"}, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "app").Return(`{"Resources": {"OldBucket": {"Type": "AWS::S3::Bucket"}}}`, nil)
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool {
+		return input.StackName == "app" && input.TemplateBody == templateContent && input.TemplateURL == ""
+	})).
+		Return(&aws.ChangeSetInfo{ChangeSetID: "changeset-1", Status: "CREATE_COMPLETE"}, nil)
+	mockCfnOps.On("ExecuteChangeSet", mock.Anything, "changeset-1", true).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", mock.Anything, "app", mock.AnythingOfType("time.Time"), mock.Anything).Return(nil)
+	mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-1").Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+
+	err := deployer.DeploySingleStack(ctx, "app", "dev", Options{Confirmed: true, NoRollback: true})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertCalled(t, "ExecuteChangeSet", mock.Anything, "changeset-1", true)
+}
+
+func TestDeployStack_AutoApprove_ProtectedContextSkipsPhrase(t *testing.T) {
+	ctx := context.Background()
+	prompt.SetAutoApprove(true)
+	defer prompt.SetAutoApprove(false)
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, &resolve.MockResolver{})
+	deployer.allowProtected = true
+	mockPrompter := &prompt.MockPrompter{}
+	deployer.SetPrompter(mockPrompter)
+
+	protected := model.NewTestContext("prod", "us-east-1", "123456789012")
+	protected.Protected = true
+
+	err := deployer.DeployStack(ctx, model.NewTestStack("vpc", protected))
+
+	var cancellationErr CancellationError
+	assert.False(t, errors.As(err, &cancellationErr), "auto-approved deployments are never cancelled")
+	require.NoError(t, err)
+	mockPrompter.AssertNotCalled(t, "ConfirmWithPhrase", mock.Anything, mock.Anything)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeploySingleStack_SummaryFile_NotWrittenWhenCancelled(t *testing.T) {
+	ctx := context.Background()
+	summaryFile := filepath.Join(t.TempDir(), "summary.json")
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	stack := model.NewTestStack("vpc", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "vpc").Return(stack, nil)
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(false, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "vpc", "dev", Options{SummaryFile: summaryFile})
+	require.NoError(t, err)
+
+	_, err = os.Stat(summaryFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestDeploySingleStack_JSONOutput_ReportsSuccess(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	stack := model.NewTestStack("vpc", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "vpc").Return(stack, nil)
+
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockCfnOps.On("GetStack", mock.Anything, "vpc").Return(&aws.Stack{
+		ID:     "arn:aws:cloudformation:us-east-1:123456789012:stack/vpc/abc",
+		Name:   "vpc",
+		Status: aws.StackStatusCreateComplete,
+	}, nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+	var output bytes.Buffer
+	deployer.SetOutput(&output)
+
+	err := deployer.DeploySingleStack(ctx, "vpc", "dev", Options{JSONOutput: true})
+	require.NoError(t, err)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	assert.Equal(t, Report{
+		Context: "dev",
+		Stacks: []StackReport{{
+			StackName: "vpc",
+			Outcome:   "deployed",
+			Status:    "CREATE_COMPLETE",
+			StackID:   "arn:aws:cloudformation:us-east-1:123456789012:stack/vpc/abc",
+		}},
+	}, report)
+}
+
+func TestDeployAllStacks_JSONOutput_ReportsFailure(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+	stack := model.NewTestStack("vpc", model.NewTestContext("dev", "us-east-1", "123456789012"))
+
+	mockProvider.On("ListStacks", "dev").Return([]string{"vpc"}, nil)
+	mockResolver.On("GetDependencyOrder", "dev", []string{"vpc"}).Return([]string{"vpc"}, nil)
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "vpc").Return(stack, nil)
+	mockProvider.On("GetStack", "vpc", "dev").Return(&config.StackConfig{Name: "vpc"}, nil)
+
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("resource creation failed"))
+	mockCfnOps.On("GetStack", mock.Anything, "vpc").Return(&aws.Stack{
+		ID:     "arn:aws:cloudformation:us-east-1:123456789012:stack/vpc/abc",
+		Name:   "vpc",
+		Status: aws.StackStatusRollbackComplete,
+	}, nil)
+
+	deployer := NewStackDeployer(mockFactory, mockProvider, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+	var output bytes.Buffer
+	deployer.SetOutput(&output)
+
+	err := deployer.DeployAllStacks(ctx, "dev", Options{JSONOutput: true})
+	require.Error(t, err)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	require.Len(t, report.Stacks, 1)
+	assert.Equal(t, "vpc", report.Stacks[0].StackName)
+	assert.Equal(t, "failed", report.Stacks[0].Outcome)
+	assert.Equal(t, "ROLLBACK_COMPLETE", report.Stacks[0].Status)
+	assert.Contains(t, report.Stacks[0].Error, "resource creation failed")
+}
+
+func TestDeployAllStacks_DependentResolvesUpstreamOutputsFromMemory(t *testing.T) {
+	ctx := context.Background()
+
+	// A real resolver and configuration, so the dependent's stack-output parameter is resolved for real
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	devContext := &config.ContextConfig{Name: "dev", Account: "123456789012", Region: "us-east-1"}
+	vpcConfig := &config.StackConfig{Name: "vpc", Template: "file://vpc.yaml"}
+	appConfig := &config.StackConfig{
+		Name:     "app",
+		Template: "file://app.yaml",
+		Parameters: map[string]*config.ParameterValue{
+			"VpcId": {
+				ResolutionType:   "stack-output",
+				ResolutionConfig: map[string]string{"stack": "vpc", "output": "VpcId"},
+			},
+		},
+		Dependencies: []string{"vpc"},
+	}
+	mockProvider.On("ListStacks", "dev").Return([]string{"vpc", "app"}, nil)
+	mockProvider.On("LoadConfig", mock.Anything, "dev").Return(&config.Config{Context: devContext}, nil)
+	mockProvider.On("GetStack", "vpc", "dev").Return(vpcConfig, nil)
+	mockProvider.On("GetStack", "app", "dev").Return(appConfig, nil)
+
+	mockFS := &resolve.MockFileSystemResolver{}
+	mockFS.On("Resolve", "file://vpc.yaml").Return(`{"Resources": {}}`, nil)
+	mockFS.On("Resolve", "file://app.yaml").Return(`{"Parameters": {"VpcId": {"Type": "String"}}, "Resources": {}}`, nil)
+	resolver := resolve.NewStackResolver(mockProvider, mockFactory)
+	resolver.SetFileSystemResolver(mockFS)
+
+	mockCfnOps.On("StackExists", mock.Anything, mock.Anything).Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockCfnOps.On("GetStack", mock.Anything, "vpc").Return(&aws.Stack{
+		Name:    "vpc",
+		Status:  aws.StackStatusCreateComplete,
+		Outputs: map[string]string{"VpcId": "vpc-0123456789"},
+	}, nil).Once()
+
+	deployer := NewStackDeployer(mockFactory, mockProvider, resolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeployAllStacks(ctx, "dev", Options{})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertNumberOfCalls(t, "GetStack", 1)
+	mockCfnOps.AssertCalled(t, "DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.StackName == "app" && len(input.Parameters) == 1 && input.Parameters[0].Value == "vpc-0123456789"
+	}), mock.Anything)
+}
+
+func TestDeployAllStacks_StackNamePrefix_DeploysUnderDeployedNames(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	devContext := &config.ContextConfig{Name: "dev", Account: "123456789012", Region: "us-east-1", StackNamePrefix: "dev-"}
+	vpcConfig := &config.StackConfig{Name: "vpc", Template: "file://vpc.yaml"}
+	appConfig := &config.StackConfig{
+		Name:     "app",
+		Template: "file://app.yaml",
+		Parameters: map[string]*config.ParameterValue{
+			"VpcId": {
+				ResolutionType:   "stack-output",
+				ResolutionConfig: map[string]string{"stack": "vpc", "output": "VpcId"},
+			},
+		},
+		Dependencies: []string{"vpc"},
+	}
+	mockProvider.On("ListStacks", "dev").Return([]string{"app", "vpc"}, nil)
+	mockProvider.On("LoadConfig", mock.Anything, "dev").Return(&config.Config{Context: devContext}, nil)
+	mockProvider.On("GetStack", "vpc", "dev").Return(vpcConfig, nil)
+	mockProvider.On("GetStack", "app", "dev").Return(appConfig, nil)
+
+	mockFS := &resolve.MockFileSystemResolver{}
+	mockFS.On("Resolve", "file://vpc.yaml").Return(`{"Resources": {}}`, nil)
+	mockFS.On("Resolve", "file://app.yaml").Return(`{"Parameters": {"VpcId": {"Type": "String"}}, "Resources": {}}`, nil)
+	resolver := resolve.NewStackResolver(mockProvider, mockFactory)
+	resolver.SetFileSystemResolver(mockFS)
+
+	var deployed []string
+	mockCfnOps.On("StackExists", mock.Anything, "dev-vpc").Return(false, nil)
+	mockCfnOps.On("StackExists", mock.Anything, "dev-app").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		deployed = append(deployed, args.Get(1).(aws.DeployStackInput).StackName)
+	}).Return(nil)
+	mockCfnOps.On("GetStack", mock.Anything, "dev-vpc").Return(&aws.Stack{
+		Name:    "dev-vpc",
+		Status:  aws.StackStatusCreateComplete,
+		Outputs: map[string]string{"VpcId": "vpc-0123456789"},
+	}, nil).Once()
+
+	deployer := NewStackDeployer(mockFactory, mockProvider, resolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeployAllStacks(ctx, "dev", Options{})
+
+	// Dependencies order the stacks by their configured names, while AWS sees the prefixed names
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev-vpc", "dev-app"}, deployed)
+	mockCfnOps.AssertNumberOfCalls(t, "GetStack", 1)
+	mockCfnOps.AssertCalled(t, "DeployStackWithCallback", mock.Anything, mock.MatchedBy(func(input aws.DeployStackInput) bool {
+		return input.StackName == "dev-app" && len(input.Parameters) == 1 && input.Parameters[0].Value == "vpc-0123456789"
+	}), mock.Anything)
+}
+
+func TestDeploySingleStack_JSONEvents_WritesEventsToOutput(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	stack := model.NewTestStack("vpc", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "vpc").Return(stack, nil)
+
+	mockCfnOps.On("StackExists", mock.Anything, "vpc").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		callback := args.Get(2).(func(aws.StackEvent))
+		callback(aws.StackEvent{StackName: "vpc", LogicalResourceId: "Vpc", ResourceType: "AWS::EC2::VPC", ResourceStatus: "CREATE_COMPLETE"})
+	}).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+	var output bytes.Buffer
+	deployer.SetOutput(&output)
+
+	err := deployer.DeploySingleStack(ctx, "vpc", "dev", Options{JSONEvents: true})
+	require.NoError(t, err)
+
+	var event map[string]string
+	require.NoError(t, json.Unmarshal(output.Bytes(), &event))
+	assert.Equal(t, "vpc", event["stack_name"])
+	assert.Equal(t, "Vpc", event["logical_id"])
+	assert.Equal(t, "AWS::EC2::VPC", event["resource_type"])
+	assert.Equal(t, "CREATE_COMPLETE", event["status"])
+}
+
+// setupWatchDeployer returns a deployer whose configuration defines stack app in the dev context
+func setupWatchDeployer(ctx context.Context) (*StackDeployer, *aws.MockCloudFormationOperations) {
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	mockProvider.On("LoadConfig", ctx, "dev").Return(&config.Config{
+		Context: &config.ContextConfig{Name: "dev", Region: "us-east-1"},
+	}, nil)
+	mockProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app"}, nil)
+
+	return NewStackDeployer(mockFactory, mockProvider, &resolve.MockResolver{}), mockCfnOps
+}
+
+func TestWatchStack_AttachesToInProgressOperation(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps := setupWatchDeployer(ctx)
+	var output bytes.Buffer
+	deployer.SetOutput(&output)
+
+	updated := time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)
+	mockCfnOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusUpdateInProgress, UpdatedTime: &updated}, nil)
+	mockCfnOps.On("WaitForStackOperation", ctx, "app", updated, mock.AnythingOfType("func(aws.StackEvent)")).Run(func(args mock.Arguments) {
+		callback := args.Get(3).(func(aws.StackEvent))
+		callback(aws.StackEvent{StackName: "app", LogicalResourceId: "Queue", ResourceType: "AWS::SQS::Queue", ResourceStatus: "UPDATE_COMPLETE"})
+	}).Return(nil)
+
+	err := deployer.WatchStack(ctx, "app", "dev", Options{})
+
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "UPDATE_COMPLETE")
+	assert.Contains(t, output.String(), "Queue")
+	mockCfnOps.AssertExpectations(t)
+	mockCfnOps.AssertNotCalled(t, "DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "ExecuteChangeSet", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWatchStack_ReportsFailedOperation(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps := setupWatchDeployer(ctx)
+	deployer.SetOutput(&bytes.Buffer{})
+
+	created := time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)
+	mockCfnOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusRollbackInProgress, CreatedTime: &created}, nil)
+	mockCfnOps.On("WaitForStackOperation", ctx, "app", created, mock.AnythingOfType("func(aws.StackEvent)")).
+		Return(aws.StackOperationFailedError{StackName: "app", Status: aws.StackStatusRollbackComplete})
+
+	err := deployer.WatchStack(ctx, "app", "dev", Options{})
+
+	require.ErrorAs(t, err, &aws.StackOperationFailedError{})
+	assert.Contains(t, err.Error(), "ROLLBACK_COMPLETE")
+}
+
+func TestWatchStack_NotInProgress(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps := setupWatchDeployer(ctx)
+
+	mockCfnOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusUpdateComplete}, nil)
+
+	err := deployer.WatchStack(ctx, "app", "dev", Options{})
+
+	var notInProgress NotInProgressError
+	require.ErrorAs(t, err, &notInProgress)
+	assert.Equal(t, aws.StackStatusUpdateComplete, notInProgress.Status)
+	assert.Equal(t, "stack app is in UPDATE_COMPLETE with no operation in progress; nothing to watch", err.Error())
+	mockCfnOps.AssertNotCalled(t, "WaitForStackOperation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStackDeployer_DeployStack_SavedChangeSet_ExecutesWithoutCreating(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(true, nil)
+	mockCfnOps.On("DescribeStack", mock.Anything, "test-stack").Return(&aws.StackInfo{Name: "test-stack", Status: "UPDATE_COMPLETE"}, nil)
+	mockCfnOps.On("DescribeChangeSet", mock.Anything, "saved-changeset").Return(&aws.ChangeSetInfo{
+		ChangeSetID:     "saved-changeset",
+		StackName:       "test-stack",
+		Status:          "CREATE_COMPLETE",
+		ExecutionStatus: "AVAILABLE",
+		Changes:         []aws.ResourceChange{{Action: "Modify", ResourceType: "AWS::S3::Bucket", LogicalID: "Bucket"}},
+	}, nil)
+	mockCfnOps.On("ExecuteChangeSet", mock.Anything, "saved-changeset", false).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", mock.Anything, "test-stack", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).Return(nil)
+
+	deployer := createMockDeployerWithConfirm(mockFactory, true)
+	deployer.changeSetID = "saved-changeset"
+	stack := &model.Stack{
+		Name:         "test-stack",
+		Context:      model.NewTestContext("dev", "us-east-1", "123456789012"),
+		TemplateBody: `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+	}
+
+	err := deployer.DeployStack(ctx, stack)
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+	mockCfnOps.AssertNotCalled(t, "CreateChangeSetForDeployment", mock.Anything, mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "GetTemplate", mock.Anything, mock.Anything)
+}
+
+func TestStackDeployer_DeployStack_SavedChangeSet_Rejected(t *testing.T) {
+	tests := []struct {
+		name          string
+		changeSet     *aws.ChangeSetInfo
+		describeErr   error
+		expectedError string
+	}{
+		{
+			name:          "changeset no longer exists",
+			describeErr:   errors.New("ChangeSet [saved-changeset] does not exist"),
+			expectedError: "changeset saved-changeset is not available",
+		},
+		{
+			name:          "changeset belongs to another stack",
+			changeSet:     &aws.ChangeSetInfo{ChangeSetID: "saved-changeset", StackName: "other-stack", ExecutionStatus: "AVAILABLE"},
+			expectedError: "changeset saved-changeset belongs to stack other-stack, not test-stack",
+		},
+		{
+			name:          "changeset already executed",
+			changeSet:     &aws.ChangeSetInfo{ChangeSetID: "saved-changeset", StackName: "test-stack", ExecutionStatus: "EXECUTE_COMPLETE"},
+			expectedError: "changeset saved-changeset cannot be executed: its execution status is EXECUTE_COMPLETE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(true, nil)
+			mockCfnOps.On("DescribeStack", mock.Anything, "test-stack").Return(&aws.StackInfo{Name: "test-stack", Status: "UPDATE_COMPLETE"}, nil)
+			if tt.describeErr != nil {
+				mockCfnOps.On("DescribeChangeSet", mock.Anything, "saved-changeset").Return(nil, tt.describeErr)
+			} else {
+				mockCfnOps.On("DescribeChangeSet", mock.Anything, "saved-changeset").Return(tt.changeSet, nil)
+			}
+
+			deployer := createMockDeployerWithConfirm(mockFactory, true)
+			deployer.changeSetID = "saved-changeset"
+			stack := &model.Stack{
+				Name:         "test-stack",
+				Context:      model.NewTestContext("dev", "us-east-1", "123456789012"),
+				TemplateBody: `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+			}
+
+			err := deployer.DeployStack(ctx, stack)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockCfnOps.AssertNotCalled(t, "ExecuteChangeSet", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestStackDeployer_DeployStack_SavedChangeSet_NewStack(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	mockCfnOps.On("StackExists", mock.Anything, "test-stack").Return(false, nil)
+
+	deployer := createMockDeployerWithConfirm(mockFactory, true)
+	deployer.changeSetID = "saved-changeset"
+	stack := &model.Stack{
+		Name:         "test-stack",
+		Context:      model.NewTestContext("dev", "us-east-1", "123456789012"),
+		TemplateBody: `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+	}
+
+	err := deployer.DeployStack(ctx, stack)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a saved changeset can only update an existing stack")
+	mockCfnOps.AssertNotCalled(t, "DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployAllStacks_SavedChangeSetRequiresSingleStack(t *testing.T) {
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	deployer := createMockDeployer(mockFactory)
+
+	err := deployer.DeployAllStacks(context.Background(), "dev", Options{ChangeSetID: "saved-changeset"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a saved changeset can only be deployed to a single stack")
+}
+
+func TestDeployAllStacks_OnFailureOverride_AppliesToCreatedStacks(t *testing.T) {
+	tests := []struct {
+		name              string
+		options           Options
+		expectedOnFailure string
+		expectedDisable   bool
+	}{
+		{name: "configuration is used without an override", expectedOnFailure: "DELETE"},
+		{name: "on-failure overrides the configuration", options: Options{OnFailure: "DO_NOTHING"}, expectedOnFailure: "DO_NOTHING"},
+		{name: "disable-rollback replaces the configuration", options: Options{DisableRollback: true}, expectedDisable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+			mockProvider := &config.MockConfigProvider{}
+			devContext := &config.ContextConfig{Name: "dev", Account: "123456789012", Region: "us-east-1"}
+			mockProvider.On("ListStacks", "dev").Return([]string{"db", "queue"}, nil)
+			mockProvider.On("LoadConfig", mock.Anything, "dev").Return(&config.Config{Context: devContext}, nil)
+			mockProvider.On("GetStack", "db", "dev").Return(&config.StackConfig{Name: "db", Template: "file://db.yaml", OnFailure: "DELETE"}, nil)
+			mockProvider.On("GetStack", "queue", "dev").Return(&config.StackConfig{Name: "queue", Template: "file://queue.yaml", OnFailure: "DELETE"}, nil)
+
+			mockFS := &resolve.MockFileSystemResolver{}
+			mockFS.On("Resolve", mock.Anything).Return(`{"Resources": {}}`, nil)
+			resolver := resolve.NewStackResolver(mockProvider, mockFactory)
+			resolver.SetFileSystemResolver(mockFS)
+
+			var inputs []aws.DeployStackInput
+			mockCfnOps.On("StackExists", mock.Anything, mock.Anything).Return(false, nil)
+			mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				inputs = append(inputs, args.Get(1).(aws.DeployStackInput))
+			}).Return(nil)
+
+			deployer := NewStackDeployer(mockFactory, mockProvider, resolver)
+			mockPrompter := &prompt.MockPrompter{}
+			mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+			deployer.SetPrompter(mockPrompter)
+
+			err := deployer.DeployAllStacks(ctx, "dev", tt.options)
+
+			require.NoError(t, err)
+			require.Len(t, inputs, 2)
+			for _, input := range inputs {
+				assert.Equal(t, tt.expectedOnFailure, input.OnFailure, input.StackName)
+				assert.Equal(t, tt.expectedDisable, input.DisableRollback, input.StackName)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+)
+
+// EventSink receives the CloudFormation events of a stack operation as they happen
+type EventSink interface {
+	WriteEvent(event aws.StackEvent)
+}
+
+// NewEventSink creates an event sink writing JSON lines when jsonEvents is set, and text otherwise
+func NewEventSink(jsonEvents bool, w io.Writer) EventSink {
+	if jsonEvents {
+		return &JSONEventSink{w: w}
+	}
+	return &TextEventSink{w: w}
+}
+
+// TextEventSink prints events as aligned lines for people watching a deployment
+type TextEventSink struct {
+	w io.Writer
+}
+
+// WriteEvent prints a single event line
+func (s *TextEventSink) WriteEvent(event aws.StackEvent) {
+	timestamp := event.Timestamp.Format("2006-01-02 15:04:05")
+	_, _ = fmt.Fprintf(s.w, "[%s] %-20s %-40s %s %s\n",
+		timestamp,
+		event.ResourceStatus,
+		event.ResourceType,
+		event.LogicalResourceId,
+		event.ResourceStatusReason,
+	)
+}
+
+// JSONEventSink writes each event as a line of JSON for CI systems to consume
+type JSONEventSink struct {
+	w io.Writer
+}
+
+// jsonEvent is the JSON representation of a stack event
+type jsonEvent struct {
+	Timestamp    string `json:"timestamp"`
+	StackName    string `json:"stack_name"`
+	LogicalID    string `json:"logical_id"`
+	ResourceType string `json:"resource_type"`
+	Status       string `json:"status"`
+	Reason       string `json:"reason"`
+}
+
+// WriteEvent writes a single event as one line of JSON
+func (s *JSONEventSink) WriteEvent(event aws.StackEvent) {
+	line, err := json.Marshal(jsonEvent{
+		Timestamp:    event.Timestamp.UTC().Format(time.RFC3339),
+		StackName:    event.StackName,
+		LogicalID:    event.LogicalResourceId,
+		ResourceType: event.ResourceType,
+		Status:       event.ResourceStatus,
+		Reason:       event.ResourceStatusReason,
+	})
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintf(s.w, "%s\n", line)
+}
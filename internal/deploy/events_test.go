@@ -0,0 +1,60 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func testStackEvent() aws.StackEvent {
+	return aws.StackEvent{
+		StackName:            "vpc",
+		LogicalResourceId:    "Subnet",
+		ResourceType:         "AWS::EC2::Subnet",
+		Timestamp:            time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC),
+		ResourceStatus:       "CREATE_FAILED",
+		ResourceStatusReason: "Resource limit exceeded",
+	}
+}
+
+func TestTextEventSink_WriteEvent(t *testing.T) {
+	var output bytes.Buffer
+
+	NewEventSink(false, &output).WriteEvent(testStackEvent())
+
+	assert.Equal(t, "[2025-03-14 09:26:53] CREATE_FAILED        AWS::EC2::Subnet                         Subnet Resource limit exceeded\n", output.String())
+}
+
+func TestJSONEventSink_WriteEvent(t *testing.T) {
+	var output bytes.Buffer
+	sink := NewEventSink(true, &output)
+
+	sink.WriteEvent(testStackEvent())
+	sink.WriteEvent(aws.StackEvent{StackName: "vpc", LogicalResourceId: "vpc", ResourceType: "AWS::CloudFormation::Stack", Timestamp: time.Date(2025, 3, 14, 9, 27, 0, 0, time.UTC), ResourceStatus: "ROLLBACK_COMPLETE"})
+
+	lines := bytes.Split(bytes.TrimSpace(output.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	assert.JSONEq(t, `{
+		"timestamp": "2025-03-14T09:26:53Z",
+		"stack_name": "vpc",
+		"logical_id": "Subnet",
+		"resource_type": "AWS::EC2::Subnet",
+		"status": "CREATE_FAILED",
+		"reason": "Resource limit exceeded"
+	}`, string(lines[0]))
+	assert.JSONEq(t, `{
+		"timestamp": "2025-03-14T09:27:00Z",
+		"stack_name": "vpc",
+		"logical_id": "vpc",
+		"resource_type": "AWS::CloudFormation::Stack",
+		"status": "ROLLBACK_COMPLETE",
+		"reason": ""
+	}`, string(lines[1]))
+}
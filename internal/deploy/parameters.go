@@ -0,0 +1,33 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+)
+
+// UndeclaredParametersError indicates that a stack's configuration sets parameters its template does not declare
+type UndeclaredParametersError struct {
+	StackName string
+	Names     []string
+}
+
+func (e UndeclaredParametersError) Error() string {
+	return fmt.Sprintf("stack %s configures parameters not declared in its template: %s; remove them from the configuration",
+		e.StackName, strings.Join(e.Names, ", "))
+}
+
+// checkDeclaredParameters returns an UndeclaredParametersError when any configured parameter is missing from the template
+func checkDeclaredParameters(stack *model.Stack) error {
+	undeclared := resolve.UndeclaredParameters(stack)
+	if len(undeclared) == 0 {
+		return nil
+	}
+	return UndeclaredParametersError{StackName: stack.Name, Names: undeclared}
+}
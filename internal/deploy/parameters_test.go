@@ -0,0 +1,97 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDeclaredParameters(t *testing.T) {
+	yamlTemplate := `AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  InstanceType:
+    Type: String
+Resources:
+  Instance:
+    Type: AWS::EC2::Instance
+    Properties:
+      InstanceType: !Ref InstanceType
+`
+	jsonTemplate := `{"Parameters": {"InstanceType": {"Type": "String"}}, "Resources": {}}`
+
+	tests := []struct {
+		name       string
+		template   string
+		parameters map[string]string
+		undeclared []string
+	}{
+		{name: "all declared in YAML", template: yamlTemplate, parameters: map[string]string{"InstanceType": "t3.micro"}},
+		{name: "all declared in JSON", template: jsonTemplate, parameters: map[string]string{"InstanceType": "t3.micro"}},
+		{name: "undeclared parameters are listed in order", template: yamlTemplate, parameters: map[string]string{"InstanceType": "t3.micro", "VpcId": "vpc-1", "OldSubnet": "subnet-1"}, undeclared: []string{"OldSubnet", "VpcId"}},
+		{name: "template without parameters", template: `{"Resources": {}}`, parameters: map[string]string{"VpcId": "vpc-1"}, undeclared: []string{"VpcId"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := &model.Stack{Name: "app", TemplateBody: tt.template, Parameters: tt.parameters}
+
+			err := checkDeclaredParameters(stack)
+
+			if tt.undeclared == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var undeclaredErr UndeclaredParametersError
+			require.ErrorAs(t, err, &undeclaredErr)
+			assert.Equal(t, tt.undeclared, undeclaredErr.Names)
+		})
+	}
+}
+
+func TestDeploySingleStack_PruneParameters_FailsOnUndeclaredParameters(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+
+	stack := model.NewTestStack("app", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.TemplateBody = `{"Parameters": {"InstanceType": {"Type": "String"}}, "Resources": {}}`
+	stack.Parameters = map[string]string{"InstanceType": "t3.micro", "LegacyFlag": "true"}
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "app").Return(stack, nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+
+	err := deployer.DeploySingleStack(ctx, "app", "dev", Options{PruneParameters: true})
+
+	require.Error(t, err)
+	assert.Equal(t, "stack app configures parameters not declared in its template: LegacyFlag; remove them from the configuration", err.Error())
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
+
+func TestDeploySingleStack_ParameterOverrideOutsideAllowedValues(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+
+	stack := model.NewTestStack("app", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.TemplateBody = `{"Parameters": {"Environment": {"Type": "String", "AllowedValues": ["dev", "prod"]}}, "Resources": {}}`
+	stack.Parameters = map[string]string{"Environment": "dev"}
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "app").Return(stack, nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+
+	err := deployer.DeploySingleStack(ctx, "app", "dev", Options{ParameterOverrides: map[string]string{"Environment": "qa"}})
+
+	require.EqualError(t, err, `invalid parameters for stack app: parameter Environment: value "qa" is not one of the allowed values: dev, prod`)
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
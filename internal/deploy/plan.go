@@ -0,0 +1,178 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/diff"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+)
+
+// PlanAllStacks previews the deployment of every stack in a context without changing anything.
+// In dependency order, each stack is resolved and a changeset is created for AWS to validate, then
+// deleted without being executed; the templates of stacks that do not exist yet are validated instead.
+func (d *StackDeployer) PlanAllStacks(ctx context.Context, contextName string, options Options) error {
+	d.changeSetMetadata = options.ChangeSetMetadata
+	d.skipAccountCheck = options.SkipAccountCheck
+	d.clientFactory.SetWaitConfig(options.waitConfig())
+
+	stackNames, err := d.provider.ListStacks(contextName)
+	if err != nil {
+		return err
+	}
+	if len(stackNames) == 0 {
+		if options.RequireStacks {
+			return fmt.Errorf("no stacks found in context %s", contextName)
+		}
+		fmt.Printf("No stacks found in context %s\n", diff.Highlight(contextName))
+		if options.JSONOutput {
+			return d.writeReport(contextName, nil)
+		}
+		return nil
+	}
+
+	deploymentOrder, err := d.resolver.GetDependencyOrder(contextName, stackNames)
+	if err != nil {
+		return err
+	}
+
+	// Refuse to plan against an account the context does not target, before resolving reads from it
+	if !d.skipAccountCheck {
+		cfg, err := d.provider.LoadConfig(ctx, contextName)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if err := d.accountVerifier.VerifyAccount(ctx, cfg.Context.Name, cfg.Context.Region, cfg.Context.Account); err != nil {
+			if options.JSONOutput {
+				if err := d.writeReport(contextName, nil); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+	}
+
+	results := make([]StackResult, 0, len(deploymentOrder))
+	failed := 0
+	for _, stackName := range deploymentOrder {
+		result := d.planStack(ctx, stackName, contextName, options)
+		results = append(results, result)
+
+		if result.Err != nil {
+			var credentialsErr aws.CredentialsError
+			credentialsFailed := errors.As(result.Err, &credentialsErr)
+			if !options.ContinueOnError || credentialsFailed || blocksEveryStack(result.Err) {
+				if options.JSONOutput {
+					if err := d.writeReport(contextName, results); err != nil {
+						return err
+					}
+				}
+				return result.Err
+			}
+			fmt.Printf("Stack %s failed: %v\n", diff.Highlight(stackName), result.Err)
+			failed++
+		}
+	}
+
+	printSummary("Plan summary", results)
+
+	if options.JSONOutput {
+		if err := d.writeReport(contextName, results); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("plan failed for %d of %d stacks in context %s", failed, len(results), contextName)
+	}
+	return nil
+}
+
+// planStack resolves a stack and shows what deploying it would change, leaving nothing behind in AWS.
+// Like a deployment, it is bounded by the stack timeout.
+func (d *StackDeployer) planStack(ctx context.Context, stackName, contextName string, options Options) (result StackResult) {
+	if options.StackTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.StackTimeout)
+		defer cancel()
+	}
+	defer func() {
+		if result.Err != nil {
+			result = d.failedResult(ctx, stackName, result.Err, options)
+		}
+	}()
+
+	result = StackResult{StackName: stackName, Outcome: OutcomeFailed}
+
+	stack, err := d.resolver.ResolveStack(ctx, contextName, stackName)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if err := resolve.ApplyParameterOverrides(stack, options.ParameterOverrides); err != nil {
+		result.Err = err
+		return result
+	}
+
+	if options.PruneParameters {
+		if err := checkDeclaredParameters(stack); err != nil {
+			result.Err = err
+			return result
+		}
+	}
+
+	cfnOps, err := d.clientFactory.GetCloudFormationOperations(ctx, stack.Context.Region)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	// Use a deployment changeset so AWS validates everything a deploy would send
+	differ := diff.NewStackDiffer(d.clientFactory)
+	diffResult, err := differ.DiffStack(ctx, stack, diff.Options{KeepChangeSet: true, ChangeSetMetadata: d.changeSetMetadata})
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if diffResult.ChangeSet != nil {
+		if err := cfnOps.DeleteChangeSet(ctx, diffResult.ChangeSet.ChangeSetID); err != nil {
+			result.Err = fmt.Errorf("failed to delete changeset for stack %s: %w", stackName, err)
+			return result
+		}
+	}
+
+	fmt.Print(diffResult.String())
+	fmt.Println()
+
+	// No changeset is created for a new stack, as deleting it would leave an empty stack behind
+	if !diffResult.StackExists && stack.TemplateBody != "" {
+		if err := cfnOps.ValidateTemplate(ctx, stack.TemplateBody); err != nil {
+			result.Err = fmt.Errorf("template for stack %s is invalid: %w", stackName, err)
+			return result
+		}
+	}
+
+	if diffResult.ChangeSetError != nil {
+		var noChangesErr aws.NoChangesError
+		if !errors.As(diffResult.ChangeSetError, &noChangesErr) {
+			result.Err = diffResult.ChangeSetError
+			return result
+		}
+		result.Outcome = OutcomeNoChanges
+		return result
+	}
+
+	if !diffResult.HasChanges() {
+		result.Outcome = OutcomeNoChanges
+		return result
+	}
+	result.Outcome = OutcomePlanned
+	return result
+}
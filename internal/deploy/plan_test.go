@@ -0,0 +1,257 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/prompt"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const deployedTemplate = `{"AWSTemplateFormatVersion": "2010-09-09", "Resources": {}}`
+
+// setupPlan configures a context of three stacks: vpc and app exist and have template changes, queue is new
+func setupPlan(t *testing.T) (*StackDeployer, *aws.MockCloudFormationOperations) {
+	t.Helper()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	stackNames := []string{"app", "queue", "vpc"}
+	devContext := model.NewTestContext("dev", "us-east-1", "123456789012")
+	mockProvider.On("ListStacks", "dev").Return(stackNames, nil)
+	mockProvider.On("LoadConfig", mock.Anything, "dev").Return(&config.Config{Context: &config.ContextConfig{Name: "dev", Region: "us-east-1", Account: "123456789012"}}, nil)
+	mockResolver.On("GetDependencyOrder", "dev", stackNames).Return([]string{"vpc", "app", "queue"}, nil)
+
+	for _, name := range stackNames {
+		mockResolver.On("ResolveStack", mock.Anything, "dev", name).Return(model.NewTestStack(name, devContext), nil)
+	}
+
+	for _, name := range []string{"vpc", "app"} {
+		mockCfnOps.On("StackExists", mock.Anything, name).Return(true, nil)
+		mockCfnOps.On("DescribeStack", mock.Anything, name).Return(&aws.StackInfo{
+			Name:     name,
+			Status:   aws.StackStatusUpdateComplete,
+			Template: deployedTemplate,
+		}, nil)
+		mockCfnOps.On("GetTemplate", mock.Anything, name).Return(deployedTemplate, nil)
+	}
+	mockCfnOps.On("StackExists", mock.Anything, "queue").Return(false, nil)
+
+	return NewStackDeployer(mockFactory, mockProvider, mockResolver), mockCfnOps
+}
+
+func TestPlanAllStacks_CreatesAndDeletesChangeSetsWithoutExecuting(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps := setupPlan(t)
+
+	for _, name := range []string{"vpc", "app"} {
+		mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool {
+			return input.StackName == name && input.Metadata == aws.ChangeSetMetadata{}
+		})).
+			Return(&aws.ChangeSetInfo{ChangeSetID: "changeset-" + name, Status: "CREATE_COMPLETE"}, nil).Once()
+		mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-"+name).Return(nil).Once()
+	}
+	mockCfnOps.On("ValidateTemplate", mock.Anything, `{"AWSTemplateFormatVersion": "2010-09-09"}`).Return(nil).Once()
+
+	err := deployer.PlanAllStacks(ctx, "dev", Options{})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+	mockCfnOps.AssertNotCalled(t, "ExecuteChangeSet", mock.Anything, mock.Anything, mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == "queue" }))
+}
+
+func TestPlanAllStacks_StopsAtFirstRejectedChangeSet(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps := setupPlan(t)
+
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == "vpc" })).
+		Return((*aws.ChangeSetInfo)(nil), errors.New("Template format error: Unresolved resource dependencies"))
+
+	err := deployer.PlanAllStacks(ctx, "dev", Options{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unresolved resource dependencies")
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, "app")
+	mockCfnOps.AssertNotCalled(t, "ExecuteChangeSet", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlanAllStacks_ContinueOnError_PlansRemainingStacks(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps := setupPlan(t)
+
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == "vpc" })).
+		Return((*aws.ChangeSetInfo)(nil), errors.New("Template format error: Unresolved resource dependencies"))
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == "app" })).
+		Return(&aws.ChangeSetInfo{ChangeSetID: "changeset-app", Status: "CREATE_COMPLETE"}, nil)
+	mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-app").Return(nil).Once()
+	mockCfnOps.On("ValidateTemplate", mock.Anything, mock.Anything).Return(errors.New("Template format error")).Once()
+
+	err := deployer.PlanAllStacks(ctx, "dev", Options{ContinueOnError: true})
+
+	require.EqualError(t, err, "plan failed for 2 of 3 stacks in context dev")
+	mockCfnOps.AssertExpectations(t)
+	mockCfnOps.AssertNotCalled(t, "ExecuteChangeSet", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlanAllStacks_NoChangesErrorIsNotAFailure(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps := setupPlan(t)
+
+	for _, name := range []string{"vpc", "app"} {
+		mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == name })).
+			Return((*aws.ChangeSetInfo)(nil), aws.NoChangesError{StackName: name})
+	}
+	mockCfnOps.On("ValidateTemplate", mock.Anything, mock.Anything).Return(nil)
+
+	err := deployer.PlanAllStacks(ctx, "dev", Options{})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertNotCalled(t, "DeleteChangeSet", mock.Anything, mock.Anything)
+}
+
+func TestPlanAllStacks_AccountMismatch_StopsBeforeResolving(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps := setupPlan(t)
+	mockSTS := &aws.MockSTSOperations{}
+	mockSTS.On("GetAccountID", mock.Anything).Return("999999999999", nil)
+	deployer.clientFactory.(*aws.MockClientFactory).SetSTSOperations("us-east-1", mockSTS)
+
+	err := deployer.PlanAllStacks(ctx, "dev", Options{})
+
+	var mismatch aws.AccountMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	deployer.resolver.(*resolve.MockResolver).AssertNotCalled(t, "ResolveStack", mock.Anything, mock.Anything, mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
+
+func TestPlanAllStacks_StackTimeout(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps := setupPlan(t)
+
+	// The vpc changeset blocks until its per-stack context expires
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == "vpc" })).
+		Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return((*aws.ChangeSetInfo)(nil), context.DeadlineExceeded)
+
+	err := deployer.PlanAllStacks(ctx, "dev", Options{StackTimeout: 10 * time.Millisecond})
+
+	var timeoutErr TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "vpc", timeoutErr.StackName)
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, "app")
+}
+
+// setupDryRun configures a single existing stack with template changes in a protected context
+func setupDryRun(t *testing.T) (*StackDeployer, *aws.MockCloudFormationOperations, *prompt.MockPrompter) {
+	t.Helper()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+
+	protected := model.NewTestContext("prod", "us-east-1", "123456789012")
+	protected.Protected = true
+	mockResolver.On("ResolveStack", mock.Anything, "prod", "app").Return(model.NewTestStack("app", protected), nil)
+
+	mockCfnOps.On("StackExists", mock.Anything, "app").Return(true, nil)
+	mockCfnOps.On("DescribeStack", mock.Anything, "app").Return(&aws.StackInfo{
+		Name:     "app",
+		Status:   aws.StackStatusUpdateComplete,
+		Template: deployedTemplate,
+	}, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "app").Return(deployedTemplate, nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	deployer.SetPrompter(mockPrompter)
+	return deployer, mockCfnOps, mockPrompter
+}
+
+func TestDeploySingleStack_DryRun_DeletesChangeSetWithoutExecuting(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps, mockPrompter := setupDryRun(t)
+
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool {
+		return input.StackName == "app" && input.Metadata == aws.ChangeSetMetadata{}
+	})).
+		Return(&aws.ChangeSetInfo{ChangeSetID: "changeset-app", Status: "CREATE_COMPLETE"}, nil).Once()
+	mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-app").Return(nil).Once()
+
+	err := deployer.DeploySingleStack(ctx, "app", "prod", Options{DryRun: true})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+	mockCfnOps.AssertNotCalled(t, "ExecuteChangeSet", mock.Anything, mock.Anything, mock.Anything)
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+}
+
+func TestDeploySingleStack_DryRun_CleanupFailureIsAWarning(t *testing.T) {
+	// A failed cleanup is reported as a warning; the valid changeset still makes the dry run succeed
+	ctx := context.Background()
+	deployer, mockCfnOps, _ := setupDryRun(t)
+
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == "app" })).
+		Return(&aws.ChangeSetInfo{ChangeSetID: "changeset-app", Status: "CREATE_COMPLETE"}, nil).Once()
+	mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-app").Return(errors.New("throttled")).Once()
+
+	err := deployer.DeploySingleStack(ctx, "app", "prod", Options{DryRun: true})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeploySingleStack_DryRun_ReportsPlannedOutcome(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockCfnOps, _ := setupDryRun(t)
+	var output bytes.Buffer
+	deployer.SetOutput(&output)
+
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool { return input.StackName == "app" })).
+		Return(&aws.ChangeSetInfo{ChangeSetID: "changeset-app", Status: "CREATE_COMPLETE"}, nil).Once()
+	mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-app").Return(nil).Once()
+	mockCfnOps.On("GetStack", mock.Anything, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusUpdateComplete}, nil)
+
+	err := deployer.DeploySingleStack(ctx, "app", "prod", Options{DryRun: true, JSONOutput: true})
+
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), `"outcome": "planned"`)
+}
+
+func TestDeploySingleStack_DryRun_NewStackValidatesTemplate(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	stack := model.NewTestStack("queue", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "queue").Return(stack, nil)
+	mockCfnOps.On("StackExists", mock.Anything, "queue").Return(false, nil)
+	mockCfnOps.On("ValidateTemplate", mock.Anything, stack.TemplateBody).Return(nil).Once()
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "queue", "dev", Options{DryRun: true})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertExpectations(t)
+	mockCfnOps.AssertNotCalled(t, "DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "CreateChangeSetForDeployment", mock.Anything, mock.Anything)
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/diff"
+	"codeberg.org/orien/stackaroo/internal/model"
+)
+
+// RefreshSingleStack applies configuration-only changes to a deployed stack. The stack is resolved
+// as for a deployment and, when its configured template matches the deployed one, updated with
+// the newly resolved parameters and tags while CloudFormation keeps the deployed template. The
+// parameter and tag changes are shown and confirmed first; no changeset is created.
+func (d *StackDeployer) RefreshSingleStack(ctx context.Context, stackName, contextName string, options Options) error {
+	d.skipAccountCheck = options.SkipAccountCheck
+	d.allowProtected = options.AllowProtected
+	d.dryRun = false
+	d.confirmed = options.Confirmed
+	d.events = options.eventSink(d.output)
+	d.clientFactory.SetWaitConfig(options.waitConfig())
+
+	stack, err := d.resolver.ResolveStack(ctx, contextName, stackName)
+	if err != nil {
+		return err
+	}
+	if err := d.checkTarget(ctx, stack); err != nil {
+		return err
+	}
+
+	cfnOps, err := d.clientFactory.GetCloudFormationOperations(ctx, stack.Context.Region)
+	if err != nil {
+		return err
+	}
+
+	deployedName := stack.CloudFormationName()
+	exists, err := cfnOps.StackExists(ctx, deployedName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("stack %s does not exist in region %s; use 'stackaroo deploy' to create it", stack.Name, stack.Context.Region)
+	}
+
+	current, err := cfnOps.DescribeStack(ctx, deployedName)
+	if err != nil {
+		return err
+	}
+	if aws.IsRollbackFailed(current.Status) {
+		return RollbackFailedError{StackName: stack.Name, Context: stack.Context.Name, Status: current.Status}
+	}
+
+	// A stack without a configured template keeps its deployed template anyway
+	if !stack.UsePreviousTemplate {
+		if err := checkTemplateUnchanged(ctx, stack, cfnOps); err != nil {
+			return err
+		}
+	}
+
+	result, err := refreshDiff(stack, current)
+	if err != nil {
+		return err
+	}
+	if !result.HasChanges() {
+		fmt.Printf("Stack %s is already up to date\n", diff.Highlight(stack.Name))
+		return nil
+	}
+
+	fmt.Print(result.String())
+	fmt.Println()
+
+	confirmed, err := d.confirm(stack, fmt.Sprintf("Do you want to apply these parameter and tag changes to stack %s?", stack.Name))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Printf("\nRefresh cancelled for stack %s\n", diff.Highlight(stack.Name))
+		return nil
+	}
+
+	fmt.Println() // Add spacing before the update starts
+
+	parameters := make([]aws.Parameter, 0, len(stack.Parameters))
+	for key, value := range stack.Parameters {
+		parameters = append(parameters, aws.Parameter{Key: key, Value: value})
+	}
+	capabilities := stack.Capabilities
+	if len(capabilities) == 0 {
+		capabilities = []string{"CAPABILITY_IAM"} // Default capability
+	}
+
+	// Capture start time to filter events to only this update
+	startTime := time.Now()
+	err = cfnOps.UpdateStack(ctx, aws.UpdateStackInput{
+		StackName:           deployedName,
+		UsePreviousTemplate: true,
+		Parameters:          parameters,
+		Tags:                stack.Tags,
+		Capabilities:        capabilities,
+	})
+	var noChangesErr aws.NoChangesError
+	if errors.As(err, &noChangesErr) {
+		fmt.Printf("Stack %s is already up to date\n", diff.Highlight(stack.Name))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := cfnOps.WaitForStackOperation(ctx, deployedName, startTime, d.events.WriteEvent); err != nil {
+		return fmt.Errorf("failed to refresh stack %s: %w", stack.Name, err)
+	}
+
+	fmt.Printf("Stack %s refreshed successfully\n", diff.Highlight(stack.Name))
+	return nil
+}
+
+// checkTemplateUnchanged returns a TemplateChangedError when the stack's configured template
+// differs from its deployed template
+func checkTemplateUnchanged(ctx context.Context, stack *model.Stack, cfnOps aws.CloudFormationOperations) error {
+	deployedTemplate, err := cfnOps.GetTemplate(ctx, stack.CloudFormationName())
+	if err != nil {
+		return fmt.Errorf("failed to get deployed template: %w", err)
+	}
+
+	templateChange, err := diff.NewYAMLTemplateComparator().Compare(ctx, deployedTemplate, stack.TemplateBody)
+	if err != nil {
+		return fmt.Errorf("failed to compare templates: %w", err)
+	}
+	if templateChange.HasChanges {
+		return TemplateChangedError{StackName: stack.Name}
+	}
+	return nil
+}
+
+// refreshDiff compares the deployed parameters and tags of a stack with the resolved ones
+func refreshDiff(stack *model.Stack, current *aws.StackInfo) (*diff.Result, error) {
+	parameterDiffs, err := diff.NewParameterComparator().Compare(current.Parameters, stack.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare parameters: %w", err)
+	}
+	for i := range parameterDiffs {
+		parameterDiffs[i].Sensitive = stack.SensitiveParameters[parameterDiffs[i].Key]
+	}
+
+	tagDiffs, err := diff.NewTagComparator().Compare(current.Tags, stack.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare tags: %w", err)
+	}
+
+	return &diff.Result{
+		StackName:      stack.Name,
+		Context:        stack.Context.Name,
+		StackExists:    true,
+		ParameterDiffs: parameterDiffs,
+		TagDiffs:       tagDiffs,
+	}, nil
+}
@@ -0,0 +1,219 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/prompt"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const refreshTemplate = `{"Resources": {"Queue": {"Type": "AWS::SQS::Queue", "Properties": {"DelaySeconds": {"Ref": "Delay"}}}}}`
+
+// refreshedStack returns stack app with new parameters and tags and the given template
+func refreshedStack(templateBody string) *model.Stack {
+	return &model.Stack{
+		Name:         "app",
+		DeployedName: "dev-app",
+		Context:      model.NewTestContext("dev", "us-west-2", "123456789012"),
+		TemplateBody: templateBody,
+		Parameters:   map[string]string{"Delay": "30"},
+		Tags:         map[string]string{"Team": "platform"},
+	}
+}
+
+// setupRefresh returns a deployer that resolves stack app to the given stack, deployed with a
+// delay of 10 and the stack's tags, and a prompter that answers confirm
+func setupRefresh(stack *model.Stack, confirm bool) (*StackDeployer, *aws.MockClientFactory, *aws.MockCloudFormationOperations, *prompt.MockPrompter) {
+	mockResolver := &resolve.MockResolver{}
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-west-2")
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "app").Return(stack, nil)
+
+	mockCfnOps.On("StackExists", mock.Anything, "dev-app").Return(true, nil)
+	mockCfnOps.On("DescribeStack", mock.Anything, "dev-app").Return(&aws.StackInfo{
+		Name:       "dev-app",
+		Status:     "UPDATE_COMPLETE",
+		Parameters: map[string]string{"Delay": "10"},
+		Tags:       map[string]string{"Team": "platform"},
+	}, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "dev-app").Return(refreshTemplate, nil)
+
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(confirm, nil)
+	mockPrompter.On("ConfirmWithPhrase", mock.Anything, mock.Anything).Return(confirm, nil)
+
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	deployer.SetPrompter(mockPrompter)
+	deployer.SetOutput(&bytes.Buffer{})
+	return deployer, mockFactory, mockCfnOps, mockPrompter
+}
+
+func TestRefreshSingleStack_UpdatesWithPreviousTemplate(t *testing.T) {
+	ctx := context.Background()
+	// Surrounding whitespace is not a template change
+	deployer, _, mockCfnOps, mockPrompter := setupRefresh(refreshedStack(refreshTemplate+"\n"), true)
+
+	mockCfnOps.On("UpdateStack", mock.Anything, aws.UpdateStackInput{
+		StackName:           "dev-app",
+		UsePreviousTemplate: true,
+		Parameters:          []aws.Parameter{{Key: "Delay", Value: "30"}},
+		Tags:                map[string]string{"Team": "platform"},
+		Capabilities:        []string{"CAPABILITY_IAM"},
+	}).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", mock.Anything, "dev-app", mock.Anything, mock.Anything).Return(nil)
+
+	err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{})
+
+	require.NoError(t, err)
+	mockPrompter.AssertCalled(t, "Confirm", "Do you want to apply these parameter and tag changes to stack app?")
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestRefreshSingleStack_TemplateChanged(t *testing.T) {
+	ctx := context.Background()
+	deployer, _, mockCfnOps, mockPrompter := setupRefresh(refreshedStack(`{"Resources": {"Queue": {"Type": "AWS::SQS::Queue"}, "Topic": {"Type": "AWS::SNS::Topic"}}}`), true)
+
+	err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{})
+
+	var changedErr TemplateChangedError
+	require.ErrorAs(t, err, &changedErr)
+	assert.EqualError(t, err, "the template of stack app has changed; use 'stackaroo deploy' to apply template changes")
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "UpdateStack", mock.Anything, mock.Anything)
+}
+
+func TestRefreshSingleStack_StackWithoutConfiguredTemplate(t *testing.T) {
+	ctx := context.Background()
+	stack := refreshedStack("")
+	stack.UsePreviousTemplate = true
+	deployer, _, mockCfnOps, _ := setupRefresh(stack, true)
+
+	mockCfnOps.On("UpdateStack", mock.Anything, mock.AnythingOfType("aws.UpdateStackInput")).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", mock.Anything, "dev-app", mock.Anything, mock.Anything).Return(nil)
+
+	err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertNotCalled(t, "GetTemplate", mock.Anything, mock.Anything)
+}
+
+func TestRefreshSingleStack_NoChanges(t *testing.T) {
+	ctx := context.Background()
+	stack := refreshedStack(refreshTemplate)
+	stack.Parameters = map[string]string{"Delay": "10"}
+	deployer, _, mockCfnOps, mockPrompter := setupRefresh(stack, true)
+
+	err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{})
+
+	require.NoError(t, err)
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+	mockCfnOps.AssertNotCalled(t, "UpdateStack", mock.Anything, mock.Anything)
+}
+
+func TestRefreshSingleStack_UserDeclines(t *testing.T) {
+	ctx := context.Background()
+	deployer, _, mockCfnOps, _ := setupRefresh(refreshedStack(refreshTemplate), false)
+
+	err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{})
+
+	require.NoError(t, err)
+	mockCfnOps.AssertNotCalled(t, "UpdateStack", mock.Anything, mock.Anything)
+}
+
+func TestRefreshSingleStack_AutoApprove(t *testing.T) {
+	ctx := context.Background()
+	prompt.SetAutoApprove(true)
+	t.Cleanup(func() { prompt.SetAutoApprove(false) })
+	deployer, _, mockCfnOps, mockPrompter := setupRefresh(refreshedStack(refreshTemplate), false)
+
+	mockCfnOps.On("UpdateStack", mock.Anything, mock.AnythingOfType("aws.UpdateStackInput")).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", mock.Anything, "dev-app", mock.Anything, mock.Anything).Return(nil)
+
+	err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{})
+
+	require.NoError(t, err)
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestRefreshSingleStack_ProtectedContext(t *testing.T) {
+	ctx := context.Background()
+	stack := refreshedStack(refreshTemplate)
+	stack.Context.Protected = true
+
+	t.Run("refused without allow protected", func(t *testing.T) {
+		deployer, _, mockCfnOps, _ := setupRefresh(stack, true)
+
+		err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{})
+
+		var protectedErr model.ProtectedContextError
+		require.ErrorAs(t, err, &protectedErr)
+		mockCfnOps.AssertNotCalled(t, "UpdateStack", mock.Anything, mock.Anything)
+	})
+
+	t.Run("confirmed by typing the context name", func(t *testing.T) {
+		deployer, _, mockCfnOps, mockPrompter := setupRefresh(stack, true)
+		mockCfnOps.On("UpdateStack", mock.Anything, mock.AnythingOfType("aws.UpdateStackInput")).Return(nil)
+		mockCfnOps.On("WaitForStackOperation", mock.Anything, "dev-app", mock.Anything, mock.Anything).Return(nil)
+
+		err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{AllowProtected: true})
+
+		require.NoError(t, err)
+		mockPrompter.AssertCalled(t, "ConfirmWithPhrase", mock.Anything, "dev")
+		mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+	})
+}
+
+func TestRefreshSingleStack_AccountMismatch(t *testing.T) {
+	ctx := context.Background()
+	deployer, mockFactory, mockCfnOps, _ := setupRefresh(refreshedStack(refreshTemplate), true)
+	mockSTS := &aws.MockSTSOperations{}
+	mockSTS.On("GetAccountID", mock.Anything).Return("999999999999", nil)
+	mockFactory.SetSTSOperations("us-west-2", mockSTS)
+
+	err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{})
+
+	var mismatch aws.AccountMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	mockCfnOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
+
+func TestRefreshSingleStack_StackDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+	mockResolver := &resolve.MockResolver{}
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-west-2")
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "app").Return(refreshedStack(refreshTemplate), nil)
+	mockCfnOps.On("StackExists", mock.Anything, "dev-app").Return(false, nil)
+	deployer := NewStackDeployer(mockFactory, &config.MockConfigProvider{}, mockResolver)
+
+	err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{})
+
+	require.EqualError(t, err, "stack app does not exist in region us-west-2; use 'stackaroo deploy' to create it")
+}
+
+func TestRefreshSingleStack_UpdateFails(t *testing.T) {
+	ctx := context.Background()
+	deployer, _, mockCfnOps, _ := setupRefresh(refreshedStack(refreshTemplate), true)
+
+	mockCfnOps.On("UpdateStack", mock.Anything, mock.AnythingOfType("aws.UpdateStackInput")).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", mock.Anything, "dev-app", mock.Anything, mock.Anything).
+		Return(aws.StackOperationFailedError{StackName: "dev-app", Status: aws.StackStatusUpdateRollbackComplete})
+
+	err := deployer.RefreshSingleStack(ctx, "app", "dev", Options{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to refresh stack app")
+	assert.True(t, errors.As(err, &aws.StackOperationFailedError{}))
+}
@@ -21,13 +21,28 @@ func (m *MockDeployer) DeployStack(ctx context.Context, stack *model.Stack) erro
 	return args.Error(0)
 }
 
-func (m *MockDeployer) DeploySingleStack(ctx context.Context, stackName, contextName string) error {
-	args := m.Called(ctx, stackName, contextName)
+func (m *MockDeployer) DeploySingleStack(ctx context.Context, stackName, contextName string, options Options) error {
+	args := m.Called(ctx, stackName, contextName, options)
 	return args.Error(0)
 }
 
-func (m *MockDeployer) DeployAllStacks(ctx context.Context, contextName string) error {
-	args := m.Called(ctx, contextName)
+func (m *MockDeployer) DeployAllStacks(ctx context.Context, contextName string, options Options) error {
+	args := m.Called(ctx, contextName, options)
+	return args.Error(0)
+}
+
+func (m *MockDeployer) WatchStack(ctx context.Context, stackName, contextName string, options Options) error {
+	args := m.Called(ctx, stackName, contextName, options)
+	return args.Error(0)
+}
+
+func (m *MockDeployer) PlanAllStacks(ctx context.Context, contextName string, options Options) error {
+	args := m.Called(ctx, contextName, options)
+	return args.Error(0)
+}
+
+func (m *MockDeployer) RefreshSingleStack(ctx context.Context, stackName, contextName string, options Options) error {
+	args := m.Called(ctx, stackName, contextName, options)
 	return args.Error(0)
 }
 
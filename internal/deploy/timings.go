@@ -0,0 +1,108 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Phase names a stage of deploying a stack whose duration is recorded with --timings
+type Phase string
+
+const (
+	PhaseConfigLoad      Phase = "config load"
+	PhaseResolve         Phase = "resolve"
+	PhaseChangeSetCreate Phase = "changeset create"
+	PhaseWait            Phase = "wait" // Executing the operation and waiting for it to finish
+)
+
+// timedPhases lists the phases in the order they happen, which is the order they are printed
+var timedPhases = []Phase{PhaseConfigLoad, PhaseResolve, PhaseChangeSetCreate, PhaseWait}
+
+// StackTimings holds how long each phase of deploying a stack took. Phases the stack
+// did not go through, such as creating a changeset for a new stack, are absent.
+type StackTimings struct {
+	StackName string
+	Phases    map[Phase]time.Duration
+}
+
+// Timings records phase durations for each stack of a run. A nil Timings records nothing,
+// so timing costs nothing unless requested.
+type Timings struct {
+	now    func() time.Time
+	stacks []StackTimings
+}
+
+// NewTimings creates an empty timings record
+func NewTimings() *Timings {
+	return &Timings{now: time.Now}
+}
+
+// start begins timing a phase of a stack and returns a function that records its duration
+func (t *Timings) start(stackName string, phase Phase) func() {
+	if t == nil {
+		return func() {}
+	}
+	started := t.now()
+	return func() {
+		t.record(stackName, phase, t.now().Sub(started))
+	}
+}
+
+// record adds a duration to a phase of a stack
+func (t *Timings) record(stackName string, phase Phase, duration time.Duration) {
+	for i := range t.stacks {
+		if t.stacks[i].StackName == stackName {
+			t.stacks[i].Phases[phase] += duration
+			return
+		}
+	}
+	t.stacks = append(t.stacks, StackTimings{StackName: stackName, Phases: map[Phase]time.Duration{phase: duration}})
+}
+
+// Stacks returns the recorded timings in the order the stacks were deployed
+func (t *Timings) Stacks() []StackTimings {
+	if t == nil {
+		return nil
+	}
+	return t.stacks
+}
+
+// Format renders the timings as a table with a column per phase and a total for each stack
+func (t *Timings) Format() string {
+	var output strings.Builder
+	output.WriteString("\nTimings:\n")
+	fmt.Fprintf(&output, "  %-30s", "STACK")
+	for _, phase := range timedPhases {
+		fmt.Fprintf(&output, " %16s", strings.ToUpper(string(phase)))
+	}
+	fmt.Fprintf(&output, " %16s\n", "TOTAL")
+
+	for _, stack := range t.Stacks() {
+		fmt.Fprintf(&output, "  %-30s", stack.StackName)
+		var total time.Duration
+		for _, phase := range timedPhases {
+			duration, timed := stack.Phases[phase]
+			if !timed {
+				fmt.Fprintf(&output, " %16s", "-")
+				continue
+			}
+			total += duration
+			fmt.Fprintf(&output, " %16s", formatDuration(duration))
+		}
+		fmt.Fprintf(&output, " %16s\n", formatDuration(total))
+	}
+	return output.String()
+}
+
+// formatDuration rounds a duration to a precision suited to its size
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
@@ -0,0 +1,167 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/prompt"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// steppingClock returns times that advance by step on every call
+func steppingClock(step time.Duration) func() time.Time {
+	now := time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestTimings_RecordsPhasesPerStack(t *testing.T) {
+	timings := NewTimings()
+	timings.now = steppingClock(2 * time.Second)
+
+	timings.start("vpc", PhaseResolve)()
+	timings.start("app", PhaseResolve)()
+	timings.start("vpc", PhaseWait)()
+	timings.start("vpc", PhaseWait)()
+
+	assert.Equal(t, []StackTimings{
+		{StackName: "vpc", Phases: map[Phase]time.Duration{PhaseResolve: 2 * time.Second, PhaseWait: 4 * time.Second}},
+		{StackName: "app", Phases: map[Phase]time.Duration{PhaseResolve: 2 * time.Second}},
+	}, timings.Stacks())
+}
+
+func TestTimings_NilRecordsNothing(t *testing.T) {
+	var timings *Timings
+
+	timings.start("vpc", PhaseResolve)()
+
+	assert.Nil(t, timings.Stacks())
+}
+
+func TestTimings_Format(t *testing.T) {
+	timings := NewTimings()
+	timings.record("vpc", PhaseConfigLoad, 1500*time.Microsecond)
+	timings.record("vpc", PhaseResolve, 250*time.Millisecond)
+	timings.record("vpc", PhaseWait, 95*time.Second+340*time.Millisecond)
+
+	text := timings.Format()
+
+	assert.Contains(t, text, "STACK")
+	assert.Contains(t, text, "CONFIG LOAD")
+	assert.Contains(t, text, "CHANGESET CREATE")
+	assert.Regexp(t, `vpc\s+2ms\s+250ms\s+-\s+1m35.3s\s+1m35.6s\n`, text)
+}
+
+func TestDeploySingleStack_Timings_RecordsEachPhase(t *testing.T) {
+	ctx := context.Background()
+	templateContent := `{"Resources": {"NewBucket": {"Type": "AWS::S3::Bucket"}}}`
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	stack := &model.Stack{
+		Name:         "app",
+		Context:      model.NewTestContext("dev", "us-east-1", "123456789012"),
+		TemplateBody: templateContent,
+		Parameters:   map[string]string{},
+		Tags:         map[string]string{},
+		Capabilities: []string{"CAPABILITY_IAM"},
+	}
+	mockProvider.On("LoadConfig", mock.Anything, "dev").Return(&config.Config{}, nil)
+	mockProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app"}, nil)
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "app").Return(stack, nil)
+
+	mockCfnOps.On("StackExists", mock.Anything, "app").Return(true, nil)
+	mockCfnOps.On("DescribeStack", mock.Anything, "app").Return(&aws.StackInfo{Name: "app", Status: "UPDATE_COMPLETE"}, nil)
+	mockCfnOps.On("GetTemplate", mock.Anything, "app").Return(`{"Resources": {"OldBucket": {"Type": "AWS::S3::Bucket"}}}`, nil)
+	mockCfnOps.On("CreateChangeSetForDeployment", mock.Anything, mock.MatchedBy(func(input aws.ChangeSetInput) bool {
+		return input.StackName == "app" && input.TemplateBody == templateContent && input.TemplateURL == ""
+	})).
+		Return(&aws.ChangeSetInfo{ChangeSetID: "changeset-1", Status: "CREATE_COMPLETE"}, nil)
+	mockCfnOps.On("ExecuteChangeSet", mock.Anything, "changeset-1", false).Return(nil)
+	mockCfnOps.On("WaitForStackOperation", mock.Anything, "app", mock.AnythingOfType("time.Time"), mock.Anything).Return(nil)
+	mockCfnOps.On("DeleteChangeSet", mock.Anything, "changeset-1").Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, mockProvider, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "app", "dev", Options{Timings: true, SkipAccountCheck: true})
+
+	require.NoError(t, err)
+	require.Len(t, deployer.timings.Stacks(), 1)
+	recorded := deployer.timings.Stacks()[0]
+	assert.Equal(t, "app", recorded.StackName)
+	for _, phase := range timedPhases {
+		assert.Contains(t, recorded.Phases, phase)
+	}
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestDeploySingleStack_Timings_NewStackHasNoChangeSetPhase(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	stack := model.NewTestStack("app", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	mockProvider.On("LoadConfig", mock.Anything, "dev").Return(&config.Config{}, nil)
+	mockProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app"}, nil)
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "app").Return(stack, nil)
+	mockCfnOps.On("StackExists", mock.Anything, "app").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, mockProvider, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "app", "dev", Options{Timings: true, SkipAccountCheck: true})
+
+	require.NoError(t, err)
+	recorded := deployer.timings.Stacks()[0].Phases
+	assert.Contains(t, recorded, PhaseConfigLoad)
+	assert.Contains(t, recorded, PhaseResolve)
+	assert.Contains(t, recorded, PhaseWait)
+	assert.NotContains(t, recorded, PhaseChangeSetCreate)
+}
+
+func TestDeploySingleStack_WithoutTimings_LeavesConfigToTheResolver(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockProvider := &config.MockConfigProvider{}
+	mockResolver := &resolve.MockResolver{}
+
+	stack := model.NewTestStack("app", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	mockResolver.On("ResolveStack", mock.Anything, "dev", "app").Return(stack, nil)
+	mockCfnOps.On("StackExists", mock.Anything, "app").Return(false, nil)
+	mockCfnOps.On("DeployStackWithCallback", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	deployer := NewStackDeployer(mockFactory, mockProvider, mockResolver)
+	mockPrompter := &prompt.MockPrompter{}
+	mockPrompter.On("Confirm", mock.Anything).Return(true, nil)
+	deployer.SetPrompter(mockPrompter)
+
+	err := deployer.DeploySingleStack(ctx, "app", "dev", Options{SkipAccountCheck: true})
+
+	require.NoError(t, err)
+	assert.Nil(t, deployer.timings)
+	mockProvider.AssertNotCalled(t, "LoadConfig", mock.Anything, mock.Anything)
+}
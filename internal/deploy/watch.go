@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/diff"
+)
+
+// progressBarWidth is the number of cells in the watch view's progress bar
+const progressBarWidth = 30
+
+// WatchEventSink redraws a live view of every resource in a stack operation and its latest
+// status in place, with a progress bar counting the resources that have finished
+type WatchEventSink struct {
+	w         io.Writer
+	styles    *diff.Styles
+	stackName string
+	resources []*watchedResource // In the order their first event arrived
+	byID      map[string]*watchedResource
+	lines     int // Lines drawn by the last render, erased before the next
+}
+
+// watchedResource is the latest known state of a resource in the watch view
+type watchedResource struct {
+	logicalID    string
+	resourceType string
+	status       string
+	reason       string
+}
+
+// NewWatchEventSink creates a watch view writing to w, colouring statuses when useColour is set
+func NewWatchEventSink(w io.Writer, useColour bool) *WatchEventSink {
+	return &WatchEventSink{w: w, styles: diff.NewStyles(useColour)}
+}
+
+// WriteEvent records the event against its resource and redraws the view. An event from a
+// different stack starts a new view below the previous one.
+func (s *WatchEventSink) WriteEvent(event aws.StackEvent) {
+	if event.StackName != s.stackName {
+		s.stackName = event.StackName
+		s.resources = nil
+		s.byID = make(map[string]*watchedResource)
+		s.lines = 0
+	}
+
+	resource, seen := s.byID[event.LogicalResourceId]
+	if !seen {
+		resource = &watchedResource{logicalID: event.LogicalResourceId}
+		s.byID[event.LogicalResourceId] = resource
+		s.resources = append(s.resources, resource)
+	}
+	resource.resourceType = event.ResourceType
+	resource.status = event.ResourceStatus
+	resource.reason = event.ResourceStatusReason
+
+	s.render()
+}
+
+// render erases the previous view and draws the current one
+func (s *WatchEventSink) render() {
+	var view strings.Builder
+	if s.lines > 0 {
+		// Move the cursor to the start of the previous view and clear everything below it
+		fmt.Fprintf(&view, "\x1b[%dA\x1b[J", s.lines)
+	}
+
+	finished := 0
+	for _, resource := range s.resources {
+		if isFinishedStatus(resource.status) {
+			finished++
+		}
+	}
+	filled := progressBarWidth * finished / len(s.resources)
+	fmt.Fprintf(&view, "%s [%s%s] %d/%d resources finished\n",
+		diff.Highlight(s.stackName),
+		strings.Repeat("#", filled),
+		strings.Repeat("-", progressBarWidth-filled),
+		finished,
+		len(s.resources),
+	)
+
+	for _, resource := range s.resources {
+		line := fmt.Sprintf("  %-40s %-40s %s", resource.logicalID, resource.resourceType, s.statusStyle(resource.status).Render(resource.status))
+		if resource.reason != "" && strings.HasSuffix(resource.status, "_FAILED") {
+			line += " " + resource.reason
+		}
+		view.WriteString(line + "\n")
+	}
+
+	s.lines = len(s.resources) + 1
+	_, _ = io.WriteString(s.w, view.String())
+}
+
+// statusStyle returns the style for a resource status: red for failures and rollbacks,
+// green for completion and yellow while in progress
+func (s *WatchEventSink) statusStyle(status string) lipgloss.Style {
+	switch {
+	case strings.HasSuffix(status, "_FAILED") || strings.Contains(status, "ROLLBACK"):
+		return s.styles.Error
+	case strings.HasSuffix(status, "_COMPLETE") || strings.HasSuffix(status, "_SKIPPED"):
+		return s.styles.Success
+	default:
+		return s.styles.Warning
+	}
+}
+
+// isFinishedStatus reports whether a resource status ends the resource's part in the operation
+func isFinishedStatus(status string) bool {
+	return strings.HasSuffix(status, "_COMPLETE") || strings.HasSuffix(status, "_FAILED") || strings.HasSuffix(status, "_SKIPPED")
+}
@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package deploy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchEventSink_RedrawsResourcesInPlace(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	var output bytes.Buffer
+	sink := NewWatchEventSink(&output, false)
+
+	sink.WriteEvent(aws.StackEvent{StackName: "vpc", LogicalResourceId: "Vpc", ResourceType: "AWS::EC2::VPC", ResourceStatus: "CREATE_IN_PROGRESS"})
+	assert.Equal(t, "vpc [------------------------------] 0/1 resources finished\n"+
+		"  Vpc                                      AWS::EC2::VPC                            CREATE_IN_PROGRESS\n", output.String())
+
+	output.Reset()
+	sink.WriteEvent(aws.StackEvent{StackName: "vpc", LogicalResourceId: "Subnet", ResourceType: "AWS::EC2::Subnet", ResourceStatus: "CREATE_IN_PROGRESS"})
+	sink.WriteEvent(aws.StackEvent{StackName: "vpc", LogicalResourceId: "Vpc", ResourceType: "AWS::EC2::VPC", ResourceStatus: "CREATE_COMPLETE"})
+
+	// Each redraw erases the previous view, and resources keep the order they first appeared in
+	assert.Equal(t, "\x1b[2A\x1b[J"+
+		"vpc [------------------------------] 0/2 resources finished\n"+
+		"  Vpc                                      AWS::EC2::VPC                            CREATE_IN_PROGRESS\n"+
+		"  Subnet                                   AWS::EC2::Subnet                         CREATE_IN_PROGRESS\n"+
+		"\x1b[3A\x1b[J"+
+		"vpc [###############---------------] 1/2 resources finished\n"+
+		"  Vpc                                      AWS::EC2::VPC                            CREATE_COMPLETE\n"+
+		"  Subnet                                   AWS::EC2::Subnet                         CREATE_IN_PROGRESS\n", output.String())
+}
+
+func TestWatchEventSink_ShowsFailureReasons(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	var output bytes.Buffer
+	sink := NewWatchEventSink(&output, false)
+
+	sink.WriteEvent(aws.StackEvent{StackName: "vpc", LogicalResourceId: "Subnet", ResourceType: "AWS::EC2::Subnet", ResourceStatus: "CREATE_IN_PROGRESS", ResourceStatusReason: "Resource creation Initiated"})
+	assert.NotContains(t, output.String(), "Resource creation Initiated")
+
+	sink.WriteEvent(aws.StackEvent{StackName: "vpc", LogicalResourceId: "Subnet", ResourceType: "AWS::EC2::Subnet", ResourceStatus: "CREATE_FAILED", ResourceStatusReason: "Resource limit exceeded"})
+	assert.Contains(t, output.String(), "CREATE_FAILED Resource limit exceeded\n")
+}
+
+func TestWatchEventSink_NewStackStartsNewView(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	var output bytes.Buffer
+	sink := NewWatchEventSink(&output, false)
+
+	sink.WriteEvent(aws.StackEvent{StackName: "vpc", LogicalResourceId: "vpc", ResourceType: "AWS::CloudFormation::Stack", ResourceStatus: "CREATE_COMPLETE"})
+	output.Reset()
+	sink.WriteEvent(aws.StackEvent{StackName: "app", LogicalResourceId: "app", ResourceType: "AWS::CloudFormation::Stack", ResourceStatus: "CREATE_IN_PROGRESS"})
+
+	assert.NotContains(t, output.String(), "\x1b[", "the previous stack's view should be kept")
+	assert.True(t, strings.HasPrefix(output.String(), "app [------------------------------] 0/1 resources finished\n"))
+}
+
+func TestOptions_EventSink_FallsBackToLinesWithoutTerminal(t *testing.T) {
+	var output bytes.Buffer
+
+	assert.IsType(t, &TextEventSink{}, Options{Watch: true}.eventSink(&output))
+	assert.IsType(t, &JSONEventSink{}, Options{Watch: true, JSONEvents: true}.eventSink(&output))
+}
@@ -34,7 +34,7 @@ func (d *StackDescriber) DescribeStack(ctx context.Context, stack *model.Stack)
 	}
 
 	// Use existing AWS operations to get stack information
-	stackInfo, err := cfOps.DescribeStack(ctx, stack.Name)
+	stackInfo, err := cfOps.DescribeStack(ctx, stack.CloudFormationName())
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +49,12 @@ func (c *DefaultParameterComparator) Compare(currentParams, proposedParams map[s
 			diff.ProposedValue = ""
 			diff.ChangeType = ChangeTypeRemove
 			diffs = append(diffs, diff)
+		} else if currentExists && proposedExists && currentValue == MaskedValue && proposedValue != MaskedValue {
+			// CloudFormation masks the deployed value of NoEcho parameters, so whether it changes is unknown
+			diff.CurrentValue = currentValue
+			diff.ProposedValue = proposedValue
+			diff.ChangeType = ChangeTypeUnknown
+			diffs = append(diffs, diff)
 		} else if currentExists && proposedExists && currentValue != proposedValue {
 			// Parameter is being modified
 			diff.CurrentValue = currentValue
@@ -149,6 +149,28 @@ func TestParameterComparator_Compare_ModifyParameters(t *testing.T) {
 	}
 }
 
+func TestParameterComparator_Compare_NoEchoCurrentValue(t *testing.T) {
+	comparator := NewParameterComparator()
+
+	currentParams := map[string]string{
+		"DatabasePassword": "****",
+		"Environment":      "dev",
+	}
+	proposedParams := map[string]string{
+		"DatabasePassword": "s3cret",
+		"Environment":      "dev",
+	}
+
+	diffs, err := comparator.Compare(currentParams, proposedParams)
+
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "DatabasePassword", diffs[0].Key)
+	assert.Equal(t, ChangeTypeUnknown, diffs[0].ChangeType)
+	assert.Equal(t, "****", diffs[0].CurrentValue)
+	assert.Equal(t, "s3cret", diffs[0].ProposedValue)
+}
+
 func TestParameterComparator_Compare_MixedChanges(t *testing.T) {
 	comparator := NewParameterComparator()
 
@@ -7,6 +7,7 @@ package diff
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/model"
@@ -32,6 +33,11 @@ func NewStackDiffer(clientFactory aws.ClientFactory) *StackDiffer {
 
 // DiffStack compares a resolved stack configuration with the deployed stack
 func (d *StackDiffer) DiffStack(ctx context.Context, stack *model.Stack, options Options) (*Result, error) {
+	// Compare against the recorded deployment without consulting AWS
+	if options.Baseline != nil {
+		return d.diffAgainstBaseline(stack, options)
+	}
+
 	// Get region-specific CloudFormation operations
 	cfClient, err := d.clientFactory.GetCloudFormationOperations(ctx, stack.Context.Region)
 	if err != nil {
@@ -45,7 +51,7 @@ func (d *StackDiffer) DiffStack(ctx context.Context, stack *model.Stack, options
 	}
 
 	// Check if stack exists in AWS
-	exists, err := cfClient.StackExists(ctx, stack.Name)
+	exists, err := cfClient.StackExists(ctx, stack.CloudFormationName())
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if stack exists: %w", err)
 	}
@@ -58,14 +64,14 @@ func (d *StackDiffer) DiffStack(ctx context.Context, stack *model.Stack, options
 	}
 
 	// Get current stack state from AWS
-	currentStack, err := cfClient.DescribeStack(ctx, stack.Name)
+	currentStack, err := cfClient.DescribeStack(ctx, stack.CloudFormationName())
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe stack: %w", err)
 	}
 
 	// Compare templates (if not filtered out)
 	if !options.ParametersOnly && !options.TagsOnly {
-		templateChange, err := d.compareTemplates(ctx, stack, currentStack, cfClient)
+		templateChange, err := d.compareTemplates(ctx, stack, currentStack, options, cfClient)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compare templates: %w", err)
 		}
@@ -78,7 +84,7 @@ func (d *StackDiffer) DiffStack(ctx context.Context, stack *model.Stack, options
 		if err != nil {
 			return nil, fmt.Errorf("failed to compare parameters: %w", err)
 		}
-		result.ParameterDiffs = parameterDiffs
+		result.ParameterDiffs = markParameters(parameterDiffs, stack)
 	}
 
 	// Compare tags (if not filtered out)
@@ -131,6 +137,8 @@ func (d *StackDiffer) handleNewStack(ctx context.Context, stack *model.Stack, re
 			CurrentValue:  "",
 			ProposedValue: value,
 			ChangeType:    ChangeTypeAdd,
+			Sensitive:     stack.SensitiveParameters[key],
+			Overridden:    stack.OverriddenParameters[key],
 		})
 	}
 
@@ -147,10 +155,40 @@ func (d *StackDiffer) handleNewStack(ctx context.Context, stack *model.Stack, re
 	return result, nil
 }
 
+// diffAgainstBaseline compares resolved parameters and tags with those recorded at the last deployment
+func (d *StackDiffer) diffAgainstBaseline(stack *model.Stack, options Options) (*Result, error) {
+	result := &Result{
+		StackName:   stack.Name,
+		Context:     stack.Context.Name,
+		StackExists: true,
+		Options:     options,
+	}
+
+	if !options.TemplateOnly && !options.TagsOnly {
+		// Snapshots only record masked values for sensitive parameters, so compare like with like
+		proposed := MaskSensitive(stack.Parameters, stack.SensitiveParameters)
+		parameterDiffs, err := d.parameterComparator.Compare(options.Baseline.Parameters, proposed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare parameters: %w", err)
+		}
+		result.ParameterDiffs = markParameters(parameterDiffs, stack)
+	}
+
+	if !options.TemplateOnly && !options.ParametersOnly {
+		tagDiffs, err := d.tagComparator.Compare(options.Baseline.Tags, stack.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare tags: %w", err)
+		}
+		result.TagDiffs = tagDiffs
+	}
+
+	return result, nil
+}
+
 // compareTemplates compares the current deployed template with the resolved template
-func (d *StackDiffer) compareTemplates(ctx context.Context, stack *model.Stack, currentStack *aws.StackInfo, cfClient aws.CloudFormationOperations) (*TemplateChange, error) {
+func (d *StackDiffer) compareTemplates(ctx context.Context, stack *model.Stack, currentStack *aws.StackInfo, options Options, cfClient aws.CloudFormationOperations) (*TemplateChange, error) {
 	// Get current template from AWS
-	currentTemplate, err := cfClient.GetTemplate(ctx, stack.Name)
+	currentTemplate, err := cfClient.GetTemplate(ctx, stack.CloudFormationName())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current template: %w", err)
 	}
@@ -161,12 +199,36 @@ func (d *StackDiffer) compareTemplates(ctx context.Context, stack *model.Stack,
 		return nil, fmt.Errorf("failed to get proposed template content: %w", err)
 	}
 
+	// Build information stamped by deploy --stamp changes on every deployment, so it takes no part in the comparison
+	if strings.Contains(currentTemplate, model.BuildInfoMetadataKey) || strings.Contains(proposedTemplate, model.BuildInfoMetadataKey) {
+		if currentTemplate, err = StripBuildInfo(currentTemplate); err != nil {
+			return nil, fmt.Errorf("failed to remove build information from current template: %w", err)
+		}
+		if proposedTemplate, err = StripBuildInfo(proposedTemplate); err != nil {
+			return nil, fmt.Errorf("failed to remove build information from proposed template: %w", err)
+		}
+	}
+
+	// Leave ignored properties out of both sides so they neither show in the diff nor count as modifications
+	if len(stack.IgnoreProperties) > 0 {
+		if currentTemplate, err = StripIgnoredProperties(currentTemplate, stack.IgnoreProperties); err != nil {
+			return nil, fmt.Errorf("failed to ignore properties of current template: %w", err)
+		}
+		if proposedTemplate, err = StripIgnoredProperties(proposedTemplate, stack.IgnoreProperties); err != nil {
+			return nil, fmt.Errorf("failed to ignore properties of proposed template: %w", err)
+		}
+	}
+
 	// Compare templates
 	templateChange, err := d.templateComparator.Compare(ctx, currentTemplate, proposedTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compare templates: %w", err)
 	}
 
+	if options.DetectRenames {
+		DetectRenames(templateChange, currentTemplate, proposedTemplate)
+	}
+
 	return templateChange, nil
 }
 
@@ -175,6 +237,31 @@ func (d *StackDiffer) compareParameters(currentStack *aws.StackInfo, stack *mode
 	return d.parameterComparator.Compare(currentStack.Parameters, stack.Parameters)
 }
 
+// markParameters flags parameter diffs whose values must be masked when displayed,
+// and those whose values were overridden on the command line
+func markParameters(diffs []ParameterDiff, stack *model.Stack) []ParameterDiff {
+	for i := range diffs {
+		diffs[i].Sensitive = stack.SensitiveParameters[diffs[i].Key]
+		diffs[i].Overridden = stack.OverriddenParameters[diffs[i].Key]
+	}
+	return diffs
+}
+
+// MaskSensitive returns a copy of parameters with sensitive values replaced by MaskedValue
+func MaskSensitive(parameters map[string]string, sensitive map[string]bool) map[string]string {
+	if len(sensitive) == 0 {
+		return parameters
+	}
+	masked := make(map[string]string, len(parameters))
+	for key, value := range parameters {
+		if sensitive[key] {
+			value = MaskedValue
+		}
+		masked[key] = value
+	}
+	return masked
+}
+
 // compareTags compares current stack tags with resolved tags
 func (d *StackDiffer) compareTags(currentStack *aws.StackInfo, stack *model.Stack) ([]TagDiff, error) {
 	return d.tagComparator.Compare(currentStack.Tags, stack.Tags)
@@ -187,6 +274,9 @@ func (d *StackDiffer) generateChangeSet(ctx context.Context, stack *model.Stack,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get template content: %w", err)
 	}
+	if stack.UsePreviousTemplate {
+		templateContent = "" // Reuse the deployed template
+	}
 
 	// Create changeset - use deployment version if we need to keep it alive
 	var changeSetInfo *aws.ChangeSetInfo
@@ -199,17 +289,21 @@ func (d *StackDiffer) generateChangeSet(ctx context.Context, stack *model.Stack,
 
 	if options.KeepChangeSet {
 		// Use deployment-style changeset that doesn't auto-delete
-		changeSetInfo, err = cfClient.CreateChangeSetForDeployment(
-			ctx,
-			stack.Name,
-			templateContent,
-			stack.Parameters,
-			capabilities,
-			stack.Tags,
-		)
+		changeSetInfo, err = cfClient.CreateChangeSetForDeployment(ctx, aws.ChangeSetInput{
+			StackName:             stack.CloudFormationName(),
+			TemplateBody:          templateContent,
+			TemplateURL:           stack.TemplateURL,
+			Parameters:            stack.Parameters,
+			Capabilities:          capabilities,
+			Tags:                  stack.Tags,
+			NotificationARNs:      stack.NotificationARNs,
+			ResourceTypes:         stack.ResourceTypes,
+			RollbackConfiguration: (*aws.RollbackConfiguration)(stack.Rollback),
+			Metadata:              options.ChangeSetMetadata,
+		})
 	} else {
 		// Use standard changeset that auto-deletes for preview only
-		changeSetInfo, err = cfClient.CreateChangeSetPreview(ctx, stack.Name, templateContent, stack.Parameters, capabilities, stack.Tags)
+		changeSetInfo, err = cfClient.CreateChangeSetPreview(ctx, stack.CloudFormationName(), templateContent, stack.TemplateURL, stack.Parameters, capabilities, stack.Tags)
 	}
 
 	if err != nil {
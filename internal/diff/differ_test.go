@@ -7,10 +7,12 @@ package diff
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/snapshot"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -104,6 +106,41 @@ func TestStackDiffer_DiffStack_ExistingStack_NoChanges(t *testing.T) {
 	tagComp.AssertExpectations(t)
 }
 
+func TestStackDiffer_DiffStack_NoEchoParameterIsNotAChange(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, cfClient := aws.NewMockClientFactoryForRegion("us-east-1")
+	templateComp := &MockTemplateComparator{}
+	differ := &StackDiffer{
+		clientFactory:       mockFactory,
+		templateComparator:  templateComp,
+		parameterComparator: NewParameterComparator(),
+		tagComparator:       NewTagComparator(),
+	}
+
+	stack := createTestResolvedStack()
+	stack.Parameters = map[string]string{"DatabasePassword": "s3cret", "Param1": "value1"}
+	currentStack := &aws.StackInfo{
+		Name:       "test-stack",
+		Parameters: map[string]string{"DatabasePassword": "****", "Param1": "value1"},
+		Tags:       stack.Tags,
+		Template:   stack.TemplateBody,
+	}
+
+	cfClient.On("StackExists", ctx, "test-stack").Return(true, nil)
+	cfClient.On("DescribeStack", ctx, "test-stack").Return(currentStack, nil)
+	cfClient.On("GetTemplate", ctx, "test-stack").Return(currentStack.Template, nil)
+	templateComp.On("Compare", ctx, currentStack.Template, stack.TemplateBody).Return(&TemplateChange{HasChanges: false}, nil)
+
+	result, err := differ.DiffStack(ctx, stack, Options{})
+
+	require.NoError(t, err)
+	assert.False(t, result.HasChanges(), "a masked NoEcho value should not count as a change")
+	require.Len(t, result.ParameterDiffs, 1)
+	assert.Equal(t, ChangeTypeUnknown, result.ParameterDiffs[0].ChangeType)
+	assert.Equal(t, []string{"DatabasePassword"}, result.UnknownParameters())
+	assert.Nil(t, result.ChangeSet, "no changeset should be generated when nothing changed")
+}
+
 func TestStackDiffer_DiffStack_ExistingStack_WithChanges(t *testing.T) {
 	// Test diff of existing stack with changes
 	ctx := context.Background()
@@ -151,7 +188,7 @@ func TestStackDiffer_DiffStack_ExistingStack_WithChanges(t *testing.T) {
 			{Action: "Modify", ResourceType: "AWS::S3::Bucket", LogicalID: "MyBucket"},
 		},
 	}
-	cfClient.On("CreateChangeSetPreview", ctx, "test-stack", stack.TemplateBody, stack.Parameters, mock.Anything, stack.Tags).Return(changeSet, nil)
+	cfClient.On("CreateChangeSetPreview", ctx, "test-stack", stack.TemplateBody, "", stack.Parameters, mock.Anything, stack.Tags).Return(changeSet, nil)
 
 	// Execute
 	result, err := differ.DiffStack(ctx, stack, options)
@@ -175,6 +212,142 @@ func TestStackDiffer_DiffStack_ExistingStack_WithChanges(t *testing.T) {
 	cfClient.AssertExpectations(t)
 }
 
+func TestStackDiffer_DiffStack_TagRemovedFromConfig(t *testing.T) {
+	// A tag on the deployed stack but no longer in config shows as a removal, and the
+	// deployment changeset carries only the configured tags so the stale tag is cleared
+	ctx := context.Background()
+
+	mockFactory, cfClient := aws.NewMockClientFactoryForRegion("us-east-1")
+	templateComp := &MockTemplateComparator{}
+	paramComp := &MockParameterComparator{}
+	differ := &StackDiffer{
+		clientFactory:       mockFactory,
+		templateComparator:  templateComp,
+		parameterComparator: paramComp,
+		tagComparator:       NewTagComparator(),
+	}
+
+	stack := createTestResolvedStack()
+	currentStack := &aws.StackInfo{
+		Name:       "test-stack",
+		Parameters: stack.Parameters,
+		Tags:       map[string]string{"Environment": "dev", "Project": "test", "Owner": "platform"},
+		Template:   stack.TemplateBody,
+	}
+
+	cfClient.On("StackExists", ctx, "test-stack").Return(true, nil)
+	cfClient.On("DescribeStack", ctx, "test-stack").Return(currentStack, nil)
+	cfClient.On("GetTemplate", ctx, "test-stack").Return(currentStack.Template, nil)
+	templateComp.On("Compare", ctx, currentStack.Template, stack.TemplateBody).Return(&TemplateChange{}, nil)
+	paramComp.On("Compare", currentStack.Parameters, stack.Parameters).Return([]ParameterDiff{}, nil)
+	cfClient.On("CreateChangeSetForDeployment", ctx, aws.ChangeSetInput{StackName: "test-stack", TemplateBody: stack.TemplateBody, Parameters: stack.Parameters, Capabilities: stack.Capabilities, Tags: map[string]string{"Environment": "dev", "Project": "test"}}).
+		Return(&aws.ChangeSetInfo{ChangeSetID: "test-changeset-id"}, nil)
+
+	result, err := differ.DiffStack(ctx, stack, Options{KeepChangeSet: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, []TagDiff{
+		{Key: "Owner", CurrentValue: "platform", ChangeType: ChangeTypeRemove},
+	}, result.TagDiffs)
+	assert.True(t, result.HasChanges())
+	cfClient.AssertExpectations(t)
+}
+
+func TestStackDiffer_DiffStack_IgnoreProperties(t *testing.T) {
+	currentTemplate := `Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Metadata:
+      BuildTime: "2025-03-14T09:26:53Z"
+    Properties:
+      BucketName: assets
+`
+
+	tests := []struct {
+		name             string
+		proposedTemplate string
+		expectChanges    bool
+		expectedModified int
+	}{
+		{
+			name:             "ignored property change produces no diff",
+			proposedTemplate: strings.Replace(currentTemplate, "2025-03-14T09:26:53Z", "2025-03-15T10:00:00Z", 1),
+		},
+		{
+			name:             "other property change still produces a diff",
+			proposedTemplate: strings.Replace(strings.Replace(currentTemplate, "2025-03-14T09:26:53Z", "2025-03-15T10:00:00Z", 1), "assets", "static-assets", 1),
+			expectChanges:    true,
+			expectedModified: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockFactory, cfClient := aws.NewMockClientFactoryForRegion("us-east-1")
+			differ := &StackDiffer{
+				clientFactory:       mockFactory,
+				templateComparator:  NewYAMLTemplateComparator(),
+				parameterComparator: NewParameterComparator(),
+				tagComparator:       NewTagComparator(),
+			}
+
+			stack := createTestResolvedStack()
+			stack.TemplateBody = tt.proposedTemplate
+			stack.IgnoreProperties = []string{"Resources.*.Metadata.BuildTime"}
+
+			cfClient.On("StackExists", ctx, "test-stack").Return(true, nil)
+			cfClient.On("DescribeStack", ctx, "test-stack").Return(&aws.StackInfo{Name: "test-stack"}, nil)
+			cfClient.On("GetTemplate", ctx, "test-stack").Return(currentTemplate, nil)
+
+			result, err := differ.DiffStack(ctx, stack, Options{TemplateOnly: true})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectChanges, result.TemplateChange.HasChanges)
+			assert.Equal(t, tt.expectedModified, result.TemplateChange.ResourceCount.Modified)
+			assert.NotContains(t, result.TemplateChange.Diff, "BuildTime")
+			cfClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStackDiffer_DiffStack_IgnoresBuildInfo(t *testing.T) {
+	// Test that a template stamped by deploy --stamp matches the same template unstamped
+	ctx := context.Background()
+	mockFactory, cfClient := aws.NewMockClientFactoryForRegion("us-east-1")
+	differ := &StackDiffer{
+		clientFactory:       mockFactory,
+		templateComparator:  NewYAMLTemplateComparator(),
+		parameterComparator: NewParameterComparator(),
+		tagComparator:       NewTagComparator(),
+	}
+
+	stack := createTestResolvedStack()
+	stack.TemplateBody = `Resources:
+  Queue:
+    Type: AWS::SQS::Queue
+`
+	currentTemplate := `Resources:
+  Queue:
+    Type: AWS::SQS::Queue
+Metadata:
+  StackarooBuildInfo:
+    ContentHash: 3b9f0c
+    Timestamp: "2025-03-14T09:26:53Z"
+    Version: v1.2.3
+`
+
+	cfClient.On("StackExists", ctx, "test-stack").Return(true, nil)
+	cfClient.On("DescribeStack", ctx, "test-stack").Return(&aws.StackInfo{Name: "test-stack"}, nil)
+	cfClient.On("GetTemplate", ctx, "test-stack").Return(currentTemplate, nil)
+
+	result, err := differ.DiffStack(ctx, stack, Options{TemplateOnly: true})
+
+	require.NoError(t, err)
+	assert.False(t, result.TemplateChange.HasChanges)
+	cfClient.AssertExpectations(t)
+}
+
 func TestStackDiffer_DiffStack_NewStack(t *testing.T) {
 	// Test diff of new stack (doesn't exist in AWS)
 	ctx := context.Background()
@@ -404,7 +577,7 @@ func TestStackDiffer_DiffStack_ChangeSetError(t *testing.T) {
 	tagComp.On("Compare", currentStack.Tags, stack.Tags).Return([]TagDiff{}, nil)
 
 	// Mock changeset creation failure
-	cfClient.On("CreateChangeSetPreview", ctx, "test-stack", stack.TemplateBody, stack.Parameters, mock.Anything, stack.Tags).Return((*aws.ChangeSetInfo)(nil), errors.New("changeset failed"))
+	cfClient.On("CreateChangeSetPreview", ctx, "test-stack", stack.TemplateBody, "", stack.Parameters, mock.Anything, stack.Tags).Return((*aws.ChangeSetInfo)(nil), errors.New("changeset failed"))
 
 	// Execute
 	result, err := differ.DiffStack(ctx, stack, options)
@@ -511,7 +684,7 @@ func TestStackDiffer_CompareTemplates_Error(t *testing.T) {
 	templateComp.On("Compare", ctx, currentStack.Template, stack.TemplateBody).Return((*TemplateChange)(nil), errors.New("template parse error"))
 
 	// Execute compareTemplates directly (this tests internal method)
-	templateChange, err := differ.compareTemplates(ctx, stack, currentStack, cfClient)
+	templateChange, err := differ.compareTemplates(ctx, stack, currentStack, Options{}, cfClient)
 
 	// Verify
 	assert.Error(t, err)
@@ -520,3 +693,109 @@ func TestStackDiffer_CompareTemplates_Error(t *testing.T) {
 
 	templateComp.AssertExpectations(t)
 }
+
+func TestStackDiffer_DiffStack_AgainstBaseline(t *testing.T) {
+	// Test that a recorded baseline is used instead of the live stack
+	ctx := context.Background()
+
+	// No AWS operations are configured, so any AWS call would fail
+	mockFactory := aws.NewMockClientFactory()
+	differ := &StackDiffer{
+		clientFactory:       mockFactory,
+		parameterComparator: NewParameterComparator(),
+		tagComparator:       NewTagComparator(),
+	}
+
+	stack := createTestResolvedStack()
+	baseline := &snapshot.StackSnapshot{
+		StackName:  "test-stack",
+		Context:    "dev",
+		Parameters: map[string]string{"Param1": "value1", "Param2": "previous"},
+		Tags:       map[string]string{"Environment": "dev", "Project": "test", "Owner": "ops"},
+	}
+
+	result, err := differ.DiffStack(ctx, stack, Options{Baseline: baseline})
+
+	require.NoError(t, err)
+	assert.True(t, result.StackExists)
+	assert.Nil(t, result.TemplateChange)
+	require.Len(t, result.ParameterDiffs, 1)
+	assert.Equal(t, ParameterDiff{Key: "Param2", CurrentValue: "previous", ProposedValue: "value2", ChangeType: ChangeTypeModify}, result.ParameterDiffs[0])
+	require.Len(t, result.TagDiffs, 1)
+	assert.Equal(t, TagDiff{Key: "Owner", CurrentValue: "ops", ChangeType: ChangeTypeRemove}, result.TagDiffs[0])
+	assert.Contains(t, result.String(), "Compared with the last recorded deployment")
+}
+
+func TestStackDiffer_DiffStack_AgainstBaseline_NoChanges(t *testing.T) {
+	ctx := context.Background()
+	differ := &StackDiffer{
+		clientFactory:       aws.NewMockClientFactory(),
+		parameterComparator: NewParameterComparator(),
+		tagComparator:       NewTagComparator(),
+	}
+
+	stack := createTestResolvedStack()
+	baseline := &snapshot.StackSnapshot{
+		StackName:  "test-stack",
+		Context:    "dev",
+		Parameters: map[string]string{"Param1": "value1", "Param2": "value2"},
+		Tags:       map[string]string{"Environment": "dev", "Project": "test"},
+	}
+
+	result, err := differ.DiffStack(ctx, stack, Options{Baseline: baseline})
+
+	require.NoError(t, err)
+	assert.False(t, result.HasChanges())
+}
+
+func TestStackDiffer_DiffStack_AgainstBaseline_SensitiveParameters(t *testing.T) {
+	// Sensitive values are recorded masked, so an unchanged secret must not show as modified
+	ctx := context.Background()
+	differ := &StackDiffer{
+		clientFactory:       aws.NewMockClientFactory(),
+		parameterComparator: NewParameterComparator(),
+		tagComparator:       NewTagComparator(),
+	}
+
+	stack := createTestResolvedStack()
+	stack.Parameters["DBPassword"] = "s3cr3t"
+	stack.SensitiveParameters = map[string]bool{"DBPassword": true}
+	baseline := &snapshot.StackSnapshot{
+		StackName:  "test-stack",
+		Context:    "dev",
+		Parameters: map[string]string{"Param1": "value1", "Param2": "value2", "DBPassword": MaskedValue},
+		Tags:       map[string]string{"Environment": "dev", "Project": "test"},
+	}
+
+	result, err := differ.DiffStack(ctx, stack, Options{Baseline: baseline})
+
+	require.NoError(t, err)
+	assert.False(t, result.HasChanges())
+}
+
+func TestStackDiffer_HandleNewStack_SensitiveParameters(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockTemplateComparator := &MockTemplateComparator{}
+	differ := &StackDiffer{
+		clientFactory:       mockFactory,
+		templateComparator:  mockTemplateComparator,
+		parameterComparator: NewParameterComparator(),
+		tagComparator:       NewTagComparator(),
+	}
+
+	stack := createTestResolvedStack()
+	stack.Parameters = map[string]string{"DBPassword": "s3cr3t"}
+	stack.SensitiveParameters = map[string]bool{"DBPassword": true}
+
+	mockCfnOps.On("StackExists", ctx, "test-stack").Return(false, nil)
+	mockTemplateComparator.On("Compare", ctx, "{}", stack.TemplateBody).Return(&TemplateChange{HasChanges: true}, nil)
+
+	result, err := differ.DiffStack(ctx, stack, Options{})
+
+	require.NoError(t, err)
+	require.Len(t, result.ParameterDiffs, 1)
+	assert.True(t, result.ParameterDiffs[0].Sensitive)
+	assert.NotContains(t, result.String(), "s3cr3t")
+	assert.Contains(t, result.String(), "DBPassword: ****")
+}
@@ -0,0 +1,175 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// StripIgnoredProperties removes the properties at dotted paths, such as
+// Resources.Bucket.Metadata.BuildTime, from a template so they take no part in a comparison.
+// A * segment matches every key at its level. With paths given, the template is always
+// re-encoded, so two templates that differ only in ignored properties come out identical.
+func StripIgnoredProperties(template string, paths []string) (string, error) {
+	if len(paths) == 0 {
+		return template, nil
+	}
+
+	segmentLists := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		segments := strings.Split(path, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return "", fmt.Errorf("invalid ignored property path %q: segments must not be empty", path)
+			}
+		}
+		segmentLists = append(segmentLists, segments)
+	}
+
+	if json.Valid([]byte(template)) {
+		return stripJSONProperties(template, segmentLists)
+	}
+	return stripYAMLProperties(template, segmentLists)
+}
+
+// stripJSONProperties removes properties from a JSON template and re-encodes it as JSON
+func stripJSONProperties(template string, segmentLists [][]string) (string, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(template), &data); err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	for _, segments := range segmentLists {
+		removeJSONPath(data, segments)
+	}
+
+	return encodeJSONTemplate(data)
+}
+
+// encodeJSONTemplate encodes a decoded JSON template with two-space indentation
+func encodeJSONTemplate(data interface{}) (string, error) {
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode template: %w", err)
+	}
+	return string(encoded) + "\n", nil
+}
+
+// removeJSONPath deletes the property at a path below a decoded JSON value
+func removeJSONPath(value interface{}, segments []string) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	segment, rest := segments[0], segments[1:]
+	for key, child := range object {
+		if segment != "*" && key != segment {
+			continue
+		}
+		if len(rest) == 0 {
+			delete(object, key)
+		} else {
+			removeJSONPath(child, rest)
+		}
+	}
+}
+
+// stripYAMLProperties removes properties from a YAML template and re-encodes it as YAML,
+// preserving intrinsic function tags
+func stripYAMLProperties(template string, segmentLists [][]string) (string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(template), &document); err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+	if len(document.Content) == 0 {
+		return template, nil
+	}
+
+	for _, segments := range segmentLists {
+		removeYAMLPath(document.Content[0], segments)
+	}
+
+	return encodeYAMLTemplate(&document)
+}
+
+// encodeYAMLTemplate encodes a YAML template document with two-space indentation
+func encodeYAMLTemplate(document *yaml.Node) (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return "", fmt.Errorf("failed to encode template: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// removeYAMLPath deletes the property at a path below a YAML mapping node
+func removeYAMLPath(node *yaml.Node, segments []string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	segment, rest := segments[0], segments[1:]
+	for i := 0; i+1 < len(node.Content); {
+		if segment != "*" && node.Content[i].Value != segment {
+			i += 2
+			continue
+		}
+		if len(rest) == 0 {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			continue
+		}
+		removeYAMLPath(node.Content[i+1], rest)
+		i += 2
+	}
+}
+
+// StripBuildInfo removes the build information recorded by deploy --stamp from a template, along
+// with a Metadata section it leaves empty. The template is always re-encoded, so stamped and
+// unstamped copies of the same template come out identical.
+func StripBuildInfo(template string) (string, error) {
+	if json.Valid([]byte(template)) {
+		var data map[string]interface{}
+		if err := json.Unmarshal([]byte(template), &data); err != nil {
+			return "", fmt.Errorf("failed to parse template: %w", err)
+		}
+		if metadata, ok := data["Metadata"].(map[string]interface{}); ok {
+			delete(metadata, model.BuildInfoMetadataKey)
+			if len(metadata) == 0 {
+				delete(data, "Metadata")
+			}
+		}
+		return encodeJSONTemplate(data)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(template), &document); err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+	if len(document.Content) == 0 {
+		return template, nil
+	}
+
+	root := document.Content[0]
+	removeYAMLPath(root, []string{"Metadata", model.BuildInfoMetadataKey})
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		metadata := root.Content[i+1]
+		if root.Content[i].Value == "Metadata" && metadata.Kind == yaml.MappingNode && len(metadata.Content) == 0 {
+			removeYAMLPath(root, []string{"Metadata"})
+			break
+		}
+	}
+	return encodeYAMLTemplate(&document)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripIgnoredProperties_YAML(t *testing.T) {
+	template := `Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Metadata:
+      BuildTime: "2025-03-14T09:26:53Z"
+      Owner: platform
+    Properties:
+      BucketName: !Sub ${AWS::StackName}-assets
+`
+
+	stripped, err := StripIgnoredProperties(template, []string{"Resources.Bucket.Metadata.BuildTime"})
+
+	require.NoError(t, err)
+	assert.Equal(t, `Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Metadata:
+      Owner: platform
+    Properties:
+      BucketName: !Sub ${AWS::StackName}-assets
+`, stripped)
+}
+
+func TestStripIgnoredProperties_JSON(t *testing.T) {
+	template := `{"Resources": {"Bucket": {"Type": "AWS::S3::Bucket", "Metadata": {"BuildTime": "now"}}}}`
+
+	stripped, err := StripIgnoredProperties(template, []string{"Resources.Bucket.Metadata"})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Resources": {"Bucket": {"Type": "AWS::S3::Bucket"}}}`, stripped)
+}
+
+func TestStripIgnoredProperties_Wildcard(t *testing.T) {
+	template := `Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Metadata:
+      BuildTime: one
+  Queue:
+    Type: AWS::SQS::Queue
+    Metadata:
+      BuildTime: two
+`
+
+	stripped, err := StripIgnoredProperties(template, []string{"Resources.*.Metadata.BuildTime"})
+
+	require.NoError(t, err)
+	assert.NotContains(t, stripped, "BuildTime")
+	assert.Contains(t, stripped, "AWS::SQS::Queue")
+}
+
+func TestStripIgnoredProperties_NoPaths(t *testing.T) {
+	template := "Resources: {}\n"
+
+	stripped, err := StripIgnoredProperties(template, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, template, stripped)
+}
+
+func TestStripIgnoredProperties_MissingPathIsIgnored(t *testing.T) {
+	stripped, err := StripIgnoredProperties("Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n", []string{"Resources.Queue.Metadata"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n", stripped)
+}
+
+func TestStripIgnoredProperties_EmptySegment(t *testing.T) {
+	_, err := StripIgnoredProperties("Resources: {}\n", []string{"Resources..Metadata"})
+
+	assert.ErrorContains(t, err, `invalid ignored property path "Resources..Metadata"`)
+}
+
+func TestStripBuildInfo_YAML(t *testing.T) {
+	stamped := `Metadata:
+  StackarooBuildInfo:
+    ContentHash: 3b9f0c
+    Timestamp: "2025-03-14T09:26:53Z"
+    Version: v1.2.3
+Resources:
+  Queue:
+    Type: AWS::SQS::Queue
+`
+	unstamped := `Resources:
+  Queue:
+    Type: AWS::SQS::Queue
+`
+
+	strippedStamped, err := StripBuildInfo(stamped)
+	require.NoError(t, err)
+	strippedUnstamped, err := StripBuildInfo(unstamped)
+	require.NoError(t, err)
+
+	assert.Equal(t, unstamped, strippedStamped)
+	assert.Equal(t, strippedUnstamped, strippedStamped)
+}
+
+func TestStripBuildInfo_KeepsOtherMetadata(t *testing.T) {
+	template := `{"Metadata": {"Owner": "platform", "StackarooBuildInfo": {"Version": "v1.2.3"}}, "Resources": {}}`
+
+	stripped, err := StripBuildInfo(template)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Metadata": {"Owner": "platform"}, "Resources": {}}`, stripped)
+}
@@ -28,6 +28,13 @@ func (r *Result) toText() string {
 	output.WriteString(styles.HeaderTitle.Render(header))
 	output.WriteString("\n\n")
 
+	// Note when comparing against a recorded deployment rather than the live stack
+	if r.Options.Baseline != nil {
+		deployedAt := r.Options.Baseline.DeployedAt.Format("2006-01-02 15:04:05 MST")
+		output.WriteString(styles.SubSection.Render(fmt.Sprintf("Compared with the last recorded deployment (%s)", deployedAt)))
+		output.WriteString("\n\n")
+	}
+
 	// Handle new stack case
 	if !r.StackExists {
 		statusLine := styles.StatusNew.Render("New Stack")
@@ -44,6 +51,9 @@ func (r *Result) toText() string {
 		output.WriteString(statusLine)
 		output.WriteString("\n")
 		output.WriteString("The deployed stack matches your local configuration.\n")
+		if unknown := r.UnknownParameters(); len(unknown) > 0 {
+			fmt.Fprintf(&output, "%s\n", styles.Warning.Render(fmt.Sprintf("Parameters hidden by NoEcho could not be compared: %s", strings.Join(unknown, ", "))))
+		}
 		return output.String()
 	}
 
@@ -70,6 +80,7 @@ func (r *Result) toText() string {
 	// Changeset information or error
 	if r.ChangeSet != nil {
 		r.formatChangeSetText(&output, styles)
+		r.formatReplacementsText(&output, styles)
 	} else if r.ChangeSetError != nil {
 		// Check if this is a "no infrastructure changes" scenario
 		var noChangesErr aws.NoChangesError
@@ -91,8 +102,8 @@ func (r *Result) formatNewStackText(output *strings.Builder, styles *Styles) {
 		for _, diff := range r.ParameterDiffs {
 			symbol := styles.AddedText.Render("+")
 			key := styles.Key.Render(diff.Key)
-			value := styles.Value.Render(diff.ProposedValue)
-			fmt.Fprintf(output, "  %s %s: %s\n", symbol, key, value)
+			value := styles.Value.Render(diff.DisplayProposedValue())
+			fmt.Fprintf(output, "  %s %s: %s%s\n", symbol, key, value, overrideNote(diff, styles))
 		}
 		output.WriteString("\n")
 	}
@@ -117,6 +128,15 @@ func (r *Result) formatTemplateChangesText(output *strings.Builder, styles *Styl
 
 	if r.TemplateChange.HasChanges && r.TemplateChange.Diff != "" {
 		output.WriteString(ColouriseUnifiedDiff(r.TemplateChange.Diff, styles))
+		for _, rename := range r.TemplateChange.Renames {
+			symbol := styles.ModifiedText.Render("~")
+			fmt.Fprintf(output, "\n  %s %s → %s (%s) renamed", symbol,
+				styles.RemovedText.Render(rename.From), styles.AddedText.Render(rename.To),
+				styles.Value.Render(HyperlinkResourceType(rename.ResourceType)))
+		}
+		if len(r.TemplateChange.Renames) > 0 {
+			output.WriteString("\n")
+		}
 	} else {
 		crossmark := styles.StatusNoChange.Render("✗")
 		fmt.Fprintf(output, "%s No template changes\n", crossmark)
@@ -136,23 +156,35 @@ func (r *Result) formatParameterChangesText(output *strings.Builder, styles *Sty
 		switch diff.ChangeType {
 		case ChangeTypeAdd:
 			key = styles.AddedText.Render(diff.Key)
-			value := styles.Value.Render(diff.ProposedValue)
-			fmt.Fprintf(output, "  %s %s: %s\n", symbol, key, value)
+			value := styles.Value.Render(diff.DisplayProposedValue())
+			fmt.Fprintf(output, "  %s %s: %s%s\n", symbol, key, value, overrideNote(diff, styles))
 		case ChangeTypeModify:
 			key = styles.ModifiedText.Render(diff.Key)
-			currentVal := styles.Value.Render(diff.CurrentValue)
-			proposedVal := styles.Value.Render(diff.ProposedValue)
+			currentVal := styles.Value.Render(diff.DisplayCurrentValue())
+			proposedVal := styles.Value.Render(diff.DisplayProposedValue())
 			arrow := styles.Arrow.Render("→")
-			fmt.Fprintf(output, "  %s %s: %s %s %s\n", symbol, key, currentVal, arrow, proposedVal)
+			fmt.Fprintf(output, "  %s %s: %s %s %s%s\n", symbol, key, currentVal, arrow, proposedVal, overrideNote(diff, styles))
 		case ChangeTypeRemove:
 			key = styles.RemovedText.Render(diff.Key)
-			value := styles.Value.Render(diff.CurrentValue)
+			value := styles.Value.Render(diff.DisplayCurrentValue())
 			fmt.Fprintf(output, "  %s %s: %s\n", symbol, key, value)
+		case ChangeTypeUnknown:
+			key = styles.Key.Render(diff.Key)
+			note := styles.Warning.Render("(deployed value hidden by NoEcho; assumed unchanged)")
+			fmt.Fprintf(output, "  %s %s: %s\n", symbol, key, note)
 		}
 	}
 	output.WriteString("\n")
 }
 
+// overrideNote marks a parameter whose proposed value was given with --parameter
+func overrideNote(diff ParameterDiff, styles *Styles) string {
+	if !diff.Overridden {
+		return ""
+	}
+	return " " + styles.Warning.Render("(overridden with --parameter)")
+}
+
 // formatTagChangesText formats tag change information
 func (r *Result) formatTagChangesText(output *strings.Builder, styles *Styles) {
 	output.WriteString(styles.SectionHeader.Render("TAGS"))
@@ -209,7 +241,7 @@ func (r *Result) formatChangeSetText(output *strings.Builder, styles *Styles) {
 
 			switch change.Replacement {
 			case "True":
-				output.WriteString(styles.RiskHigh.Render(" REPLACE"))
+				output.WriteString(styles.RiskHigh.Render(" ⚠ REPLACE"))
 			case "Conditional":
 				output.WriteString(styles.RiskHigh.Render(" REPLACE (conditional)"))
 			}
@@ -221,11 +253,72 @@ func (r *Result) formatChangeSetText(output *strings.Builder, styles *Styles) {
 				output.WriteString(detailText)
 				output.WriteString("\n")
 			}
+
+			// Explain which property changes cause a replacement
+			for _, reason := range replacementReasons(change) {
+				output.WriteString(styles.RiskHigh.Render(fmt.Sprintf("    %s", reason)))
+				output.WriteString("\n")
+			}
 		}
 	}
 	output.WriteString("\n")
 }
 
+// formatReplacementsText summarises the resources the changeset will replace, as replacing a
+// resource deletes the original and often its data. Nothing is written when there are none.
+func (r *Result) formatReplacementsText(output *strings.Builder, styles *Styles) {
+	var replaced []aws.ResourceChange
+	for _, change := range r.ChangeSet.Changes {
+		if change.Replacement == "True" {
+			replaced = append(replaced, change)
+		}
+	}
+	if len(replaced) == 0 {
+		return
+	}
+
+	output.WriteString(styles.RiskHigh.Render("⚠ Resources that will be REPLACED"))
+	output.WriteString("\n\n")
+	for _, change := range replaced {
+		fmt.Fprintf(output, "  %s %s (%s)", styles.RiskHigh.Render("!"), styles.RemovedText.Render(change.LogicalID),
+			styles.Value.Render(HyperlinkResourceType(change.ResourceType)))
+		if change.PhysicalID != "" {
+			fmt.Fprintf(output, " %s", styles.SubSection.Render(fmt.Sprintf("[%s]", change.PhysicalID)))
+		}
+		output.WriteString("\n")
+	}
+	output.WriteString("\n")
+	output.WriteString(styles.Warning.Render("Each is deleted and created anew, losing any data it holds unless it is backed up or retained."))
+	output.WriteString("\n\n")
+}
+
+// replacementReasons explains which property changes cause a resource to be replaced
+func replacementReasons(change aws.ResourceChange) []string {
+	if change.Replacement != "True" && change.Replacement != "Conditional" {
+		return nil
+	}
+
+	var reasons []string
+	for _, property := range change.Properties {
+		var verb string
+		switch property.RequiresRecreation {
+		case "Always":
+			verb = "will be replaced"
+		case "Conditionally":
+			verb = "may be replaced"
+		default:
+			continue
+		}
+
+		reason := fmt.Sprintf("%s %s because %s changed", change.LogicalID, verb, property.Name)
+		if property.CausingEntity != "" {
+			reason += fmt.Sprintf(" (caused by %s)", property.CausingEntity)
+		}
+		reasons = append(reasons, reason)
+	}
+	return reasons
+}
+
 // formatNoInfrastructureChangesText formats output when template changes don't affect infrastructure
 func (r *Result) formatNoInfrastructureChangesText(output *strings.Builder, styles *Styles) {
 	output.WriteString(styles.SectionHeader.Render("PLAN"))
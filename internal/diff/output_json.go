@@ -0,0 +1,153 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+)
+
+// jsonResult is the machine-readable form of a diff result
+type jsonResult struct {
+	StackName      string              `json:"stack_name"`
+	Context        string              `json:"context"`
+	StackExists    bool                `json:"stack_exists"`
+	HasChanges     bool                `json:"has_changes"`
+	Template       *jsonTemplateChange `json:"template,omitempty"`
+	Parameters     []jsonValueDiff     `json:"parameters"`
+	Tags           []jsonValueDiff     `json:"tags"`
+	ChangeSet      *jsonChangeSet      `json:"changeset,omitempty"`
+	ChangeSetError string              `json:"changeset_error,omitempty"`
+}
+
+// jsonTemplateChange summarises template differences without the rendered diff text
+type jsonTemplateChange struct {
+	HasChanges    bool                 `json:"has_changes"`
+	CurrentHash   string               `json:"current_hash"`
+	ProposedHash  string               `json:"proposed_hash"`
+	ResourceCount jsonResourceCount    `json:"resource_count"`
+	Renames       []jsonResourceRename `json:"renames,omitempty"`
+}
+
+type jsonResourceCount struct {
+	Added    int `json:"added"`
+	Modified int `json:"modified"`
+	Removed  int `json:"removed"`
+}
+
+type jsonResourceRename struct {
+	From         string `json:"from"`
+	To           string `json:"to"`
+	ResourceType string `json:"resource_type"`
+}
+
+// jsonValueDiff describes a parameter or tag difference; sensitive values are masked
+type jsonValueDiff struct {
+	Key           string `json:"key"`
+	ChangeType    string `json:"change_type"`
+	CurrentValue  string `json:"current_value,omitempty"`
+	ProposedValue string `json:"proposed_value,omitempty"`
+	Overridden    bool   `json:"overridden,omitempty"` // Proposed value was given with --parameter
+}
+
+type jsonChangeSet struct {
+	ChangeSetID string               `json:"changeset_id"`
+	Status      string               `json:"status"`
+	Changes     []jsonResourceChange `json:"changes"`
+}
+
+type jsonResourceChange struct {
+	Action       string `json:"action"`
+	LogicalID    string `json:"logical_id"`
+	PhysicalID   string `json:"physical_id,omitempty"`
+	ResourceType string `json:"resource_type"`
+	Replacement  string `json:"replacement,omitempty"`
+}
+
+// JSON returns a stable machine-readable representation of the diff results
+func (r *Result) JSON() (string, error) {
+	return r.toJSON()
+}
+
+// toJSON serialises the diff results as indented JSON
+func (r *Result) toJSON() (string, error) {
+	result := jsonResult{
+		StackName:   r.StackName,
+		Context:     r.Context,
+		StackExists: r.StackExists,
+		HasChanges:  r.HasChanges(),
+		Parameters:  make([]jsonValueDiff, 0, len(r.ParameterDiffs)),
+		Tags:        make([]jsonValueDiff, 0, len(r.TagDiffs)),
+	}
+
+	if r.TemplateChange != nil {
+		template := &jsonTemplateChange{
+			HasChanges:   r.TemplateChange.HasChanges,
+			CurrentHash:  r.TemplateChange.CurrentHash,
+			ProposedHash: r.TemplateChange.ProposedHash,
+			ResourceCount: jsonResourceCount{
+				Added:    r.TemplateChange.ResourceCount.Added,
+				Modified: r.TemplateChange.ResourceCount.Modified,
+				Removed:  r.TemplateChange.ResourceCount.Removed,
+			},
+		}
+		for _, rename := range r.TemplateChange.Renames {
+			template.Renames = append(template.Renames, jsonResourceRename(rename))
+		}
+		result.Template = template
+	}
+
+	for _, diff := range r.ParameterDiffs {
+		result.Parameters = append(result.Parameters, jsonValueDiff{
+			Key:           diff.Key,
+			ChangeType:    string(diff.ChangeType),
+			CurrentValue:  diff.DisplayCurrentValue(),
+			ProposedValue: diff.DisplayProposedValue(),
+			Overridden:    diff.Overridden,
+		})
+	}
+
+	for _, diff := range r.TagDiffs {
+		result.Tags = append(result.Tags, jsonValueDiff{
+			Key:           diff.Key,
+			ChangeType:    string(diff.ChangeType),
+			CurrentValue:  diff.CurrentValue,
+			ProposedValue: diff.ProposedValue,
+		})
+	}
+
+	if r.ChangeSet != nil {
+		changeSet := &jsonChangeSet{
+			ChangeSetID: r.ChangeSet.ChangeSetID,
+			Status:      r.ChangeSet.Status,
+			Changes:     make([]jsonResourceChange, 0, len(r.ChangeSet.Changes)),
+		}
+		for _, change := range r.ChangeSet.Changes {
+			changeSet.Changes = append(changeSet.Changes, jsonResourceChange{
+				Action:       change.Action,
+				LogicalID:    change.LogicalID,
+				PhysicalID:   change.PhysicalID,
+				ResourceType: change.ResourceType,
+				Replacement:  change.Replacement,
+			})
+		}
+		result.ChangeSet = changeSet
+	}
+
+	// A changeset with no infrastructure changes is not an error for consumers
+	var noChangesErr aws.NoChangesError
+	if r.ChangeSetError != nil && !errors.As(r.ChangeSetError, &noChangesErr) {
+		result.ChangeSetError = r.ChangeSetError.Error()
+	}
+
+	encoded, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode diff result: %w", err)
+	}
+	return string(encoded) + "\n", nil
+}
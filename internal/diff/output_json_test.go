@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package diff
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResult_JSON_MatchesGoldenFile(t *testing.T) {
+	templateChange := &TemplateChange{
+		HasChanges:   true,
+		CurrentHash:  "abc123def456",
+		ProposedHash: "654fed321cba",
+		Diff:         "not included in JSON output",
+		Renames:      []ResourceRename{{From: "OldQueue", To: "OrdersQueue", ResourceType: "AWS::SQS::Queue"}},
+	}
+	templateChange.ResourceCount.Added = 1
+	templateChange.ResourceCount.Modified = 2
+
+	result := &Result{
+		StackName:      "app",
+		Context:        "prod",
+		StackExists:    true,
+		TemplateChange: templateChange,
+		ParameterDiffs: []ParameterDiff{
+			{Key: "InstanceType", CurrentValue: "t3.small", ProposedValue: "t3.large", ChangeType: ChangeTypeModify},
+			{Key: "DBPassword", CurrentValue: "old-secret", ProposedValue: "new-secret", ChangeType: ChangeTypeModify, Sensitive: true},
+		},
+		TagDiffs: []TagDiff{
+			{Key: "Team", ProposedValue: "platform", ChangeType: ChangeTypeAdd},
+		},
+		ChangeSet: &aws.ChangeSetInfo{
+			ChangeSetID: "arn:aws:cloudformation:us-east-1:123456789012:changeSet/stackaroo-diff-1/abc",
+			Status:      "CREATE_COMPLETE",
+			Changes: []aws.ResourceChange{
+				{Action: "Modify", LogicalID: "Instance", PhysicalID: "i-0123456789", ResourceType: "AWS::EC2::Instance", Replacement: "True"},
+				{Action: "Add", LogicalID: "Alarm", ResourceType: "AWS::CloudWatch::Alarm"},
+			},
+		},
+	}
+
+	actual, err := result.JSON()
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile(filepath.Join("testdata", "result.golden.json"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), actual)
+}
+
+func TestResult_JSON_NewStackWithoutChangeSet(t *testing.T) {
+	result := &Result{
+		StackName:      "app",
+		Context:        "dev",
+		StackExists:    false,
+		ChangeSetError: aws.NoChangesError{StackName: "app"},
+	}
+
+	actual, err := result.JSON()
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"stack_name": "app",
+		"context": "dev",
+		"stack_exists": false,
+		"has_changes": true,
+		"parameters": [],
+		"tags": []
+	}`, actual)
+}
+
+func TestResult_JSON_ReportsChangeSetError(t *testing.T) {
+	result := &Result{
+		StackName:      "app",
+		Context:        "dev",
+		StackExists:    true,
+		ChangeSetError: errors.New("changeset creation failed: Template format error"),
+	}
+
+	actual, err := result.JSON()
+
+	require.NoError(t, err)
+	assert.Contains(t, actual, `"changeset_error": "changeset creation failed: Template format error"`)
+}
@@ -239,6 +239,19 @@ func TestResult_FormatTemplateChangesText(t *testing.T) {
 				"Template diff content here",
 			},
 		},
+		{
+			name: "with renamed resource",
+			templateChange: &TemplateChange{
+				HasChanges: true,
+				Diff:       "Template diff content here",
+				Renames:    []ResourceRename{{From: "OldQueue", To: "OrdersQueue", ResourceType: "AWS::SQS::Queue"}},
+			},
+			expectedOutput: []string{
+				"Template diff content here",
+				"~ OldQueue → OrdersQueue (",
+				"AWS::SQS::Queue",
+			},
+		},
 		{
 			name: "with changes but no diff",
 			templateChange: &TemplateChange{
@@ -298,6 +311,82 @@ func TestResult_FormatParameterChangesText(t *testing.T) {
 	assert.Contains(t, text, "  - RemovedParam: oldvalue")
 }
 
+func TestResult_FormatParameterChangesText_NoEchoParameters(t *testing.T) {
+	_ = os.Setenv("NO_COLOR", "1")
+	defer func() { _ = os.Unsetenv("NO_COLOR") }()
+
+	t.Run("listed alongside other changes", func(t *testing.T) {
+		result := &Result{
+			StackExists: true,
+			ParameterDiffs: []ParameterDiff{
+				{Key: "DatabasePassword", CurrentValue: "****", ProposedValue: "s3cret", ChangeType: ChangeTypeUnknown},
+				{Key: "InstanceType", CurrentValue: "t3.micro", ProposedValue: "t3.small", ChangeType: ChangeTypeModify},
+			},
+		}
+
+		text := result.toText()
+
+		assert.Contains(t, text, "  ? DatabasePassword: (deployed value hidden by NoEcho; assumed unchanged)")
+		assert.NotContains(t, text, "s3cret")
+		assert.Contains(t, text, "  ~ InstanceType: t3.micro → t3.small")
+	})
+
+	t.Run("noted when nothing else changes", func(t *testing.T) {
+		result := &Result{
+			StackExists: true,
+			ParameterDiffs: []ParameterDiff{
+				{Key: "DatabasePassword", CurrentValue: "****", ProposedValue: "s3cret", ChangeType: ChangeTypeUnknown},
+			},
+		}
+
+		text := result.toText()
+
+		assert.Contains(t, text, "No Changes")
+		assert.Contains(t, text, "Parameters hidden by NoEcho could not be compared: DatabasePassword")
+	})
+}
+
+func TestResult_FormatParameterChangesText_MasksSensitiveValues(t *testing.T) {
+	result := &Result{
+		ParameterDiffs: []ParameterDiff{
+			{Key: "DBPassword", CurrentValue: "old-secret", ProposedValue: "new-secret", ChangeType: ChangeTypeModify, Sensitive: true},
+			{Key: "ApiKey", CurrentValue: "", ProposedValue: "key-123", ChangeType: ChangeTypeAdd, Sensitive: true},
+			{Key: "Environment", CurrentValue: "dev", ProposedValue: "prod", ChangeType: ChangeTypeModify},
+		},
+	}
+
+	// Set NO_COLOR for plain output in tests
+	_ = os.Setenv("NO_COLOR", "1")
+	defer func() { _ = os.Unsetenv("NO_COLOR") }()
+
+	var output strings.Builder
+	styles := NewStyles(false) // Use plain styles for testing
+	result.formatParameterChangesText(&output, styles)
+	text := output.String()
+
+	assert.Contains(t, text, "  ~ DBPassword: **** → ****")
+	assert.Contains(t, text, "  + ApiKey: ****")
+	assert.Contains(t, text, "  ~ Environment: dev → prod")
+	assert.NotContains(t, text, "secret")
+	assert.NotContains(t, text, "key-123")
+}
+
+func TestResult_FormatParameterChangesText_MarksOverrides(t *testing.T) {
+	result := &Result{
+		ParameterDiffs: []ParameterDiff{
+			{Key: "ImageTag", CurrentValue: "v1.2.0", ProposedValue: "abc123", ChangeType: ChangeTypeModify, Overridden: true},
+			{Key: "Environment", CurrentValue: "dev", ProposedValue: "prod", ChangeType: ChangeTypeModify},
+		},
+	}
+
+	var output strings.Builder
+	result.formatParameterChangesText(&output, NewStyles(false))
+	text := output.String()
+
+	assert.Contains(t, text, "  ~ ImageTag: v1.2.0 → abc123 (overridden with --parameter)\n")
+	assert.Contains(t, text, "  ~ Environment: dev → prod\n")
+}
+
 func TestResult_FormatTagChangesText(t *testing.T) {
 	result := &Result{
 		TagDiffs: []TagDiff{
@@ -384,6 +473,97 @@ func TestResult_FormatChangeSetText(t *testing.T) {
 	assert.Contains(t, text, "    Property: SecurityGroups")
 }
 
+func TestResult_FormatChangeSetText_ExplainsReplacement(t *testing.T) {
+	result := &Result{
+		ChangeSet: &aws.ChangeSetInfo{
+			Changes: []aws.ResourceChange{
+				{
+					Action:       "Modify",
+					ResourceType: "AWS::EC2::Instance",
+					LogicalID:    "WebServer",
+					Replacement:  "True",
+					Details:      []string{"Property: ImageId (Properties)", "Property: Tags (Properties)"},
+					Properties: []aws.PropertyChange{
+						{Name: "ImageId", Attribute: "Properties", RequiresRecreation: "Always", ChangeSource: "ParameterReference", CausingEntity: "AmiId"},
+						{Name: "Tags", Attribute: "Properties", RequiresRecreation: "Never", ChangeSource: "DirectModification"},
+					},
+				},
+				{
+					Action:       "Modify",
+					ResourceType: "AWS::RDS::DBInstance",
+					LogicalID:    "Database",
+					Replacement:  "Conditional",
+					Properties: []aws.PropertyChange{
+						{Name: "DBInstanceIdentifier", Attribute: "Properties", RequiresRecreation: "Conditionally", ChangeSource: "DirectModification"},
+					},
+				},
+				{
+					Action:       "Modify",
+					ResourceType: "AWS::S3::Bucket",
+					LogicalID:    "Bucket",
+					Replacement:  "False",
+					Properties: []aws.PropertyChange{
+						{Name: "Tags", Attribute: "Properties", RequiresRecreation: "Never"},
+					},
+				},
+			},
+		},
+	}
+
+	var output strings.Builder
+	result.formatChangeSetText(&output, NewStyles(false))
+	text := output.String()
+
+	assert.Contains(t, text, "WebServer will be replaced because ImageId changed (caused by AmiId)")
+	assert.Contains(t, text, "Database may be replaced because DBInstanceIdentifier changed")
+	assert.NotContains(t, text, "because Tags changed")
+	assert.NotContains(t, text, "Bucket will be replaced")
+}
+
+func TestResult_ToText_ReplacementsSummary(t *testing.T) {
+	_ = os.Setenv("NO_COLOR", "1")
+	defer func() { _ = os.Unsetenv("NO_COLOR") }()
+
+	newResult := func(changes ...aws.ResourceChange) *Result {
+		return &Result{
+			StackName:      "app",
+			Context:        "dev",
+			StackExists:    true,
+			TemplateChange: &TemplateChange{HasChanges: true, Diff: "-a\n+b\n"},
+			ChangeSet:      &aws.ChangeSetInfo{Changes: changes},
+		}
+	}
+
+	t.Run("listed when resources are replaced", func(t *testing.T) {
+		text := newResult(
+			aws.ResourceChange{Action: "Modify", ResourceType: "AWS::RDS::DBInstance", LogicalID: "Database", PhysicalID: "app-db", Replacement: "True"},
+			aws.ResourceChange{Action: "Modify", ResourceType: "AWS::EC2::Instance", LogicalID: "WebServer", Replacement: "Conditional"},
+			aws.ResourceChange{Action: "Modify", ResourceType: "AWS::SQS::Queue", LogicalID: "Queue", Replacement: "False"},
+		).toText()
+
+		// Resource types are hyperlinked, so only the text around them is compared
+		assert.Contains(t, text, "[app-db] ⚠ REPLACE\n")
+		assert.Contains(t, text, "⚠ Resources that will be REPLACED\n\n  ! Database (")
+		assert.Contains(t, text, "losing any data it holds")
+
+		// Only certain replacements are summarised
+		summary := text[strings.Index(text, "Resources that will be REPLACED"):]
+		assert.NotContains(t, summary, "WebServer")
+		assert.NotContains(t, summary, "Queue")
+		assert.NotContains(t, text, "\x1b[", "NO_COLOR output should not contain escape codes")
+	})
+
+	t.Run("absent without replacements", func(t *testing.T) {
+		text := newResult(
+			aws.ResourceChange{Action: "Modify", ResourceType: "AWS::EC2::Instance", LogicalID: "WebServer", Replacement: "Conditional"},
+			aws.ResourceChange{Action: "Add", ResourceType: "AWS::SQS::Queue", LogicalID: "Queue"},
+		).toText()
+
+		assert.Contains(t, text, "PLAN")
+		assert.NotContains(t, text, "Resources that will be REPLACED")
+	})
+}
+
 func TestResult_GetChangeSymbol(t *testing.T) {
 	// Set NO_COLOR for plain output in tests
 	_ = os.Setenv("NO_COLOR", "1")
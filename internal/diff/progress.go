@@ -0,0 +1,67 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package diff
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"github.com/charmbracelet/x/term"
+)
+
+// spinnerFrames animate the changeset progress line, one frame per status check
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// ChangeSetProgressPrinter shows the status of a changeset while CloudFormation creates it, so
+// large changesets do not leave the diff silent. On a terminal a single spinner line is redrawn
+// in place; otherwise a status line is printed after every check.
+type ChangeSetProgressPrinter struct {
+	w           io.Writer
+	stackName   string
+	interactive bool
+	frame       int
+	drawn       bool // Whether a spinner line is on screen and needs clearing
+}
+
+// NewChangeSetProgressPrinter creates a printer reporting on the changeset of a stack, redrawing
+// a spinner in place when interactive is set
+func NewChangeSetProgressPrinter(w io.Writer, stackName string, interactive bool) *ChangeSetProgressPrinter {
+	return &ChangeSetProgressPrinter{w: w, stackName: stackName, interactive: interactive}
+}
+
+// Update shows the latest status of the changeset
+func (p *ChangeSetProgressPrinter) Update(progress aws.ChangeSetProgress) {
+	elapsed := progress.Elapsed.Truncate(time.Second)
+	if !p.interactive {
+		_, _ = fmt.Fprintf(p.w, "Waiting for changeset for stack %s: %s (%s elapsed)\n", p.stackName, progress.Status, elapsed)
+		return
+	}
+
+	frame := spinnerFrames[p.frame%len(spinnerFrames)]
+	p.frame++
+	_, _ = fmt.Fprintf(p.w, "\r\x1b[K%s Waiting for changeset for stack %s: %s (%s)", frame, Highlight(p.stackName), progress.Status, elapsed)
+	p.drawn = true
+}
+
+// Done clears the spinner line so the diff is printed from the start of a clean line
+func (p *ChangeSetProgressPrinter) Done() {
+	if p.drawn {
+		_, _ = io.WriteString(p.w, "\r\x1b[K")
+		p.drawn = false
+	}
+}
+
+// IsInteractiveTerminal reports whether w is a terminal able to redraw output in place
+func IsInteractiveTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(file.Fd()) {
+		return false
+	}
+	termName := os.Getenv("TERM")
+	return termName != "" && termName != "dumb"
+}
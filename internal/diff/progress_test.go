@@ -0,0 +1,53 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package diff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeSetProgressPrinter_PrintsStatusLinesWhenNotInteractive(t *testing.T) {
+	var output bytes.Buffer
+	printer := NewChangeSetProgressPrinter(&output, "vpc", false)
+
+	printer.Update(aws.ChangeSetProgress{Status: "CREATE_PENDING"})
+	printer.Update(aws.ChangeSetProgress{Status: "CREATE_IN_PROGRESS", Elapsed: 2500 * time.Millisecond})
+	printer.Done()
+
+	assert.Equal(t, "Waiting for changeset for stack vpc: CREATE_PENDING (0s elapsed)\n"+
+		"Waiting for changeset for stack vpc: CREATE_IN_PROGRESS (2s elapsed)\n", output.String())
+}
+
+func TestChangeSetProgressPrinter_RedrawsSpinnerWhenInteractive(t *testing.T) {
+	var output bytes.Buffer
+	printer := NewChangeSetProgressPrinter(&output, "vpc", true)
+
+	printer.Update(aws.ChangeSetProgress{Status: "CREATE_PENDING"})
+	printer.Update(aws.ChangeSetProgress{Status: "CREATE_IN_PROGRESS", Elapsed: 4 * time.Second})
+	printer.Done()
+
+	rendered := output.String()
+	assert.Contains(t, rendered, "\r\x1b[K⠋ Waiting for changeset for stack ")
+	assert.Contains(t, rendered, ": CREATE_PENDING (0s)")
+	assert.Contains(t, rendered, "\r\x1b[K⠙ Waiting for changeset for stack ")
+	assert.Contains(t, rendered, ": CREATE_IN_PROGRESS (4s)")
+	assert.NotContains(t, rendered, "\n", "the spinner should stay on one line")
+	assert.True(t, strings.HasSuffix(rendered, "\r\x1b[K"), "done should clear the spinner line")
+}
+
+func TestChangeSetProgressPrinter_DoneWithoutUpdatesWritesNothing(t *testing.T) {
+	var output bytes.Buffer
+	printer := NewChangeSetProgressPrinter(&output, "vpc", true)
+
+	printer.Done()
+
+	assert.Empty(t, output.String())
+}
@@ -318,6 +318,8 @@ func (s *Styles) GetChangeSymbol(changeType ChangeType) string {
 		return s.ModifiedText.Render("~")
 	case ChangeTypeRemove:
 		return s.RemovedText.Render("-")
+	case ChangeTypeUnknown:
+		return s.Warning.Render("?")
 	default:
 		return "?"
 	}
@@ -119,6 +119,75 @@ func (c *YAMLTemplateComparator) compareResources(currentData, proposedData map[
 	return counts, nil
 }
 
+// DetectRenames reports removed resources that reappear under a new logical ID with the same
+// type and properties, removing them from the added and removed counts.
+// Detection is best-effort: templates that cannot be parsed leave the change untouched.
+func DetectRenames(change *TemplateChange, currentTemplate, proposedTemplate string) {
+	if change == nil || !change.HasChanges {
+		return
+	}
+
+	var currentData, proposedData map[string]interface{}
+	if yaml.Unmarshal([]byte(currentTemplate), &currentData) != nil || yaml.Unmarshal([]byte(proposedTemplate), &proposedData) != nil {
+		return
+	}
+
+	c := &YAMLTemplateComparator{}
+	currentResources := c.getResourcesSection(currentData)
+	proposedResources := c.getResourcesSection(proposedData)
+
+	var removed, added []string
+	for name := range currentResources {
+		if _, exists := proposedResources[name]; !exists {
+			removed = append(removed, name)
+		}
+	}
+	for name := range proposedResources {
+		if _, exists := currentResources[name]; !exists {
+			added = append(added, name)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+
+	matched := make(map[string]bool)
+	for _, from := range removed {
+		for _, to := range added {
+			if matched[to] {
+				continue
+			}
+			resourceType, same := sameResourceDefinition(currentResources[from], proposedResources[to])
+			if !same {
+				continue
+			}
+			matched[to] = true
+			change.Renames = append(change.Renames, ResourceRename{From: from, To: to, ResourceType: resourceType})
+			break
+		}
+	}
+
+	change.ResourceCount.Added -= len(change.Renames)
+	change.ResourceCount.Removed -= len(change.Renames)
+}
+
+// sameResourceDefinition reports whether two resources share a type and properties, returning the type
+func sameResourceDefinition(current, proposed interface{}) (string, bool) {
+	currentResource, ok := current.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	proposedResource, ok := proposed.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	resourceType, ok := currentResource["Type"].(string)
+	if !ok || resourceType != proposedResource["Type"] {
+		return "", false
+	}
+	return resourceType, reflect.DeepEqual(currentResource["Properties"], proposedResource["Properties"])
+}
+
 // getResourcesSection extracts the Resources section from a template
 func (c *YAMLTemplateComparator) getResourcesSection(templateData map[string]interface{}) map[string]interface{} {
 	if resources, ok := templateData["Resources"]; ok {
@@ -177,6 +177,66 @@ Resources:
 	assert.Equal(t, 1, result.ResourceCount.Removed)  // OldQueue
 }
 
+func TestDetectRenames_IdenticalDefinitionIsRename(t *testing.T) {
+	comparator := NewYAMLTemplateComparator()
+	ctx := context.Background()
+
+	currentTemplate := `Resources:
+  OldQueue:
+    Type: AWS::SQS::Queue
+    Properties:
+      VisibilityTimeout: 60
+  MyTopic:
+    Type: AWS::SNS::Topic`
+
+	proposedTemplate := `Resources:
+  OrdersQueue:
+    Type: AWS::SQS::Queue
+    Properties:
+      VisibilityTimeout: 60
+  MyTopic:
+    Type: AWS::SNS::Topic`
+
+	result, err := comparator.Compare(ctx, currentTemplate, proposedTemplate)
+	require.NoError(t, err)
+
+	DetectRenames(result, currentTemplate, proposedTemplate)
+
+	assert.Equal(t, []ResourceRename{{From: "OldQueue", To: "OrdersQueue", ResourceType: "AWS::SQS::Queue"}}, result.Renames)
+	assert.Equal(t, 0, result.ResourceCount.Added)
+	assert.Equal(t, 0, result.ResourceCount.Removed)
+}
+
+func TestDetectRenames_DifferentDefinitionIsAddAndRemove(t *testing.T) {
+	comparator := NewYAMLTemplateComparator()
+	ctx := context.Background()
+
+	currentTemplate := `Resources:
+  OldQueue:
+    Type: AWS::SQS::Queue
+    Properties:
+      VisibilityTimeout: 60
+  OldBucket:
+    Type: AWS::S3::Bucket`
+
+	proposedTemplate := `Resources:
+  NewQueue:
+    Type: AWS::SQS::Queue
+    Properties:
+      VisibilityTimeout: 120
+  NewTopic:
+    Type: AWS::SNS::Topic`
+
+	result, err := comparator.Compare(ctx, currentTemplate, proposedTemplate)
+	require.NoError(t, err)
+
+	DetectRenames(result, currentTemplate, proposedTemplate)
+
+	assert.Empty(t, result.Renames)
+	assert.Equal(t, 2, result.ResourceCount.Added)
+	assert.Equal(t, 2, result.ResourceCount.Removed)
+}
+
 func TestYAMLTemplateComparator_Compare_InvalidCurrentTemplate(t *testing.T) {
 	comparator := NewYAMLTemplateComparator()
 	ctx := context.Background()
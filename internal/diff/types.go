@@ -6,9 +6,11 @@ package diff
 
 import (
 	"context"
+	"fmt"
 
 	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/snapshot"
 )
 
 // Differ defines the interface for performing stack diffs
@@ -24,8 +26,15 @@ type Options struct {
 	ParametersOnly bool // Only compare parameters
 	TagsOnly       bool // Only compare tags
 
+	// DetectRenames reports removed and added resources with identical definitions as renames
+	DetectRenames bool
+
 	// Changeset lifecycle control
-	KeepChangeSet bool // Keep changeset alive after diff (for deployment use)
+	KeepChangeSet     bool                  // Keep changeset alive after diff (for deployment use)
+	ChangeSetMetadata aws.ChangeSetMetadata // Recorded on changesets kept for deployment
+
+	// Baseline compares parameters and tags against a recorded deployment instead of the live stack
+	Baseline *snapshot.StackSnapshot
 }
 
 // Result contains the results of a stack diff operation
@@ -51,8 +60,10 @@ func (r *Result) HasChanges() bool {
 		return true
 	}
 
-	if len(r.ParameterDiffs) > 0 {
-		return true
+	for _, diff := range r.ParameterDiffs {
+		if diff.ChangeType != ChangeTypeUnknown {
+			return true
+		}
 	}
 
 	if len(r.TagDiffs) > 0 {
@@ -62,11 +73,33 @@ func (r *Result) HasChanges() bool {
 	return false
 }
 
+// UnknownParameters returns the keys of parameters whose deployed values are masked by NoEcho
+func (r *Result) UnknownParameters() []string {
+	var keys []string
+	for _, diff := range r.ParameterDiffs {
+		if diff.ChangeType == ChangeTypeUnknown {
+			keys = append(keys, diff.Key)
+		}
+	}
+	return keys
+}
+
 // String returns a human-readable representation of the diff results
 func (r *Result) String() string {
 	return r.toText()
 }
 
+// ChangesDetectedError indicates that stacks in a context differ from their deployed state
+type ChangesDetectedError struct {
+	Context string
+	Changed int // Existing stacks with changes
+	New     int // Stacks that do not exist yet
+}
+
+func (e ChangesDetectedError) Error() string {
+	return fmt.Sprintf("changes detected in context %s: %d stack(s) changed, %d new", e.Context, e.Changed, e.New)
+}
+
 // TemplateChange represents differences in CloudFormation templates
 type TemplateChange struct {
 	HasChanges    bool
@@ -78,14 +111,43 @@ type TemplateChange struct {
 		Modified int
 		Removed  int
 	}
+	Renames []ResourceRename // Removed resources that reappear under a new logical ID
 }
 
+// ResourceRename pairs a removed resource with an added one of identical type and properties
+type ResourceRename struct {
+	From         string // Logical ID in the current template
+	To           string // Logical ID in the proposed template
+	ResourceType string
+}
+
+// MaskedValue is displayed in place of sensitive parameter values
+const MaskedValue = model.MaskedValue
+
 // ParameterDiff represents a difference in stack parameters
 type ParameterDiff struct {
 	Key           string
 	CurrentValue  string
 	ProposedValue string
 	ChangeType    ChangeType
+	Sensitive     bool // Values are masked when displayed
+	Overridden    bool // The proposed value was given with --parameter rather than resolved from configuration
+}
+
+// DisplayCurrentValue returns the current value, masked if the parameter is sensitive
+func (d ParameterDiff) DisplayCurrentValue() string {
+	if d.Sensitive && d.CurrentValue != "" {
+		return MaskedValue
+	}
+	return d.CurrentValue
+}
+
+// DisplayProposedValue returns the proposed value, masked if the parameter is sensitive
+func (d ParameterDiff) DisplayProposedValue() string {
+	if d.Sensitive && d.ProposedValue != "" {
+		return MaskedValue
+	}
+	return d.ProposedValue
 }
 
 // TagDiff represents a difference in stack tags
@@ -103,6 +165,9 @@ const (
 	ChangeTypeAdd    ChangeType = "ADD"
 	ChangeTypeModify ChangeType = "MODIFY"
 	ChangeTypeRemove ChangeType = "REMOVE"
+	// ChangeTypeUnknown marks a parameter whose deployed value CloudFormation masks because it
+	// is NoEcho, so it cannot be compared. It is reported but does not count as a change.
+	ChangeTypeUnknown ChangeType = "UNKNOWN"
 )
 
 // Comparator interfaces for different types of comparisons
@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package drift
+
+import (
+	"context"
+	"fmt"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/model"
+)
+
+// Detector defines the interface for checking deployed stacks against their templates
+type Detector interface {
+	DetectDrift(ctx context.Context, stack *model.Stack) (*Result, error)
+}
+
+// Result contains the drift status of a deployed stack and its resources
+type Result struct {
+	StackName string
+	Region    string
+	Status    string // DRIFTED, IN_SYNC, UNKNOWN or NOT_CHECKED
+	Resources []aws.ResourceDrift
+}
+
+// DriftedResources returns the resources that no longer match the template
+func (r *Result) DriftedResources() []aws.ResourceDrift {
+	var drifted []aws.ResourceDrift
+	for _, resource := range r.Resources {
+		if resource.IsDrifted() {
+			drifted = append(drifted, resource)
+		}
+	}
+	return drifted
+}
+
+// HasDrift reports whether any resource in the stack has drifted
+func (r *Result) HasDrift() bool {
+	return len(r.DriftedResources()) > 0
+}
+
+// DriftDetectedError indicates that a stack has drifted from its template
+type DriftDetectedError struct {
+	StackName string
+	Count     int
+}
+
+func (e DriftDetectedError) Error() string {
+	return fmt.Sprintf("stack %s has drifted: %d resource(s) differ from the template", e.StackName, e.Count)
+}
+
+// DriftDetector implements the Detector interface using AWS CloudFormation drift detection
+type DriftDetector struct {
+	clientFactory aws.ClientFactory
+}
+
+// NewDriftDetector creates a new drift detector with the provided client factory
+func NewDriftDetector(clientFactory aws.ClientFactory) Detector {
+	return &DriftDetector{
+		clientFactory: clientFactory,
+	}
+}
+
+// DetectDrift runs CloudFormation drift detection on a deployed stack
+func (d *DriftDetector) DetectDrift(ctx context.Context, stack *model.Stack) (*Result, error) {
+	cfOps, err := d.clientFactory.GetCloudFormationOperations(ctx, stack.Context.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CloudFormation operations for region %s: %w", stack.Context.Region, err)
+	}
+
+	exists, err := cfOps.StackExists(ctx, stack.CloudFormationName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if stack exists: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("stack %s does not exist in region %s", stack.Name, stack.Context.Region)
+	}
+
+	driftResult, err := cfOps.DetectStackDrift(ctx, stack.CloudFormationName())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		StackName: stack.Name,
+		Region:    stack.Context.Region,
+		Status:    driftResult.DriftStatus,
+		Resources: driftResult.Resources,
+	}, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package drift
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriftDetector_DetectDrift_ReturnsResult(t *testing.T) {
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	detector := NewDriftDetector(mockFactory)
+
+	ctx := context.Background()
+	stack := &model.Stack{
+		Name:    "app",
+		Context: model.NewTestContext("production", "us-east-1", "123456789012"),
+	}
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("DetectStackDrift", ctx, "app").Return(&aws.StackDriftResult{
+		StackName:   "app",
+		DriftStatus: "DRIFTED",
+		Resources: []aws.ResourceDrift{
+			{LogicalID: "Bucket", ResourceType: "AWS::S3::Bucket", DriftStatus: "MODIFIED"},
+			{LogicalID: "Queue", ResourceType: "AWS::SQS::Queue", DriftStatus: "IN_SYNC"},
+		},
+	}, nil)
+
+	result, err := detector.DetectDrift(ctx, stack)
+
+	require.NoError(t, err)
+	assert.Equal(t, "app", result.StackName)
+	assert.Equal(t, "us-east-1", result.Region)
+	assert.Equal(t, "DRIFTED", result.Status)
+	assert.True(t, result.HasDrift())
+	require.Len(t, result.DriftedResources(), 1)
+	assert.Equal(t, "Bucket", result.DriftedResources()[0].LogicalID)
+	mockCFOps.AssertExpectations(t)
+}
+
+func TestDriftDetector_DetectDrift_InSync(t *testing.T) {
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	detector := NewDriftDetector(mockFactory)
+
+	ctx := context.Background()
+	stack := &model.Stack{
+		Name:    "app",
+		Context: model.NewTestContext("production", "us-east-1", "123456789012"),
+	}
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("DetectStackDrift", ctx, "app").Return(&aws.StackDriftResult{
+		StackName:   "app",
+		DriftStatus: "IN_SYNC",
+		Resources: []aws.ResourceDrift{
+			{LogicalID: "Bucket", ResourceType: "AWS::S3::Bucket", DriftStatus: "IN_SYNC"},
+		},
+	}, nil)
+
+	result, err := detector.DetectDrift(ctx, stack)
+
+	require.NoError(t, err)
+	assert.False(t, result.HasDrift())
+}
+
+func TestDriftDetector_DetectDrift_StackDoesNotExist(t *testing.T) {
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	detector := NewDriftDetector(mockFactory)
+
+	ctx := context.Background()
+	stack := &model.Stack{
+		Name:    "app",
+		Context: model.NewTestContext("production", "us-east-1", "123456789012"),
+	}
+
+	mockCFOps.On("StackExists", ctx, "app").Return(false, nil)
+
+	_, err := detector.DetectDrift(ctx, stack)
+
+	require.Error(t, err)
+	assert.Equal(t, "stack app does not exist in region us-east-1", err.Error())
+	mockCFOps.AssertNotCalled(t, "DetectStackDrift")
+}
+
+func TestDriftDetector_DetectDrift_PropagatesError(t *testing.T) {
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	detector := NewDriftDetector(mockFactory)
+
+	ctx := context.Background()
+	stack := &model.Stack{
+		Name:    "app",
+		Context: model.NewTestContext("production", "us-east-1", "123456789012"),
+	}
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("DetectStackDrift", ctx, "app").Return(nil, errors.New("drift detection failed: throttled"))
+
+	_, err := detector.DetectDrift(ctx, stack)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "throttled")
+}
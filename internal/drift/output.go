@@ -0,0 +1,44 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package drift
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatResult formats a drift detection result for display
+func FormatResult(result *Result) string {
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "Stack: %s\n", result.StackName)
+	fmt.Fprintf(&output, "Region: %s\n", result.Region)
+	fmt.Fprintf(&output, "Drift status: %s\n", result.Status)
+
+	drifted := result.DriftedResources()
+	if len(drifted) == 0 {
+		output.WriteString("\nNo drifted resources\n")
+		return output.String()
+	}
+
+	fmt.Fprintf(&output, "\nDrifted resources (%d):\n", len(drifted))
+	for _, resource := range drifted {
+		fmt.Fprintf(&output, "\n  %s (%s): %s\n", resource.LogicalID, resource.ResourceType, resource.DriftStatus)
+		if resource.PhysicalID != "" {
+			fmt.Fprintf(&output, "    Physical ID: %s\n", resource.PhysicalID)
+		}
+		for _, difference := range resource.Differences {
+			fmt.Fprintf(&output, "    %s %s\n", difference.DifferenceType, difference.Path)
+			if difference.ExpectedValue != "" {
+				fmt.Fprintf(&output, "      expected: %s\n", difference.ExpectedValue)
+			}
+			if difference.ActualValue != "" {
+				fmt.Fprintf(&output, "      actual:   %s\n", difference.ActualValue)
+			}
+		}
+	}
+
+	return output.String()
+}
@@ -0,0 +1,59 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package drift
+
+import (
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatResult_ListsDriftedResources(t *testing.T) {
+	result := &Result{
+		StackName: "app",
+		Region:    "us-east-1",
+		Status:    "DRIFTED",
+		Resources: []aws.ResourceDrift{
+			{
+				LogicalID:    "Bucket",
+				PhysicalID:   "app-bucket-123",
+				ResourceType: "AWS::S3::Bucket",
+				DriftStatus:  "MODIFIED",
+				Differences: []aws.PropertyDifference{
+					{Path: "/VersioningConfiguration/Status", ExpectedValue: "Enabled", ActualValue: "Suspended", DifferenceType: "NOT_EQUAL"},
+				},
+			},
+			{LogicalID: "Queue", ResourceType: "AWS::SQS::Queue", DriftStatus: "IN_SYNC"},
+		},
+	}
+
+	expected := `Stack: app
+Region: us-east-1
+Drift status: DRIFTED
+
+Drifted resources (1):
+
+  Bucket (AWS::S3::Bucket): MODIFIED
+    Physical ID: app-bucket-123
+    NOT_EQUAL /VersioningConfiguration/Status
+      expected: Enabled
+      actual:   Suspended
+`
+	assert.Equal(t, expected, FormatResult(result))
+}
+
+func TestFormatResult_NoDrift(t *testing.T) {
+	result := &Result{
+		StackName: "app",
+		Region:    "us-east-1",
+		Status:    "IN_SYNC",
+	}
+
+	output := FormatResult(result)
+
+	assert.Contains(t, output, "Drift status: IN_SYNC")
+	assert.Contains(t, output, "No drifted resources")
+}
@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+
+// Package export captures the deployed state of a stack as a single JSON artifact
+// for disaster recovery, auditing and later comparison.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+)
+
+// StackState is the exported state of a deployed stack
+type StackState struct {
+	StackName   string            `json:"stack_name"`
+	Context     string            `json:"context"`
+	Region      string            `json:"region"`
+	StackID     string            `json:"stack_id"`
+	Status      string            `json:"status"`
+	Description string            `json:"description,omitempty"`
+	CreatedTime *time.Time        `json:"created_time,omitempty"`
+	UpdatedTime *time.Time        `json:"updated_time,omitempty"`
+	ExportedAt  time.Time         `json:"exported_at"`
+	Parameters  map[string]string `json:"parameters"`
+	Outputs     map[string]string `json:"outputs"`
+	Tags        map[string]string `json:"tags"`
+	Template    string            `json:"template"`
+}
+
+// JSON returns the exported state as indented JSON
+func (s *StackState) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state of stack %s: %w", s.StackName, err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Save writes the exported state to the given path
+func (s *StackState) Save(path string) error {
+	data, err := s.JSON()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Exporter defines the interface for capturing the deployed state of a configured stack
+type Exporter interface {
+	ExportStack(ctx context.Context, contextName, stackName string) (*StackState, error)
+}
+
+// StackExporter implements the Exporter interface using the configuration and AWS CloudFormation
+type StackExporter struct {
+	provider      config.ConfigProvider
+	clientFactory aws.ClientFactory
+}
+
+// NewStackExporter creates a new exporter with the provided configuration and client factory
+func NewStackExporter(provider config.ConfigProvider, clientFactory aws.ClientFactory) Exporter {
+	return &StackExporter{
+		provider:      provider,
+		clientFactory: clientFactory,
+	}
+}
+
+// ExportStack reads the template, parameters, tags and outputs of a deployed stack.
+// Values come from CloudFormation rather than the configuration, so NoEcho parameters are masked by AWS.
+func (e *StackExporter) ExportStack(ctx context.Context, contextName, stackName string) (*StackState, error) {
+	cfg, err := e.provider.LoadConfig(ctx, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if _, err := e.provider.GetStack(stackName, contextName); err != nil {
+		return nil, err
+	}
+
+	region := cfg.Context.Region
+	cfOps, err := e.clientFactory.GetCloudFormationOperations(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CloudFormation operations for region %s: %w", region, err)
+	}
+
+	deployedName := cfg.Context.DeployedStackName(stackName)
+	exists, err := cfOps.StackExists(ctx, deployedName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if stack exists: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("stack %s does not exist in region %s", stackName, region)
+	}
+
+	stackInfo, err := cfOps.DescribeStack(ctx, deployedName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StackState{
+		StackName:   stackInfo.Name,
+		Context:     contextName,
+		Region:      region,
+		StackID:     stackInfo.ID,
+		Status:      string(stackInfo.Status),
+		Description: stackInfo.Description,
+		CreatedTime: stackInfo.CreatedTime,
+		UpdatedTime: stackInfo.UpdatedTime,
+		ExportedAt:  time.Now().UTC(),
+		Parameters:  nonNil(stackInfo.Parameters),
+		Outputs:     nonNil(stackInfo.Outputs),
+		Tags:        nonNil(stackInfo.Tags),
+		Template:    stackInfo.Template,
+	}, nil
+}
+
+// nonNil returns an empty map in place of nil so the exported JSON always contains an object
+func nonNil(values map[string]string) map[string]string {
+	if values == nil {
+		return map[string]string{}
+	}
+	return values
+}
@@ -0,0 +1,107 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupExporter returns an exporter whose configuration defines stack app in the dev context
+func setupExporter(ctx context.Context) (Exporter, *aws.MockCloudFormationOperations) {
+	mockProvider := &config.MockConfigProvider{}
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-west-2")
+
+	mockProvider.On("LoadConfig", ctx, "dev").Return(&config.Config{
+		Context: &config.ContextConfig{Name: "dev", Region: "us-west-2"},
+	}, nil)
+	mockProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app"}, nil)
+
+	return NewStackExporter(mockProvider, mockFactory), mockCFOps
+}
+
+func TestStackExporter_ExportStack_IncludesAllStackInfoFields(t *testing.T) {
+	ctx := context.Background()
+	exporter, mockCFOps := setupExporter(ctx)
+
+	created := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	stackInfo := &aws.StackInfo{
+		ID:          "arn:aws:cloudformation:us-west-2:123456789012:stack/app/abc",
+		Name:        "app",
+		Status:      aws.StackStatusUpdateComplete,
+		CreatedTime: &created,
+		UpdatedTime: &updated,
+		Description: "Application stack",
+		Parameters:  map[string]string{"InstanceType": "t3.micro"},
+		Outputs:     map[string]string{"Endpoint": "app.example.com"},
+		Tags:        map[string]string{"Team": "platform"},
+		Template:    "Resources: {}\n",
+	}
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("DescribeStack", ctx, "app").Return(stackInfo, nil)
+
+	state, err := exporter.ExportStack(ctx, "dev", "app")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, state.Save(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var exported map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &exported))
+
+	assert.Equal(t, "app", exported["stack_name"])
+	assert.Equal(t, "dev", exported["context"])
+	assert.Equal(t, "us-west-2", exported["region"])
+	assert.Equal(t, stackInfo.ID, exported["stack_id"])
+	assert.Equal(t, "UPDATE_COMPLETE", exported["status"])
+	assert.Equal(t, "Application stack", exported["description"])
+	assert.Equal(t, "2025-01-15T10:00:00Z", exported["created_time"])
+	assert.Equal(t, "2025-03-01T09:30:00Z", exported["updated_time"])
+	assert.Equal(t, map[string]interface{}{"InstanceType": "t3.micro"}, exported["parameters"])
+	assert.Equal(t, map[string]interface{}{"Endpoint": "app.example.com"}, exported["outputs"])
+	assert.Equal(t, map[string]interface{}{"Team": "platform"}, exported["tags"])
+	assert.Equal(t, "Resources: {}\n", exported["template"])
+	assert.Contains(t, exported, "exported_at")
+}
+
+func TestStackExporter_ExportStack_EmptyMapsAreObjects(t *testing.T) {
+	ctx := context.Background()
+	exporter, mockCFOps := setupExporter(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("DescribeStack", ctx, "app").Return(&aws.StackInfo{Name: "app", Status: aws.StackStatusCreateComplete}, nil)
+
+	state, err := exporter.ExportStack(ctx, "dev", "app")
+	require.NoError(t, err)
+
+	data, err := state.JSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"outputs": {}`)
+	assert.NotContains(t, string(data), "updated_time")
+}
+
+func TestStackExporter_ExportStack_StackDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+	exporter, mockCFOps := setupExporter(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(false, nil)
+
+	_, err := exporter.ExportStack(ctx, "dev", "app")
+
+	require.Error(t, err)
+	assert.Equal(t, "stack app does not exist in region us-west-2", err.Error())
+}
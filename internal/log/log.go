@@ -0,0 +1,79 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+
+// Package log provides the diagnostic logger shared by stackaroo's operations. Log records
+// describe API calls and decisions for debugging, and are written to standard error so they
+// never mix with the status output users read.
+package log
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// Supported log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// defaultLogger is the logger components pick up when they are created
+var defaultLogger atomic.Pointer[slog.Logger]
+
+func init() {
+	defaultLogger.Store(Discard())
+}
+
+// Default returns the logger configured for this run, which discards records until one is set
+func Default() *slog.Logger {
+	return defaultLogger.Load()
+}
+
+// SetDefault sets the logger components pick up when they are created
+func SetDefault(logger *slog.Logger) {
+	defaultLogger.Store(logger)
+}
+
+// Discard returns a logger that drops every record
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// New creates a logger writing records at or above level to w in the given format, text or json
+func New(w io.Writer, level string, format string) (*slog.Logger, error) {
+	parsedLevel, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &slog.HandlerOptions{Level: parsedLevel}
+	switch format {
+	case "", FormatText:
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q: must be text or json", format)
+	}
+}
+
+// ParseLevel converts a level name, debug, info, warn or error, into a slog level
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "", "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unsupported log level %q: must be debug, info, warn or error", level)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected slog.Level
+	}{
+		{level: "debug", expected: slog.LevelDebug},
+		{level: "INFO", expected: slog.LevelInfo},
+		{level: "warn", expected: slog.LevelWarn},
+		{level: "warning", expected: slog.LevelWarn},
+		{level: "", expected: slog.LevelWarn},
+		{level: "error", expected: slog.LevelError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			level, err := ParseLevel(tt.level)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+func TestParseLevel_Unsupported(t *testing.T) {
+	_, err := ParseLevel("trace")
+
+	assert.EqualError(t, err, `unsupported log level "trace": must be debug, info, warn or error`)
+}
+
+func TestNew_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info", FormatJSON)
+	require.NoError(t, err)
+
+	logger.Debug("hidden")
+	logger.Info("deploying stack", "stack", "vpc")
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "deploying stack", record["msg"])
+	assert.Equal(t, "vpc", record["stack"])
+}
+
+func TestNew_Text(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "debug", FormatText)
+	require.NoError(t, err)
+
+	logger.Debug("resolving stack", "stack", "vpc")
+
+	assert.Contains(t, buf.String(), `level=DEBUG msg="resolving stack" stack=vpc`)
+}
+
+func TestNew_Errors(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, "info", "xml")
+	assert.EqualError(t, err, `unsupported log format "xml": must be text or json`)
+
+	_, err = New(&bytes.Buffer{}, "loud", FormatText)
+	assert.EqualError(t, err, `unsupported log level "loud": must be debug, info, warn or error`)
+}
+
+func TestDefault_DiscardsUntilSet(t *testing.T) {
+	original := Default()
+	defer SetDefault(original)
+
+	assert.False(t, Default().Enabled(t.Context(), slog.LevelError))
+
+	var buf bytes.Buffer
+	logger, err := New(&buf, "warn", FormatText)
+	require.NoError(t, err)
+	SetDefault(logger)
+
+	Default().Warn("stack is protected")
+	assert.Contains(t, buf.String(), "stack is protected")
+}
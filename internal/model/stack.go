@@ -4,22 +4,90 @@ SPDX-License-Identifier: BSD-3-Clause
 */
 package model
 
+import "fmt"
+
 // Context holds context-specific information for stack operations
 type Context struct {
-	Name    string
-	Region  string
-	Account string
+	Name      string
+	Region    string
+	Account   string
+	Protected bool // Stacks may only be changed when protected contexts are explicitly allowed
+}
+
+// Describe names the context with the account and region it targets, for warnings before changing its stacks
+func (c *Context) Describe() string {
+	if c.Account == "" {
+		return fmt.Sprintf("context %s (region %s)", c.Name, c.Region)
+	}
+	return fmt.Sprintf("context %s (account %s, region %s)", c.Name, c.Account, c.Region)
+}
+
+// BuildInfoMetadataKey is the key in a template's Metadata section under which deploy --stamp records provenance
+const BuildInfoMetadataKey = "StackarooBuildInfo"
+
+// ProtectedContextError indicates an attempt to change stacks in a protected context without permission
+type ProtectedContextError struct {
+	Context string
+}
+
+func (e ProtectedContextError) Error() string {
+	return fmt.Sprintf("context %s is protected; pass --allow-protected to change its stacks", e.Context)
 }
 
 // Stack represents a fully resolved stack ready for deployment
 type Stack struct {
-	Name         string
-	Context      *Context
-	TemplateBody string
-	Parameters   map[string]string
-	Tags         map[string]string
-	Capabilities []string
-	Dependencies []string
+	Name                  string
+	DeployedName          string // Name of the stack in CloudFormation, with any configured prefix and suffix
+	Context               *Context
+	TemplateBody          string
+	TemplateURL           string // S3 URL CloudFormation reads the template from instead of TemplateBody (empty for none)
+	Parameters            map[string]string
+	SensitiveParameters   map[string]bool  // Parameters resolved from secrets, never displayed
+	ParameterTraces       []ParameterTrace // How each parameter value was resolved, ordered by name
+	OverriddenParameters  map[string]bool  // Parameters whose resolved values were replaced with --parameter
+	Tags                  map[string]string
+	Capabilities          []string
+	Dependencies          []string
+	TerminationProtection *bool                  // Desired termination protection (nil leaves it unchanged)
+	StackPolicyBody       string                 // Stack policy JSON document (empty for none)
+	NotificationARNs      []string               // SNS topics that receive stack events
+	UsePreviousTemplate   bool                   // Deploy with the stack's current template; TemplateBody holds a copy of it
+	OnFailure             string                 // Action when stack creation fails (empty for the CloudFormation default)
+	TimeoutInMinutes      *int32                 // Time allowed for stack creation before it fails (nil for no limit)
+	Rollback              *RollbackConfiguration // Alarms that roll stack operations back (nil leaves any existing triggers)
+	ResourceTypes         []string               // Resource types the stack may create or update (nil allows all)
+	IgnoreProperties      []string               // Dotted template paths left out of template diffs
+	ClientRequestToken    string                 // Idempotency token for the stack operation (empty for none)
+	RequiredApprovals     int                    // Distinct approvals a change needs before it is deployed (zero for none)
+}
+
+// RollbackConfiguration lists CloudWatch alarms that roll a stack operation back when they go
+// into ALARM. Its fields match aws.RollbackConfiguration so one converts directly to the other.
+type RollbackConfiguration struct {
+	AlarmARNs               []string
+	MonitoringTimeInMinutes *int32 // Minutes to keep monitoring once the operation completes (nil for none)
+}
+
+// MaskedValue is displayed in place of sensitive parameter values
+const MaskedValue = "****"
+
+// ParameterTrace records how a single parameter value was resolved
+type ParameterTrace struct {
+	Name      string
+	Resolver  string            // Resolution type: literal, stack-output, ssm, secret, list or override
+	Inputs    map[string]string // Resolver configuration
+	AWSCalls  []string          // AWS API calls made while resolving
+	Value     string            // Resolved value, MaskedValue when sensitive
+	Sensitive bool
+}
+
+// CloudFormationName returns the name the stack has in CloudFormation, which is its
+// configured name unless a stack name prefix or suffix applies
+func (rs *Stack) CloudFormationName() string {
+	if rs.DeployedName != "" {
+		return rs.DeployedName
+	}
+	return rs.Name
 }
 
 // GetTemplateContent returns the template content for this stack
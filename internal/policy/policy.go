@@ -0,0 +1,110 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+)
+
+// Manager defines the interface for reading and replacing the stack policies of deployed stacks
+type Manager interface {
+	// SetPolicy replaces the stack policy of a stack without changing its template or parameters
+	SetPolicy(ctx context.Context, contextName, stackName, policyBody string, options Options) error
+	// GetPolicy returns the current stack policy of a stack, or an empty string when it has none
+	GetPolicy(ctx context.Context, contextName, stackName string) (string, error)
+}
+
+// Options configures how a stack policy is set
+type Options struct {
+	// AllowProtected permits changing the policy of stacks in contexts marked as protected
+	AllowProtected bool
+}
+
+// StackPolicyManager implements the Manager interface using the configuration and AWS CloudFormation
+type StackPolicyManager struct {
+	provider      config.ConfigProvider
+	clientFactory aws.ClientFactory
+}
+
+// NewStackPolicyManager creates a new policy manager with the provided configuration and client factory
+func NewStackPolicyManager(provider config.ConfigProvider, clientFactory aws.ClientFactory) Manager {
+	return &StackPolicyManager{
+		provider:      provider,
+		clientFactory: clientFactory,
+	}
+}
+
+// SetPolicy replaces the stack policy of a deployed stack. The policy must be a JSON document.
+func (m *StackPolicyManager) SetPolicy(ctx context.Context, contextName, stackName, policyBody string, options Options) error {
+	if !json.Valid([]byte(policyBody)) {
+		return fmt.Errorf("stack policy for stack %s is not valid JSON", stackName)
+	}
+
+	cfg, err := m.provider.LoadConfig(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Context.Protected && !options.AllowProtected {
+		return model.ProtectedContextError{Context: contextName}
+	}
+
+	cfOps, deployedName, err := m.existingStack(ctx, cfg, contextName, stackName)
+	if err != nil {
+		return err
+	}
+
+	if err := cfOps.SetStackPolicy(ctx, deployedName, policyBody); err != nil {
+		return err
+	}
+
+	fmt.Printf("Stack policy updated for stack %s\n", stackName)
+	return nil
+}
+
+// GetPolicy returns the stack policy of a deployed stack
+func (m *StackPolicyManager) GetPolicy(ctx context.Context, contextName, stackName string) (string, error) {
+	cfg, err := m.provider.LoadConfig(ctx, contextName)
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cfOps, deployedName, err := m.existingStack(ctx, cfg, contextName, stackName)
+	if err != nil {
+		return "", err
+	}
+
+	return cfOps.GetStackPolicy(ctx, deployedName)
+}
+
+// existingStack checks that a configured stack exists in the context's region, returning the
+// CloudFormation operations for that region and the stack's deployed name
+func (m *StackPolicyManager) existingStack(ctx context.Context, cfg *config.Config, contextName, stackName string) (aws.CloudFormationOperations, string, error) {
+	if _, err := m.provider.GetStack(stackName, contextName); err != nil {
+		return nil, "", err
+	}
+
+	region := cfg.Context.Region
+	cfOps, err := m.clientFactory.GetCloudFormationOperations(ctx, region)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get CloudFormation operations for region %s: %w", region, err)
+	}
+
+	deployedName := cfg.Context.DeployedStackName(stackName)
+	exists, err := cfOps.StackExists(ctx, deployedName)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to check if stack exists: %w", err)
+	}
+	if !exists {
+		return nil, "", fmt.Errorf("stack %s does not exist in region %s", stackName, region)
+	}
+
+	return cfOps, deployedName, nil
+}
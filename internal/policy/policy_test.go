@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `{"Statement": [{"Effect": "Deny", "Action": "Update:Replace", "Principal": "*", "Resource": "*"}]}`
+
+// setupManager returns a policy manager whose configuration defines stack app in the given context
+func setupManager(ctx context.Context, contextConfig *config.ContextConfig) (Manager, *aws.MockCloudFormationOperations) {
+	mockProvider := &config.MockConfigProvider{}
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-west-2")
+
+	mockProvider.On("LoadConfig", ctx, contextConfig.Name).Return(&config.Config{Context: contextConfig}, nil)
+	mockProvider.On("GetStack", "app", contextConfig.Name).Return(&config.StackConfig{Name: "app"}, nil)
+
+	return NewStackPolicyManager(mockProvider, mockFactory), mockCFOps
+}
+
+func TestStackPolicyManager_SetPolicy_ReplacesPolicy(t *testing.T) {
+	ctx := context.Background()
+	manager, mockCFOps := setupManager(ctx, &config.ContextConfig{Name: "dev", Region: "us-west-2"})
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("SetStackPolicy", ctx, "app", testPolicy).Return(nil)
+
+	err := manager.SetPolicy(ctx, "dev", "app", testPolicy, Options{})
+
+	require.NoError(t, err)
+	mockCFOps.AssertExpectations(t)
+	mockCFOps.AssertNotCalled(t, "DeployStack", mock.Anything, mock.Anything)
+}
+
+func TestStackPolicyManager_SetPolicy_UsesDeployedStackName(t *testing.T) {
+	ctx := context.Background()
+	manager, mockCFOps := setupManager(ctx, &config.ContextConfig{Name: "dev", Region: "us-west-2", StackNamePrefix: "dev-"})
+
+	mockCFOps.On("StackExists", ctx, "dev-app").Return(true, nil)
+	mockCFOps.On("SetStackPolicy", ctx, "dev-app", testPolicy).Return(nil)
+
+	err := manager.SetPolicy(ctx, "dev", "app", testPolicy, Options{})
+
+	require.NoError(t, err)
+	mockCFOps.AssertExpectations(t)
+}
+
+func TestStackPolicyManager_SetPolicy_RejectsInvalidJSON(t *testing.T) {
+	ctx := context.Background()
+	manager, mockCFOps := setupManager(ctx, &config.ContextConfig{Name: "dev", Region: "us-west-2"})
+
+	err := manager.SetPolicy(ctx, "dev", "app", "Statement: []", Options{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stack policy for stack app is not valid JSON")
+	mockCFOps.AssertNotCalled(t, "SetStackPolicy", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStackPolicyManager_SetPolicy_ProtectedContext(t *testing.T) {
+	ctx := context.Background()
+	manager, mockCFOps := setupManager(ctx, &config.ContextConfig{Name: "prod", Region: "us-west-2", Protected: true})
+
+	err := manager.SetPolicy(ctx, "prod", "app", testPolicy, Options{})
+
+	var protectedErr model.ProtectedContextError
+	require.ErrorAs(t, err, &protectedErr)
+	assert.Equal(t, "prod", protectedErr.Context)
+	mockCFOps.AssertNotCalled(t, "SetStackPolicy", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStackPolicyManager_SetPolicy_AllowProtected(t *testing.T) {
+	ctx := context.Background()
+	manager, mockCFOps := setupManager(ctx, &config.ContextConfig{Name: "prod", Region: "us-west-2", Protected: true})
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("SetStackPolicy", ctx, "app", testPolicy).Return(nil)
+
+	err := manager.SetPolicy(ctx, "prod", "app", testPolicy, Options{AllowProtected: true})
+
+	require.NoError(t, err)
+	mockCFOps.AssertExpectations(t)
+}
+
+func TestStackPolicyManager_SetPolicy_StackDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+	manager, mockCFOps := setupManager(ctx, &config.ContextConfig{Name: "dev", Region: "us-west-2"})
+
+	mockCFOps.On("StackExists", ctx, "app").Return(false, nil)
+
+	err := manager.SetPolicy(ctx, "dev", "app", testPolicy, Options{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stack app does not exist in region us-west-2")
+	mockCFOps.AssertNotCalled(t, "SetStackPolicy", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStackPolicyManager_GetPolicy_ReturnsPolicy(t *testing.T) {
+	ctx := context.Background()
+	manager, mockCFOps := setupManager(ctx, &config.ContextConfig{Name: "dev", Region: "us-west-2"})
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStackPolicy", ctx, "app").Return(testPolicy, nil)
+
+	policy, err := manager.GetPolicy(ctx, "dev", "app")
+
+	require.NoError(t, err)
+	assert.Equal(t, testPolicy, policy)
+}
+
+func TestStackPolicyManager_GetPolicy_Error(t *testing.T) {
+	ctx := context.Background()
+	manager, mockCFOps := setupManager(ctx, &config.ContextConfig{Name: "dev", Region: "us-west-2"})
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStackPolicy", ctx, "app").Return("", errors.New("access denied"))
+
+	_, err := manager.GetPolicy(ctx, "dev", "app")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+}
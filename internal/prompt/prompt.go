@@ -15,6 +15,10 @@ import (
 // Prompter defines the interface for user prompting
 type Prompter interface {
 	Confirm(message string) (bool, error)
+	// ConfirmWithDefault asks a yes/no question, answering def when the user just presses enter
+	ConfirmWithDefault(message string, def bool) (bool, error)
+	// ConfirmWithPhrase asks the user to type a phrase exactly, for changes too risky for y/N
+	ConfirmWithPhrase(message, phrase string) (bool, error)
 }
 
 // StdinPrompter implements Prompter using standard input
@@ -29,26 +33,67 @@ func NewStdinPrompter() *StdinPrompter {
 
 // Confirm prompts the user with the given message and returns their response
 func (p *StdinPrompter) Confirm(message string) (bool, error) {
-	// Add newline prefix and [y/N] suffix to message
-	formattedMessage := fmt.Sprintf("\n%s [y/N]: ", message)
-	fmt.Print(formattedMessage)
+	return p.ConfirmWithDefault(message, false)
+}
+
+// ConfirmWithDefault prompts the user with the given message and returns their response,
+// or def when they enter nothing. Anything other than y/yes or an empty answer is a no.
+func (p *StdinPrompter) ConfirmWithDefault(message string, def bool) (bool, error) {
+	// Add newline prefix and a suffix showing the default in capitals
+	options := "y/N"
+	if def {
+		options = "Y/n"
+	}
+	fmt.Printf("\n%s [%s]: ", message, options)
 
 	scanner := bufio.NewScanner(p.input)
 	if !scanner.Scan() {
 		if err := scanner.Err(); err != nil {
 			return false, fmt.Errorf("failed to read user input: %w", err)
 		}
-		// EOF or empty input - treat as "no"
-		return false, nil
+		// EOF - treat as an empty answer
+		return def, nil
 	}
 
 	response := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	if response == "" {
+		return def, nil
+	}
 	return response == "y" || response == "yes", nil
 }
 
+// ConfirmWithPhrase prompts the user with the given message and confirms only when they type the phrase exactly
+func (p *StdinPrompter) ConfirmWithPhrase(message, phrase string) (bool, error) {
+	fmt.Printf("\n%s\nType %s to confirm: ", message, phrase)
+
+	scanner := bufio.NewScanner(p.input)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return false, fmt.Errorf("failed to read user input: %w", err)
+		}
+		// EOF - nothing was typed, so the phrase was not
+		return false, nil
+	}
+
+	return strings.TrimSpace(scanner.Text()) == phrase, nil
+}
+
 // defaultPrompter is the package-level default prompter
 var defaultPrompter Prompter = NewStdinPrompter()
 
+// autoApprove answers every confirmation with yes without asking, for unattended runs
+var autoApprove bool
+
+// SetAutoApprove turns answering every confirmation with yes on or off
+func SetAutoApprove(enabled bool) {
+	autoApprove = enabled
+}
+
+// AutoApprove reports whether confirmations are answered with yes without asking
+func AutoApprove() bool {
+	return autoApprove
+}
+
 // SetPrompter allows injection of a custom prompter (for testing)
 func SetPrompter(p Prompter) {
 	defaultPrompter = p
@@ -60,7 +105,28 @@ func GetDefaultPrompter() Prompter {
 }
 
 // Confirm prompts the user with the given message using the default prompter
-// Returns true if the user confirms (y/yes), false otherwise
+// Returns true if the user confirms (y/yes) or auto-approval is on, false otherwise
 func Confirm(message string) (bool, error) {
+	if autoApprove {
+		return true, nil
+	}
 	return defaultPrompter.Confirm(message)
 }
+
+// ConfirmWithDefault prompts the user with the given message using the default prompter
+// Returns def if the user enters nothing, and true without asking if auto-approval is on
+func ConfirmWithDefault(message string, def bool) (bool, error) {
+	if autoApprove {
+		return true, nil
+	}
+	return defaultPrompter.ConfirmWithDefault(message, def)
+}
+
+// ConfirmWithPhrase prompts the user to type the phrase using the default prompter
+// Returns true only if the user types the phrase exactly or auto-approval is on
+func ConfirmWithPhrase(message, phrase string) (bool, error) {
+	if autoApprove {
+		return true, nil
+	}
+	return defaultPrompter.ConfirmWithPhrase(message, phrase)
+}
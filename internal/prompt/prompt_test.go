@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // TestMockPrompter_Interface verifies MockPrompter implements Prompter interface
@@ -207,6 +208,108 @@ func TestConfirm_UsesDefaultPrompter(t *testing.T) {
 	mockPrompter.AssertExpectations(t)
 }
 
+// TestStdinPrompter_ConfirmWithPhrase tests that only the exact phrase confirms
+func TestStdinPrompter_ConfirmWithPhrase(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"exact phrase", "production\n", true},
+		{"surrounding whitespace", "  production \n", true},
+		{"different case", "Production\n", false},
+		{"yes", "y\n", false},
+		{"partial phrase", "prod\n", false},
+		{"empty", "\n", false},
+		{"no input", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter := &StdinPrompter{input: strings.NewReader(tt.input)}
+
+			result, err := prompter.ConfirmWithPhrase("Deploy to production?", "production")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestConfirmWithPhrase_UsesDefaultPrompter verifies package function uses default prompter
+func TestConfirmWithPhrase_UsesDefaultPrompter(t *testing.T) {
+	originalPrompter := defaultPrompter
+	defer SetPrompter(originalPrompter)
+
+	mockPrompter := &MockPrompter{}
+	mockPrompter.On("ConfirmWithPhrase", "Delete stack vpc?", "prod").Return(true, nil).Once()
+
+	SetPrompter(mockPrompter)
+
+	result, err := ConfirmWithPhrase("Delete stack vpc?", "prod")
+
+	assert.NoError(t, err)
+	assert.True(t, result)
+	mockPrompter.AssertExpectations(t)
+}
+
+// TestStdinPrompter_ConfirmWithDefault tests that an empty answer takes the default
+func TestStdinPrompter_ConfirmWithDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		def      bool
+		expected bool
+	}{
+		{"empty defaults to yes", "\n", true, true},
+		{"empty defaults to no", "\n", false, false},
+		{"no input defaults to yes", "", true, true},
+		{"whitespace defaults to yes", "   \n", true, true},
+		{"no overrides yes default", "n\n", true, false},
+		{"yes overrides no default", "yes\n", false, true},
+		{"other text is no", "maybe\n", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter := &StdinPrompter{input: strings.NewReader(tt.input)}
+
+			result, err := prompter.ConfirmWithDefault("Continue?", tt.def)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestAutoApprove_SkipsPrompter verifies every package function answers yes without prompting
+func TestAutoApprove_SkipsPrompter(t *testing.T) {
+	originalPrompter := defaultPrompter
+	defer SetPrompter(originalPrompter)
+	defer SetAutoApprove(false)
+
+	mockPrompter := &MockPrompter{}
+	SetPrompter(mockPrompter)
+	SetAutoApprove(true)
+
+	confirmed, err := Confirm("Delete stack vpc?")
+	assert.NoError(t, err)
+	assert.True(t, confirmed)
+
+	confirmed, err = ConfirmWithDefault("Continue?", false)
+	assert.NoError(t, err)
+	assert.True(t, confirmed)
+
+	confirmed, err = ConfirmWithPhrase("Delete stack vpc?", "prod")
+	assert.NoError(t, err)
+	assert.True(t, confirmed)
+
+	assert.True(t, AutoApprove())
+	mockPrompter.AssertNotCalled(t, "Confirm", mock.Anything)
+	mockPrompter.AssertNotCalled(t, "ConfirmWithDefault", mock.Anything, mock.Anything)
+	mockPrompter.AssertNotCalled(t, "ConfirmWithPhrase", mock.Anything, mock.Anything)
+}
+
 // TestStdinPrompter_CreatesCorrectly tests StdinPrompter creation
 func TestStdinPrompter_CreatesCorrectly(t *testing.T) {
 	prompter := NewStdinPrompter()
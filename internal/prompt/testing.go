@@ -18,3 +18,15 @@ func (m *MockPrompter) Confirm(message string) (bool, error) {
 	args := m.Called(message)
 	return args.Bool(0), args.Error(1)
 }
+
+// ConfirmWithDefault mock implementation
+func (m *MockPrompter) ConfirmWithDefault(message string, def bool) (bool, error) {
+	args := m.Called(message, def)
+	return args.Bool(0), args.Error(1)
+}
+
+// ConfirmWithPhrase mock implementation
+func (m *MockPrompter) ConfirmWithPhrase(message, phrase string) (bool, error) {
+	args := m.Called(message, phrase)
+	return args.Bool(0), args.Error(1)
+}
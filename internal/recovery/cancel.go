@@ -0,0 +1,63 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+)
+
+// CancelOptions configures how a stack update is cancelled
+type CancelOptions struct {
+	// NoWait returns once the cancellation is requested instead of waiting for the rollback
+	NoWait bool
+	// AllowProtected permits cancelling updates of stacks in contexts marked as protected
+	AllowProtected bool
+}
+
+// NotCancellableError indicates that a stack has no update in progress to cancel
+type NotCancellableError struct {
+	StackName string
+	Status    aws.StackStatus
+}
+
+func (e NotCancellableError) Error() string {
+	return fmt.Sprintf("stack %s is in %s; only stacks in %s can be cancelled", e.StackName, e.Status, aws.StackStatusUpdateInProgress)
+}
+
+// CancelUpdate cancels the update of a stack in UPDATE_IN_PROGRESS. CloudFormation rolls the
+// stack back to its previous configuration; unless options.NoWait is set, the rollback events
+// are streamed until the stack reaches UPDATE_ROLLBACK_COMPLETE.
+func (r *StackRecoverer) CancelUpdate(ctx context.Context, contextName, stackName string, options CancelOptions) error {
+	cfOps, deployedName, stack, err := r.deployedStack(ctx, contextName, stackName, options.AllowProtected)
+	if err != nil {
+		return err
+	}
+	if stack.Status != aws.StackStatusUpdateInProgress {
+		return NotCancellableError{StackName: stackName, Status: stack.Status}
+	}
+
+	fmt.Printf("Cancelling update of stack %s...\n", stackName)
+	startTime := time.Now()
+
+	if err := cfOps.CancelUpdateStack(ctx, deployedName); err != nil {
+		return err
+	}
+
+	if options.NoWait {
+		fmt.Printf("Cancellation of stack %s requested; CloudFormation is rolling it back\n", stackName)
+		return nil
+	}
+
+	if err := waitForRollback(ctx, cfOps, deployedName, startTime); err != nil {
+		return fmt.Errorf("failed to wait for rollback of stack %s: %w", stackName, err)
+	}
+
+	fmt.Printf("Update of stack %s cancelled: rolled back to %s\n", stackName, aws.StackStatusUpdateRollbackComplete)
+	return nil
+}
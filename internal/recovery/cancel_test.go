@@ -0,0 +1,103 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package recovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStackRecoverer_CancelUpdate_WaitsForRollback(t *testing.T) {
+	ctx := context.Background()
+	recoverer, mockCFOps := setupRecoverer(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusUpdateInProgress}, nil)
+	mockCFOps.On("CancelUpdateStack", ctx, "app").Return(nil)
+	mockCFOps.On("WaitForStackOperation", ctx, "app", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).
+		Return(aws.StackOperationFailedError{StackName: "app", Status: aws.StackStatusUpdateRollbackComplete})
+
+	err := recoverer.CancelUpdate(ctx, "dev", "app", CancelOptions{})
+
+	require.NoError(t, err)
+	mockCFOps.AssertExpectations(t)
+}
+
+func TestStackRecoverer_CancelUpdate_NoWait(t *testing.T) {
+	ctx := context.Background()
+	recoverer, mockCFOps := setupRecoverer(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusUpdateInProgress}, nil)
+	mockCFOps.On("CancelUpdateStack", ctx, "app").Return(nil)
+
+	err := recoverer.CancelUpdate(ctx, "dev", "app", CancelOptions{NoWait: true})
+
+	require.NoError(t, err)
+	mockCFOps.AssertNotCalled(t, "WaitForStackOperation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStackRecoverer_CancelUpdate_RollbackFails(t *testing.T) {
+	ctx := context.Background()
+	recoverer, mockCFOps := setupRecoverer(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusUpdateInProgress}, nil)
+	mockCFOps.On("CancelUpdateStack", ctx, "app").Return(nil)
+	mockCFOps.On("WaitForStackOperation", ctx, "app", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).
+		Return(aws.StackOperationFailedError{StackName: "app", Status: aws.StackStatusUpdateRollbackFailed})
+
+	err := recoverer.CancelUpdate(ctx, "dev", "app", CancelOptions{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to wait for rollback of stack app")
+	assert.Contains(t, err.Error(), "UPDATE_ROLLBACK_FAILED")
+}
+
+func TestStackRecoverer_CancelUpdate_RequiresUpdateInProgress(t *testing.T) {
+	for _, status := range []aws.StackStatus{
+		aws.StackStatusUpdateComplete,
+		aws.StackStatusCreateInProgress,
+		aws.StackStatusUpdateRollbackInProgress,
+	} {
+		t.Run(string(status), func(t *testing.T) {
+			ctx := context.Background()
+			recoverer, mockCFOps := setupRecoverer(ctx)
+
+			mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+			mockCFOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: status}, nil)
+
+			err := recoverer.CancelUpdate(ctx, "dev", "app", CancelOptions{})
+
+			var notCancellable NotCancellableError
+			require.True(t, errors.As(err, &notCancellable))
+			assert.Equal(t, status, notCancellable.Status)
+			assert.Equal(t, "stack app is in "+string(status)+"; only stacks in UPDATE_IN_PROGRESS can be cancelled", err.Error())
+			mockCFOps.AssertNotCalled(t, "CancelUpdateStack", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestStackRecoverer_CancelUpdate_ProtectedContext(t *testing.T) {
+	ctx := context.Background()
+	mockProvider := &config.MockConfigProvider{}
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-west-2")
+	mockProvider.On("LoadConfig", ctx, "prod").Return(&config.Config{
+		Context: &config.ContextConfig{Name: "prod", Region: "us-west-2", Protected: true},
+	}, nil)
+
+	err := NewStackRecoverer(mockProvider, mockFactory).CancelUpdate(ctx, "prod", "app", CancelOptions{})
+
+	require.ErrorAs(t, err, &model.ProtectedContextError{})
+	mockCFOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
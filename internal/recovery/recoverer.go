@@ -0,0 +1,143 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package recovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+)
+
+// Recoverer defines the interface for bringing stacks stuck after a failed rollback back into service
+type Recoverer interface {
+	// RecoverStack continues the rollback of a stack in UPDATE_ROLLBACK_FAILED
+	RecoverStack(ctx context.Context, contextName, stackName string, options Options) error
+	// CancelUpdate cancels the update of a stack in UPDATE_IN_PROGRESS, rolling it back
+	CancelUpdate(ctx context.Context, contextName, stackName string, options CancelOptions) error
+}
+
+// Options configures how a stack is recovered
+type Options struct {
+	// ResourcesToSkip lists logical IDs of resources to mark as rolled back without changing them
+	ResourcesToSkip []string
+	// AllowProtected permits recovering stacks in contexts marked as protected
+	AllowProtected bool
+}
+
+// NotRecoverableError indicates that a stack is in a state that ContinueUpdateRollback cannot fix
+type NotRecoverableError struct {
+	StackName string
+	Status    aws.StackStatus
+}
+
+func (e NotRecoverableError) Error() string {
+	if e.Status == aws.StackStatusRollbackFailed {
+		return fmt.Sprintf("stack %s is in %s after a failed creation and cannot be rolled back; delete it with 'stackaroo delete' and deploy it again", e.StackName, e.Status)
+	}
+	return fmt.Sprintf("stack %s is in %s; only stacks in %s can be recovered", e.StackName, e.Status, aws.StackStatusUpdateRollbackFailed)
+}
+
+// StackRecoverer implements the Recoverer interface using the configuration and AWS CloudFormation
+type StackRecoverer struct {
+	provider      config.ConfigProvider
+	clientFactory aws.ClientFactory
+}
+
+// NewStackRecoverer creates a new recoverer with the provided configuration and client factory
+func NewStackRecoverer(provider config.ConfigProvider, clientFactory aws.ClientFactory) Recoverer {
+	return &StackRecoverer{
+		provider:      provider,
+		clientFactory: clientFactory,
+	}
+}
+
+// RecoverStack continues a failed update rollback and waits for the stack to reach UPDATE_ROLLBACK_COMPLETE.
+// Stack parameters are not resolved, so recovery works even when the stacks it depends on are unhealthy.
+func (r *StackRecoverer) RecoverStack(ctx context.Context, contextName, stackName string, options Options) error {
+	cfOps, deployedName, stack, err := r.deployedStack(ctx, contextName, stackName, options.AllowProtected)
+	if err != nil {
+		return err
+	}
+	if !aws.IsRollbackFailed(stack.Status) {
+		fmt.Printf("Stack %s is in %s; nothing to recover\n", stackName, stack.Status)
+		return nil
+	}
+	if stack.Status != aws.StackStatusUpdateRollbackFailed {
+		return NotRecoverableError{StackName: stackName, Status: stack.Status}
+	}
+
+	fmt.Printf("Continuing rollback of stack %s...\n", stackName)
+	startTime := time.Now()
+
+	if err := cfOps.ContinueUpdateRollback(ctx, deployedName, options.ResourcesToSkip); err != nil {
+		return err
+	}
+
+	if err := waitForRollback(ctx, cfOps, deployedName, startTime); err != nil {
+		return fmt.Errorf("failed to wait for rollback of stack %s: %w", stackName, err)
+	}
+
+	fmt.Printf("Stack %s recovered: rolled back to %s and ready to deploy\n", stackName, aws.StackStatusUpdateRollbackComplete)
+	return nil
+}
+
+// deployedStack looks up a configured stack in AWS, returning the CloudFormation operations for
+// its region, its deployed name and its current state. Stacks in protected contexts are refused
+// unless allowProtected is set. Stack parameters are not resolved.
+func (r *StackRecoverer) deployedStack(ctx context.Context, contextName, stackName string, allowProtected bool) (aws.CloudFormationOperations, string, *aws.Stack, error) {
+	cfg, err := r.provider.LoadConfig(ctx, contextName)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Context.Protected && !allowProtected {
+		return nil, "", nil, model.ProtectedContextError{Context: contextName}
+	}
+	if _, err := r.provider.GetStack(stackName, contextName); err != nil {
+		return nil, "", nil, err
+	}
+
+	region := cfg.Context.Region
+	cfOps, err := r.clientFactory.GetCloudFormationOperations(ctx, region)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to get CloudFormation operations for region %s: %w", region, err)
+	}
+
+	deployedName := cfg.Context.DeployedStackName(stackName)
+	exists, err := cfOps.StackExists(ctx, deployedName)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to check if stack exists: %w", err)
+	}
+	if !exists {
+		return nil, "", nil, fmt.Errorf("stack %s does not exist in region %s", stackName, region)
+	}
+
+	stack, err := cfOps.GetStack(ctx, deployedName)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return cfOps, deployedName, stack, nil
+}
+
+// waitForRollback streams the events of a stack until its update rollback finishes. Reaching
+// UPDATE_ROLLBACK_COMPLETE is the goal here, even though deployments treat it as a failure.
+func waitForRollback(ctx context.Context, cfOps aws.CloudFormationOperations, deployedName string, startTime time.Time) error {
+	err := cfOps.WaitForStackOperation(ctx, deployedName, startTime, func(event aws.StackEvent) {
+		fmt.Printf("  %s: %s - %s\n", event.Timestamp.Format("15:04:05"), event.ResourceType, event.ResourceStatus)
+		if event.ResourceStatusReason != "" {
+			fmt.Printf("    Reason: %s\n", event.ResourceStatusReason)
+		}
+	})
+
+	var failedErr aws.StackOperationFailedError
+	if err != nil && !(errors.As(err, &failedErr) && failedErr.Status == aws.StackStatusUpdateRollbackComplete) {
+		return err
+	}
+	return nil
+}
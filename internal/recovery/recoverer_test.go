@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package recovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRecoverer returns a recoverer whose configuration defines stack app in the dev context
+func setupRecoverer(ctx context.Context) (Recoverer, *aws.MockCloudFormationOperations) {
+	mockProvider := &config.MockConfigProvider{}
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-west-2")
+
+	mockProvider.On("LoadConfig", ctx, "dev").Return(&config.Config{
+		Context: &config.ContextConfig{Name: "dev", Region: "us-west-2"},
+	}, nil)
+	mockProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app"}, nil)
+
+	return NewStackRecoverer(mockProvider, mockFactory), mockCFOps
+}
+
+func TestStackRecoverer_RecoverStack_ContinuesRollback(t *testing.T) {
+	ctx := context.Background()
+	recoverer, mockCFOps := setupRecoverer(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusUpdateRollbackFailed}, nil)
+	mockCFOps.On("ContinueUpdateRollback", ctx, "app", []string{"Database"}).Return(nil)
+	mockCFOps.On("WaitForStackOperation", ctx, "app", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).
+		Return(aws.StackOperationFailedError{StackName: "app", Status: aws.StackStatusUpdateRollbackComplete})
+
+	err := recoverer.RecoverStack(ctx, "dev", "app", Options{ResourcesToSkip: []string{"Database"}})
+
+	require.NoError(t, err)
+	mockCFOps.AssertExpectations(t)
+}
+
+func TestStackRecoverer_RecoverStack_RollbackFailsAgain(t *testing.T) {
+	ctx := context.Background()
+	recoverer, mockCFOps := setupRecoverer(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusUpdateRollbackFailed}, nil)
+	mockCFOps.On("ContinueUpdateRollback", ctx, "app", []string(nil)).Return(nil)
+	mockCFOps.On("WaitForStackOperation", ctx, "app", mock.AnythingOfType("time.Time"), mock.AnythingOfType("func(aws.StackEvent)")).
+		Return(aws.StackOperationFailedError{StackName: "app", Status: aws.StackStatusUpdateRollbackFailed})
+
+	err := recoverer.RecoverStack(ctx, "dev", "app", Options{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to wait for rollback of stack app")
+	assert.Contains(t, err.Error(), "UPDATE_ROLLBACK_FAILED")
+}
+
+func TestStackRecoverer_RecoverStack_NothingToRecover(t *testing.T) {
+	ctx := context.Background()
+	recoverer, mockCFOps := setupRecoverer(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusUpdateComplete}, nil)
+
+	err := recoverer.RecoverStack(ctx, "dev", "app", Options{})
+
+	require.NoError(t, err)
+	mockCFOps.AssertNotCalled(t, "ContinueUpdateRollback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStackRecoverer_RecoverStack_CreateRollbackFailedIsNotRecoverable(t *testing.T) {
+	ctx := context.Background()
+	recoverer, mockCFOps := setupRecoverer(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusRollbackFailed}, nil)
+
+	err := recoverer.RecoverStack(ctx, "dev", "app", Options{})
+
+	var notRecoverable NotRecoverableError
+	require.True(t, errors.As(err, &notRecoverable))
+	assert.Equal(t, aws.StackStatusRollbackFailed, notRecoverable.Status)
+	assert.Contains(t, err.Error(), "stackaroo delete")
+	mockCFOps.AssertNotCalled(t, "ContinueUpdateRollback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStackRecoverer_RecoverStack_ProtectedContext(t *testing.T) {
+	ctx := context.Background()
+	mockProvider := &config.MockConfigProvider{}
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-west-2")
+	mockProvider.On("LoadConfig", ctx, "prod").Return(&config.Config{
+		Context: &config.ContextConfig{Name: "prod", Region: "us-west-2", Protected: true},
+	}, nil)
+
+	err := NewStackRecoverer(mockProvider, mockFactory).RecoverStack(ctx, "prod", "app", Options{})
+
+	require.ErrorAs(t, err, &model.ProtectedContextError{})
+	mockCFOps.AssertNotCalled(t, "StackExists", mock.Anything, mock.Anything)
+}
+
+func TestStackRecoverer_RecoverStack_StackDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+	recoverer, mockCFOps := setupRecoverer(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(false, nil)
+
+	err := recoverer.RecoverStack(ctx, "dev", "app", Options{})
+
+	require.Error(t, err)
+	assert.Equal(t, "stack app does not exist in region us-west-2", err.Error())
+}
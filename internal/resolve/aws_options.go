@@ -0,0 +1,98 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/model"
+)
+
+// resourceTypePattern matches the resource types CloudFormation accepts in ResourceTypes:
+// AWS::*, Custom::* or Custom::name, and Provider::Service::Type where the type may be a * wildcard
+var resourceTypePattern = regexp.MustCompile(`^(AWS::\*|Custom::(\*|[A-Za-z0-9_@-]+)|[A-Za-z0-9]+::[A-Za-z0-9]+::(\*|[A-Za-z0-9]+))$`)
+
+// validateResourceTypes checks that each entry of a resource type allow-list is a type or glob CloudFormation accepts
+func validateResourceTypes(resourceTypes []string) error {
+	for _, resourceType := range resourceTypes {
+		if !resourceTypePattern.MatchString(resourceType) {
+			return fmt.Errorf("invalid resource type %q: expected AWS::*, AWS::Service::* or AWS::Service::Type", resourceType)
+		}
+	}
+	return nil
+}
+
+// awsOptionAppliers maps each supported aws_options field to the function that applies it to a stack
+var awsOptionAppliers = map[string]func(stack *model.Stack, value interface{}) error{
+	"ResourceTypes": func(stack *model.Stack, value interface{}) error {
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("ResourceTypes must be a list of resource types")
+		}
+		resourceTypes := make([]string, len(items))
+		for i, item := range items {
+			resourceType, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("ResourceTypes must be a list of resource types")
+			}
+			resourceTypes[i] = resourceType
+		}
+		if err := validateResourceTypes(resourceTypes); err != nil {
+			return fmt.Errorf("ResourceTypes: %w", err)
+		}
+		stack.ResourceTypes = resourceTypes
+		return nil
+	},
+	"ClientRequestToken": func(stack *model.Stack, value interface{}) error {
+		token, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("ClientRequestToken must be a string")
+		}
+		stack.ClientRequestToken = token
+		return nil
+	},
+	"EnableTerminationProtection": func(stack *model.Stack, value interface{}) error {
+		enabled, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("EnableTerminationProtection must be true or false")
+		}
+		stack.TerminationProtection = &enabled
+		return nil
+	},
+}
+
+// applyAWSOptions applies raw CloudFormation API fields from a stack's aws_options to the resolved
+// stack, rejecting any field outside the supported pass-through set
+func applyAWSOptions(stack *model.Stack, options map[string]interface{}) error {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		apply, supported := awsOptionAppliers[name]
+		if !supported {
+			return fmt.Errorf("unsupported field %q (supported: %s)", name, supportedAWSOptions())
+		}
+		if err := apply(stack, options[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// supportedAWSOptions lists the supported aws_options fields in alphabetical order
+func supportedAWSOptions() string {
+	names := make([]string, 0, len(awsOptionAppliers))
+	for name := range awsOptionAppliers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,41 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// autoExpandCapability lets CloudFormation expand macros such as AWS::Include and AWS::Serverless
+	autoExpandCapability = "CAPABILITY_AUTO_EXPAND"
+	// defaultCapability is what the deployer requests when a stack declares no capabilities
+	defaultCapability = "CAPABILITY_IAM"
+)
+
+// templateHasTransform reports whether a template declares a top-level Transform section.
+// YAML is a superset of JSON, so both template formats are parsed as YAML. Templates that
+// cannot be parsed are reported as having no transform and left for CloudFormation to reject.
+func templateHasTransform(templateBody string) bool {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(templateBody), &document); err != nil || len(document.Content) == 0 {
+		return false
+	}
+	return mappingValue(document.Content[0], "Transform") != nil
+}
+
+// withAutoExpand returns the capabilities with CAPABILITY_AUTO_EXPAND added, and whether it
+// was added. Stacks declaring no capabilities keep the deployer's default of CAPABILITY_IAM.
+func withAutoExpand(capabilities []string) ([]string, bool) {
+	if slices.Contains(capabilities, autoExpandCapability) {
+		return capabilities, false
+	}
+	if len(capabilities) == 0 {
+		return []string{defaultCapability, autoExpandCapability}, true
+	}
+	return append(slices.Clone(capabilities), autoExpandCapability), true
+}
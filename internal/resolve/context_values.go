@@ -0,0 +1,68 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"maps"
+
+	"codeberg.org/orien/stackaroo/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// withContextValues adds a literal parameter for each top-level key of the context's values file
+// that names a parameter declared by the template. Parameters configured on the stack take
+// precedence, so the values file only supplies defaults. The given map is not modified.
+func (r *StackResolver) withContextValues(valuesFile, templateBody string, params map[string]*config.ParameterValue) (map[string]*config.ParameterValue, error) {
+	if valuesFile == "" {
+		return params, nil
+	}
+
+	root, err := newValuesFiles(r.fileSystemResolver).load(valuesFile)
+	if err != nil {
+		return nil, err
+	}
+	if root.Kind != yaml.MappingNode {
+		return params, nil
+	}
+
+	declared := declaredParameters(templateBody)
+	merged := maps.Clone(params)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		name := root.Content[i].Value
+		if _, configured := params[name]; configured || !declared[name] {
+			continue
+		}
+		value, err := lookupValue(root, name, valuesFile)
+		if err != nil {
+			return nil, err
+		}
+		if merged == nil {
+			merged = make(map[string]*config.ParameterValue)
+		}
+		merged[name] = &config.ParameterValue{
+			ResolutionType:   "literal",
+			ResolutionConfig: map[string]string{"value": value},
+		}
+	}
+	return merged, nil
+}
+
+// declaredParameters returns the names of the parameters a JSON or YAML template declares
+func declaredParameters(templateBody string) map[string]bool {
+	names := make(map[string]bool)
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(templateBody), &document); err != nil || len(document.Content) == 0 {
+		return names
+	}
+
+	parameters := mappingValue(document.Content[0], "Parameters")
+	if parameters == nil || parameters.Kind != yaml.MappingNode {
+		return names
+	}
+	for i := 0; i < len(parameters.Content); i += 2 {
+		names[parameters.Content[i].Value] = true
+	}
+	return names
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+)
+
+// callLog collects the AWS calls made while resolving a single parameter.
+// A nil log records nothing.
+type callLog []string
+
+// record appends a description of an AWS call to the log
+func (l *callLog) record(format string, args ...interface{}) {
+	if l == nil {
+		return
+	}
+	*l = append(*l, fmt.Sprintf(format, args...))
+}
+
+// newParameterTrace builds the trace for a resolved parameter, masking sensitive values
+func newParameterTrace(name string, paramValue *config.ParameterValue, value string, calls callLog) model.ParameterTrace {
+	trace := model.ParameterTrace{
+		Name:      name,
+		Resolver:  paramValue.ResolutionType,
+		Inputs:    paramValue.ResolutionConfig,
+		AWSCalls:  calls,
+		Value:     value,
+		Sensitive: isSensitive(paramValue),
+	}
+
+	if paramValue.ResolutionType == "list" {
+		itemTypes := make([]string, len(paramValue.ListItems))
+		for i, item := range paramValue.ListItems {
+			if item != nil {
+				itemTypes[i] = item.ResolutionType
+			}
+		}
+		trace.Inputs = map[string]string{"items": strings.Join(itemTypes, ", ")}
+	}
+
+	if trace.Sensitive {
+		trace.Value = model.MaskedValue
+	}
+
+	return trace
+}
+
+// FormatExplanation describes how each parameter of a resolved stack got its value
+func FormatExplanation(stack *model.Stack) string {
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "Parameter resolution for stack %s", stack.Name)
+	if stack.Context != nil {
+		fmt.Fprintf(&output, " in context %s", stack.Context.Name)
+	}
+	output.WriteString(":\n")
+
+	if len(stack.ParameterTraces) == 0 {
+		output.WriteString("  (no parameters)\n\n")
+		return output.String()
+	}
+
+	for _, trace := range stack.ParameterTraces {
+		fmt.Fprintf(&output, "\n  %s\n", trace.Name)
+		fmt.Fprintf(&output, "    resolver: %s\n", trace.Resolver)
+		if len(trace.Inputs) > 0 {
+			fmt.Fprintf(&output, "    inputs:   %s\n", formatInputs(trace.Inputs))
+		}
+		for _, call := range trace.AWSCalls {
+			fmt.Fprintf(&output, "    aws call: %s\n", call)
+		}
+		fmt.Fprintf(&output, "    value:    %s\n", trace.Value)
+	}
+	output.WriteString("\n")
+
+	return output.String()
+}
+
+// formatInputs renders resolver configuration as sorted key=value pairs
+func formatInputs(inputs map[string]string) string {
+	keys := make([]string, 0, len(inputs))
+	for key := range inputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%s", key, inputs[key])
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatExplanation(t *testing.T) {
+	stack := model.NewTestStack("app", model.NewTestContext("dev", "us-east-1", "123456789012"))
+	stack.ParameterTraces = []model.ParameterTrace{
+		{
+			Name:      "DBPassword",
+			Resolver:  "secret",
+			Inputs:    map[string]string{"secret_id": "prod/db/password", "json_key": "password"},
+			AWSCalls:  []string{"secretsmanager:GetSecretValue prod/db/password (us-east-1)"},
+			Value:     "****",
+			Sensitive: true,
+		},
+		{
+			Name:     "Environment",
+			Resolver: "literal",
+			Inputs:   map[string]string{"value": "dev"},
+			Value:    "dev",
+		},
+	}
+
+	text := FormatExplanation(stack)
+
+	assert.Equal(t, `Parameter resolution for stack app in context dev:
+
+  DBPassword
+    resolver: secret
+    inputs:   json_key=password, secret_id=prod/db/password
+    aws call: secretsmanager:GetSecretValue prod/db/password (us-east-1)
+    value:    ****
+
+  Environment
+    resolver: literal
+    inputs:   value=dev
+    value:    dev
+
+`, text)
+}
+
+func TestFormatExplanation_NoParameters(t *testing.T) {
+	stack := model.NewTestStack("app", model.NewTestContext("dev", "us-east-1", "123456789012"))
+
+	assert.Contains(t, FormatExplanation(stack), "(no parameters)")
+}
+
+func TestNewParameterTrace_List(t *testing.T) {
+	paramValue := &config.ParameterValue{
+		ResolutionType: "list",
+		ListItems: []*config.ParameterValue{
+			{ResolutionType: "literal", ResolutionConfig: map[string]string{"value": "sg-1"}},
+			{ResolutionType: "secret", ResolutionConfig: map[string]string{"secret_id": "prod/sg"}},
+		},
+	}
+
+	trace := newParameterTrace("SecurityGroups", paramValue, "sg-1,sg-2", callLog{"secretsmanager:GetSecretValue prod/sg (us-east-1)"})
+
+	assert.Equal(t, "list", trace.Resolver)
+	assert.Equal(t, map[string]string{"items": "literal, secret"}, trace.Inputs)
+	assert.True(t, trace.Sensitive)
+	assert.Equal(t, "****", trace.Value)
+}
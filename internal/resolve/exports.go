@@ -0,0 +1,113 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stripExports removes the Export block from every output of a CloudFormation template.
+// Templates without exports are returned unchanged; otherwise JSON templates are re-encoded
+// as JSON and YAML templates as YAML, with intrinsic function tags preserved.
+func stripExports(templateBody string) (string, error) {
+	if json.Valid([]byte(templateBody)) {
+		return stripJSONExports(templateBody)
+	}
+	return stripYAMLExports(templateBody)
+}
+
+// stripJSONExports removes Export blocks from a JSON template
+func stripJSONExports(templateBody string) (string, error) {
+	var template map[string]interface{}
+	if err := json.Unmarshal([]byte(templateBody), &template); err != nil {
+		return "", fmt.Errorf("failed to parse template outputs: %w", err)
+	}
+
+	outputs, ok := template["Outputs"].(map[string]interface{})
+	if !ok {
+		return templateBody, nil
+	}
+
+	removed := false
+	for _, output := range outputs {
+		if fields, ok := output.(map[string]interface{}); ok {
+			if _, exists := fields["Export"]; exists {
+				delete(fields, "Export")
+				removed = true
+			}
+		}
+	}
+	if !removed {
+		return templateBody, nil
+	}
+
+	encoded, err := json.MarshalIndent(template, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode template: %w", err)
+	}
+	return string(encoded) + "\n", nil
+}
+
+// stripYAMLExports removes Export blocks from a YAML template
+func stripYAMLExports(templateBody string) (string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(templateBody), &document); err != nil {
+		return "", fmt.Errorf("failed to parse template outputs: %w", err)
+	}
+	if len(document.Content) == 0 {
+		return templateBody, nil
+	}
+
+	outputs := mappingValue(document.Content[0], "Outputs")
+	if outputs == nil || outputs.Kind != yaml.MappingNode {
+		return templateBody, nil
+	}
+
+	removed := false
+	for i := 1; i < len(outputs.Content); i += 2 {
+		output := outputs.Content[i]
+		if output.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j < len(output.Content); j += 2 {
+			if output.Content[j].Value == "Export" {
+				output.Content = append(output.Content[:j], output.Content[j+2:]...)
+				removed = true
+				break
+			}
+		}
+	}
+	if !removed {
+		return templateBody, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return "", fmt.Errorf("failed to encode template: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// mappingValue returns the value node for a key in a YAML mapping, or nil if absent
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GitRunner defines the interface for running git commands in the working directory
+type GitRunner interface {
+	Run(args ...string) (string, error)
+}
+
+// DefaultGitRunner implements GitRunner by running the git executable
+type DefaultGitRunner struct{}
+
+// Run runs git with the given arguments and returns its trimmed output
+func (g *DefaultGitRunner) Run(args ...string) (string, error) {
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("failed to run git: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// gitFieldArgs maps each supported git field to the command that reads it
+var gitFieldArgs = map[string][]string{
+	"sha":       {"rev-parse", "HEAD"},
+	"short-sha": {"rev-parse", "--short", "HEAD"},
+	"branch":    {"rev-parse", "--abbrev-ref", "HEAD"},
+}
+
+// resolveGitParameter resolves the commit SHA, short SHA or branch of the git repository in the working directory
+func (r *StackResolver) resolveGitParameter(gitConfig map[string]string) (string, error) {
+	field, exists := gitConfig["field"]
+	if !exists || field == "" {
+		return "", fmt.Errorf("git resolver missing required 'field'")
+	}
+	args, supported := gitFieldArgs[field]
+	if !supported {
+		return "", fmt.Errorf("unsupported git field '%s': must be sha, short-sha or branch", field)
+	}
+
+	if _, err := r.gitRunner.Run("rev-parse", "--is-inside-work-tree"); err != nil {
+		return "", fmt.Errorf("git resolver must run inside a git repository: %w", err)
+	}
+
+	value, err := r.gitRunner.Run(args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve git %s: %w", field, err)
+	}
+	if field == "branch" && value == "HEAD" {
+		return "", fmt.Errorf("cannot resolve git branch: HEAD is detached")
+	}
+	return value, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitResolver returns a resolver whose git commands run against a stubbed repository
+func newGitResolver() (*StackResolver, *MockGitRunner) {
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	resolver := NewStackResolver(&config.MockConfigProvider{}, mockFactory)
+	mockGit := &MockGitRunner{}
+	resolver.SetGitRunner(mockGit)
+	return resolver, mockGit
+}
+
+func gitParameter(field string) map[string]*config.ParameterValue {
+	return map[string]*config.ParameterValue{
+		"Revision": {ResolutionType: "git", ResolutionConfig: map[string]string{"field": field}},
+	}
+}
+
+func TestStackResolver_ResolveParameters_Git(t *testing.T) {
+	tests := []struct {
+		field    string
+		args     []string
+		output   string
+		expected string
+	}{
+		{field: "sha", args: []string{"rev-parse", "HEAD"}, output: "4f2c8e1a9b7d6c5e4f3a2b1c0d9e8f7a6b5c4d3e", expected: "4f2c8e1a9b7d6c5e4f3a2b1c0d9e8f7a6b5c4d3e"},
+		{field: "short-sha", args: []string{"rev-parse", "--short", "HEAD"}, output: "4f2c8e1", expected: "4f2c8e1"},
+		{field: "branch", args: []string{"rev-parse", "--abbrev-ref", "HEAD"}, output: "main", expected: "main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			resolver, mockGit := newGitResolver()
+			mockGit.On("Run", []string{"rev-parse", "--is-inside-work-tree"}).Return("true", nil)
+			mockGit.On("Run", tt.args).Return(tt.output, nil)
+
+			resolved, err := resolver.resolveParameters(context.Background(), gitParameter(tt.field), "us-east-1")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved["Revision"])
+			mockGit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStackResolver_ResolveParameters_GitErrors(t *testing.T) {
+	t.Run("not a repository", func(t *testing.T) {
+		resolver, mockGit := newGitResolver()
+		mockGit.On("Run", []string{"rev-parse", "--is-inside-work-tree"}).
+			Return("", errors.New("git rev-parse --is-inside-work-tree failed: fatal: not a git repository (or any of the parent directories): .git"))
+
+		_, err := resolver.resolveParameters(context.Background(), gitParameter("sha"), "us-east-1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "git resolver must run inside a git repository")
+		assert.Contains(t, err.Error(), "not a git repository")
+	})
+
+	t.Run("detached head has no branch", func(t *testing.T) {
+		resolver, mockGit := newGitResolver()
+		mockGit.On("Run", []string{"rev-parse", "--is-inside-work-tree"}).Return("true", nil)
+		mockGit.On("Run", []string{"rev-parse", "--abbrev-ref", "HEAD"}).Return("HEAD", nil)
+
+		_, err := resolver.resolveParameters(context.Background(), gitParameter("branch"), "us-east-1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "HEAD is detached")
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		resolver, mockGit := newGitResolver()
+
+		_, err := resolver.resolveParameters(context.Background(), gitParameter(""), "us-east-1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "git resolver missing required 'field'")
+		mockGit.AssertNotCalled(t, "Run", mock.Anything)
+	})
+
+	t.Run("unsupported field", func(t *testing.T) {
+		resolver, _ := newGitResolver()
+
+		_, err := resolver.resolveParameters(context.Background(), gitParameter("tag"), "us-east-1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported git field 'tag': must be sha, short-sha or branch")
+	})
+}
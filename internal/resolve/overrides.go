@@ -0,0 +1,49 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"codeberg.org/orien/stackaroo/internal/model"
+)
+
+// OverrideResolver names the resolution of parameters replaced from the command line
+const OverrideResolver = "override"
+
+// ApplyParameterOverrides replaces the resolved values of a stack's parameters with values given
+// on the command line. Only parameters the stack already has are replaced, so one set of overrides
+// can be applied to every stack in a context. Sensitive parameters stay masked. The overridden
+// values are checked against the template's declarations, as resolved values are.
+func ApplyParameterOverrides(stack *model.Stack, overrides map[string]string) error {
+	for key, value := range overrides {
+		if _, exists := stack.Parameters[key]; !exists {
+			continue
+		}
+
+		stack.Parameters[key] = value
+		if stack.OverriddenParameters == nil {
+			stack.OverriddenParameters = make(map[string]bool)
+		}
+		stack.OverriddenParameters[key] = true
+
+		for i := range stack.ParameterTraces {
+			trace := &stack.ParameterTraces[i]
+			if trace.Name != key {
+				continue
+			}
+			trace.Resolver = OverrideResolver
+			trace.Inputs = nil
+			trace.AWSCalls = nil
+			trace.Value = value
+			if trace.Sensitive {
+				trace.Value = model.MaskedValue
+			}
+		}
+	}
+
+	if len(stack.OverriddenParameters) == 0 {
+		return nil
+	}
+	return ValidateParameters(stack)
+}
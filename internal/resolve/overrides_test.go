@@ -0,0 +1,66 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyParameterOverrides_ReplacesResolvedValues(t *testing.T) {
+	stack := &model.Stack{
+		Name:       "app",
+		Parameters: map[string]string{"ImageTag": "v1.2.0", "Environment": "dev"},
+		ParameterTraces: []model.ParameterTrace{
+			{Name: "Environment", Resolver: "literal", Value: "dev"},
+			{Name: "ImageTag", Resolver: "ssm", Inputs: map[string]string{"parameter": "/app/tag"}, AWSCalls: []string{"ssm:GetParameter /app/tag"}, Value: "v1.2.0"},
+		},
+	}
+
+	ApplyParameterOverrides(stack, map[string]string{"ImageTag": "abc123"})
+
+	assert.Equal(t, map[string]string{"ImageTag": "abc123", "Environment": "dev"}, stack.Parameters)
+	assert.Equal(t, map[string]bool{"ImageTag": true}, stack.OverriddenParameters)
+	assert.Equal(t, model.ParameterTrace{Name: "ImageTag", Resolver: OverrideResolver, Value: "abc123"}, stack.ParameterTraces[1])
+	assert.Equal(t, "literal", stack.ParameterTraces[0].Resolver)
+}
+
+func TestApplyParameterOverrides_IgnoresParametersTheStackLacks(t *testing.T) {
+	stack := &model.Stack{Name: "vpc", Parameters: map[string]string{"CidrBlock": "10.0.0.0/16"}}
+
+	ApplyParameterOverrides(stack, map[string]string{"ImageTag": "abc123"})
+
+	assert.Equal(t, map[string]string{"CidrBlock": "10.0.0.0/16"}, stack.Parameters)
+	assert.Nil(t, stack.OverriddenParameters)
+}
+
+func TestApplyParameterOverrides_ValidatesOverriddenValues(t *testing.T) {
+	stack := &model.Stack{
+		Name:         "app",
+		TemplateBody: parameterTemplate,
+		Parameters:   map[string]string{"Environment": "dev", "DatabasePassword": "correcthorsebattery"},
+	}
+
+	err := ApplyParameterOverrides(stack, map[string]string{"Environment": "qa"})
+
+	require.EqualError(t, err, `invalid parameters for stack app: parameter Environment: value "qa" is not one of the allowed values: dev, staging, prod`)
+}
+
+func TestApplyParameterOverrides_KeepsSensitiveValuesMasked(t *testing.T) {
+	stack := &model.Stack{
+		Name:                "db",
+		Parameters:          map[string]string{"DBPassword": "s3cr3t"},
+		SensitiveParameters: map[string]bool{"DBPassword": true},
+		ParameterTraces:     []model.ParameterTrace{{Name: "DBPassword", Resolver: "secret", Value: model.MaskedValue, Sensitive: true}},
+	}
+
+	ApplyParameterOverrides(stack, map[string]string{"DBPassword": "temporary"})
+
+	assert.Equal(t, "temporary", stack.Parameters["DBPassword"])
+	assert.Equal(t, model.MaskedValue, stack.ParameterTraces[0].Value)
+}
@@ -6,19 +6,47 @@ package resolve
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/log"
 	"codeberg.org/orien/stackaroo/internal/model"
 )
 
+// maxParameterWorkers bounds the number of parameters resolved concurrently
+const maxParameterWorkers = 8
+
+// snsTopicARNPattern matches SNS topic ARNs in any AWS partition
+var snsTopicARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:sns:[a-z0-9-]+:[0-9]{12}:[A-Za-z0-9_.-]+$`)
+
+// cloudWatchAlarmARNPattern matches CloudWatch alarm ARNs in any AWS partition
+var cloudWatchAlarmARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:cloudwatch:[a-z0-9-]+:[0-9]{12}:alarm:.+$`)
+
+// Limits CloudFormation places on rollback configuration
+const (
+	maxRollbackTriggers          = 5
+	maxRollbackMonitoringMinutes = 180
+)
+
 // Resolver defines the interface for stack resolution operations
 type Resolver interface {
 	ResolveStack(ctx context.Context, context string, stackName string) (*model.Stack, error)
 	GetDependencyOrder(context string, stackNames []string) ([]string, error)
+	// RecordStackOutputs remembers the outputs of a stack just deployed so that
+	// stack-output parameters referring to it are resolved without calling AWS
+	RecordStackOutputs(region, stackName string, outputs map[string]string)
 }
 
 // StackResolver resolves configuration into deployment-ready artifacts
@@ -27,6 +55,12 @@ type StackResolver struct {
 	fileSystemResolver FileSystemResolver
 	clientFactory      aws.ClientFactory
 	templateProcessor  TemplateProcessor
+	gitRunner          GitRunner
+	recordedOutputs    map[string]map[string]string // Outputs of stacks deployed in this run, keyed by region and stack name
+	templateOverrides  map[string]string            // Template URIs used instead of the configured template, keyed by stack name
+	outputsMutex       sync.RWMutex
+	notices            io.Writer // Receives notes about adjustments made while resolving
+	logger             *slog.Logger
 }
 
 // NewStackResolver creates a new stack resolver instance with the given config provider and client factory
@@ -36,21 +70,69 @@ func NewStackResolver(configProvider config.ConfigProvider, clientFactory aws.Cl
 		fileSystemResolver: &DefaultFileSystemResolver{},
 		clientFactory:      clientFactory,
 		templateProcessor:  NewCfnTemplateProcessor(),
+		gitRunner:          &DefaultGitRunner{},
+		recordedOutputs:    make(map[string]map[string]string),
+		templateOverrides:  make(map[string]string),
+		notices:            os.Stderr,
+		logger:             log.Default(),
 	}
 }
 
+// SetLogger replaces the logger that records resolution decisions
+func (r *StackResolver) SetLogger(logger *slog.Logger) {
+	r.logger = logger
+}
+
 // SetFileSystemResolver allows injecting a custom file system resolver (for testing)
 func (r *StackResolver) SetFileSystemResolver(fileSystemResolver FileSystemResolver) {
 	r.fileSystemResolver = fileSystemResolver
 }
 
-// SetTemplateProcessor allows injecting a custom template processor (for testing)
+// RecordStackOutputs remembers the outputs of a stack deployed in this run
+func (r *StackResolver) RecordStackOutputs(region, stackName string, outputs map[string]string) {
+	recorded := make(map[string]string, len(outputs))
+	for key, value := range outputs {
+		recorded[key] = value
+	}
+
+	r.outputsMutex.Lock()
+	r.recordedOutputs[outputsKey(region, stackName)] = recorded
+	r.outputsMutex.Unlock()
+}
+
+// recordedStackOutputs returns the outputs recorded for a stack, if it was deployed in this run
+func (r *StackResolver) recordedStackOutputs(region, stackName string) (map[string]string, bool) {
+	r.outputsMutex.RLock()
+	defer r.outputsMutex.RUnlock()
+	outputs, exists := r.recordedOutputs[outputsKey(region, stackName)]
+	return outputs, exists
+}
+
+// outputsKey identifies a stack by region, as stacks in different regions may share a name
+func outputsKey(region, stackName string) string {
+	return region + "/" + stackName
+}
+
+// SetGitRunner allows injecting a custom git runner (for testing)
+func (r *StackResolver) SetGitRunner(gitRunner GitRunner) {
+	r.gitRunner = gitRunner
+}
+
+// SetTemplateProcessor replaces the template processor, such as with one that stamps build information or a mock for testing
 func (r *StackResolver) SetTemplateProcessor(templateProcessor TemplateProcessor) {
 	r.templateProcessor = templateProcessor
 }
 
+// SetTemplateOverride makes ResolveStack read the template of a stack from templateURI, a
+// file:// or s3:// URI, instead of the template in its configuration
+func (r *StackResolver) SetTemplateOverride(stackName, templateURI string) {
+	r.templateOverrides[stackName] = templateURI
+}
+
 // ResolveStack resolves a single stack configuration
 func (r *StackResolver) ResolveStack(ctx context.Context, context string, stackName string) (*model.Stack, error) {
+	r.logger.DebugContext(ctx, "resolving stack", "stack", stackName, "context", context)
+
 	// Load configuration
 	cfg, err := r.configProvider.LoadConfig(ctx, context)
 	if err != nil {
@@ -62,52 +144,264 @@ func (r *StackResolver) ResolveStack(ctx context.Context, context string, stackN
 	if err != nil {
 		return nil, err
 	}
+	if stackConfig.Disabled {
+		return nil, fmt.Errorf("stack %s is disabled in context %s", stackName, context)
+	}
+
+	templateURI := stackConfig.Template
+	files := r.fileSystemResolver
+	if override, ok := r.templateOverrides[stackName]; ok {
+		r.logger.DebugContext(ctx, "using template override", "stack", stackName, "template", override)
+		templateURI = override
+		// An override names a local file, even when the configuration's templates are fetched remotely
+		files = &DefaultFileSystemResolver{}
+	}
+
+	// Without a configured template the stack keeps its deployed template
+	var templateBody, templateURL string
+	usePreviousTemplate := templateURI == ""
+	if usePreviousTemplate {
+		r.logger.DebugContext(ctx, "stack has no template configured; using its deployed template", "stack", stackName)
+		templateBody, err = r.deployedTemplate(ctx, cfg.Context.DeployedStackName(stackName), cfg.Context.Region)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Read raw template content
+		rawTemplate, objectURL, err := r.readTemplate(ctx, templateURI, files, cfg.Context)
+		if err != nil {
+			return nil, err
+		}
+
+		// Process template with variables (parameters and context)
+		templateVars := r.buildTemplateVariables(stackConfig, context, cfg.Context.ExportsEnabled())
+		templateBody, err = r.templateProcessor.Process(rawTemplate, templateVars)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process template: %w", err)
+		}
+
+		// CloudFormation can read an S3 template itself, unless processing changed it
+		if templateBody == rawTemplate {
+			templateURL = objectURL
+		}
+		r.logger.DebugContext(ctx, "processed template", "stack", stackName, "template", templateURI, "changed", templateBody != rawTemplate)
+	}
+
+	// Templates using transforms such as AWS::Serverless need CAPABILITY_AUTO_EXPAND
+	capabilities := stackConfig.Capabilities
+	if templateHasTransform(templateBody) {
+		var added bool
+		capabilities, added = withAutoExpand(capabilities)
+		if added {
+			_, _ = fmt.Fprintf(r.notices, "Added %s to stack %s because its template declares a Transform\n", autoExpandCapability, stackName)
+		}
+	}
+
+	// Read the stack policy, rejecting documents CloudFormation would refuse
+	var stackPolicyBody string
+	if stackConfig.StackPolicy != "" {
+		stackPolicyBody, err = r.fileSystemResolver.Resolve(stackConfig.StackPolicy)
+		if err != nil {
+			return nil, err
+		}
+		if !json.Valid([]byte(stackPolicyBody)) {
+			return nil, fmt.Errorf("stack policy for stack %s is not valid JSON", stackName)
+		}
+	}
+
+	for _, arn := range stackConfig.NotificationARNs {
+		if !snsTopicARNPattern.MatchString(arn) {
+			return nil, fmt.Errorf("notification ARN %q for stack %s is not an SNS topic ARN", arn, stackName)
+		}
+	}
 
-	// Read raw template content
-	rawTemplate, err := r.fileSystemResolver.Resolve(stackConfig.Template)
+	if err := aws.ValidateOnFailure(stackConfig.OnFailure); err != nil {
+		return nil, fmt.Errorf("stack %s: %w", stackName, err)
+	}
+
+	if stackConfig.TimeoutInMinutes != nil && *stackConfig.TimeoutInMinutes < 1 {
+		return nil, fmt.Errorf("timeout_minutes for stack %s must be at least 1", stackName)
+	}
+
+	rollback, err := resolveRollback(stackConfig.Rollback)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("rollback for stack %s: %w", stackName, err)
+	}
+
+	if err := validateResourceTypes(stackConfig.AllowedResourceTypes); err != nil {
+		return nil, fmt.Errorf("allowed_resource_types for stack %s: %w", stackName, err)
+	}
+
+	deployedName := cfg.Context.DeployedStackName(stackName)
+	stackParameters, err := r.withContextValues(cfg.Context.ValuesFile, templateBody, stackConfig.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve parameters for stack %s: %w", stackName, err)
+	}
+
+	stackParameters, err = r.deployedOutputReferences(context, cfg.Context, stackParameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve parameters for stack %s: %w", stackName, err)
 	}
 
-	// Process template with variables (parameters and context)
-	templateVars := r.buildTemplateVariables(stackConfig, context)
-	templateBody, err := r.templateProcessor.Process(rawTemplate, templateVars)
+	// Parameters that read this stack's own outputs need a fallback before its first deploy
+	stackParameters, err = r.applySelfReferenceFallbacks(ctx, deployedName, stackParameters, cfg.Context.Region)
 	if err != nil {
-		return nil, fmt.Errorf("failed to process template: %w", err)
+		return nil, fmt.Errorf("failed to resolve parameters for stack %s: %w", stackName, err)
 	}
 
 	// Resolve parameters with new system, passing region for cross-region stack outputs
-	parameters, err := r.resolveParameters(ctx, stackConfig.Parameters, cfg.Context.Region)
+	parameters, traces, err := r.resolveParametersWithTrace(ctx, stackParameters, cfg.Context.Region)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve parameters for stack %s: %w", stackName, err)
 	}
 
+	for _, trace := range traces {
+		r.logger.DebugContext(ctx, "resolved parameter", "stack", stackName, "parameter", trace.Name, "resolver", trace.Resolver, "aws_calls", len(trace.AWSCalls))
+	}
+
+	// Catch parameters the template would reject before CloudFormation does
+	warnings, err := validateParametersAgainstTemplate(templateBody, parameters, sensitiveParameters(stackParameters))
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters for stack %s: %w", stackName, err)
+	}
+	for _, warning := range warnings {
+		_, _ = fmt.Fprintf(r.notices, "Warning: stack %s: %s\n", stackName, warning)
+	}
+
 	// Merge tags: global + context + stack (stack takes precedence)
 	globalAndContextTags := r.mergeTags(cfg.Tags, cfg.Context.Tags)
 	tags := r.mergeTags(globalAndContextTags, stackConfig.Tags)
 
+	// Mandatory tags, such as compliance tags, are merged last so nothing can override them
+	tags = r.mergeTags(tags, cfg.MandatoryTags)
+
 	// Create context info from resolved configuration
 	stackContext := &model.Context{
-		Name:    cfg.Context.Name,
-		Region:  cfg.Context.Region,
-		Account: cfg.Context.Account,
-	}
-
-	return &model.Stack{
-		Name:         stackConfig.Name,
-		Context:      stackContext,
-		TemplateBody: templateBody,
-		Parameters:   parameters,
-		Tags:         tags,
-		Capabilities: stackConfig.Capabilities,
-		Dependencies: stackConfig.Dependencies,
+		Name:      cfg.Context.Name,
+		Region:    cfg.Context.Region,
+		Account:   cfg.Context.Account,
+		Protected: cfg.Context.Protected,
+	}
+
+	stack := &model.Stack{
+		Name:                  stackConfig.Name,
+		DeployedName:          deployedName,
+		Context:               stackContext,
+		TemplateBody:          templateBody,
+		TemplateURL:           templateURL,
+		Parameters:            parameters,
+		SensitiveParameters:   sensitiveParameters(stackParameters),
+		ParameterTraces:       traces,
+		Tags:                  tags,
+		Capabilities:          capabilities,
+		Dependencies:          stackConfig.Dependencies,
+		TerminationProtection: stackConfig.TerminationProtection,
+		StackPolicyBody:       stackPolicyBody,
+		NotificationARNs:      stackConfig.NotificationARNs,
+		UsePreviousTemplate:   usePreviousTemplate,
+		OnFailure:             stackConfig.OnFailure,
+		TimeoutInMinutes:      stackConfig.TimeoutInMinutes,
+		Rollback:              rollback,
+		ResourceTypes:         stackConfig.AllowedResourceTypes,
+		IgnoreProperties:      stackConfig.IgnoreProperties,
+		RequiredApprovals:     stackConfig.RequiredApprovals,
+	}
+	if err := applyAWSOptions(stack, stackConfig.AWSOptions); err != nil {
+		return nil, fmt.Errorf("invalid aws_options for stack %s: %w", stackName, err)
+	}
+
+	return stack, nil
+}
+
+// resolveRollback checks rollback triggers against the limits CloudFormation enforces
+func resolveRollback(rollback *config.RollbackConfig) (*model.RollbackConfiguration, error) {
+	if rollback == nil {
+		return nil, nil
+	}
+
+	if len(rollback.AlarmARNs) > maxRollbackTriggers {
+		return nil, fmt.Errorf("at most %d alarms can be given, not %d", maxRollbackTriggers, len(rollback.AlarmARNs))
+	}
+	for _, arn := range rollback.AlarmARNs {
+		if !cloudWatchAlarmARNPattern.MatchString(arn) {
+			return nil, fmt.Errorf("%q is not a CloudWatch alarm ARN", arn)
+		}
+	}
+	if minutes := rollback.MonitoringTimeInMinutes; minutes != nil && (*minutes < 0 || *minutes > maxRollbackMonitoringMinutes) {
+		return nil, fmt.Errorf("monitoring_minutes must be between 0 and %d", maxRollbackMonitoringMinutes)
+	}
+
+	return &model.RollbackConfiguration{
+		AlarmARNs:               slices.Clone(rollback.AlarmARNs),
+		MonitoringTimeInMinutes: rollback.MonitoringTimeInMinutes,
 	}, nil
 }
 
-// GetDependencyOrder calculates the dependency order for stacks without resolving them
-func (r *StackResolver) GetDependencyOrder(context string, stackNames []string) ([]string, error) {
-	// Get stack configurations
+// readTemplate reads a template from an s3://bucket/key URI or through the given file system resolver.
+// S3 templates are read in the context's region, and their HTTPS object URL is also returned.
+func (r *StackResolver) readTemplate(ctx context.Context, templateURI string, files FileSystemResolver, contextConfig *config.ContextConfig) (string, string, error) {
+	if !strings.HasPrefix(templateURI, "s3://") {
+		body, err := files.Resolve(templateURI)
+		return body, "", err
+	}
+
+	bucket, key, err := aws.ParseS3URI(templateURI)
+	if err != nil {
+		return "", "", err
+	}
+
+	region := contextConfig.Region
+	s3Ops, err := r.clientFactory.GetS3Operations(ctx, region)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get S3 operations for region %s: %w", region, err)
+	}
+
+	body, err := s3Ops.GetObject(ctx, bucket, key)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read template %s: %w", templateURI, err)
+	}
+	return body, aws.S3ObjectURL(bucket, key, region), nil
+}
+
+// deployedTemplate fetches the current template of a stack that has no template configured.
+// Only existing stacks can be deployed this way; creating a stack needs a template.
+func (r *StackResolver) deployedTemplate(ctx context.Context, stackName string, region string) (string, error) {
+	cfnOps, err := r.clientFactory.GetCloudFormationOperations(ctx, region)
+	if err != nil {
+		return "", fmt.Errorf("failed to get CloudFormation operations for region %s: %w", region, err)
+	}
+
+	exists, err := cfnOps.StackExists(ctx, stackName)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if stack '%s' exists: %w", stackName, err)
+	}
+	if !exists {
+		return "", fmt.Errorf("stack %s has no template configured; a template is required to create it", stackName)
+	}
+
+	templateBody, err := cfnOps.GetTemplate(ctx, stackName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployed template for stack %s: %w", stackName, err)
+	}
+	return templateBody, nil
+}
+
+// GetDependencyGraph returns the dependencies of each stack without resolving them, keeping
+// only dependencies among the given stacks. Stacks disabled in the context are left out; an
+// enabled stack depending on one is an error. Cycles are not detected; see FindCycle.
+func (r *StackResolver) GetDependencyGraph(context string, stackNames []string) (map[string][]string, error) {
+	stackConfigs, err := r.enabledStackConfigs(context, stackNames)
+	if err != nil {
+		return nil, err
+	}
+	return dependencyGraph(stackConfigs), nil
+}
+
+// enabledStackConfigs loads the configuration of the given stacks, leaving out those disabled
+// in the context and refusing enabled stacks that depend on a disabled one
+func (r *StackResolver) enabledStackConfigs(context string, stackNames []string) ([]*config.StackConfig, error) {
 	var stackConfigs []*config.StackConfig
+	disabled := make(map[string]bool)
 
 	for _, stackName := range stackNames {
 		stackConfig, err := r.configProvider.GetStack(stackName, context)
@@ -115,9 +409,92 @@ func (r *StackResolver) GetDependencyOrder(context string, stackNames []string)
 			return nil, fmt.Errorf("failed to get stack config %s: %w", stackName, err)
 		}
 
+		if stackConfig.Disabled {
+			r.logger.Debug("leaving out disabled stack", "stack", stackName, "context", context)
+			disabled[stackName] = true
+			continue
+		}
 		stackConfigs = append(stackConfigs, stackConfig)
 	}
 
+	for _, stackConfig := range stackConfigs {
+		for _, dep := range stackConfig.Dependencies {
+			if disabled[dep] {
+				return nil, fmt.Errorf("stack %s depends on stack %s, which is disabled in context %s", stackConfig.Name, dep, context)
+			}
+		}
+	}
+
+	return stackConfigs, nil
+}
+
+// dependencyGraph maps each stack to its sorted dependencies among the given stacks
+func dependencyGraph(stackConfigs []*config.StackConfig) map[string][]string {
+	graph := make(map[string][]string, len(stackConfigs))
+	for _, stackConfig := range stackConfigs {
+		graph[stackConfig.Name] = []string{}
+	}
+	for _, stackConfig := range stackConfigs {
+		for _, dep := range stackConfig.Dependencies {
+			if _, exists := graph[dep]; exists && !slices.Contains(graph[stackConfig.Name], dep) {
+				graph[stackConfig.Name] = append(graph[stackConfig.Name], dep)
+			}
+		}
+		sort.Strings(graph[stackConfig.Name])
+	}
+	return graph
+}
+
+// FindCycle returns a circular chain of dependencies in the graph, starting and ending with the
+// same stack, or nil when there is none. Stacks are visited in name order so the result is stable.
+func FindCycle(graph map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(graph))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range graph[name] {
+			switch state[dep] {
+			case visiting:
+				start := slices.Index(path, dep)
+				return append(slices.Clone(path[start:]), dep)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	names := slices.Sorted(maps.Keys(graph))
+	for _, name := range names {
+		if state[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// GetDependencyOrder calculates the dependency order for stacks without resolving them.
+// Stacks disabled in the context are left out; an enabled stack depending on one is an error.
+func (r *StackResolver) GetDependencyOrder(context string, stackNames []string) ([]string, error) {
+	stackConfigs, err := r.enabledStackConfigs(context, stackNames)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate deployment order using topological sort
 	// Build name to stack config map
 	stackMap := make(map[string]*config.StackConfig)
@@ -157,7 +534,7 @@ func (r *StackResolver) GetDependencyOrder(context string, stackNames []string)
 	}
 
 	// Sort queue for deterministic results
-	sort.Strings(queue)
+	sortByPriority(queue, stackMap)
 
 	for len(queue) > 0 {
 		// Remove node from queue
@@ -172,44 +549,202 @@ func (r *StackResolver) GetDependencyOrder(context string, stackNames []string)
 			inDegree[neighbor]--
 			if inDegree[neighbor] == 0 {
 				queue = append(queue, neighbor)
-				sort.Strings(queue) // Keep queue sorted
+				sortByPriority(queue, stackMap) // Keep queue sorted
 			}
 		}
 	}
 
 	// Check for cycles
 	if len(result) != len(stackConfigs) {
+		if cycle := FindCycle(dependencyGraph(stackConfigs)); cycle != nil {
+			return nil, fmt.Errorf("circular dependency detected in stacks: %s", strings.Join(cycle, " -> "))
+		}
 		return nil, fmt.Errorf("circular dependency detected in stacks")
 	}
 
+	r.logger.Debug("calculated dependency order", "context", context, "order", result)
 	return result, nil
 }
 
-// resolveParameters resolves parameters from ParameterValue objects to final string values
+// sortByPriority orders stacks that are ready to deploy by descending priority, then by name
+func sortByPriority(stackNames []string, stackMap map[string]*config.StackConfig) {
+	sort.Slice(stackNames, func(i, j int) bool {
+		left, right := stackMap[stackNames[i]].Priority, stackMap[stackNames[j]].Priority
+		if left != right {
+			return left > right
+		}
+		return stackNames[i] < stackNames[j]
+	})
+}
+
+// resolveParameters resolves parameters from ParameterValue objects to final string values.
+// Parameters are resolved concurrently, but the result is the same as resolving them in key order.
 func (r *StackResolver) resolveParameters(ctx context.Context, params map[string]*config.ParameterValue, contextRegion string) (map[string]string, error) {
+	result, _, err := r.resolveParametersWithTrace(ctx, params, contextRegion)
+	return result, err
+}
+
+// resolveParametersWithTrace resolves parameters and records how each value was obtained
+func (r *StackResolver) resolveParametersWithTrace(ctx context.Context, params map[string]*config.ParameterValue, contextRegion string) (map[string]string, []model.ParameterTrace, error) {
 	if params == nil {
-		return nil, nil
+		return nil, nil, nil
+	}
+
+	// Resolve in a stable key order so results and errors are deterministic
+	keys := make([]string, 0, len(params))
+	for key, paramValue := range params {
+		if paramValue != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	values := make([]string, len(keys))
+	calls := make([]callLog, len(keys))
+	files := newValuesFiles(r.fileSystemResolver)
+	errs := make([]error, len(keys))
+	semaphore := make(chan struct{}, maxParameterWorkers)
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// Skip remaining work once any parameter has failed
+			if failed.Load() {
+				return
+			}
+
+			value, err := r.resolveSingleParameter(ctx, params[key], contextRegion, files, &calls[i])
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to resolve parameter '%s': %w", key, err)
+				failed.Store(true)
+				return
+			}
+			values[i] = value
+		}(i, key)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	result := make(map[string]string, len(params))
+	result := make(map[string]string, len(keys))
+	traces := make([]model.ParameterTrace, len(keys))
+	for i, key := range keys {
+		result[key] = values[i]
+		traces[i] = newParameterTrace(key, params[key], values[i], calls[i])
+	}
+
+	return result, traces, nil
+}
+
+// deployedOutputReferences points stack-output parameters that name a stack configured in the
+// context, in the context region, at that stack's deployed name. Other references name a
+// CloudFormation stack directly and are left alone, as are all references when the context
+// applies no stack name prefix or suffix.
+func (r *StackResolver) deployedOutputReferences(context string, contextConfig *config.ContextConfig, params map[string]*config.ParameterValue) (map[string]*config.ParameterValue, error) {
+	if contextConfig.StackNamePrefix == "" && contextConfig.StackNameSuffix == "" {
+		return params, nil
+	}
 
+	configuredStacks, err := r.configProvider.ListStacks(context)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*config.ParameterValue, len(params))
 	for key, paramValue := range params {
-		if paramValue == nil {
+		result[key] = paramValue
+		if paramValue == nil || paramValue.ResolutionType != "stack-output" {
+			continue
+		}
+		referenced := paramValue.ResolutionConfig["stack"]
+		region := paramValue.ResolutionConfig["region"]
+		if !slices.Contains(configuredStacks, referenced) || (region != "" && region != contextConfig.Region) {
 			continue
 		}
 
-		resolvedValue, err := r.resolveSingleParameter(ctx, paramValue, contextRegion)
-		if err != nil {
-			return nil, fmt.Errorf("failed to resolve parameter '%s': %w", key, err)
+		resolutionConfig := maps.Clone(paramValue.ResolutionConfig)
+		resolutionConfig["stack"] = contextConfig.DeployedStackName(referenced)
+		result[key] = &config.ParameterValue{
+			ResolutionType:   paramValue.ResolutionType,
+			ResolutionConfig: resolutionConfig,
+			ListItems:        paramValue.ListItems,
+		}
+	}
+
+	return result, nil
+}
+
+// applySelfReferenceFallbacks handles stack-output parameters that reference the stack being resolved.
+// Once the stack exists they read its last-deployed outputs as normal. Before the first deploy there
+// are no outputs to read, so the resolver's 'default' is used instead, or the parameter is omitted
+// to let the template default apply.
+func (r *StackResolver) applySelfReferenceFallbacks(ctx context.Context, stackName string, params map[string]*config.ParameterValue, contextRegion string) (map[string]*config.ParameterValue, error) {
+	var selfReferences []string
+	for key, paramValue := range params {
+		if isSelfReference(paramValue, stackName, contextRegion) {
+			selfReferences = append(selfReferences, key)
+		}
+	}
+	if len(selfReferences) == 0 {
+		return params, nil
+	}
+
+	cfnOps, err := r.clientFactory.GetCloudFormationOperations(ctx, contextRegion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CloudFormation operations for region %s: %w", contextRegion, err)
+	}
+
+	exists, err := cfnOps.StackExists(ctx, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if stack '%s' exists: %w", stackName, err)
+	}
+	if exists {
+		return params, nil
+	}
+
+	result := make(map[string]*config.ParameterValue, len(params))
+	for key, paramValue := range params {
+		result[key] = paramValue
+	}
+	for _, key := range selfReferences {
+		defaultValue, hasDefault := params[key].ResolutionConfig["default"]
+		if !hasDefault {
+			delete(result, key)
+			continue
+		}
+		result[key] = &config.ParameterValue{
+			ResolutionType:   "literal",
+			ResolutionConfig: map[string]string{"value": defaultValue},
 		}
-		result[key] = resolvedValue
 	}
 
 	return result, nil
 }
 
+// isSelfReference reports whether a parameter reads an output of the named stack in the context region
+func isSelfReference(paramValue *config.ParameterValue, stackName, contextRegion string) bool {
+	if paramValue == nil || paramValue.ResolutionType != "stack-output" {
+		return false
+	}
+	if paramValue.ResolutionConfig["stack"] != stackName {
+		return false
+	}
+	region := paramValue.ResolutionConfig["region"]
+	return region == "" || region == contextRegion
+}
+
 // resolveStackOutput resolves a stack output reference to its actual value
-func (r *StackResolver) resolveStackOutput(ctx context.Context, outputConfig map[string]string, contextRegion string) (string, error) {
+func (r *StackResolver) resolveStackOutput(ctx context.Context, outputConfig map[string]string, contextRegion string, calls *callLog) (string, error) {
 	stackName, exists := outputConfig["stack"]
 	if !exists {
 		return "", fmt.Errorf("stack output resolver missing required 'stack'")
@@ -226,28 +761,191 @@ func (r *StackResolver) resolveStackOutput(ctx context.Context, outputConfig map
 		region = configRegion
 	}
 
+	// Stacks deployed earlier in this run already reported their outputs
+	outputs, recorded := r.recordedStackOutputs(region, stackName)
+	if !recorded {
+		// Get region-specific CloudFormation operations
+		cfnOps, err := r.clientFactory.GetCloudFormationOperations(ctx, region)
+		if err != nil {
+			return "", fmt.Errorf("failed to get CloudFormation operations for region %s: %w", region, err)
+		}
+
+		// Fetch stack information from CloudFormation
+		calls.record("cloudformation:DescribeStacks %s (%s)", stackName, region)
+		stack, err := cfnOps.GetStack(ctx, stackName)
+		if err != nil {
+			return "", fmt.Errorf("failed to get stack '%s' in region %s: %w", stackName, region, err)
+		}
+		outputs = stack.Outputs
+	}
+
+	value, exists := outputs[outputKey]
+	if !exists {
+		return "", fmt.Errorf("stack '%s' does not have output '%s'", stackName, outputKey)
+	}
+
+	return value, nil
+}
+
+// resolveExport resolves a parameter from a CloudFormation export by name, whichever stack exports it
+func (r *StackResolver) resolveExport(ctx context.Context, exportConfig map[string]string, contextRegion string, calls *callLog) (string, error) {
+	name, exists := exportConfig["name"]
+	if !exists || name == "" {
+		return "", fmt.Errorf("export resolver missing required 'name'")
+	}
+
+	// Determine which region to use for the export lookup
+	region := contextRegion
+	if configRegion, exists := exportConfig["region"]; exists && configRegion != "" {
+		region = configRegion
+	}
+
 	// Get region-specific CloudFormation operations
 	cfnOps, err := r.clientFactory.GetCloudFormationOperations(ctx, region)
 	if err != nil {
 		return "", fmt.Errorf("failed to get CloudFormation operations for region %s: %w", region, err)
 	}
 
-	// Fetch stack information from CloudFormation
-	stack, err := cfnOps.GetStack(ctx, stackName)
+	calls.record("cloudformation:ListExports (%s)", region)
+	exports, err := cfnOps.ListExports(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get stack '%s' in region %s: %w", stackName, region, err)
+		return "", fmt.Errorf("failed to resolve export '%s' in region %s: %w", name, region, err)
 	}
 
-	value, exists := stack.Outputs[outputKey]
+	for _, export := range exports {
+		if export.Name == name {
+			return export.Value, nil
+		}
+	}
+	return "", fmt.Errorf("export '%s' not found in region %s", name, region)
+}
+
+// resolveSSMParameter resolves an SSM Parameter Store reference to its current value
+func (r *StackResolver) resolveSSMParameter(ctx context.Context, ssmConfig map[string]string, contextRegion string, calls *callLog) (string, error) {
+	name, exists := ssmConfig["name"]
 	if !exists {
-		return "", fmt.Errorf("stack '%s' does not have output '%s'", stackName, outputKey)
+		name, exists = ssmConfig["parameter_name"]
+	}
+	if !exists || name == "" {
+		return "", fmt.Errorf("ssm resolver missing required 'name'")
+	}
+
+	// Determine which region to use for the parameter lookup
+	region := contextRegion
+	if configRegion, exists := ssmConfig["region"]; exists && configRegion != "" {
+		region = configRegion
+	}
+
+	// Get region-specific SSM operations
+	ssmOps, err := r.clientFactory.GetSSMOperations(ctx, region)
+	if err != nil {
+		return "", fmt.Errorf("failed to get SSM operations for region %s: %w", region, err)
+	}
+
+	calls.record("ssm:GetParameter %s (%s)", name, region)
+	value, err := ssmOps.GetParameter(ctx, name, ssmConfig["version"])
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve SSM parameter '%s' in region %s: %w", name, region, err)
 	}
 
 	return value, nil
 }
 
+// resolveSecret resolves a Secrets Manager reference, optionally extracting one field of a JSON secret
+func (r *StackResolver) resolveSecret(ctx context.Context, secretConfig map[string]string, contextRegion string, calls *callLog) (string, error) {
+	secretID, exists := secretConfig["secret_id"]
+	if !exists || secretID == "" {
+		return "", fmt.Errorf("secret resolver missing required 'secret_id'")
+	}
+
+	// Determine which region to use for the secret lookup
+	region := contextRegion
+	if configRegion, exists := secretConfig["region"]; exists && configRegion != "" {
+		region = configRegion
+	}
+
+	// Get region-specific Secrets Manager operations
+	secretsOps, err := r.clientFactory.GetSecretsManagerOperations(ctx, region)
+	if err != nil {
+		return "", fmt.Errorf("failed to get Secrets Manager operations for region %s: %w", region, err)
+	}
+
+	calls.record("secretsmanager:GetSecretValue %s (%s)", secretID, region)
+	value, err := secretsOps.GetSecretValue(ctx, secretID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve secret '%s' in region %s: %w", secretID, region, err)
+	}
+
+	jsonKey, exists := secretConfig["json_key"]
+	if !exists || jsonKey == "" {
+		return value, nil
+	}
+
+	// Never include the secret value itself in these errors
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", fmt.Errorf("secret '%s' is not a JSON object, cannot extract key '%s'", secretID, jsonKey)
+	}
+
+	field, exists := fields[jsonKey]
+	if !exists {
+		return "", fmt.Errorf("secret '%s' does not have key '%s'", secretID, jsonKey)
+	}
+
+	switch v := field.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode key '%s' of secret '%s': %w", jsonKey, secretID, err)
+		}
+		return string(encoded), nil
+	}
+}
+
+// resolveEnvVariable resolves a parameter from an environment variable, falling back to an optional default
+func (r *StackResolver) resolveEnvVariable(envConfig map[string]string) (string, error) {
+	name, exists := envConfig["name"]
+	if !exists || name == "" {
+		return "", fmt.Errorf("env resolver missing required 'name'")
+	}
+
+	if value, set := os.LookupEnv(name); set {
+		return value, nil
+	}
+	if defaultValue, exists := envConfig["default"]; exists {
+		return defaultValue, nil
+	}
+	return "", fmt.Errorf("environment variable '%s' is not set and no default is provided", name)
+}
+
+// resolveFileParameter resolves a value from a JSON or YAML file using a dotted key path
+func (r *StackResolver) resolveFileParameter(fileConfig map[string]string, files *valuesFiles) (string, error) {
+	path, exists := fileConfig["path"]
+	if !exists || path == "" {
+		return "", fmt.Errorf("file resolver missing required 'path'")
+	}
+	key, exists := fileConfig["key"]
+	if !exists || key == "" {
+		return "", fmt.Errorf("file resolver missing required 'key'")
+	}
+
+	if files == nil {
+		files = newValuesFiles(r.fileSystemResolver)
+	}
+	root, err := files.load(path)
+	if err != nil {
+		return "", err
+	}
+
+	return lookupValue(root, key, path)
+}
+
 // resolveSingleParameter resolves a single parameter value to a string
-func (r *StackResolver) resolveSingleParameter(ctx context.Context, paramValue *config.ParameterValue, contextRegion string) (string, error) {
+func (r *StackResolver) resolveSingleParameter(ctx context.Context, paramValue *config.ParameterValue, contextRegion string, files *valuesFiles, calls *callLog) (string, error) {
 	switch paramValue.ResolutionType {
 	case "literal":
 		if value, exists := paramValue.ResolutionConfig["value"]; exists {
@@ -257,10 +955,28 @@ func (r *StackResolver) resolveSingleParameter(ctx context.Context, paramValue *
 		}
 
 	case "stack-output":
-		return r.resolveStackOutput(ctx, paramValue.ResolutionConfig, contextRegion)
+		return r.resolveStackOutput(ctx, paramValue.ResolutionConfig, contextRegion, calls)
+
+	case "export":
+		return r.resolveExport(ctx, paramValue.ResolutionConfig, contextRegion, calls)
+
+	case "ssm":
+		return r.resolveSSMParameter(ctx, paramValue.ResolutionConfig, contextRegion, calls)
+
+	case "secret":
+		return r.resolveSecret(ctx, paramValue.ResolutionConfig, contextRegion, calls)
+
+	case "env":
+		return r.resolveEnvVariable(paramValue.ResolutionConfig)
+
+	case "file":
+		return r.resolveFileParameter(paramValue.ResolutionConfig, files)
+
+	case "git":
+		return r.resolveGitParameter(paramValue.ResolutionConfig)
 
 	case "list":
-		return r.resolveParameterList(ctx, paramValue.ListItems, contextRegion)
+		return r.resolveParameterList(ctx, paramValue.ListItems, contextRegion, files, calls)
 
 	default:
 		return "", fmt.Errorf("unsupported resolution type '%s'", paramValue.ResolutionType)
@@ -268,7 +984,7 @@ func (r *StackResolver) resolveSingleParameter(ctx context.Context, paramValue *
 }
 
 // resolveParameterList resolves lists with mixed resolution types
-func (r *StackResolver) resolveParameterList(ctx context.Context, listItems []*config.ParameterValue, contextRegion string) (string, error) {
+func (r *StackResolver) resolveParameterList(ctx context.Context, listItems []*config.ParameterValue, contextRegion string, files *valuesFiles, calls *callLog) (string, error) {
 	if len(listItems) == 0 {
 		return "", nil // Empty list becomes empty string
 	}
@@ -283,7 +999,7 @@ func (r *StackResolver) resolveParameterList(ctx context.Context, listItems []*c
 		var resolvedValue string
 		var err error
 
-		resolvedValue, err = r.resolveSingleParameter(ctx, item, contextRegion)
+		resolvedValue, err = r.resolveSingleParameter(ctx, item, contextRegion, files, calls)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve list item %d: %w", i, err)
 		}
@@ -298,6 +1014,36 @@ func (r *StackResolver) resolveParameterList(ctx context.Context, listItems []*c
 	return strings.Join(resolvedValues, ","), nil
 }
 
+// sensitiveParameters returns the names of parameters whose resolved values must not be displayed
+func sensitiveParameters(params map[string]*config.ParameterValue) map[string]bool {
+	var result map[string]bool
+	for key, paramValue := range params {
+		if isSensitive(paramValue) {
+			if result == nil {
+				result = make(map[string]bool)
+			}
+			result[key] = true
+		}
+	}
+	return result
+}
+
+// isSensitive reports whether a parameter, or any item of a list parameter, is resolved from a secret
+func isSensitive(paramValue *config.ParameterValue) bool {
+	if paramValue == nil {
+		return false
+	}
+	if paramValue.ResolutionType == "secret" {
+		return true
+	}
+	for _, item := range paramValue.ListItems {
+		if isSensitive(item) {
+			return true
+		}
+	}
+	return false
+}
+
 // mergeTags merges tags with inheritance
 func (r *StackResolver) mergeTags(globalTags, stackTags map[string]string) map[string]string {
 	result := make(map[string]string)
@@ -316,12 +1062,13 @@ func (r *StackResolver) mergeTags(globalTags, stackTags map[string]string) map[s
 }
 
 // buildTemplateVariables creates the variable map for template processing
-func (r *StackResolver) buildTemplateVariables(stackConfig *config.StackConfig, context string) map[string]interface{} {
+func (r *StackResolver) buildTemplateVariables(stackConfig *config.StackConfig, context string, exports bool) map[string]interface{} {
 	variables := make(map[string]interface{})
 
 	// Add context information
 	variables["Context"] = context
 	variables["StackName"] = stackConfig.Name
+	variables["Exports"] = exports
 
 	return variables
 }
@@ -5,12 +5,19 @@ SPDX-License-Identifier: BSD-3-Clause
 package resolve
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"codeberg.org/orien/stackaroo/internal/aws"
 	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -83,6 +90,11 @@ func TestStackResolver_ResolveStack_Success(t *testing.T) {
 
 	templateContent := `{
 		"AWSTemplateFormatVersion": "2010-09-09",
+		"Parameters": {
+			"VpcCidr": {
+				"Type": "String"
+			}
+		},
 		"Resources": {
 			"VPC": {
 				"Type": "AWS::EC2::VPC"
@@ -121,6 +133,689 @@ func TestStackResolver_ResolveStack_Success(t *testing.T) {
 	mockTemplateProcessor.AssertExpectations(t)
 }
 
+func TestStackResolver_ResolveStack_TemplateOverride(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	configuredTemplate := "Resources:\n  Queue:\n    Type: AWS::SQS::Queue\n"
+	overrideTemplate := "Resources:\n  Queue:\n    Type: AWS::SQS::Queue\n  Topic:\n    Type: AWS::SNS::Topic\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "configured.yaml"), []byte(configuredTemplate), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "override.yaml"), []byte(overrideTemplate), 0644))
+
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	cfg := &config.Config{
+		Project: "test-project",
+		Context: &config.ContextConfig{Name: "dev", Region: "us-east-1"},
+	}
+	configuredURI := "file://" + filepath.Join(tmpDir, "configured.yaml")
+	mockConfigProvider.On("LoadConfig", ctx, "dev").Return(cfg, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app", Template: configuredURI}, nil)
+	mockConfigProvider.On("GetStack", "worker", "dev").Return(&config.StackConfig{Name: "worker", Template: configuredURI}, nil)
+
+	// Configured templates go through the config's resolver, such as the fetcher of a remote
+	// configuration, while the override is always read from the local file system
+	mockFileSystemResolver := &MockFileSystemResolver{}
+	mockFileSystemResolver.On("Resolve", configuredURI).Return(configuredTemplate, nil)
+
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+	stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+	stackResolver.SetTemplateOverride("app", "file://"+filepath.Join(tmpDir, "override.yaml"))
+
+	app, err := stackResolver.ResolveStack(ctx, "dev", "app")
+	require.NoError(t, err)
+	assert.Equal(t, overrideTemplate, app.TemplateBody)
+	assert.False(t, app.UsePreviousTemplate)
+
+	// Other stacks keep their configured template
+	worker, err := stackResolver.ResolveStack(ctx, "dev", "worker")
+	require.NoError(t, err)
+	assert.Equal(t, configuredTemplate, worker.TemplateBody)
+	mockFileSystemResolver.AssertExpectations(t)
+}
+
+func TestStackResolver_ResolveStack_StackPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        string
+		expectedError string
+	}{
+		{
+			name:   "valid policy is attached",
+			policy: `{"Statement":[{"Effect":"Deny","Action":"Update:Replace","Principal":"*","Resource":"LogicalResourceId/Database"}]}`,
+		},
+		{
+			name:          "invalid JSON is rejected",
+			policy:        `{"Statement": [`,
+			expectedError: "stack policy for stack database is not valid JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockFileSystemResolver := &MockFileSystemResolver{}
+			mockTemplateProcessor := &MockTemplateProcessor{}
+			mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			cfg := &config.Config{
+				Project: "test-project",
+				Context: &config.ContextConfig{Name: "prod", Region: "us-east-1"},
+			}
+			stackConfig := &config.StackConfig{
+				Name:        "database",
+				Template:    "templates/rds.yaml",
+				StackPolicy: "policies/rds.json",
+			}
+
+			mockConfigProvider.On("LoadConfig", ctx, "prod").Return(cfg, nil)
+			mockConfigProvider.On("GetStack", "database", "prod").Return(stackConfig, nil)
+			mockFileSystemResolver.On("Resolve", "templates/rds.yaml").Return("template", nil)
+			mockFileSystemResolver.On("Resolve", "policies/rds.json").Return(tt.policy, nil)
+			mockTemplateProcessor.On("Process", "template", mock.Anything).Return("template", nil)
+
+			stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+			stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+			stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+
+			resolved, err := stackResolver.ResolveStack(ctx, "prod", "database")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.policy, resolved.StackPolicyBody)
+		})
+	}
+}
+
+func TestStackResolver_ResolveStack_NotificationARNs(t *testing.T) {
+	tests := []struct {
+		name          string
+		arns          []string
+		expectedError string
+	}{
+		{
+			name: "SNS topic ARNs are passed through",
+			arns: []string{"arn:aws:sns:us-east-1:123456789012:stack-events", "arn:aws-us-gov:sns:us-gov-west-1:123456789012:ops.fifo"},
+		},
+		{
+			name:          "malformed ARN is rejected",
+			arns:          []string{"stack-events"},
+			expectedError: `notification ARN "stack-events" for stack database is not an SNS topic ARN`,
+		},
+		{
+			name:          "non-SNS ARN is rejected",
+			arns:          []string{"arn:aws:sqs:us-east-1:123456789012:events"},
+			expectedError: "is not an SNS topic ARN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockFileSystemResolver := &MockFileSystemResolver{}
+			mockTemplateProcessor := &MockTemplateProcessor{}
+			mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			cfg := &config.Config{
+				Project: "test-project",
+				Context: &config.ContextConfig{Name: "prod", Region: "us-east-1"},
+			}
+			stackConfig := &config.StackConfig{
+				Name:             "database",
+				Template:         "templates/rds.yaml",
+				NotificationARNs: tt.arns,
+			}
+
+			mockConfigProvider.On("LoadConfig", ctx, "prod").Return(cfg, nil)
+			mockConfigProvider.On("GetStack", "database", "prod").Return(stackConfig, nil)
+			mockFileSystemResolver.On("Resolve", "templates/rds.yaml").Return("template", nil)
+			mockTemplateProcessor.On("Process", "template", mock.Anything).Return("template", nil)
+
+			stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+			stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+			stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+
+			resolved, err := stackResolver.ResolveStack(ctx, "prod", "database")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.arns, resolved.NotificationARNs)
+		})
+	}
+}
+
+func TestStackResolver_ResolveStack_Rollback(t *testing.T) {
+	alarm := "arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-5xx"
+	tests := []struct {
+		name          string
+		rollback      *config.RollbackConfig
+		expected      *model.RollbackConfiguration
+		expectedError string
+	}{
+		{
+			name:     "alarms and monitoring time are passed through",
+			rollback: &config.RollbackConfig{AlarmARNs: []string{alarm}, MonitoringTimeInMinutes: int32Ptr(10)},
+			expected: &model.RollbackConfiguration{AlarmARNs: []string{alarm}, MonitoringTimeInMinutes: int32Ptr(10)},
+		},
+		{
+			name:     "no rollback configuration leaves it unset",
+			rollback: nil,
+			expected: nil,
+		},
+		{
+			name:          "non-alarm ARN is rejected",
+			rollback:      &config.RollbackConfig{AlarmARNs: []string{"arn:aws:sns:us-east-1:123456789012:events"}},
+			expectedError: `rollback for stack database: "arn:aws:sns:us-east-1:123456789012:events" is not a CloudWatch alarm ARN`,
+		},
+		{
+			name:          "more than five alarms are rejected",
+			rollback:      &config.RollbackConfig{AlarmARNs: []string{alarm, alarm, alarm, alarm, alarm, alarm}},
+			expectedError: "at most 5 alarms can be given, not 6",
+		},
+		{
+			name:          "monitoring time beyond three hours is rejected",
+			rollback:      &config.RollbackConfig{AlarmARNs: []string{alarm}, MonitoringTimeInMinutes: int32Ptr(181)},
+			expectedError: "monitoring_minutes must be between 0 and 180",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockFileSystemResolver := &MockFileSystemResolver{}
+			mockTemplateProcessor := &MockTemplateProcessor{}
+			mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			cfg := &config.Config{
+				Project: "test-project",
+				Context: &config.ContextConfig{Name: "prod", Region: "us-east-1"},
+			}
+			stackConfig := &config.StackConfig{
+				Name:     "database",
+				Template: "templates/rds.yaml",
+				Rollback: tt.rollback,
+			}
+
+			mockConfigProvider.On("LoadConfig", ctx, "prod").Return(cfg, nil)
+			mockConfigProvider.On("GetStack", "database", "prod").Return(stackConfig, nil)
+			mockFileSystemResolver.On("Resolve", "templates/rds.yaml").Return("template", nil)
+			mockTemplateProcessor.On("Process", "template", mock.Anything).Return("template", nil)
+
+			stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+			stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+			stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+
+			resolved, err := stackResolver.ResolveStack(ctx, "prod", "database")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved.Rollback)
+		})
+	}
+}
+
+func TestStackResolver_ResolveStack_AllowedResourceTypes(t *testing.T) {
+	tests := []struct {
+		name          string
+		resourceTypes []string
+		expectedError string
+	}{
+		{
+			name:          "types and glob patterns are passed through",
+			resourceTypes: []string{"AWS::S3::*", "AWS::SQS::Queue", "AWS::*", "Custom::*", "Custom::Seeder"},
+		},
+		{
+			name:          "no allow-list permits every type",
+			resourceTypes: nil,
+		},
+		{
+			name:          "glob inside a service name is rejected",
+			resourceTypes: []string{"AWS::S*::Bucket"},
+			expectedError: `allowed_resource_types for stack database: invalid resource type "AWS::S*::Bucket"`,
+		},
+		{
+			name:          "bare service is rejected",
+			resourceTypes: []string{"AWS::S3"},
+			expectedError: `invalid resource type "AWS::S3"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockFileSystemResolver := &MockFileSystemResolver{}
+			mockTemplateProcessor := &MockTemplateProcessor{}
+			mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			cfg := &config.Config{
+				Project: "test-project",
+				Context: &config.ContextConfig{Name: "prod", Region: "us-east-1"},
+			}
+			stackConfig := &config.StackConfig{
+				Name:                 "database",
+				Template:             "templates/rds.yaml",
+				AllowedResourceTypes: tt.resourceTypes,
+			}
+
+			mockConfigProvider.On("LoadConfig", ctx, "prod").Return(cfg, nil)
+			mockConfigProvider.On("GetStack", "database", "prod").Return(stackConfig, nil)
+			mockFileSystemResolver.On("Resolve", "templates/rds.yaml").Return("template", nil)
+			mockTemplateProcessor.On("Process", "template", mock.Anything).Return("template", nil)
+
+			stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+			stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+			stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+
+			resolved, err := stackResolver.ResolveStack(ctx, "prod", "database")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.resourceTypes, resolved.ResourceTypes)
+		})
+	}
+}
+
+func TestStackResolver_ResolveStack_OnFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		onFailure     string
+		expectedError string
+	}{
+		{name: "unset", onFailure: ""},
+		{name: "DO_NOTHING is passed through", onFailure: "DO_NOTHING"},
+		{name: "unknown action is rejected", onFailure: "KEEP", expectedError: `stack database: invalid on_failure value "KEEP"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockFileSystemResolver := &MockFileSystemResolver{}
+			mockTemplateProcessor := &MockTemplateProcessor{}
+			mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			cfg := &config.Config{
+				Project: "test-project",
+				Context: &config.ContextConfig{Name: "prod", Region: "us-east-1"},
+			}
+			stackConfig := &config.StackConfig{
+				Name:      "database",
+				Template:  "templates/rds.yaml",
+				OnFailure: tt.onFailure,
+			}
+
+			mockConfigProvider.On("LoadConfig", ctx, "prod").Return(cfg, nil)
+			mockConfigProvider.On("GetStack", "database", "prod").Return(stackConfig, nil)
+			mockFileSystemResolver.On("Resolve", "templates/rds.yaml").Return("template", nil)
+			mockTemplateProcessor.On("Process", "template", mock.Anything).Return("template", nil)
+
+			stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+			stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+			stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+
+			resolved, err := stackResolver.ResolveStack(ctx, "prod", "database")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.onFailure, resolved.OnFailure)
+		})
+	}
+}
+
+func TestStackResolver_ResolveStack_TimeoutInMinutes(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeout       *int32
+		expectedError string
+	}{
+		{name: "unset"},
+		{name: "positive timeout is passed through", timeout: int32Ptr(20)},
+		{name: "zero is rejected", timeout: int32Ptr(0), expectedError: "timeout_minutes for stack database must be at least 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockFileSystemResolver := &MockFileSystemResolver{}
+			mockTemplateProcessor := &MockTemplateProcessor{}
+			mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			cfg := &config.Config{
+				Project: "test-project",
+				Context: &config.ContextConfig{Name: "prod", Region: "us-east-1"},
+			}
+			stackConfig := &config.StackConfig{
+				Name:             "database",
+				Template:         "templates/rds.yaml",
+				TimeoutInMinutes: tt.timeout,
+			}
+
+			mockConfigProvider.On("LoadConfig", ctx, "prod").Return(cfg, nil)
+			mockConfigProvider.On("GetStack", "database", "prod").Return(stackConfig, nil)
+			mockFileSystemResolver.On("Resolve", "templates/rds.yaml").Return("template", nil)
+			mockTemplateProcessor.On("Process", "template", mock.Anything).Return("template", nil)
+
+			stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+			stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+			stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+
+			resolved, err := stackResolver.ResolveStack(ctx, "prod", "database")
+
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.timeout, resolved.TimeoutInMinutes)
+		})
+	}
+}
+
+// int32Ptr returns a pointer to the given value
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestStackResolver_ResolveStack_AWSOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       map[string]interface{}
+		expectedError string
+		check         func(t *testing.T, stack *model.Stack)
+	}{
+		{
+			name: "supported options are forwarded",
+			options: map[string]interface{}{
+				"ResourceTypes":               []interface{}{"AWS::S3::*", "AWS::RDS::DBInstance"},
+				"ClientRequestToken":          "deploy-42",
+				"EnableTerminationProtection": true,
+			},
+			check: func(t *testing.T, stack *model.Stack) {
+				assert.Equal(t, []string{"AWS::S3::*", "AWS::RDS::DBInstance"}, stack.ResourceTypes)
+				assert.Equal(t, "deploy-42", stack.ClientRequestToken)
+				require.NotNil(t, stack.TerminationProtection)
+				assert.True(t, *stack.TerminationProtection)
+			},
+		},
+		{
+			name:          "unknown option is rejected",
+			options:       map[string]interface{}{"RoleARN": "arn:aws:iam::123456789012:role/deploy"},
+			expectedError: `invalid aws_options for stack database: unsupported field "RoleARN" (supported: ClientRequestToken, EnableTerminationProtection, ResourceTypes)`,
+		},
+		{
+			name:          "wrongly typed option is rejected",
+			options:       map[string]interface{}{"ResourceTypes": "AWS::S3::*"},
+			expectedError: "ResourceTypes must be a list of resource types",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockFileSystemResolver := &MockFileSystemResolver{}
+			mockTemplateProcessor := &MockTemplateProcessor{}
+			mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			cfg := &config.Config{
+				Project: "test-project",
+				Context: &config.ContextConfig{Name: "prod", Region: "us-east-1"},
+			}
+			stackConfig := &config.StackConfig{
+				Name:       "database",
+				Template:   "templates/rds.yaml",
+				AWSOptions: tt.options,
+			}
+
+			mockConfigProvider.On("LoadConfig", ctx, "prod").Return(cfg, nil)
+			mockConfigProvider.On("GetStack", "database", "prod").Return(stackConfig, nil)
+			mockFileSystemResolver.On("Resolve", "templates/rds.yaml").Return("template", nil)
+			mockTemplateProcessor.On("Process", "template", mock.Anything).Return("template", nil)
+
+			stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+			stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+			stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+
+			resolved, err := stackResolver.ResolveStack(ctx, "prod", "database")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, resolved)
+		})
+	}
+}
+
+func TestStackResolver_ResolveStack_TransformAddsAutoExpand(t *testing.T) {
+	tests := []struct {
+		name                 string
+		template             string
+		capabilities         []string
+		expectedCapabilities []string
+		expectNotice         bool
+	}{
+		{
+			name:                 "YAML template with transform",
+			template:             "Transform: AWS::Serverless-2016-10-31\nResources: {}\n",
+			expectedCapabilities: []string{"CAPABILITY_IAM", "CAPABILITY_AUTO_EXPAND"},
+			expectNotice:         true,
+		},
+		{
+			name:                 "JSON template with transform list",
+			template:             `{"Transform": ["AWS::Include"], "Resources": {}}`,
+			capabilities:         []string{"CAPABILITY_NAMED_IAM"},
+			expectedCapabilities: []string{"CAPABILITY_NAMED_IAM", "CAPABILITY_AUTO_EXPAND"},
+			expectNotice:         true,
+		},
+		{
+			name:                 "transform with capability already declared",
+			template:             "Transform: AWS::Serverless-2016-10-31\nResources: {}\n",
+			capabilities:         []string{"CAPABILITY_IAM", "CAPABILITY_AUTO_EXPAND"},
+			expectedCapabilities: []string{"CAPABILITY_IAM", "CAPABILITY_AUTO_EXPAND"},
+		},
+		{
+			name:                 "YAML template without transform",
+			template:             "Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n",
+			capabilities:         []string{"CAPABILITY_IAM"},
+			expectedCapabilities: []string{"CAPABILITY_IAM"},
+		},
+		{
+			name:     "JSON template without transform",
+			template: `{"Resources": {"Bucket": {"Type": "AWS::S3::Bucket"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockFileSystemResolver := &MockFileSystemResolver{}
+			mockTemplateProcessor := &MockTemplateProcessor{}
+			mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			cfg := &config.Config{
+				Project: "test-project",
+				Context: &config.ContextConfig{Name: "dev", Region: "us-east-1"},
+			}
+			stackConfig := &config.StackConfig{
+				Name:         "api",
+				Template:     "templates/api.yaml",
+				Capabilities: tt.capabilities,
+			}
+
+			mockConfigProvider.On("LoadConfig", ctx, "dev").Return(cfg, nil)
+			mockConfigProvider.On("GetStack", "api", "dev").Return(stackConfig, nil)
+			mockFileSystemResolver.On("Resolve", "templates/api.yaml").Return(tt.template, nil)
+			mockTemplateProcessor.On("Process", tt.template, mock.Anything).Return(tt.template, nil)
+
+			var notices bytes.Buffer
+			stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+			stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+			stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+			stackResolver.notices = &notices
+
+			resolved, err := stackResolver.ResolveStack(ctx, "dev", "api")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCapabilities, resolved.Capabilities)
+			if tt.expectNotice {
+				assert.Equal(t, "Added CAPABILITY_AUTO_EXPAND to stack api because its template declares a Transform\n", notices.String())
+			} else {
+				assert.Empty(t, notices.String())
+			}
+			// The configured capabilities are left untouched
+			assert.Equal(t, tt.capabilities, stackConfig.Capabilities)
+		})
+	}
+}
+
+func TestStackResolver_ResolveStack_S3Template(t *testing.T) {
+	const template = "Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n"
+
+	tests := []struct {
+		name        string
+		processed   string
+		expectedURL string
+	}{
+		{
+			name:        "unchanged by processing is deployed from its URL",
+			processed:   template,
+			expectedURL: "https://templates.s3.us-east-1.amazonaws.com/network/vpc.yaml",
+		},
+		{
+			name:      "changed by processing is deployed from its body",
+			processed: "Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n    Properties:\n      BucketName: dev\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockFileSystemResolver := &MockFileSystemResolver{}
+			mockTemplateProcessor := &MockTemplateProcessor{}
+			mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+			mockS3 := &aws.MockS3Operations{}
+			mockFactory.SetS3Operations("us-east-1", mockS3)
+
+			cfg := &config.Config{
+				Project: "test-project",
+				Context: &config.ContextConfig{Name: "dev", Region: "us-east-1"},
+			}
+			stackConfig := &config.StackConfig{
+				Name:     "vpc",
+				Template: "s3://templates/network/vpc.yaml",
+			}
+
+			mockConfigProvider.On("LoadConfig", ctx, "dev").Return(cfg, nil)
+			mockConfigProvider.On("GetStack", "vpc", "dev").Return(stackConfig, nil)
+			mockS3.On("GetObject", ctx, "templates", "network/vpc.yaml").Return(template, nil)
+			mockTemplateProcessor.On("Process", template, mock.Anything).Return(tt.processed, nil)
+
+			stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+			stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+			stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+
+			resolved, err := stackResolver.ResolveStack(ctx, "dev", "vpc")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.processed, resolved.TemplateBody)
+			assert.Equal(t, tt.expectedURL, resolved.TemplateURL)
+			mockS3.AssertExpectations(t)
+			mockFileSystemResolver.AssertNotCalled(t, "Resolve", mock.Anything)
+		})
+	}
+}
+
+func TestStackResolver_ResolveStack_S3TemplateNotFound(t *testing.T) {
+	ctx := context.Background()
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockS3 := &aws.MockS3Operations{}
+	mockFactory.SetS3Operations("us-east-1", mockS3)
+
+	cfg := &config.Config{
+		Project: "test-project",
+		Context: &config.ContextConfig{Name: "dev", Region: "us-east-1"},
+	}
+	stackConfig := &config.StackConfig{Name: "vpc", Template: "s3://templates/missing.yaml"}
+
+	mockConfigProvider.On("LoadConfig", ctx, "dev").Return(cfg, nil)
+	mockConfigProvider.On("GetStack", "vpc", "dev").Return(stackConfig, nil)
+	mockS3.On("GetObject", ctx, "templates", "missing.yaml").
+		Return("", aws.ObjectNotFoundError{Bucket: "templates", Key: "missing.yaml"})
+
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+	_, err := stackResolver.ResolveStack(ctx, "dev", "vpc")
+
+	require.Error(t, err)
+	assert.Equal(t, "failed to read template s3://templates/missing.yaml: S3 object s3://templates/missing.yaml not found", err.Error())
+}
+
+func TestStackResolver_ResolveStack_ContextValuesFile(t *testing.T) {
+	ctx := context.Background()
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFileSystemResolver := &MockFileSystemResolver{}
+	mockTemplateProcessor := &MockTemplateProcessor{}
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	template := "Parameters:\n  InstanceType:\n    Type: String\n  DesiredCount:\n    Type: Number\n"
+	cfg := &config.Config{
+		Project: "test-project",
+		Context: &config.ContextConfig{Name: "dev", Region: "us-east-1", ValuesFile: "file:///config/dev.values.yaml"},
+	}
+	stackConfig := &config.StackConfig{
+		Name:       "app",
+		Template:   "templates/app.yaml",
+		Parameters: convertStringMapToParameterValues(map[string]string{"DesiredCount": "3"}),
+	}
+
+	mockConfigProvider.On("LoadConfig", ctx, "dev").Return(cfg, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(stackConfig, nil)
+	mockFileSystemResolver.On("Resolve", "templates/app.yaml").Return(template, nil)
+	mockFileSystemResolver.On("Resolve", "file:///config/dev.values.yaml").Return("InstanceType: t3.small\nDesiredCount: 1\nVpcId: vpc-123\n", nil)
+	mockTemplateProcessor.On("Process", template, mock.Anything).Return(template, nil)
+
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+	stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+	stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+
+	resolved, err := stackResolver.ResolveStack(ctx, "dev", "app")
+
+	require.NoError(t, err)
+	// Stack parameters take precedence and keys the template does not declare are ignored
+	assert.Equal(t, map[string]string{"InstanceType": "t3.small", "DesiredCount": "3"}, resolved.Parameters)
+	assert.Len(t, stackConfig.Parameters, 1, "the stack configuration should not be modified")
+}
+
 func TestStackResolver_ResolveParameters_LiteralValues(t *testing.T) {
 	// Test resolution of literal parameter values
 	ctx := context.Background()
@@ -200,6 +895,93 @@ func TestStackResolver_ResolveParameters_StackOutputs(t *testing.T) {
 	mockCfnOps.AssertExpectations(t)
 }
 
+func TestStackResolver_ResolveParameters_CrossRegionStackOutput(t *testing.T) {
+	// A stack deployed to us-west-2 reads a certificate from a stack in us-east-1
+	ctx := context.Background()
+
+	mockFactory := aws.NewMockClientFactory()
+	westOps := &aws.MockCloudFormationOperations{}
+	eastOps := &aws.MockCloudFormationOperations{}
+	mockFactory.SetOperations("us-west-2", westOps)
+	mockFactory.SetOperations("us-east-1", eastOps)
+	resolver := NewStackResolver(&config.MockConfigProvider{}, mockFactory)
+
+	eastOps.On("GetStack", ctx, "certificates").Return(&aws.Stack{
+		Name:    "certificates",
+		Outputs: map[string]string{"CertificateArn": "arn:aws:acm:us-east-1:123456789012:certificate/abc"},
+	}, nil)
+	westOps.On("GetStack", ctx, "vpc").Return(&aws.Stack{
+		Name:    "vpc",
+		Outputs: map[string]string{"VpcId": "vpc-west"},
+	}, nil)
+
+	params := map[string]*config.ParameterValue{
+		"CertificateArn": {
+			ResolutionType: "stack-output",
+			ResolutionConfig: map[string]string{
+				"stack":  "certificates",
+				"output": "CertificateArn",
+				"region": "us-east-1",
+			},
+		},
+		"VpcId": {
+			ResolutionType: "stack-output",
+			ResolutionConfig: map[string]string{
+				"stack":  "vpc",
+				"output": "VpcId",
+			},
+		},
+	}
+
+	resolved, err := resolver.resolveParameters(ctx, params, "us-west-2")
+
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:acm:us-east-1:123456789012:certificate/abc", resolved["CertificateArn"])
+	assert.Equal(t, "vpc-west", resolved["VpcId"])
+	eastOps.AssertExpectations(t)
+	westOps.AssertExpectations(t)
+	westOps.AssertNotCalled(t, "GetStack", ctx, "certificates")
+}
+
+func TestStackResolver_ResolveStackOutput_RecordedOutputs(t *testing.T) {
+	ctx := context.Background()
+
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	resolver := NewStackResolver(mockConfigProvider, mockFactory)
+	resolver.RecordStackOutputs("us-east-1", "vpc-stack", map[string]string{"VpcId": "vpc-12345"})
+
+	t.Run("recorded stack is not described", func(t *testing.T) {
+		value, err := resolver.resolveStackOutput(ctx, map[string]string{"stack": "vpc-stack", "output": "VpcId"}, "us-east-1", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "vpc-12345", value)
+		mockCfnOps.AssertNotCalled(t, "GetStack", mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing recorded output", func(t *testing.T) {
+		_, err := resolver.resolveStackOutput(ctx, map[string]string{"stack": "vpc-stack", "output": "SubnetId"}, "us-east-1", nil)
+
+		require.Error(t, err)
+		mockCfnOps.AssertNotCalled(t, "GetStack", mock.Anything, mock.Anything)
+	})
+
+	t.Run("same stack name in another region is described", func(t *testing.T) {
+		westFactory := aws.NewMockClientFactory()
+		westOps := &aws.MockCloudFormationOperations{}
+		westFactory.SetOperations("us-west-2", westOps)
+		westOps.On("GetStack", ctx, "vpc-stack").Return(&aws.Stack{Name: "vpc-stack", Outputs: map[string]string{"VpcId": "vpc-west"}}, nil)
+		westResolver := NewStackResolver(mockConfigProvider, westFactory)
+		westResolver.RecordStackOutputs("us-east-1", "vpc-stack", map[string]string{"VpcId": "vpc-12345"})
+
+		value, err := westResolver.resolveStackOutput(ctx, map[string]string{"stack": "vpc-stack", "output": "VpcId"}, "us-west-2", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "vpc-west", value)
+		westOps.AssertExpectations(t)
+	})
+}
+
 func TestStackResolver_ResolveParameters_MixedTypes(t *testing.T) {
 	// Test resolution of mixed literal and output parameters
 	ctx := context.Background()
@@ -216,21 +998,716 @@ func TestStackResolver_ResolveParameters_MixedTypes(t *testing.T) {
 		},
 	}
 
-	mockCfnOps.On("GetStack", ctx, "networking").Return(mockStack, nil)
+	mockCfnOps.On("GetStack", ctx, "networking").Return(mockStack, nil)
+
+	// Test mixed parameter types
+	params := map[string]*config.ParameterValue{
+		"Environment": {
+			ResolutionType: "literal",
+			ResolutionConfig: map[string]string{
+				"value": "staging",
+			},
+		},
+		"VpcId": {
+			ResolutionType: "stack-output",
+			ResolutionConfig: map[string]string{
+				"stack":  "networking",
+				"output": "VpcId",
+			},
+		},
+	}
+
+	resolved, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+	require.NoError(t, err)
+	assert.Len(t, resolved, 2)
+	assert.Equal(t, "staging", resolved["Environment"])
+	assert.Equal(t, "vpc-abcdef", resolved["VpcId"])
+
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestStackResolver_ResolveParameters_ErrorCases(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unsupported resolution type", func(t *testing.T) {
+		mockConfigProvider := &config.MockConfigProvider{}
+		mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+		resolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+		params := map[string]*config.ParameterValue{
+			"BadParam": {
+				ResolutionType: "unsupported",
+				ResolutionConfig: map[string]string{
+					"something": "value",
+				},
+			},
+		}
+
+		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported resolution type 'unsupported'")
+	})
+
+	t.Run("literal missing value", func(t *testing.T) {
+		mockConfigProvider := &config.MockConfigProvider{}
+		mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+		resolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+		params := map[string]*config.ParameterValue{
+			"BadLiteral": {
+				ResolutionType:   "literal",
+				ResolutionConfig: map[string]string{
+					// Missing "value" key
+				},
+			},
+		}
+
+		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "literal parameter missing 'value' config")
+	})
+
+	t.Run("stack not found", func(t *testing.T) {
+		mockConfigProvider := &config.MockConfigProvider{}
+		mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+		resolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+		mockCfnOps.On("GetStack", ctx, "missing-stack").Return(nil, fmt.Errorf("stack not found"))
+
+		params := map[string]*config.ParameterValue{
+			"VpcId": {
+				ResolutionType: "stack-output",
+				ResolutionConfig: map[string]string{
+					"stack":  "missing-stack",
+					"output": "VpcId",
+				},
+			},
+		}
+
+		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get stack 'missing-stack'")
+		assert.Contains(t, err.Error(), "failed to get stack 'missing-stack'")
+
+		mockCfnOps.AssertExpectations(t)
+	})
+
+	t.Run("output key not found", func(t *testing.T) {
+		mockConfigProvider := &config.MockConfigProvider{}
+		mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+		resolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+		mockStack := &aws.Stack{
+			Name: "vpc-stack",
+			Outputs: map[string]string{
+				"VpcId": "vpc-12345",
+			},
+		}
+		mockCfnOps.On("GetStack", ctx, "vpc-stack").Return(mockStack, nil)
+
+		params := map[string]*config.ParameterValue{
+			"BadOutput": {
+				ResolutionType: "stack-output",
+				ResolutionConfig: map[string]string{
+					"stack":  "vpc-stack",
+					"output": "MissingOutput",
+				},
+			},
+		}
+
+		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "stack 'vpc-stack' does not have output 'MissingOutput'")
+		assert.Contains(t, err.Error(), "stack 'vpc-stack' does not have output 'MissingOutput'")
+
+		mockCfnOps.AssertExpectations(t)
+	})
+}
+
+func TestStackResolver_ResolveParameters_ManyResolversMatchSerialResults(t *testing.T) {
+	// Test that resolving more parameters than there are workers gives the same result as resolving each in turn
+	ctx := context.Background()
+
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	resolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+	outputs := make(map[string]string)
+	params := make(map[string]*config.ParameterValue)
+	for i := 0; i < maxParameterWorkers*3; i++ {
+		outputKey := fmt.Sprintf("Output%02d", i)
+		outputs[outputKey] = fmt.Sprintf("value-%02d", i)
+		params[fmt.Sprintf("Param%02d", i)] = &config.ParameterValue{
+			ResolutionType: "stack-output",
+			ResolutionConfig: map[string]string{
+				"stack":  "shared-stack",
+				"output": outputKey,
+			},
+		}
+	}
+	params["Literal"] = &config.ParameterValue{
+		ResolutionType:   "literal",
+		ResolutionConfig: map[string]string{"value": "plain"},
+	}
+	mockCfnOps.On("GetStack", ctx, "shared-stack").Return(&aws.Stack{Name: "shared-stack", Outputs: outputs}, nil)
+
+	expected := make(map[string]string, len(params))
+	for key, paramValue := range params {
+		value, err := resolver.resolveSingleParameter(ctx, paramValue, "us-east-1", nil, nil)
+		require.NoError(t, err)
+		expected[key] = value
+	}
+
+	resolved, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, resolved)
+}
+
+func TestStackResolver_ResolveParameters_ManyResolversSurfacesError(t *testing.T) {
+	// Test that a failure in one of many concurrently resolved parameters is reported
+	ctx := context.Background()
+
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	resolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+	params := make(map[string]*config.ParameterValue)
+	for i := 0; i < maxParameterWorkers*2; i++ {
+		params[fmt.Sprintf("Param%02d", i)] = &config.ParameterValue{
+			ResolutionType:   "literal",
+			ResolutionConfig: map[string]string{"value": "ok"},
+		}
+	}
+	params["Broken"] = &config.ParameterValue{
+		ResolutionType: "stack-output",
+		ResolutionConfig: map[string]string{
+			"stack":  "missing-stack",
+			"output": "VpcId",
+		},
+	}
+	mockCfnOps.On("GetStack", ctx, "missing-stack").Return(nil, fmt.Errorf("stack not found"))
+
+	resolved, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+	require.Error(t, err)
+	assert.Nil(t, resolved)
+	assert.Contains(t, err.Error(), "failed to resolve parameter 'Broken'")
+	assert.Contains(t, err.Error(), "stack not found")
+}
+
+// setupSelfReferenceResolution prepares a bucket stack whose parameters read the stack's own outputs
+func setupSelfReferenceResolution(t *testing.T, ctx context.Context) (*StackResolver, *aws.MockCloudFormationOperations) {
+	t.Helper()
+
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFileSystemResolver := &MockFileSystemResolver{}
+	mockTemplateProcessor := &MockTemplateProcessor{}
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	cfg := &config.Config{
+		Project: "test-project",
+		Context: &config.ContextConfig{Name: "dev", Region: "us-east-1"},
+	}
+	stackConfig := &config.StackConfig{
+		Name:     "bucket",
+		Template: "templates/bucket.yaml",
+		Parameters: map[string]*config.ParameterValue{
+			"BucketName": {
+				ResolutionType: "stack-output",
+				ResolutionConfig: map[string]string{
+					"stack":  "bucket",
+					"output": "BucketName",
+				},
+			},
+			"KeyArn": {
+				ResolutionType: "stack-output",
+				ResolutionConfig: map[string]string{
+					"stack":   "bucket",
+					"output":  "KeyArn",
+					"default": "none",
+				},
+			},
+		},
+	}
+
+	mockConfigProvider.On("LoadConfig", ctx, "dev").Return(cfg, nil)
+	mockConfigProvider.On("GetStack", "bucket", "dev").Return(stackConfig, nil)
+	mockFileSystemResolver.On("Resolve", "templates/bucket.yaml").Return("template", nil)
+	mockTemplateProcessor.On("Process", "template", mock.Anything).Return("template", nil)
+
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+	stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+	stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+	return stackResolver, mockCfnOps
+}
+
+func TestStackResolver_ResolveStack_SelfReference_UsesPreviousOutputsOnUpdate(t *testing.T) {
+	ctx := context.Background()
+	stackResolver, mockCfnOps := setupSelfReferenceResolution(t, ctx)
+
+	mockCfnOps.On("StackExists", ctx, "bucket").Return(true, nil)
+	mockCfnOps.On("GetStack", ctx, "bucket").Return(&aws.Stack{
+		Name: "bucket",
+		Outputs: map[string]string{
+			"BucketName": "bucket-abc123",
+			"KeyArn":     "arn:aws:kms:us-east-1:123456789012:key/abc",
+		},
+	}, nil)
+
+	resolved, err := stackResolver.ResolveStack(ctx, "dev", "bucket")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"BucketName": "bucket-abc123",
+		"KeyArn":     "arn:aws:kms:us-east-1:123456789012:key/abc",
+	}, resolved.Parameters)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestStackResolver_ResolveStack_SelfReference_FallsBackOnCreate(t *testing.T) {
+	ctx := context.Background()
+	stackResolver, mockCfnOps := setupSelfReferenceResolution(t, ctx)
+
+	mockCfnOps.On("StackExists", ctx, "bucket").Return(false, nil)
+
+	resolved, err := stackResolver.ResolveStack(ctx, "dev", "bucket")
+
+	// BucketName has no default so is omitted; KeyArn uses its default
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"KeyArn": "none"}, resolved.Parameters)
+	mockCfnOps.AssertNotCalled(t, "GetStack", mock.Anything, mock.Anything)
+}
+
+func TestStackResolver_ResolveStack_StackNamePrefixAndSuffix(t *testing.T) {
+	ctx := context.Background()
+
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFileSystemResolver := &MockFileSystemResolver{}
+	mockTemplateProcessor := &MockTemplateProcessor{}
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	cfg := &config.Config{
+		Project: "test-project",
+		Context: &config.ContextConfig{Name: "dev", Region: "us-east-1", StackNamePrefix: "dev-", StackNameSuffix: "-blue"},
+	}
+	stackConfig := &config.StackConfig{
+		Name:     "app",
+		Template: "templates/app.yaml",
+		Parameters: map[string]*config.ParameterValue{
+			"VpcId": {
+				ResolutionType:   "stack-output",
+				ResolutionConfig: map[string]string{"stack": "vpc", "output": "VpcId"},
+			},
+			"ZoneId": {
+				ResolutionType:   "stack-output",
+				ResolutionConfig: map[string]string{"stack": "shared-dns", "output": "ZoneId"},
+			},
+			"QueueUrl": {
+				ResolutionType:   "stack-output",
+				ResolutionConfig: map[string]string{"stack": "app", "output": "QueueUrl", "default": "none"},
+			},
+		},
+		Dependencies: []string{"vpc"},
+	}
+
+	mockConfigProvider.On("LoadConfig", ctx, "dev").Return(cfg, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(stackConfig, nil)
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"app", "vpc"}, nil)
+	mockFileSystemResolver.On("Resolve", "templates/app.yaml").Return("template", nil)
+	mockTemplateProcessor.On("Process", "template", mock.Anything).Return("template", nil)
+
+	// Configured stacks are looked up by deployed name; other stacks by the name given
+	mockCfnOps.On("StackExists", ctx, "dev-app-blue").Return(false, nil)
+	mockCfnOps.On("GetStack", ctx, "dev-vpc-blue").Return(&aws.Stack{Outputs: map[string]string{"VpcId": "vpc-123"}}, nil)
+	mockCfnOps.On("GetStack", ctx, "shared-dns").Return(&aws.Stack{Outputs: map[string]string{"ZoneId": "Z123"}}, nil)
+
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+	stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+	stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+
+	resolved, err := stackResolver.ResolveStack(ctx, "dev", "app")
+
+	require.NoError(t, err)
+	assert.Equal(t, "app", resolved.Name)
+	assert.Equal(t, "dev-app-blue", resolved.DeployedName)
+	assert.Equal(t, "dev-app-blue", resolved.CloudFormationName())
+	assert.Equal(t, []string{"vpc"}, resolved.Dependencies)
+	assert.Equal(t, map[string]string{"VpcId": "vpc-123", "ZoneId": "Z123", "QueueUrl": "none"}, resolved.Parameters)
+	assert.Equal(t, "vpc", stackConfig.Parameters["VpcId"].ResolutionConfig["stack"], "configuration should not be modified")
+	mockCfnOps.AssertExpectations(t)
+}
+
+func setupTemplatelessResolution(t *testing.T, ctx context.Context) (*StackResolver, *aws.MockCloudFormationOperations, *MockFileSystemResolver) {
+	t.Helper()
+
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFileSystemResolver := &MockFileSystemResolver{}
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	cfg := &config.Config{
+		Project: "test-project",
+		Context: &config.ContextConfig{Name: "dev", Region: "us-east-1"},
+	}
+	stackConfig := &config.StackConfig{
+		Name:       "app",
+		Parameters: convertStringMapToParameterValues(map[string]string{"InstanceType": "t3.small"}),
+	}
+
+	mockConfigProvider.On("LoadConfig", ctx, "dev").Return(cfg, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(stackConfig, nil)
+
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+	stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+	return stackResolver, mockCfnOps, mockFileSystemResolver
+}
+
+func TestStackResolver_ResolveStack_TemplatelessUpdateUsesDeployedTemplate(t *testing.T) {
+	ctx := context.Background()
+	stackResolver, mockCfnOps, mockFileSystemResolver := setupTemplatelessResolution(t, ctx)
+
+	deployedTemplate := `{"Parameters": {"InstanceType": {"Type": "String"}}, "Resources": {"Queue": {"Type": "AWS::SQS::Queue"}}}`
+	mockCfnOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCfnOps.On("GetTemplate", ctx, "app").Return(deployedTemplate, nil)
+
+	resolved, err := stackResolver.ResolveStack(ctx, "dev", "app")
+
+	require.NoError(t, err)
+	assert.True(t, resolved.UsePreviousTemplate)
+	assert.Equal(t, deployedTemplate, resolved.TemplateBody)
+	assert.Equal(t, map[string]string{"InstanceType": "t3.small"}, resolved.Parameters)
+	mockFileSystemResolver.AssertNotCalled(t, "Resolve", mock.Anything)
+	mockCfnOps.AssertExpectations(t)
+}
+
+func TestStackResolver_ResolveStack_TemplatelessNewStackRequiresTemplate(t *testing.T) {
+	ctx := context.Background()
+	stackResolver, mockCfnOps, _ := setupTemplatelessResolution(t, ctx)
+
+	mockCfnOps.On("StackExists", ctx, "app").Return(false, nil)
+
+	_, err := stackResolver.ResolveStack(ctx, "dev", "app")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stack app has no template configured; a template is required to create it")
+	mockCfnOps.AssertNotCalled(t, "GetTemplate", mock.Anything, mock.Anything)
+}
+
+func TestStackResolver_ResolveParameters_SSM(t *testing.T) {
+	// Test resolution of SSM parameters at the top level and inside lists
+	ctx := context.Background()
+
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFactory := aws.NewMockClientFactory()
+	mockSSMOps := &aws.MockSSMOperations{}
+	mockFactory.SetSSMOperations("us-east-1", mockSSMOps)
+	resolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+	mockSSMOps.On("GetParameter", ctx, "/myapp/dev/vpc-id", "").Return("vpc-12345", nil)
+	mockSSMOps.On("GetParameter", ctx, "/myapp/dev/subnet-a", "2").Return("subnet-a", nil)
+
+	params := map[string]*config.ParameterValue{
+		"VpcId": {
+			ResolutionType:   "ssm",
+			ResolutionConfig: map[string]string{"name": "/myapp/dev/vpc-id"},
+		},
+		"SubnetIds": {
+			ResolutionType: "list",
+			ListItems: []*config.ParameterValue{
+				{
+					ResolutionType:   "ssm",
+					ResolutionConfig: map[string]string{"parameter_name": "/myapp/dev/subnet-a", "version": "2"},
+				},
+				{
+					ResolutionType:   "literal",
+					ResolutionConfig: map[string]string{"value": "subnet-b"},
+				},
+			},
+		},
+	}
+
+	resolved, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-12345", resolved["VpcId"])
+	assert.Equal(t, "subnet-a,subnet-b", resolved["SubnetIds"])
+	mockSSMOps.AssertExpectations(t)
+}
+
+func TestStackResolver_ResolveParameters_SSMErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing name", func(t *testing.T) {
+		resolver := NewStackResolver(&config.MockConfigProvider{}, aws.NewMockClientFactory())
+
+		params := map[string]*config.ParameterValue{
+			"VpcId": {ResolutionType: "ssm", ResolutionConfig: map[string]string{}},
+		}
+
+		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ssm resolver missing required 'name'")
+	})
+
+	t.Run("parameter not found", func(t *testing.T) {
+		mockFactory := aws.NewMockClientFactory()
+		mockSSMOps := &aws.MockSSMOperations{}
+		mockFactory.SetSSMOperations("us-east-1", mockSSMOps)
+		resolver := NewStackResolver(&config.MockConfigProvider{}, mockFactory)
+
+		mockSSMOps.On("GetParameter", ctx, "/myapp/dev/missing", "").Return("", aws.ParameterNotFoundError{Name: "/myapp/dev/missing"})
+
+		params := map[string]*config.ParameterValue{
+			"VpcId": {ResolutionType: "ssm", ResolutionConfig: map[string]string{"name": "/myapp/dev/missing"}},
+		}
+
+		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to resolve parameter 'VpcId'")
+		assert.Contains(t, err.Error(), "SSM parameter /myapp/dev/missing not found")
+	})
+
+	t.Run("region override", func(t *testing.T) {
+		mockFactory := aws.NewMockClientFactory()
+		mockSSMOps := &aws.MockSSMOperations{}
+		mockFactory.SetSSMOperations("eu-west-1", mockSSMOps)
+		resolver := NewStackResolver(&config.MockConfigProvider{}, mockFactory)
+
+		mockSSMOps.On("GetParameter", ctx, "/shared/ami", "").Return("ami-123", nil)
+
+		params := map[string]*config.ParameterValue{
+			"AmiId": {ResolutionType: "ssm", ResolutionConfig: map[string]string{"name": "/shared/ami", "region": "eu-west-1"}},
+		}
+
+		resolved, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "ami-123", resolved["AmiId"])
+	})
+}
+
+func TestStackResolver_ResolveParameters_Export(t *testing.T) {
+	// Test resolution of CloudFormation exports by name, whichever stack exports them
+	ctx := context.Background()
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	resolver := NewStackResolver(&config.MockConfigProvider{}, mockFactory)
+
+	mockCfnOps.On("ListExports", ctx).Return([]aws.Export{
+		{Name: "network-VpcId", Value: "vpc-0abc123", ExportingStack: "network"},
+		{Name: "SharedKmsKeyArn", Value: "arn:aws:kms:us-east-1:123456789012:key/abc", ExportingStack: "security"},
+	}, nil)
+
+	params := map[string]*config.ParameterValue{
+		"VpcId": {ResolutionType: "export", ResolutionConfig: map[string]string{"name": "network-VpcId"}},
+	}
+
+	resolved, traces, err := resolver.resolveParametersWithTrace(ctx, params, "us-east-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-0abc123", resolved["VpcId"])
+	assert.Equal(t, []string{"cloudformation:ListExports (us-east-1)"}, traces[0].AWSCalls)
+}
+
+func TestStackResolver_ResolveParameters_ExportErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing name", func(t *testing.T) {
+		resolver := NewStackResolver(&config.MockConfigProvider{}, aws.NewMockClientFactory())
+
+		params := map[string]*config.ParameterValue{
+			"VpcId": {ResolutionType: "export", ResolutionConfig: map[string]string{}},
+		}
+
+		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "export resolver missing required 'name'")
+	})
+
+	t.Run("export not found", func(t *testing.T) {
+		mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+		resolver := NewStackResolver(&config.MockConfigProvider{}, mockFactory)
+
+		mockCfnOps.On("ListExports", ctx).Return([]aws.Export{{Name: "network-VpcId", Value: "vpc-0abc123"}}, nil)
+
+		params := map[string]*config.ParameterValue{
+			"VpcId": {ResolutionType: "export", ResolutionConfig: map[string]string{"name": "network-SubnetIds"}},
+		}
+
+		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to resolve parameter 'VpcId'")
+		assert.Contains(t, err.Error(), "export 'network-SubnetIds' not found in region us-east-1")
+	})
+
+	t.Run("region override", func(t *testing.T) {
+		mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("eu-west-1")
+		resolver := NewStackResolver(&config.MockConfigProvider{}, mockFactory)
+
+		mockCfnOps.On("ListExports", ctx).Return([]aws.Export{{Name: "shared-ZoneId", Value: "Z123"}}, nil)
+
+		params := map[string]*config.ParameterValue{
+			"ZoneId": {ResolutionType: "export", ResolutionConfig: map[string]string{"name": "shared-ZoneId", "region": "eu-west-1"}},
+		}
+
+		resolved, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Z123", resolved["ZoneId"])
+	})
+}
+
+func TestStackResolver_ResolveParameters_Secret(t *testing.T) {
+	ctx := context.Background()
+
+	mockFactory := aws.NewMockClientFactory()
+	mockSecretsOps := &aws.MockSecretsManagerOperations{}
+	mockFactory.SetSecretsManagerOperations("us-east-1", mockSecretsOps)
+	resolver := NewStackResolver(&config.MockConfigProvider{}, mockFactory)
+
+	mockSecretsOps.On("GetSecretValue", ctx, "prod/db/password").Return("plain-password", nil)
+	mockSecretsOps.On("GetSecretValue", ctx, "prod/db/credentials").Return(`{"username":"admin","password":"json-password","port":5432}`, nil)
+
+	params := map[string]*config.ParameterValue{
+		"DBPassword": {
+			ResolutionType:   "secret",
+			ResolutionConfig: map[string]string{"secret_id": "prod/db/password"},
+		},
+		"DBUser": {
+			ResolutionType:   "secret",
+			ResolutionConfig: map[string]string{"secret_id": "prod/db/credentials", "json_key": "username"},
+		},
+		"DBPort": {
+			ResolutionType:   "secret",
+			ResolutionConfig: map[string]string{"secret_id": "prod/db/credentials", "json_key": "port"},
+		},
+	}
+
+	resolved, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "plain-password", resolved["DBPassword"])
+	assert.Equal(t, "admin", resolved["DBUser"])
+	assert.Equal(t, "5432", resolved["DBPort"])
+}
+
+func TestStackResolver_ResolveParameters_Env(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewStackResolver(&config.MockConfigProvider{}, aws.NewMockClientFactory())
+
+	t.Setenv("STACKAROO_TEST_IMAGE_TAG", "build-1234")
+	t.Setenv("STACKAROO_TEST_EMPTY", "")
+
+	params := map[string]*config.ParameterValue{
+		"ImageTag": {
+			ResolutionType:   "env",
+			ResolutionConfig: map[string]string{"name": "STACKAROO_TEST_IMAGE_TAG", "default": "latest"},
+		},
+		"Empty": {
+			ResolutionType:   "env",
+			ResolutionConfig: map[string]string{"name": "STACKAROO_TEST_EMPTY", "default": "unused"},
+		},
+		"BuildNumber": {
+			ResolutionType:   "env",
+			ResolutionConfig: map[string]string{"name": "STACKAROO_TEST_UNSET", "default": "0"},
+		},
+		"Tags": {
+			ResolutionType: "list",
+			ListItems: []*config.ParameterValue{
+				{ResolutionType: "literal", ResolutionConfig: map[string]string{"value": "base"}},
+				{ResolutionType: "env", ResolutionConfig: map[string]string{"name": "STACKAROO_TEST_IMAGE_TAG"}},
+			},
+		},
+	}
+
+	resolved, err := resolver.resolveParameters(ctx, params, "us-east-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "build-1234", resolved["ImageTag"])
+	assert.Equal(t, "", resolved["Empty"])
+	assert.Equal(t, "0", resolved["BuildNumber"])
+	assert.Equal(t, "base,build-1234", resolved["Tags"])
+}
+
+func TestStackResolver_ResolveParameters_EnvErrors(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewStackResolver(&config.MockConfigProvider{}, aws.NewMockClientFactory())
+
+	tests := []struct {
+		name          string
+		param         *config.ParameterValue
+		expectedError string
+	}{
+		{
+			name:          "unset without default",
+			param:         &config.ParameterValue{ResolutionType: "env", ResolutionConfig: map[string]string{"name": "STACKAROO_TEST_UNSET"}},
+			expectedError: "failed to resolve parameter 'ImageTag': environment variable 'STACKAROO_TEST_UNSET' is not set and no default is provided",
+		},
+		{
+			name: "unset inside list",
+			param: &config.ParameterValue{
+				ResolutionType: "list",
+				ListItems: []*config.ParameterValue{
+					{ResolutionType: "env", ResolutionConfig: map[string]string{"name": "STACKAROO_TEST_UNSET"}},
+				},
+			},
+			expectedError: "failed to resolve parameter 'ImageTag': failed to resolve list item 0: environment variable 'STACKAROO_TEST_UNSET' is not set",
+		},
+		{
+			name:          "missing name",
+			param:         &config.ParameterValue{ResolutionType: "env", ResolutionConfig: map[string]string{}},
+			expectedError: "env resolver missing required 'name'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolver.resolveParameters(ctx, map[string]*config.ParameterValue{"ImageTag": tt.param}, "us-east-1")
 
-	// Test mixed parameter types
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestStackResolver_ResolveParameters_File(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewStackResolver(&config.MockConfigProvider{}, aws.NewMockClientFactory())
+	mockFS := &MockFileSystemResolver{}
+	resolver.SetFileSystemResolver(mockFS)
+
+	// Each file is read once per resolution pass however many parameters use it
+	mockFS.On("Resolve", "file:///config/values.yaml").Return(`
+database:
+  instance_class: db.t3.medium
+  storage: 100
+  replicas:
+    - eu-west-1a
+    - eu-west-1b
+`, nil).Once()
+	mockFS.On("Resolve", "file:///config/values.json").Return(`{"image": {"tag": "build-1234"}}`, nil).Once()
+
+	file := func(path, key string) *config.ParameterValue {
+		return &config.ParameterValue{ResolutionType: "file", ResolutionConfig: map[string]string{"path": path, "key": key}}
+	}
 	params := map[string]*config.ParameterValue{
-		"Environment": {
-			ResolutionType: "literal",
-			ResolutionConfig: map[string]string{
-				"value": "staging",
-			},
-		},
-		"VpcId": {
-			ResolutionType: "stack-output",
-			ResolutionConfig: map[string]string{
-				"stack":  "networking",
-				"output": "VpcId",
+		"InstanceClass": file("file:///config/values.yaml", "database.instance_class"),
+		"Storage":       file("file:///config/values.yaml", "database.storage"),
+		"ImageTag":      file("file:///config/values.json", "image.tag"),
+		"Zones": {
+			ResolutionType: "list",
+			ListItems: []*config.ParameterValue{
+				file("file:///config/values.yaml", "database.replicas.0"),
+				file("file:///config/values.yaml", "database.replicas.1"),
 			},
 		},
 	}
@@ -238,113 +1715,223 @@ func TestStackResolver_ResolveParameters_MixedTypes(t *testing.T) {
 	resolved, err := resolver.resolveParameters(ctx, params, "us-east-1")
 
 	require.NoError(t, err)
-	assert.Len(t, resolved, 2)
-	assert.Equal(t, "staging", resolved["Environment"])
-	assert.Equal(t, "vpc-abcdef", resolved["VpcId"])
-
-	mockCfnOps.AssertExpectations(t)
+	assert.Equal(t, "db.t3.medium", resolved["InstanceClass"])
+	assert.Equal(t, "100", resolved["Storage"])
+	assert.Equal(t, "build-1234", resolved["ImageTag"])
+	assert.Equal(t, "eu-west-1a,eu-west-1b", resolved["Zones"])
+	mockFS.AssertExpectations(t)
 }
 
-func TestStackResolver_ResolveParameters_ErrorCases(t *testing.T) {
+func TestStackResolver_ResolveParameters_FileErrors(t *testing.T) {
 	ctx := context.Background()
+	values := `
+database:
+  instance_class: db.t3.medium
+  replicas: [eu-west-1a]
+`
+
+	tests := []struct {
+		name          string
+		config        map[string]string
+		content       string
+		readErr       error
+		expectedError string
+	}{
+		{
+			name:          "missing key",
+			config:        map[string]string{"path": "file:///config/values.yaml", "key": "database.engine"},
+			content:       values,
+			expectedError: "values file /config/values.yaml does not have key 'database.engine'",
+		},
+		{
+			name:          "mapping is not a scalar",
+			config:        map[string]string{"path": "file:///config/values.yaml", "key": "database"},
+			content:       values,
+			expectedError: "key 'database' in values file /config/values.yaml is a mapping, not a scalar value",
+		},
+		{
+			name:          "list is not a scalar",
+			config:        map[string]string{"path": "file:///config/values.yaml", "key": "database.replicas"},
+			content:       values,
+			expectedError: "key 'database.replicas' in values file /config/values.yaml is a list, not a scalar value",
+		},
+		{
+			name:          "invalid file",
+			config:        map[string]string{"path": "file:///config/values.yaml", "key": "database"},
+			content:       "database: [unclosed",
+			expectedError: "failed to parse values file /config/values.yaml",
+		},
+		{
+			name:          "unreadable file",
+			config:        map[string]string{"path": "file:///config/missing.yaml", "key": "database"},
+			readErr:       errors.New("failed to read file /config/missing.yaml: no such file or directory"),
+			expectedError: "failed to read file /config/missing.yaml",
+		},
+		{
+			name:          "missing path",
+			config:        map[string]string{"key": "database"},
+			expectedError: "file resolver missing required 'path'",
+		},
+		{
+			name:          "missing key config",
+			config:        map[string]string{"path": "file:///config/values.yaml"},
+			expectedError: "file resolver missing required 'key'",
+		},
+	}
 
-	t.Run("unsupported resolution type", func(t *testing.T) {
-		mockConfigProvider := &config.MockConfigProvider{}
-		mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
-		resolver := NewStackResolver(mockConfigProvider, mockFactory)
-
-		params := map[string]*config.ParameterValue{
-			"BadParam": {
-				ResolutionType: "unsupported",
-				ResolutionConfig: map[string]string{
-					"something": "value",
-				},
-			},
-		}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewStackResolver(&config.MockConfigProvider{}, aws.NewMockClientFactory())
+			mockFS := &MockFileSystemResolver{}
+			mockFS.On("Resolve", tt.config["path"]).Return(tt.content, tt.readErr)
+			resolver.SetFileSystemResolver(mockFS)
 
-		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+			params := map[string]*config.ParameterValue{
+				"InstanceClass": {ResolutionType: "file", ResolutionConfig: tt.config},
+			}
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "unsupported resolution type 'unsupported'")
-	})
+			_, err := resolver.resolveParameters(ctx, params, "us-east-1")
 
-	t.Run("literal missing value", func(t *testing.T) {
-		mockConfigProvider := &config.MockConfigProvider{}
-		mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
-		resolver := NewStackResolver(mockConfigProvider, mockFactory)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
 
-		params := map[string]*config.ParameterValue{
-			"BadLiteral": {
-				ResolutionType:   "literal",
-				ResolutionConfig: map[string]string{
-					// Missing "value" key
-				},
-			},
-		}
+func TestStackResolver_ResolveParameters_SecretErrors(t *testing.T) {
+	ctx := context.Background()
 
-		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+	tests := []struct {
+		name          string
+		config        map[string]string
+		secretValue   string
+		secretErr     error
+		expectedError string
+	}{
+		{
+			name:          "missing secret_id",
+			config:        map[string]string{},
+			expectedError: "secret resolver missing required 'secret_id'",
+		},
+		{
+			name:          "secret not found",
+			config:        map[string]string{"secret_id": "prod/db/password"},
+			secretErr:     aws.SecretNotFoundError{SecretID: "prod/db/password"},
+			expectedError: "secret prod/db/password not found",
+		},
+		{
+			name:          "json key on non-JSON secret",
+			config:        map[string]string{"secret_id": "prod/db/password", "json_key": "password"},
+			secretValue:   "hunter2",
+			expectedError: "secret 'prod/db/password' is not a JSON object, cannot extract key 'password'",
+		},
+		{
+			name:          "missing json key",
+			config:        map[string]string{"secret_id": "prod/db/password", "json_key": "password"},
+			secretValue:   `{"username":"admin"}`,
+			expectedError: "secret 'prod/db/password' does not have key 'password'",
+		},
+	}
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "literal parameter missing 'value' config")
-	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFactory := aws.NewMockClientFactory()
+			mockSecretsOps := &aws.MockSecretsManagerOperations{}
+			mockFactory.SetSecretsManagerOperations("us-east-1", mockSecretsOps)
+			resolver := NewStackResolver(&config.MockConfigProvider{}, mockFactory)
 
-	t.Run("stack not found", func(t *testing.T) {
-		mockConfigProvider := &config.MockConfigProvider{}
-		mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
-		resolver := NewStackResolver(mockConfigProvider, mockFactory)
+			if secretID, ok := tt.config["secret_id"]; ok {
+				mockSecretsOps.On("GetSecretValue", ctx, secretID).Return(tt.secretValue, tt.secretErr)
+			}
 
-		mockCfnOps.On("GetStack", ctx, "missing-stack").Return(nil, fmt.Errorf("stack not found"))
+			params := map[string]*config.ParameterValue{
+				"DBPassword": {ResolutionType: "secret", ResolutionConfig: tt.config},
+			}
 
-		params := map[string]*config.ParameterValue{
-			"VpcId": {
-				ResolutionType: "stack-output",
-				ResolutionConfig: map[string]string{
-					"stack":  "missing-stack",
-					"output": "VpcId",
-				},
-			},
-		}
+			_, err := resolver.resolveParameters(ctx, params, "us-east-1")
 
-		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			if tt.secretValue != "" {
+				assert.NotContains(t, err.Error(), tt.secretValue)
+			}
+		})
+	}
+}
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to get stack 'missing-stack'")
-		assert.Contains(t, err.Error(), "failed to get stack 'missing-stack'")
+func TestSensitiveParameters(t *testing.T) {
+	params := map[string]*config.ParameterValue{
+		"DBPassword":  {ResolutionType: "secret", ResolutionConfig: map[string]string{"secret_id": "prod/db/password"}},
+		"Environment": {ResolutionType: "literal", ResolutionConfig: map[string]string{"value": "prod"}},
+		"Credentials": {
+			ResolutionType: "list",
+			ListItems: []*config.ParameterValue{
+				{ResolutionType: "literal", ResolutionConfig: map[string]string{"value": "admin"}},
+				{ResolutionType: "secret", ResolutionConfig: map[string]string{"secret_id": "prod/api-key"}},
+			},
+		},
+	}
 
-		mockCfnOps.AssertExpectations(t)
-	})
+	assert.Equal(t, map[string]bool{"DBPassword": true, "Credentials": true}, sensitiveParameters(params))
+	assert.Nil(t, sensitiveParameters(map[string]*config.ParameterValue{"Environment": params["Environment"]}))
+}
 
-	t.Run("output key not found", func(t *testing.T) {
-		mockConfigProvider := &config.MockConfigProvider{}
-		mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
-		resolver := NewStackResolver(mockConfigProvider, mockFactory)
+func TestStackResolver_ResolveParametersWithTrace(t *testing.T) {
+	ctx := context.Background()
 
-		mockStack := &aws.Stack{
-			Name: "vpc-stack",
-			Outputs: map[string]string{
-				"VpcId": "vpc-12345",
-			},
-		}
-		mockCfnOps.On("GetStack", ctx, "vpc-stack").Return(mockStack, nil)
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockSecretsOps := &aws.MockSecretsManagerOperations{}
+	mockFactory.SetSecretsManagerOperations("us-east-1", mockSecretsOps)
+	resolver := NewStackResolver(&config.MockConfigProvider{}, mockFactory)
 
-		params := map[string]*config.ParameterValue{
-			"BadOutput": {
-				ResolutionType: "stack-output",
-				ResolutionConfig: map[string]string{
-					"stack":  "vpc-stack",
-					"output": "MissingOutput",
-				},
-			},
-		}
+	mockCfnOps.On("GetStack", ctx, "network").Return(&aws.Stack{
+		Name:    "network",
+		Outputs: map[string]string{"VpcId": "vpc-123"},
+	}, nil)
+	mockSecretsOps.On("GetSecretValue", ctx, "prod/db/password").Return("s3cr3t", nil)
 
-		_, err := resolver.resolveParameters(ctx, params, "us-east-1")
+	params := map[string]*config.ParameterValue{
+		"Environment": {
+			ResolutionType:   "literal",
+			ResolutionConfig: map[string]string{"value": "prod"},
+		},
+		"VpcId": {
+			ResolutionType:   "stack-output",
+			ResolutionConfig: map[string]string{"stack": "network", "output": "VpcId"},
+		},
+		"DBPassword": {
+			ResolutionType:   "secret",
+			ResolutionConfig: map[string]string{"secret_id": "prod/db/password"},
+		},
+	}
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "stack 'vpc-stack' does not have output 'MissingOutput'")
-		assert.Contains(t, err.Error(), "stack 'vpc-stack' does not have output 'MissingOutput'")
+	resolved, traces, err := resolver.resolveParametersWithTrace(ctx, params, "us-east-1")
 
-		mockCfnOps.AssertExpectations(t)
-	})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", resolved["DBPassword"])
+	assert.Equal(t, []model.ParameterTrace{
+		{
+			Name:      "DBPassword",
+			Resolver:  "secret",
+			Inputs:    map[string]string{"secret_id": "prod/db/password"},
+			AWSCalls:  []string{"secretsmanager:GetSecretValue prod/db/password (us-east-1)"},
+			Value:     "****",
+			Sensitive: true,
+		},
+		{
+			Name:     "Environment",
+			Resolver: "literal",
+			Inputs:   map[string]string{"value": "prod"},
+			Value:    "prod",
+		},
+		{
+			Name:     "VpcId",
+			Resolver: "stack-output",
+			Inputs:   map[string]string{"stack": "network", "output": "VpcId"},
+			AWSCalls: []string{"cloudformation:DescribeStacks network (us-east-1)"},
+			Value:    "vpc-123",
+		},
+	}, traces)
 }
 
 func TestStackResolver_ResolveStackOutput_MissingConfig(t *testing.T) {
@@ -360,7 +1947,7 @@ func TestStackResolver_ResolveStackOutput_MissingConfig(t *testing.T) {
 			// Missing stack
 		}
 
-		_, err := resolver.resolveStackOutput(ctx, outputConfig, "us-west-2")
+		_, err := resolver.resolveStackOutput(ctx, outputConfig, "us-west-2", nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "stack output resolver missing required 'stack'")
@@ -372,7 +1959,7 @@ func TestStackResolver_ResolveStackOutput_MissingConfig(t *testing.T) {
 			// Missing output
 		}
 
-		_, err := resolver.resolveStackOutput(ctx, outputConfig, "us-west-2")
+		_, err := resolver.resolveStackOutput(ctx, outputConfig, "us-west-2", nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "stack output resolver missing required 'output'")
@@ -429,6 +2016,24 @@ func TestStackResolver_ResolveStack_StackNotFoundError(t *testing.T) {
 	mockFileSystemResolver.AssertExpectations(t)
 }
 
+func TestStackResolver_ResolveStack_DisabledStackError(t *testing.T) {
+	// Test that a stack disabled in the context is not resolved
+	ctx := context.Background()
+
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockConfigProvider.On("LoadConfig", ctx, "dev").Return(&config.Config{Project: "test-project"}, nil)
+	mockConfigProvider.On("GetStack", "monitoring", "dev").Return(&config.StackConfig{Name: "monitoring", Disabled: true}, nil)
+
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+	resolved, err := stackResolver.ResolveStack(ctx, "dev", "monitoring")
+
+	assert.Nil(t, resolved)
+	require.Error(t, err)
+	assert.Equal(t, "stack monitoring is disabled in context dev", err.Error())
+}
+
 func TestStackResolver_ResolveStack_TemplateReadError(t *testing.T) {
 	// Test error handling when template reading fails
 	ctx := context.Background()
@@ -494,7 +2099,7 @@ func TestStackResolver_ResolveStack_ParameterInheritance(t *testing.T) {
 
 	mockConfigProvider.On("LoadConfig", ctx, "staging").Return(cfg, nil)
 	mockConfigProvider.On("GetStack", "web", "staging").Return(stackConfig, nil)
-	mockFileSystemResolver.On("Resolve", "templates/web.yaml").Return("{}", nil)
+	mockFileSystemResolver.On("Resolve", "templates/web.yaml").Return(`{"Parameters": {"InstanceType": {"Type": "String"}}}`, nil)
 
 	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
 	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
@@ -556,7 +2161,7 @@ func TestStackResolver_ResolveStack_ContextTagInheritance(t *testing.T) {
 
 	mockConfigProvider.On("LoadConfig", ctx, "production").Return(cfg, nil)
 	mockConfigProvider.On("GetStack", "web", "production").Return(stackConfig, nil)
-	mockFileSystemResolver.On("Resolve", "templates/web.yaml").Return("{}", nil)
+	mockFileSystemResolver.On("Resolve", "templates/web.yaml").Return(`{"Parameters": {"InstanceType": {"Type": "String"}}}`, nil)
 
 	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
 	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
@@ -578,6 +2183,58 @@ func TestStackResolver_ResolveStack_ContextTagInheritance(t *testing.T) {
 	mockFileSystemResolver.AssertExpectations(t)
 }
 
+func TestStackResolver_ResolveStack_MandatoryTagsCannotBeOverridden(t *testing.T) {
+	ctx := context.Background()
+
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFileSystemResolver := &MockFileSystemResolver{}
+
+	cfg := &config.Config{
+		Project: "test-project",
+		Tags: map[string]string{
+			"Owner": "platform-team",
+		},
+		MandatoryTags: map[string]string{
+			"DataClassification": "confidential",
+			"CostCenter":         "finance",
+		},
+		Context: &config.ContextConfig{
+			Name:   "production",
+			Region: "us-east-1",
+			Tags: map[string]string{
+				"CostCenter": "context-cost-center", // Should not override the mandatory tag
+			},
+		},
+	}
+
+	stackConfig := &config.StackConfig{
+		Name:     "web",
+		Template: "templates/web.yaml",
+		Tags: map[string]string{
+			"Component":          "web-server",
+			"DataClassification": "public", // Should not override the mandatory tag
+		},
+	}
+
+	mockConfigProvider.On("LoadConfig", ctx, "production").Return(cfg, nil)
+	mockConfigProvider.On("GetStack", "web", "production").Return(stackConfig, nil)
+	mockFileSystemResolver.On("Resolve", "templates/web.yaml").Return("{}", nil)
+
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+	stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+
+	resolved, err := stackResolver.ResolveStack(ctx, "production", "web")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"Owner":              "platform-team",
+		"Component":          "web-server",
+		"CostCenter":         "finance",
+		"DataClassification": "confidential",
+	}, resolved.Tags)
+}
+
 func TestStackResolver_GetDependencyOrder_Success(t *testing.T) {
 	// Test successful dependency order calculation without full resolution
 	mockConfigProvider := &config.MockConfigProvider{}
@@ -656,6 +2313,128 @@ func TestStackResolver_GetDependencyOrder_NoDependencies(t *testing.T) {
 	mockConfigProvider.AssertExpectations(t)
 }
 
+func TestStackResolver_GetDependencyOrder_PriorityBreaksTies(t *testing.T) {
+	// Test that priority reorders independent stacks without breaking dependency constraints
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	mockConfigProvider.On("GetStack", "vpc", "dev").Return(&config.StackConfig{Name: "vpc"}, nil)
+	mockConfigProvider.On("GetStack", "monitoring", "dev").Return(&config.StackConfig{Name: "monitoring", Priority: 10}, nil)
+	mockConfigProvider.On("GetStack", "dns", "dev").Return(&config.StackConfig{Name: "dns"}, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app", Dependencies: []string{"vpc"}, Priority: 5}, nil)
+	mockConfigProvider.On("GetStack", "cache", "dev").Return(&config.StackConfig{Name: "cache", Dependencies: []string{"vpc"}, Priority: 20}, nil)
+
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+	order, err := stackResolver.GetDependencyOrder("dev", []string{"vpc", "monitoring", "dns", "app", "cache"})
+
+	require.NoError(t, err)
+	// monitoring outranks the other independent stacks, which fall back to alphabetical order;
+	// cache outranks app, but neither may move ahead of vpc
+	assert.Equal(t, []string{"monitoring", "dns", "vpc", "cache", "app"}, order)
+}
+
+func TestStackResolver_GetDependencyOrder_SkipsStacksDisabledInContext(t *testing.T) {
+	// Test that a stack disabled in dev is left out there but ordered in prod
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	mockConfigProvider.On("GetStack", "vpc", "dev").Return(&config.StackConfig{Name: "vpc"}, nil)
+	mockConfigProvider.On("GetStack", "monitoring", "dev").Return(&config.StackConfig{Name: "monitoring", Dependencies: []string{"vpc"}, Disabled: true}, nil)
+	mockConfigProvider.On("GetStack", "vpc", "prod").Return(&config.StackConfig{Name: "vpc"}, nil)
+	mockConfigProvider.On("GetStack", "monitoring", "prod").Return(&config.StackConfig{Name: "monitoring", Dependencies: []string{"vpc"}}, nil)
+
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+	devOrder, err := stackResolver.GetDependencyOrder("dev", []string{"vpc", "monitoring"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vpc"}, devOrder)
+
+	prodOrder, err := stackResolver.GetDependencyOrder("prod", []string{"vpc", "monitoring"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vpc", "monitoring"}, prodOrder)
+}
+
+func TestStackResolver_GetDependencyGraph(t *testing.T) {
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockConfigProvider.On("GetStack", "vpc", "dev").Return(&config.StackConfig{Name: "vpc"}, nil)
+	mockConfigProvider.On("GetStack", "database", "dev").Return(&config.StackConfig{Name: "database", Dependencies: []string{"vpc"}}, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app", Dependencies: []string{"queue", "database", "vpc"}}, nil)
+	mockConfigProvider.On("GetStack", "monitoring", "dev").Return(&config.StackConfig{Name: "monitoring", Dependencies: []string{"app"}, Disabled: true}, nil)
+
+	graph, err := NewStackResolver(mockConfigProvider, nil).GetDependencyGraph("dev", []string{"vpc", "database", "app", "monitoring"})
+
+	require.NoError(t, err)
+	// The queue is not among the given stacks and monitoring is disabled, so both are left out
+	assert.Equal(t, map[string][]string{
+		"vpc":      {},
+		"database": {"vpc"},
+		"app":      {"database", "vpc"},
+	}, graph)
+}
+
+func TestFindCycle(t *testing.T) {
+	assert.Nil(t, FindCycle(map[string][]string{"app": {"database"}, "database": {"vpc"}, "vpc": {}}))
+	assert.Equal(t, []string{"app", "app"}, FindCycle(map[string][]string{"app": {"app"}}))
+	assert.Equal(t, []string{"database", "network", "vpc", "database"}, FindCycle(map[string][]string{
+		"app":      {"database"},
+		"database": {"network"},
+		"network":  {"vpc"},
+		"vpc":      {"database"},
+	}))
+}
+
+func TestStackResolver_LogsDecisionsAtDebug(t *testing.T) {
+	ctx := context.Background()
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockConfigProvider.On("GetStack", "vpc", "dev").Return(&config.StackConfig{Name: "vpc"}, nil)
+	mockConfigProvider.On("GetStack", "monitoring", "dev").Return(&config.StackConfig{Name: "monitoring", Disabled: true}, nil)
+	mockConfigProvider.On("LoadConfig", ctx, "dev").Return(nil, errors.New("no such context"))
+
+	var logs bytes.Buffer
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+	stackResolver.SetLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	_, err := stackResolver.GetDependencyOrder("dev", []string{"vpc", "monitoring"})
+	require.NoError(t, err)
+	_, err = stackResolver.ResolveStack(ctx, "dev", "vpc")
+	require.Error(t, err)
+
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(&logs)
+	for decoder.More() {
+		var record map[string]interface{}
+		require.NoError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 3)
+	assert.Equal(t, "leaving out disabled stack", records[0]["msg"])
+	assert.Equal(t, "monitoring", records[0]["stack"])
+	assert.Equal(t, "calculated dependency order", records[1]["msg"])
+	assert.Equal(t, "resolving stack", records[2]["msg"])
+	for _, record := range records {
+		assert.Equal(t, "DEBUG", record["level"])
+	}
+}
+
+func TestStackResolver_GetDependencyOrder_EnabledStackDependsOnDisabledStack(t *testing.T) {
+	// Test that an enabled stack cannot depend on a stack disabled in the context
+	mockConfigProvider := &config.MockConfigProvider{}
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+	mockConfigProvider.On("GetStack", "monitoring", "dev").Return(&config.StackConfig{Name: "monitoring", Disabled: true}, nil)
+	mockConfigProvider.On("GetStack", "alerts", "dev").Return(&config.StackConfig{Name: "alerts", Dependencies: []string{"monitoring"}}, nil)
+
+	stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+
+	order, err := stackResolver.GetDependencyOrder("dev", []string{"monitoring", "alerts"})
+
+	assert.Nil(t, order)
+	require.Error(t, err)
+	assert.Equal(t, "stack alerts depends on stack monitoring, which is disabled in context dev", err.Error())
+}
+
 func TestStackResolver_GetDependencyOrder_ComplexChain(t *testing.T) {
 	// Test complex dependency chain: vpc -> security -> database -> app
 	mockConfigProvider := &config.MockConfigProvider{}
@@ -724,6 +2503,7 @@ func TestStackResolver_GetDependencyOrder_CircularDependency(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, order)
 	assert.Contains(t, err.Error(), "circular dependency detected")
+	assert.Contains(t, err.Error(), "stack-a -> stack-b -> stack-a")
 
 	mockConfigProvider.AssertExpectations(t)
 }
@@ -0,0 +1,131 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/version"
+	"gopkg.in/yaml.v3"
+)
+
+// StampingTemplateProcessor records provenance in each template it processes. After the wrapped
+// processor runs, it adds Metadata.StackarooBuildInfo with a hash of the processed template, the
+// time and the stackaroo version. The hash is taken before stamping, so it identifies the content
+// alone; diffs leave the block out of comparisons.
+type StampingTemplateProcessor struct {
+	processor TemplateProcessor
+	version   string
+	now       func() time.Time
+}
+
+// NewStampingTemplateProcessor creates a processor that stamps the output of the given processor
+func NewStampingTemplateProcessor(processor TemplateProcessor) *StampingTemplateProcessor {
+	return &StampingTemplateProcessor{
+		processor: processor,
+		version:   version.Short(),
+		now:       time.Now,
+	}
+}
+
+// Process processes the template with the wrapped processor and stamps the result
+func (sp *StampingTemplateProcessor) Process(templateContent string, variables map[string]interface{}) (string, error) {
+	processed, err := sp.processor.Process(templateContent, variables)
+	if err != nil {
+		return "", err
+	}
+
+	info := [][2]string{
+		{"ContentHash", fmt.Sprintf("%x", sha256.Sum256([]byte(processed)))},
+		{"Timestamp", sp.now().UTC().Format(time.RFC3339)},
+		{"Version", sp.version},
+	}
+
+	if json.Valid([]byte(processed)) {
+		return stampJSONTemplate(processed, info)
+	}
+	return stampYAMLTemplate(processed, info)
+}
+
+// stampJSONTemplate adds the build information to the Metadata section of a JSON template
+func stampJSONTemplate(templateBody string, info [][2]string) (string, error) {
+	var template map[string]interface{}
+	if err := json.Unmarshal([]byte(templateBody), &template); err != nil {
+		return "", fmt.Errorf("failed to stamp template: %w", err)
+	}
+
+	metadata, ok := template["Metadata"].(map[string]interface{})
+	if !ok {
+		if _, exists := template["Metadata"]; exists {
+			return "", fmt.Errorf("failed to stamp template: Metadata is not a mapping")
+		}
+		metadata = make(map[string]interface{})
+		template["Metadata"] = metadata
+	}
+
+	buildInfo := make(map[string]interface{}, len(info))
+	for _, field := range info {
+		buildInfo[field[0]] = field[1]
+	}
+	metadata[model.BuildInfoMetadataKey] = buildInfo
+
+	encoded, err := json.MarshalIndent(template, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode template: %w", err)
+	}
+	return string(encoded) + "\n", nil
+}
+
+// stampYAMLTemplate adds the build information to the Metadata section of a YAML template,
+// keeping the rest of the document, including intrinsic function tags, as it is
+func stampYAMLTemplate(templateBody string, info [][2]string) (string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(templateBody), &document); err != nil {
+		return "", fmt.Errorf("failed to stamp template: %w", err)
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return "", fmt.Errorf("failed to stamp template: template is not a mapping")
+	}
+	root := document.Content[0]
+
+	metadata := mappingValue(root, "Metadata")
+	if metadata == nil {
+		metadata = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		root.Content = append(root.Content, stringNode("Metadata"), metadata)
+	} else if metadata.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("failed to stamp template: Metadata is not a mapping")
+	}
+
+	buildInfo := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, field := range info {
+		buildInfo.Content = append(buildInfo.Content, stringNode(field[0]), stringNode(field[1]))
+	}
+	if existing := mappingValue(metadata, model.BuildInfoMetadataKey); existing != nil {
+		*existing = *buildInfo
+	} else {
+		metadata.Content = append(metadata.Content, stringNode(model.BuildInfoMetadataKey), buildInfo)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return "", fmt.Errorf("failed to encode template: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// stringNode returns a YAML scalar node holding a string
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// newTestStampingProcessor returns a stamping processor with a fixed clock and version
+func newTestStampingProcessor(processor TemplateProcessor) *StampingTemplateProcessor {
+	stamping := NewStampingTemplateProcessor(processor)
+	stamping.version = "v1.2.3"
+	stamping.now = func() time.Time { return time.Date(2025, 3, 14, 9, 26, 53, 0, time.FixedZone("AEDT", 11*60*60)) }
+	return stamping
+}
+
+func TestStampingTemplateProcessor_YAML(t *testing.T) {
+	template := `AWSTemplateFormatVersion: '2010-09-09'
+Metadata:
+  Owner: platform
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Sub ${AWS::StackName}-{{ .Context }}
+`
+	processed := `AWSTemplateFormatVersion: '2010-09-09'
+Metadata:
+  Owner: platform
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Sub ${AWS::StackName}-dev
+`
+	processor := newTestStampingProcessor(NewCfnTemplateProcessor())
+
+	stamped, err := processor.Process(template, map[string]interface{}{"Context": "dev"})
+
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(`AWSTemplateFormatVersion: '2010-09-09'
+Metadata:
+  Owner: platform
+  StackarooBuildInfo:
+    ContentHash: %x
+    Timestamp: "2025-03-13T22:26:53Z"
+    Version: v1.2.3
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Sub ${AWS::StackName}-dev
+`, sha256.Sum256([]byte(processed))), stamped)
+}
+
+func TestStampingTemplateProcessor_YAMLWithoutMetadata(t *testing.T) {
+	template := `Resources:
+  Queue:
+    Type: AWS::SQS::Queue
+`
+	processor := newTestStampingProcessor(NewCfnTemplateProcessor())
+
+	stamped, err := processor.Process(template, nil)
+
+	require.NoError(t, err)
+	var document struct {
+		Metadata map[string]map[string]string `yaml:"Metadata"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(stamped), &document))
+	assert.Equal(t, map[string]string{
+		"ContentHash": fmt.Sprintf("%x", sha256.Sum256([]byte(template))),
+		"Timestamp":   "2025-03-13T22:26:53Z",
+		"Version":     "v1.2.3",
+	}, document.Metadata["StackarooBuildInfo"])
+}
+
+func TestStampingTemplateProcessor_JSON(t *testing.T) {
+	template := `{"Resources": {"Queue": {"Type": "AWS::SQS::Queue"}}}`
+	processor := newTestStampingProcessor(NewCfnTemplateProcessor())
+
+	stamped, err := processor.Process(template, nil)
+
+	require.NoError(t, err)
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stamped), &document))
+	assert.Equal(t, map[string]interface{}{
+		"StackarooBuildInfo": map[string]interface{}{
+			"ContentHash": fmt.Sprintf("%x", sha256.Sum256([]byte(template))),
+			"Timestamp":   "2025-03-13T22:26:53Z",
+			"Version":     "v1.2.3",
+		},
+	}, document["Metadata"])
+	assert.Contains(t, document, "Resources")
+}
+
+func TestStampingTemplateProcessor_ReplacesExistingBuildInfo(t *testing.T) {
+	template := `Metadata:
+  StackarooBuildInfo:
+    ContentHash: stale
+Resources:
+  Queue:
+    Type: AWS::SQS::Queue
+`
+	processor := newTestStampingProcessor(NewCfnTemplateProcessor())
+
+	stamped, err := processor.Process(template, nil)
+
+	require.NoError(t, err)
+	assert.NotContains(t, stamped, "stale")
+	assert.Contains(t, stamped, "Version: v1.2.3")
+}
+
+func TestStampingTemplateProcessor_Errors(t *testing.T) {
+	t.Run("processing error is returned", func(t *testing.T) {
+		mockProcessor := &MockTemplateProcessor{}
+		mockProcessor.On("Process", "template", map[string]interface{}(nil)).Return("", errors.New("failed to parse template"))
+
+		_, err := newTestStampingProcessor(mockProcessor).Process("template", nil)
+
+		assert.EqualError(t, err, "failed to parse template")
+	})
+
+	t.Run("metadata that is not a mapping", func(t *testing.T) {
+		_, err := newTestStampingProcessor(NewCfnTemplateProcessor()).Process("Metadata: none\nResources: {}\n", nil)
+
+		assert.EqualError(t, err, "failed to stamp template: Metadata is not a mapping")
+	})
+}
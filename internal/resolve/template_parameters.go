@@ -0,0 +1,175 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// templateParameter is the part of a template's parameter declaration checked before deploying
+type templateParameter struct {
+	Type           string    `yaml:"Type"`
+	Default        yaml.Node `yaml:"Default"` // Zero when the parameter has no default
+	AllowedValues  []string  `yaml:"AllowedValues"`
+	AllowedPattern string    `yaml:"AllowedPattern"`
+}
+
+// templateParameters returns the parameters a JSON or YAML template declares, and whether its
+// Parameters section could be read. Templates that cannot be parsed are left for CloudFormation.
+func templateParameters(templateBody string) (map[string]templateParameter, bool) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(templateBody), &document); err != nil || len(document.Content) == 0 {
+		return nil, false
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, false
+	}
+
+	declared := make(map[string]templateParameter)
+	parameters := mappingValue(root, "Parameters")
+	if parameters == nil {
+		return declared, true
+	}
+	if parameters.Kind != yaml.MappingNode {
+		return nil, false
+	}
+	for i := 0; i+1 < len(parameters.Content); i += 2 {
+		var parameter templateParameter
+		if err := parameters.Content[i+1].Decode(&parameter); err != nil {
+			return nil, false
+		}
+		declared[parameters.Content[i].Value] = parameter
+	}
+	return declared, true
+}
+
+// validateParametersAgainstTemplate checks resolved parameters against the template's declarations
+// before CloudFormation sees them. Values outside AllowedValues, values not matching AllowedPattern
+// and non-numeric Number values are errors. Parameters the template does not declare are returned
+// as warnings, leaving --prune-parameters to reject them, as are declared parameters with neither a
+// value nor a default, since CloudFormation may still take their previous value on update.
+// Sensitive values are not shown.
+func validateParametersAgainstTemplate(templateBody string, parameters map[string]string, sensitive map[string]bool) ([]string, error) {
+	declared, ok := templateParameters(templateBody)
+	if !ok {
+		return nil, nil
+	}
+
+	if problems := parameterValueProblems(declared, parameters, sensitive); len(problems) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+
+	var warnings []string
+	for _, name := range undeclaredParameters(declared, parameters) {
+		warnings = append(warnings, fmt.Sprintf("parameter %s is not declared in the template", name))
+	}
+	for _, name := range slices.Sorted(maps.Keys(declared)) {
+		if _, configured := parameters[name]; !configured && declared[name].Default.Kind == 0 {
+			warnings = append(warnings, fmt.Sprintf("template parameter %s has no value and no default", name))
+		}
+	}
+	return warnings, nil
+}
+
+// ValidateParameters checks a stack's parameter values against its template's declarations, for
+// values that replaced resolved ones after ResolveStack checked them
+func ValidateParameters(stack *model.Stack) error {
+	declared, ok := templateParameters(stack.TemplateBody)
+	if !ok {
+		return nil
+	}
+	if problems := parameterValueProblems(declared, stack.Parameters, stack.SensitiveParameters); len(problems) > 0 {
+		return fmt.Errorf("invalid parameters for stack %s: %s", stack.Name, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// UndeclaredParameters returns, in name order, the stack's parameters that its template does not
+// declare. Templates that cannot be parsed are left for CloudFormation, so none are returned.
+func UndeclaredParameters(stack *model.Stack) []string {
+	declared, ok := templateParameters(stack.TemplateBody)
+	if !ok {
+		return nil
+	}
+	return undeclaredParameters(declared, stack.Parameters)
+}
+
+// undeclaredParameters returns, in name order, the parameters missing from the declarations
+func undeclaredParameters(declared map[string]templateParameter, parameters map[string]string) []string {
+	var undeclared []string
+	for _, name := range slices.Sorted(maps.Keys(parameters)) {
+		if _, exists := declared[name]; !exists {
+			undeclared = append(undeclared, name)
+		}
+	}
+	return undeclared
+}
+
+// parameterValueProblems describes each value of a declared parameter that breaks its constraints
+func parameterValueProblems(declared map[string]templateParameter, parameters map[string]string, sensitive map[string]bool) []string {
+	var problems []string
+	for _, name := range slices.Sorted(maps.Keys(parameters)) {
+		parameter, exists := declared[name]
+		if !exists {
+			continue
+		}
+
+		display := func(value string) string {
+			if sensitive[name] {
+				return model.MaskedValue
+			}
+			return strconv.Quote(value)
+		}
+		for _, value := range parameterItems(parameter.Type, parameters[name]) {
+			if problem := checkParameterValue(parameter, value); problem != "" {
+				problems = append(problems, fmt.Sprintf("parameter %s: value %s %s", name, display(value), problem))
+			}
+		}
+	}
+	return problems
+}
+
+// parameterItems splits the value of a list parameter into its items, as CloudFormation checks
+// AllowedValues and AllowedPattern against each one
+func parameterItems(parameterType, value string) []string {
+	if parameterType != "CommaDelimitedList" && !strings.HasPrefix(parameterType, "List<") {
+		return []string{value}
+	}
+	items := strings.Split(value, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
+
+// checkParameterValue describes how a single value breaks its parameter's constraints, or
+// returns an empty string when it meets them. Patterns Go cannot compile are not checked.
+func checkParameterValue(parameter templateParameter, value string) string {
+	if parameter.Type == "Number" || parameter.Type == "List<Number>" {
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "is not a number"
+		}
+	}
+	if len(parameter.AllowedValues) > 0 && !slices.Contains(parameter.AllowedValues, value) {
+		return fmt.Sprintf("is not one of the allowed values: %s", strings.Join(parameter.AllowedValues, ", "))
+	}
+	if parameter.AllowedPattern != "" {
+		// CloudFormation requires the whole value to match
+		pattern, err := regexp.Compile("^(?:" + parameter.AllowedPattern + ")$")
+		if err == nil && !pattern.MatchString(value) {
+			return fmt.Sprintf("does not match the allowed pattern %s", parameter.AllowedPattern)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,194 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const parameterTemplate = `
+Parameters:
+  Environment:
+    Type: String
+    AllowedValues: [dev, staging, prod]
+  InstanceType:
+    Type: String
+    Default: t3.micro
+    AllowedPattern: "t3\\.[a-z]+"
+  DesiredCount:
+    Type: Number
+    Default: 1
+  Subnets:
+    Type: CommaDelimitedList
+    Default: ""
+    AllowedPattern: "subnet-[0-9a-f]+"
+  DatabasePassword:
+    Type: String
+    NoEcho: true
+    AllowedPattern: "[A-Za-z0-9]{12,}"
+Resources: {}
+`
+
+func TestValidateParametersAgainstTemplate(t *testing.T) {
+	tests := []struct {
+		name             string
+		template         string
+		parameters       map[string]string
+		sensitive        map[string]bool
+		expectedWarnings []string
+		expectedError    string
+	}{
+		{
+			name:       "valid parameters",
+			template:   parameterTemplate,
+			parameters: map[string]string{"Environment": "dev", "InstanceType": "t3.small", "DesiredCount": "2", "Subnets": "subnet-1a, subnet-2b", "DatabasePassword": "correcthorsebattery"},
+		},
+		{
+			name:          "value outside allowed values",
+			template:      parameterTemplate,
+			parameters:    map[string]string{"Environment": "test", "DatabasePassword": "correcthorsebattery"},
+			expectedError: `parameter Environment: value "test" is not one of the allowed values: dev, staging, prod`,
+		},
+		{
+			name:          "pattern must match the whole value",
+			template:      parameterTemplate,
+			parameters:    map[string]string{"Environment": "dev", "InstanceType": "m5.t3.large", "DatabasePassword": "correcthorsebattery"},
+			expectedError: `parameter InstanceType: value "m5.t3.large" does not match the allowed pattern t3\.[a-z]+`,
+		},
+		{
+			name:          "list items are checked individually",
+			template:      parameterTemplate,
+			parameters:    map[string]string{"Environment": "dev", "Subnets": "subnet-1a,vpc-2b", "DatabasePassword": "correcthorsebattery"},
+			expectedError: `parameter Subnets: value "vpc-2b" does not match the allowed pattern subnet-[0-9a-f]+`,
+		},
+		{
+			name:          "number parameter with a non-numeric value",
+			template:      parameterTemplate,
+			parameters:    map[string]string{"Environment": "dev", "DesiredCount": "three", "DatabasePassword": "correcthorsebattery"},
+			expectedError: `parameter DesiredCount: value "three" is not a number`,
+		},
+		{
+			name:          "sensitive values are masked",
+			template:      parameterTemplate,
+			parameters:    map[string]string{"Environment": "dev", "DatabasePassword": "hunter2"},
+			sensitive:     map[string]bool{"DatabasePassword": true},
+			expectedError: `parameter DatabasePassword: value **** does not match the allowed pattern [A-Za-z0-9]{12,}`,
+		},
+		{
+			name:             "parameters the template does not declare",
+			template:         parameterTemplate,
+			parameters:       map[string]string{"Environment": "dev", "DatabasePassword": "correcthorsebattery", "VpcId": "vpc-123", "Colour": "blue"},
+			expectedWarnings: []string{"parameter Colour is not declared in the template", "parameter VpcId is not declared in the template"},
+		},
+		{
+			name:             "required parameters without a value",
+			template:         parameterTemplate,
+			parameters:       map[string]string{"InstanceType": "t3.large"},
+			expectedWarnings: []string{"template parameter DatabasePassword has no value and no default", "template parameter Environment has no value and no default"},
+		},
+		{
+			name:             "template without parameters",
+			template:         `{"Resources": {}}`,
+			parameters:       map[string]string{"Environment": "dev"},
+			expectedWarnings: []string{"parameter Environment is not declared in the template"},
+		},
+		{
+			name:       "unparseable template is left to CloudFormation",
+			template:   "Resources: [",
+			parameters: map[string]string{"Environment": "dev"},
+		},
+		{
+			name:       "unsupported pattern is not checked",
+			template:   "Parameters:\n  Name:\n    Type: String\n    AllowedPattern: \"(?=abc).*\"\n",
+			parameters: map[string]string{"Name": "xyz"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := validateParametersAgainstTemplate(tt.template, tt.parameters, tt.sensitive)
+
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedWarnings, warnings)
+		})
+	}
+}
+
+func TestStackResolver_ResolveStack_ValidatesParametersAgainstTemplate(t *testing.T) {
+	tests := []struct {
+		name            string
+		parameters      map[string]string
+		expectedNotices string
+		expectedError   string
+	}{
+		{
+			name:            "missing required parameter is a warning",
+			parameters:      map[string]string{"InstanceType": "t3.small"},
+			expectedNotices: "Warning: stack app: template parameter DatabasePassword has no value and no default\nWarning: stack app: template parameter Environment has no value and no default\n",
+		},
+		{
+			name:            "undeclared parameter is a warning",
+			parameters:      map[string]string{"Environment": "dev", "DatabasePassword": "correcthorsebattery", "LegacyFlag": "true"},
+			expectedNotices: "Warning: stack app: parameter LegacyFlag is not declared in the template\n",
+		},
+		{
+			name:          "disallowed value is an error",
+			parameters:    map[string]string{"Environment": "qa", "DatabasePassword": "correcthorsebattery"},
+			expectedError: `invalid parameters for stack app: parameter Environment: value "qa" is not one of the allowed values: dev, staging, prod`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockConfigProvider := &config.MockConfigProvider{}
+			mockFileSystemResolver := &MockFileSystemResolver{}
+			mockTemplateProcessor := &MockTemplateProcessor{}
+			mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+
+			cfg := &config.Config{
+				Project: "test-project",
+				Context: &config.ContextConfig{Name: "dev", Region: "us-east-1"},
+			}
+			stackConfig := &config.StackConfig{
+				Name:       "app",
+				Template:   "templates/app.yaml",
+				Parameters: convertStringMapToParameterValues(tt.parameters),
+			}
+
+			mockConfigProvider.On("LoadConfig", ctx, "dev").Return(cfg, nil)
+			mockConfigProvider.On("GetStack", "app", "dev").Return(stackConfig, nil)
+			mockFileSystemResolver.On("Resolve", "templates/app.yaml").Return(parameterTemplate, nil)
+			mockTemplateProcessor.On("Process", parameterTemplate, mock.Anything).Return(parameterTemplate, nil)
+
+			var notices bytes.Buffer
+			stackResolver := NewStackResolver(mockConfigProvider, mockFactory)
+			stackResolver.SetFileSystemResolver(mockFileSystemResolver)
+			stackResolver.SetTemplateProcessor(mockTemplateProcessor)
+			stackResolver.notices = &notices
+
+			_, err := stackResolver.ResolveStack(ctx, "dev", "app")
+
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNotices, notices.String())
+		})
+	}
+}
@@ -42,5 +42,10 @@ func (tp *CfnTemplateProcessor) Process(templateContent string, variables map[st
 		return "", fmt.Errorf("failed to execute template: %w", err)
 	}
 
+	// Contexts that do not export drop Export blocks to avoid duplicate export names
+	if exports, ok := variables["Exports"].(bool); ok && !exports {
+		return stripExports(buf.String())
+	}
+
 	return buf.String(), nil
 }
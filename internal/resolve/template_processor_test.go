@@ -213,3 +213,73 @@ Resources:
 		assert.Contains(t, result, "Environment: development")
 	})
 }
+
+func TestCfnTemplateProcessor_Process_StripsExportsWhenDisabled(t *testing.T) {
+	processor := NewCfnTemplateProcessor()
+
+	template := `AWSTemplateFormatVersion: '2010-09-09'
+Outputs:
+  VpcId:
+    Value: !Ref Vpc
+    Export:
+      Name: !Sub '${AWS::StackName}-VpcId'
+  BucketName:
+    Value: !Ref Bucket`
+
+	result, err := processor.Process(template, map[string]interface{}{"Exports": false})
+
+	require.NoError(t, err)
+	assert.NotContains(t, result, "Export")
+	assert.Contains(t, result, "Value: !Ref Vpc")
+	assert.Contains(t, result, "Value: !Ref Bucket")
+}
+
+func TestCfnTemplateProcessor_Process_KeepsExportsWhenEnabled(t *testing.T) {
+	processor := NewCfnTemplateProcessor()
+
+	template := `Outputs:
+  VpcId:
+    Value: !Ref Vpc
+    Export:
+      Name: shared-vpc`
+
+	enabled, err := processor.Process(template, map[string]interface{}{"Exports": true})
+	require.NoError(t, err)
+	assert.Equal(t, template, enabled)
+
+	unset, err := processor.Process(template, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, template, unset)
+}
+
+func TestCfnTemplateProcessor_Process_StripsJSONExports(t *testing.T) {
+	processor := NewCfnTemplateProcessor()
+
+	template := `{
+  "Outputs": {
+    "VpcId": {
+      "Value": {"Ref": "Vpc"},
+      "Export": {"Name": "shared-vpc"}
+    }
+  }
+}`
+
+	result, err := processor.Process(template, map[string]interface{}{"Exports": false})
+
+	require.NoError(t, err)
+	assert.NotContains(t, result, "Export")
+	assert.JSONEq(t, `{"Outputs": {"VpcId": {"Value": {"Ref": "Vpc"}}}}`, result)
+}
+
+func TestCfnTemplateProcessor_Process_NoOutputsUnchangedWhenExportsDisabled(t *testing.T) {
+	processor := NewCfnTemplateProcessor()
+
+	template := `Resources:
+  Bucket:
+    Type: AWS::S3::Bucket`
+
+	result, err := processor.Process(template, map[string]interface{}{"Exports": false})
+
+	require.NoError(t, err)
+	assert.Equal(t, template, result)
+}
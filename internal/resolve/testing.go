@@ -21,6 +21,16 @@ func (m *MockFileSystemResolver) Resolve(templateURI string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+// MockGitRunner implements GitRunner for testing
+type MockGitRunner struct {
+	mock.Mock
+}
+
+func (m *MockGitRunner) Run(args ...string) (string, error) {
+	callArgs := m.Called(args)
+	return callArgs.String(0), callArgs.Error(1)
+}
+
 // MockResolver implements Resolver for testing
 type MockResolver struct {
 	mock.Mock
@@ -42,6 +52,10 @@ func (m *MockResolver) GetDependencyOrder(context string, stackNames []string) (
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockResolver) RecordStackOutputs(region, stackName string, outputs map[string]string) {
+	m.Called(region, stackName, outputs)
+}
+
 // MockTemplateProcessor implements TemplateProcessor for testing
 type MockTemplateProcessor struct {
 	mock.Mock
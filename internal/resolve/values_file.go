@@ -0,0 +1,117 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resolve
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// valuesFiles caches parsed values files for the duration of a single resolution pass
+type valuesFiles struct {
+	fileSystemResolver FileSystemResolver
+	parsed             map[string]*yaml.Node
+	mutex              sync.Mutex
+}
+
+// newValuesFiles creates an empty values file cache reading through the given file system resolver
+func newValuesFiles(fileSystemResolver FileSystemResolver) *valuesFiles {
+	return &valuesFiles{
+		fileSystemResolver: fileSystemResolver,
+		parsed:             make(map[string]*yaml.Node),
+	}
+}
+
+// load reads and parses a JSON or YAML values file, reusing an earlier parse of the same file
+func (v *valuesFiles) load(fileURI string) (*yaml.Node, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if root, exists := v.parsed[fileURI]; exists {
+		return root, nil
+	}
+
+	content, err := v.fileSystemResolver.Resolve(fileURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is a subset of YAML, so one parser handles both formats
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		return nil, fmt.Errorf("failed to parse values file %s: %w", displayFilePath(fileURI), err)
+	}
+
+	root := &document
+	if document.Kind == yaml.DocumentNode && len(document.Content) > 0 {
+		root = document.Content[0]
+	}
+
+	v.parsed[fileURI] = root
+	return root, nil
+}
+
+// lookupValue follows a dotted key through mappings and, by numeric index, sequences to a scalar value
+func lookupValue(root *yaml.Node, key, fileURI string) (string, error) {
+	node := root
+	for _, part := range strings.Split(key, ".") {
+		node = childNode(node, part)
+		if node == nil {
+			return "", fmt.Errorf("values file %s does not have key '%s'", displayFilePath(fileURI), key)
+		}
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return "", nil
+		}
+		return node.Value, nil
+	case yaml.MappingNode:
+		return "", fmt.Errorf("key '%s' in values file %s is a mapping, not a scalar value", key, displayFilePath(fileURI))
+	case yaml.SequenceNode:
+		return "", fmt.Errorf("key '%s' in values file %s is a list, not a scalar value", key, displayFilePath(fileURI))
+	default:
+		return "", fmt.Errorf("key '%s' in values file %s is not a scalar value", key, displayFilePath(fileURI))
+	}
+}
+
+// childNode returns the named entry of a mapping or the indexed item of a sequence, or nil if there is none
+func childNode(node *yaml.Node, name string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == name {
+				return resolveAlias(node.Content[i+1])
+			}
+		}
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(name)
+		if err == nil && index >= 0 && index < len(node.Content) {
+			return resolveAlias(node.Content[index])
+		}
+	}
+	return nil
+}
+
+// resolveAlias returns the node an alias refers to, or the node itself
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		return node.Alias
+	}
+	return node
+}
+
+// displayFilePath shows the local path of a file:// URI in messages, falling back to the URI itself
+func displayFilePath(fileURI string) string {
+	if path, err := parseFileURI(fileURI); err == nil {
+		return path
+	}
+	return fileURI
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+)
+
+// FormatResources formats the resources of a stack as an aligned table
+func FormatResources(stackName string, resources []aws.StackResource) string {
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "Stack: %s\n\n", stackName)
+
+	if len(resources) == 0 {
+		output.WriteString("No resources\n")
+		return output.String()
+	}
+
+	logicalWidth, typeWidth, statusWidth := len("LOGICAL ID"), len("TYPE"), len("STATUS")
+	for _, resource := range resources {
+		logicalWidth = max(logicalWidth, len(resource.LogicalID))
+		typeWidth = max(typeWidth, len(resource.ResourceType))
+		statusWidth = max(statusWidth, len(resource.Status))
+	}
+
+	fmt.Fprintf(&output, "%-*s  %-*s  %-*s  %s\n", logicalWidth, "LOGICAL ID", typeWidth, "TYPE", statusWidth, "STATUS", "PHYSICAL ID")
+	for _, resource := range resources {
+		physicalID := resource.PhysicalID
+		if physicalID == "" {
+			physicalID = "-"
+		}
+		fmt.Fprintf(&output, "%-*s  %-*s  %-*s  %s\n", logicalWidth, resource.LogicalID, typeWidth, resource.ResourceType, statusWidth, resource.Status, physicalID)
+	}
+
+	return output.String()
+}
+
+// jsonListing is the JSON representation of the resources of a stack
+type jsonListing struct {
+	StackName string         `json:"stack_name"`
+	Context   string         `json:"context"`
+	Resources []jsonResource `json:"resources"`
+}
+
+// jsonResource is the JSON representation of a single stack resource
+type jsonResource struct {
+	LogicalID    string `json:"logical_id"`
+	PhysicalID   string `json:"physical_id"`
+	ResourceType string `json:"resource_type"`
+	Status       string `json:"status"`
+	StatusReason string `json:"status_reason,omitempty"`
+}
+
+// JSON returns the resources of a stack as an indented JSON document
+func JSON(contextName, stackName string, resources []aws.StackResource) ([]byte, error) {
+	listing := jsonListing{
+		StackName: stackName,
+		Context:   contextName,
+		Resources: make([]jsonResource, 0, len(resources)),
+	}
+	for _, resource := range resources {
+		listing.Resources = append(listing.Resources, jsonResource{
+			LogicalID:    resource.LogicalID,
+			PhysicalID:   resource.PhysicalID,
+			ResourceType: resource.ResourceType,
+			Status:       resource.Status,
+			StatusReason: resource.StatusReason,
+		})
+	}
+
+	data, err := json.MarshalIndent(listing, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resources of stack %s: %w", stackName, err)
+	}
+	return append(data, '\n'), nil
+}
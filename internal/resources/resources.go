@@ -0,0 +1,62 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+
+// Package resources lists the resources a deployed stack manages.
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+)
+
+// Lister defines the interface for listing the resources of a configured stack
+type Lister interface {
+	ListResources(ctx context.Context, contextName, stackName string) ([]aws.StackResource, error)
+}
+
+// StackLister implements the Lister interface using the configuration and AWS CloudFormation
+type StackLister struct {
+	provider      config.ConfigProvider
+	clientFactory aws.ClientFactory
+}
+
+// NewStackLister creates a new resource lister with the provided configuration and client factory
+func NewStackLister(provider config.ConfigProvider, clientFactory aws.ClientFactory) Lister {
+	return &StackLister{
+		provider:      provider,
+		clientFactory: clientFactory,
+	}
+}
+
+// ListResources returns every resource of a deployed stack in the context's region
+func (l *StackLister) ListResources(ctx context.Context, contextName, stackName string) ([]aws.StackResource, error) {
+	cfg, err := l.provider.LoadConfig(ctx, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if _, err := l.provider.GetStack(stackName, contextName); err != nil {
+		return nil, err
+	}
+
+	region := cfg.Context.Region
+	cfOps, err := l.clientFactory.GetCloudFormationOperations(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CloudFormation operations for region %s: %w", region, err)
+	}
+
+	deployedName := cfg.Context.DeployedStackName(stackName)
+	exists, err := cfOps.StackExists(ctx, deployedName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if stack exists: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("stack %s does not exist in region %s", stackName, region)
+	}
+
+	return cfOps.ListStackResources(ctx, deployedName)
+}
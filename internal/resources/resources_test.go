@@ -0,0 +1,91 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupLister returns a lister whose configuration defines stack app in the dev context
+func setupLister(ctx context.Context) (Lister, *aws.MockCloudFormationOperations) {
+	mockProvider := &config.MockConfigProvider{}
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-west-2")
+
+	mockProvider.On("LoadConfig", ctx, "dev").Return(&config.Config{
+		Context: &config.ContextConfig{Name: "dev", Region: "us-west-2"},
+	}, nil)
+	mockProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app"}, nil)
+
+	return NewStackLister(mockProvider, mockFactory), mockCFOps
+}
+
+func TestStackLister_ListResources(t *testing.T) {
+	ctx := context.Background()
+	lister, mockCFOps := setupLister(ctx)
+
+	expected := []aws.StackResource{
+		{LogicalID: "Bucket", PhysicalID: "app-bucket-123", ResourceType: "AWS::S3::Bucket", Status: "CREATE_COMPLETE"},
+	}
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("ListStackResources", ctx, "app").Return(expected, nil)
+
+	resources, err := lister.ListResources(ctx, "dev", "app")
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, resources)
+}
+
+func TestStackLister_ListResources_StackNotDeployed(t *testing.T) {
+	ctx := context.Background()
+	lister, mockCFOps := setupLister(ctx)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(false, nil)
+
+	_, err := lister.ListResources(ctx, "dev", "app")
+
+	require.EqualError(t, err, "stack app does not exist in region us-west-2")
+	mockCFOps.AssertNotCalled(t, "ListStackResources", ctx, "app")
+}
+
+func TestFormatResources(t *testing.T) {
+	output := FormatResources("app", []aws.StackResource{
+		{LogicalID: "Bucket", PhysicalID: "app-bucket-123", ResourceType: "AWS::S3::Bucket", Status: "CREATE_COMPLETE"},
+		{LogicalID: "DeadLetterQueue", ResourceType: "AWS::SQS::Queue", Status: "CREATE_IN_PROGRESS"},
+	})
+
+	assert.Equal(t, `Stack: app
+
+LOGICAL ID       TYPE             STATUS              PHYSICAL ID
+Bucket           AWS::S3::Bucket  CREATE_COMPLETE     app-bucket-123
+DeadLetterQueue  AWS::SQS::Queue  CREATE_IN_PROGRESS  -
+`, output)
+}
+
+func TestJSON(t *testing.T) {
+	data, err := JSON("dev", "app", []aws.StackResource{
+		{LogicalID: "Queue", ResourceType: "AWS::SQS::Queue", Status: "CREATE_FAILED", StatusReason: "Resource limit exceeded"},
+	})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"stack_name": "app",
+		"context": "dev",
+		"resources": [
+			{
+				"logical_id": "Queue",
+				"physical_id": "",
+				"resource_type": "AWS::SQS::Queue",
+				"status": "CREATE_FAILED",
+				"status_reason": "Resource limit exceeded"
+			}
+		]
+	}`, string(data))
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+
+// Package snapshot records the parameters and tags stackaroo last deployed for each stack,
+// so later runs can compare against what was deployed rather than what is live.
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// StackSnapshot captures the deployed parameters and tags of a single stack
+type StackSnapshot struct {
+	StackName  string            `json:"stack_name"`
+	Context    string            `json:"context"`
+	DeployedAt time.Time         `json:"deployed_at"`
+	Parameters map[string]string `json:"parameters"`
+	Tags       map[string]string `json:"tags"`
+}
+
+// Summary is the on-disk record of the most recent deployment of each stack
+type Summary struct {
+	Stacks []StackSnapshot `json:"stacks"`
+}
+
+// Load reads a summary file, returning an empty summary if the file does not exist
+func Load(path string) (*Summary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Summary{}, nil
+		}
+		return nil, fmt.Errorf("failed to read summary file %s: %w", path, err)
+	}
+
+	var summary Summary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse summary file %s: %w", path, err)
+	}
+	return &summary, nil
+}
+
+// Save writes the summary to the given path
+func (s *Summary) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write summary file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Find returns the recorded snapshot for a stack in a context
+func (s *Summary) Find(contextName, stackName string) (*StackSnapshot, bool) {
+	for i := range s.Stacks {
+		if s.Stacks[i].Context == contextName && s.Stacks[i].StackName == stackName {
+			return &s.Stacks[i], true
+		}
+	}
+	return nil, false
+}
+
+// Record adds a snapshot, replacing any previous snapshot for the same stack and context
+func (s *Summary) Record(snapshot StackSnapshot) {
+	for i := range s.Stacks {
+		if s.Stacks[i].Context == snapshot.Context && s.Stacks[i].StackName == snapshot.StackName {
+			s.Stacks[i] = snapshot
+			return
+		}
+	}
+	s.Stacks = append(s.Stacks, snapshot)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingFile_ReturnsEmptySummary(t *testing.T) {
+	summary, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+
+	require.NoError(t, err)
+	assert.Empty(t, summary.Stacks)
+}
+
+func TestLoad_InvalidJSON_ReturnsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0644))
+
+	_, err := Load(path)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse summary file")
+}
+
+func TestSummary_SaveAndLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	deployedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	summary := &Summary{}
+	summary.Record(StackSnapshot{
+		StackName:  "vpc",
+		Context:    "dev",
+		DeployedAt: deployedAt,
+		Parameters: map[string]string{"CidrBlock": "10.0.0.0/16"},
+		Tags:       map[string]string{"Team": "platform"},
+	})
+	require.NoError(t, summary.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+
+	found, ok := loaded.Find("dev", "vpc")
+	require.True(t, ok)
+	assert.Equal(t, deployedAt, found.DeployedAt)
+	assert.Equal(t, "10.0.0.0/16", found.Parameters["CidrBlock"])
+	assert.Equal(t, "platform", found.Tags["Team"])
+}
+
+func TestSummary_Record_ReplacesExistingSnapshot(t *testing.T) {
+	summary := &Summary{}
+	summary.Record(StackSnapshot{StackName: "vpc", Context: "dev", Parameters: map[string]string{"A": "1"}})
+	summary.Record(StackSnapshot{StackName: "vpc", Context: "prod", Parameters: map[string]string{"A": "2"}})
+	summary.Record(StackSnapshot{StackName: "vpc", Context: "dev", Parameters: map[string]string{"A": "3"}})
+
+	require.Len(t, summary.Stacks, 2)
+	found, ok := summary.Find("dev", "vpc")
+	require.True(t, ok)
+	assert.Equal(t, "3", found.Parameters["A"])
+
+	_, ok = summary.Find("staging", "vpc")
+	assert.False(t, ok)
+}
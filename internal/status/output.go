@@ -0,0 +1,47 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package status
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatStatuses formats stack statuses as an aligned table
+func FormatStatuses(contextName string, statuses []StackStatus) string {
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "Context: %s\n\n", contextName)
+
+	if len(statuses) == 0 {
+		output.WriteString("No stacks configured\n")
+		return output.String()
+	}
+
+	nameWidth, statusWidth, updatedWidth := len("STACK"), len("STATUS"), len("LAST UPDATED")
+	rows := make([][4]string, len(statuses))
+	for i, stackStatus := range statuses {
+		updated := "-"
+		if stackStatus.UpdatedTime != nil {
+			updated = stackStatus.UpdatedTime.Format("2006-01-02 15:04:05 MST")
+		}
+		drift := "-"
+		if stackStatus.DriftStatus != "" {
+			drift = stackStatus.DriftStatus
+		}
+
+		rows[i] = [4]string{stackStatus.Name, stackStatus.Status, updated, drift}
+		nameWidth = max(nameWidth, len(stackStatus.Name))
+		statusWidth = max(statusWidth, len(stackStatus.Status))
+		updatedWidth = max(updatedWidth, len(updated))
+	}
+
+	fmt.Fprintf(&output, "%-*s  %-*s  %-*s  %s\n", nameWidth, "STACK", statusWidth, "STATUS", updatedWidth, "LAST UPDATED", "DRIFT")
+	for _, row := range rows {
+		fmt.Fprintf(&output, "%-*s  %-*s  %-*s  %s\n", nameWidth, row[0], statusWidth, row[1], updatedWidth, row[2], row[3])
+	}
+
+	return output.String()
+}
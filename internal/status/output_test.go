@@ -0,0 +1,32 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatStatuses_AlignsColumns(t *testing.T) {
+	updated := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	statuses := []StackStatus{
+		{Name: "app", Status: "UPDATE_COMPLETE", UpdatedTime: &updated, DriftStatus: "DRIFTED"},
+		{Name: "database", Status: NotDeployed},
+	}
+
+	expected := `Context: dev
+
+STACK     STATUS           LAST UPDATED             DRIFT
+app       UPDATE_COMPLETE  2025-03-01 09:30:00 UTC  DRIFTED
+database  NOT DEPLOYED     -                        -
+`
+	assert.Equal(t, expected, FormatStatuses("dev", statuses))
+}
+
+func TestFormatStatuses_NoStacks(t *testing.T) {
+	assert.Equal(t, "Context: dev\n\nNo stacks configured\n", FormatStatuses("dev", nil))
+}
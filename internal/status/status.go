@@ -0,0 +1,147 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+)
+
+// NotDeployed is the status shown for configured stacks that do not exist in AWS
+const NotDeployed = "NOT DEPLOYED"
+
+// StackStatus describes the live state of a configured stack
+type StackStatus struct {
+	Name        string
+	Status      string
+	UpdatedTime *time.Time // Last update, or creation time for stacks never updated
+	DriftStatus string     // Empty when drift has never been checked
+}
+
+// IsDeployed reports whether the stack exists in AWS
+func (s StackStatus) IsDeployed() bool {
+	return s.Status != NotDeployed
+}
+
+// IsFailed reports whether the stack is in a failed or unusable state
+func (s StackStatus) IsFailed() bool {
+	return strings.HasSuffix(s.Status, "_FAILED") || s.Status == string(aws.StackStatusRollbackComplete)
+}
+
+// FailedStacksError indicates that one or more stacks are in a failed state
+type FailedStacksError struct {
+	StackNames []string
+}
+
+func (e FailedStacksError) Error() string {
+	return fmt.Sprintf("stacks in a failed state: %s", strings.Join(e.StackNames, ", "))
+}
+
+// Checker defines the interface for retrieving the live state of configured stacks
+type Checker interface {
+	// CheckStatus returns the status of the named stacks, or of every configured stack when none are named
+	CheckStatus(ctx context.Context, contextName string, stackNames []string) ([]StackStatus, error)
+}
+
+// StackChecker implements the Checker interface using the configuration and AWS CloudFormation
+type StackChecker struct {
+	provider      config.ConfigProvider
+	clientFactory aws.ClientFactory
+}
+
+// NewStackChecker creates a new status checker with the provided configuration and client factory
+func NewStackChecker(provider config.ConfigProvider, clientFactory aws.ClientFactory) Checker {
+	return &StackChecker{
+		provider:      provider,
+		clientFactory: clientFactory,
+	}
+}
+
+// CheckStatus looks up the current CloudFormation state of configured stacks
+func (c *StackChecker) CheckStatus(ctx context.Context, contextName string, stackNames []string) ([]StackStatus, error) {
+	cfg, err := c.provider.LoadConfig(ctx, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(stackNames) == 0 {
+		stackNames, err = config.EnabledStacks(c.provider, contextName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stacks: %w", err)
+		}
+	} else {
+		for _, stackName := range stackNames {
+			if _, err := c.provider.GetStack(stackName, contextName); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sorted := append([]string(nil), stackNames...)
+	sort.Strings(sorted)
+
+	cfOps, err := c.clientFactory.GetCloudFormationOperations(ctx, cfg.Context.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CloudFormation operations for region %s: %w", cfg.Context.Region, err)
+	}
+
+	statuses := make([]StackStatus, 0, len(sorted))
+	for _, stackName := range sorted {
+		stackStatus, err := checkStack(ctx, cfOps, stackName, cfg.Context.DeployedStackName(stackName))
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, stackStatus)
+	}
+
+	return statuses, nil
+}
+
+// checkStack retrieves the status of a single stack, looking it up by its deployed name
+func checkStack(ctx context.Context, cfOps aws.CloudFormationOperations, stackName, deployedName string) (StackStatus, error) {
+	exists, err := cfOps.StackExists(ctx, deployedName)
+	if err != nil {
+		return StackStatus{}, fmt.Errorf("failed to check if stack %s exists: %w", stackName, err)
+	}
+	if !exists {
+		return StackStatus{Name: stackName, Status: NotDeployed}, nil
+	}
+
+	stack, err := cfOps.GetStack(ctx, deployedName)
+	if err != nil {
+		return StackStatus{}, err
+	}
+
+	stackStatus := StackStatus{
+		Name:        stackName,
+		Status:      string(stack.Status),
+		UpdatedTime: stack.UpdatedTime,
+	}
+	if stackStatus.UpdatedTime == nil {
+		stackStatus.UpdatedTime = stack.CreatedTime
+	}
+	if stack.DriftStatus != "NOT_CHECKED" {
+		stackStatus.DriftStatus = stack.DriftStatus
+	}
+
+	return stackStatus, nil
+}
+
+// FailedStacks returns the names of stacks in a failed state
+func FailedStacks(statuses []StackStatus) []string {
+	var failed []string
+	for _, stackStatus := range statuses {
+		if stackStatus.IsFailed() {
+			failed = append(failed, stackStatus.Name)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,147 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(region string) *config.Config {
+	return &config.Config{
+		Context: &config.ContextConfig{Name: "dev", Region: region},
+	}
+}
+
+func TestStackChecker_CheckStatus_AllStacks(t *testing.T) {
+	ctx := context.Background()
+	mockProvider := &config.MockConfigProvider{}
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-west-2")
+	checker := NewStackChecker(mockProvider, mockFactory)
+
+	updated := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	created := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	mockProvider.On("LoadConfig", ctx, "dev").Return(newTestConfig("us-west-2"), nil)
+	mockProvider.On("ListStacks", "dev").Return([]string{"vpc", "app", "db", "legacy"}, nil)
+	for _, stackName := range []string{"vpc", "app", "db"} {
+		mockProvider.On("GetStack", stackName, "dev").Return(&config.StackConfig{Name: stackName}, nil)
+	}
+	// Stacks the context disables are not reported
+	mockProvider.On("GetStack", "legacy", "dev").Return(&config.StackConfig{Name: "legacy", Disabled: true}, nil)
+
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "app").Return(&aws.Stack{
+		Name:        "app",
+		Status:      aws.StackStatusUpdateComplete,
+		CreatedTime: &created,
+		UpdatedTime: &updated,
+		DriftStatus: "DRIFTED",
+	}, nil)
+	mockCFOps.On("StackExists", ctx, "db").Return(false, nil)
+	mockCFOps.On("StackExists", ctx, "vpc").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "vpc").Return(&aws.Stack{
+		Name:        "vpc",
+		Status:      aws.StackStatusCreateComplete,
+		CreatedTime: &created,
+		DriftStatus: "NOT_CHECKED",
+	}, nil)
+
+	statuses, err := checker.CheckStatus(ctx, "dev", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []StackStatus{
+		{Name: "app", Status: "UPDATE_COMPLETE", UpdatedTime: &updated, DriftStatus: "DRIFTED"},
+		{Name: "db", Status: NotDeployed},
+		{Name: "vpc", Status: "CREATE_COMPLETE", UpdatedTime: &created},
+	}, statuses)
+	assert.False(t, statuses[1].IsDeployed())
+	assert.Empty(t, FailedStacks(statuses))
+	mockCFOps.AssertExpectations(t)
+}
+
+func TestStackChecker_CheckStatus_SingleStack(t *testing.T) {
+	ctx := context.Background()
+	mockProvider := &config.MockConfigProvider{}
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-west-2")
+	checker := NewStackChecker(mockProvider, mockFactory)
+
+	mockProvider.On("LoadConfig", ctx, "dev").Return(newTestConfig("us-west-2"), nil)
+	mockProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app"}, nil)
+	mockCFOps.On("StackExists", ctx, "app").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "app").Return(&aws.Stack{Name: "app", Status: aws.StackStatusRollbackComplete}, nil)
+
+	statuses, err := checker.CheckStatus(ctx, "dev", []string{"app"})
+
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].IsFailed())
+	assert.Equal(t, []string{"app"}, FailedStacks(statuses))
+	mockProvider.AssertNotCalled(t, "ListStacks", "dev")
+}
+
+func TestStackChecker_CheckStatus_StackNamePrefix(t *testing.T) {
+	ctx := context.Background()
+	mockProvider := &config.MockConfigProvider{}
+	mockFactory, mockCFOps := aws.NewMockClientFactoryForRegion("us-west-2")
+	checker := NewStackChecker(mockProvider, mockFactory)
+
+	cfg := newTestConfig("us-west-2")
+	cfg.Context.StackNamePrefix = "dev-"
+	mockProvider.On("LoadConfig", ctx, "dev").Return(cfg, nil)
+	mockProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app"}, nil)
+	mockCFOps.On("StackExists", ctx, "dev-app").Return(true, nil)
+	mockCFOps.On("GetStack", ctx, "dev-app").Return(&aws.Stack{Name: "dev-app", Status: aws.StackStatusCreateComplete}, nil)
+
+	statuses, err := checker.CheckStatus(ctx, "dev", []string{"app"})
+
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "app", statuses[0].Name)
+	assert.Equal(t, "CREATE_COMPLETE", statuses[0].Status)
+	mockCFOps.AssertExpectations(t)
+}
+
+func TestStackChecker_CheckStatus_UnknownStack(t *testing.T) {
+	ctx := context.Background()
+	mockProvider := &config.MockConfigProvider{}
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-west-2")
+	checker := NewStackChecker(mockProvider, mockFactory)
+
+	mockProvider.On("LoadConfig", ctx, "dev").Return(newTestConfig("us-west-2"), nil)
+	mockProvider.On("GetStack", "missing", "dev").Return(nil, errors.New("stack 'missing' not found in configuration"))
+
+	_, err := checker.CheckStatus(ctx, "dev", []string{"missing"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stack 'missing' not found")
+}
+
+func TestStackStatus_IsFailed(t *testing.T) {
+	tests := []struct {
+		status   string
+		expected bool
+	}{
+		{"CREATE_COMPLETE", false},
+		{"UPDATE_ROLLBACK_COMPLETE", false},
+		{NotDeployed, false},
+		{"CREATE_FAILED", true},
+		{"UPDATE_ROLLBACK_FAILED", true},
+		{"ROLLBACK_COMPLETE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StackStatus{Status: tt.status}.IsFailed())
+		})
+	}
+}
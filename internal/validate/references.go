@@ -0,0 +1,280 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pseudoParameters are the parameters CloudFormation defines for every template
+var pseudoParameters = map[string]bool{
+	"AWS::AccountId":        true,
+	"AWS::NotificationARNs": true,
+	"AWS::NoValue":          true,
+	"AWS::Partition":        true,
+	"AWS::Region":           true,
+	"AWS::StackId":          true,
+	"AWS::StackName":        true,
+	"AWS::URLSuffix":        true,
+}
+
+// referenceSections are the top-level template sections whose Ref and Fn::GetAtt uses are checked
+var referenceSections = []string{"Rules", "Conditions", "Resources", "Outputs"}
+
+// subVariablePattern matches the ${Name} and ${Resource.Attribute} variables of an Fn::Sub string,
+// but not the ${!Literal} escape
+var subVariablePattern = regexp.MustCompile(`\$\{([^!}][^}]*)\}`)
+
+// DanglingReference is a reference in a template to a logical ID the template does not define
+type DanglingReference struct {
+	Kind     string // Ref, Fn::GetAtt or DependsOn
+	Target   string // The undefined logical ID
+	Location string // Where the reference appears, such as Resources.Bucket
+}
+
+// DanglingReferencesError reports the references in a template to undefined logical IDs
+type DanglingReferencesError struct {
+	References []DanglingReference
+}
+
+func (e DanglingReferencesError) Error() string {
+	descriptions := make([]string, len(e.References))
+	for i, ref := range e.References {
+		descriptions[i] = fmt.Sprintf("%s to '%s' in %s", ref.Kind, ref.Target, ref.Location)
+	}
+	return "template references undefined logical IDs: " + strings.Join(descriptions, "; ")
+}
+
+// referenceChecker collects the dangling references of a single template
+type referenceChecker struct {
+	parameters map[string]bool
+	resources  map[string]bool
+	used       map[string]bool // Parameters referenced by Ref or Fn::Sub
+	seen       map[DanglingReference]bool
+	dangling   []DanglingReference
+}
+
+// checkReferences reports Ref, Fn::GetAtt and DependsOn uses that name a logical ID the template
+// does not define. Refs may name parameters, resources or pseudo-parameters; Fn::GetAtt and
+// DependsOn must name resources. Templates that cannot be parsed are left for CloudFormation to
+// reject, and templates declaring a Transform are skipped, as macros add resources of their own.
+func checkReferences(templateBody string) error {
+	checker := scanReferences(templateBody)
+	if checker == nil || len(checker.dangling) == 0 {
+		return nil
+	}
+	return DanglingReferencesError{References: checker.dangling}
+}
+
+// unusedParameters returns, in name order, the parameters a template declares but never
+// references. Templates are skipped on the same terms as checkReferences.
+func unusedParameters(templateBody string) []string {
+	checker := scanReferences(templateBody)
+	if checker == nil {
+		return nil
+	}
+
+	var unused []string
+	for name := range checker.parameters {
+		if !checker.used[name] {
+			unused = append(unused, name)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// scanReferences walks the references of a template, or returns nil for a template that
+// cannot be parsed or declares a Transform
+func scanReferences(templateBody string) *referenceChecker {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(templateBody), &document); err != nil || len(document.Content) == 0 {
+		return nil
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode || mappingValue(root, "Transform") != nil {
+		return nil
+	}
+
+	checker := &referenceChecker{
+		parameters: mappingKeys(mappingValue(root, "Parameters")),
+		resources:  mappingKeys(mappingValue(root, "Resources")),
+		used:       make(map[string]bool),
+		seen:       make(map[DanglingReference]bool),
+	}
+
+	for _, section := range referenceSections {
+		entries := mappingValue(root, section)
+		if entries == nil || entries.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(entries.Content); i += 2 {
+			location := section + "." + entries.Content[i].Value
+			entry := entries.Content[i+1]
+			checker.walk(entry, location)
+			if section == "Resources" {
+				checker.checkDependsOn(mappingValue(entry, "DependsOn"), location)
+			}
+		}
+	}
+
+	return checker
+}
+
+// walk checks every reference within a node, in both the long form (Ref: Name) and the
+// short form (!Ref Name) CloudFormation accepts in YAML templates
+func (c *referenceChecker) walk(node *yaml.Node, location string) {
+	if node == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!Ref":
+			c.checkRef(node.Value, location)
+		case "!GetAtt":
+			c.checkGetAtt(node, location)
+		case "!Sub":
+			c.markSubstitutions(node)
+		}
+	case yaml.SequenceNode:
+		switch node.Tag {
+		case "!GetAtt":
+			c.checkGetAtt(node, location)
+			return
+		case "!Sub":
+			c.markSubstitutions(node)
+		}
+		for _, item := range node.Content {
+			c.walk(item, location)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			switch {
+			case key == "Ref" && value.Kind == yaml.ScalarNode:
+				c.checkRef(value.Value, location)
+			case key == "Fn::GetAtt":
+				c.checkGetAtt(value, location)
+			case key == "Fn::Sub":
+				c.markSubstitutions(value)
+				c.walk(value, location)
+			default:
+				c.walk(value, location)
+			}
+		}
+	}
+}
+
+// checkRef records a Ref to anything other than a parameter, resource or pseudo-parameter
+func (c *referenceChecker) checkRef(target, location string) {
+	if c.parameters[target] {
+		c.used[target] = true
+		return
+	}
+	if c.resources[target] || pseudoParameters[target] {
+		return
+	}
+	c.record(DanglingReference{Kind: "Ref", Target: target, Location: location})
+}
+
+// markSubstitutions marks the parameters named by the variables of an Fn::Sub string, given
+// either alone or as the first item of a list
+func (c *referenceChecker) markSubstitutions(node *yaml.Node) {
+	if node.Kind == yaml.SequenceNode {
+		if len(node.Content) == 0 {
+			return
+		}
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.ScalarNode {
+		return
+	}
+
+	for _, match := range subVariablePattern.FindAllStringSubmatch(node.Value, -1) {
+		name, _, _ := strings.Cut(strings.TrimSpace(match[1]), ".")
+		if c.parameters[name] {
+			c.used[name] = true
+		}
+	}
+}
+
+// checkGetAtt records a Fn::GetAtt of anything other than a resource. The resource is named
+// either before the first dot of a string or as the first item of a list.
+func (c *referenceChecker) checkGetAtt(node *yaml.Node, location string) {
+	var target string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		target, _, _ = strings.Cut(node.Value, ".")
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 || node.Content[0].Kind != yaml.ScalarNode {
+			return
+		}
+		target = node.Content[0].Value
+	default:
+		return
+	}
+
+	if !c.resources[target] {
+		c.record(DanglingReference{Kind: "Fn::GetAtt", Target: target, Location: location})
+	}
+}
+
+// checkDependsOn records DependsOn entries, a single name or a list, that are not resources
+func (c *referenceChecker) checkDependsOn(node *yaml.Node, location string) {
+	if node == nil {
+		return
+	}
+
+	targets := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		targets = node.Content
+	}
+	for _, target := range targets {
+		if target.Kind == yaml.ScalarNode && !c.resources[target.Value] {
+			c.record(DanglingReference{Kind: "DependsOn", Target: target.Value, Location: location})
+		}
+	}
+}
+
+// record adds a dangling reference, reporting each one only once per location
+func (c *referenceChecker) record(ref DanglingReference) {
+	if c.seen[ref] {
+		return
+	}
+	c.seen[ref] = true
+	c.dangling = append(c.dangling, ref)
+}
+
+// mappingKeys returns the keys of a mapping node, or an empty set for any other node
+func mappingKeys(node *yaml.Node) map[string]bool {
+	keys := make(map[string]bool)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return keys
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		keys[node.Content[i].Value] = true
+	}
+	return keys
+}
+
+// mappingValue returns the value stored under key in a mapping node, or nil if absent
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
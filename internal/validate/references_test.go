@@ -0,0 +1,196 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckReferences_ValidReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{
+			name: "YAML short form with pseudo-parameters",
+			template: `
+Parameters:
+  Environment:
+    Type: String
+Conditions:
+  IsProd: !Equals [!Ref Environment, prod]
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Sub "${AWS::StackName}-bucket"
+      Tags:
+        - Key: Region
+          Value: !Ref AWS::Region
+        - Key: Account
+          Value: !Ref "AWS::AccountId"
+  Policy:
+    Type: AWS::S3::BucketPolicy
+    DependsOn: [Bucket]
+    Properties:
+      Bucket: !Ref Bucket
+      PolicyDocument:
+        Resource: !GetAtt Bucket.Arn
+Outputs:
+  BucketArn:
+    Value: !GetAtt [Bucket, Arn]
+  Partition:
+    Value: !Ref AWS::Partition
+`,
+		},
+		{
+			name:     "JSON long form",
+			template: `{"Parameters": {"Name": {"Type": "String"}}, "Resources": {"Queue": {"Type": "AWS::SQS::Queue", "Properties": {"QueueName": {"Ref": "Name"}}}, "Alarm": {"Type": "AWS::CloudWatch::Alarm", "DependsOn": "Queue", "Properties": {"Dimensions": [{"Value": {"Fn::GetAtt": ["Queue", "QueueName"]}}]}}}}`,
+		},
+		{
+			name:     "template declaring a transform",
+			template: "Transform: AWS::Serverless-2016-10-31\nResources:\n  Function:\n    Type: AWS::Serverless::Function\n    Properties:\n      Role: !GetAtt FunctionRole.Arn\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, checkReferences(tt.template))
+		})
+	}
+}
+
+func TestCheckReferences_DanglingReferences(t *testing.T) {
+	template := `
+Parameters:
+  Environment:
+    Type: String
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    DependsOn:
+      - Vpc
+    Properties:
+      BucketName: !Ref BucketName
+      Tags:
+        - Key: Name
+          Value: !Ref BucketName
+        - Key: Region
+          Value: !Ref AWS::Regoin
+Outputs:
+  QueueArn:
+    Value:
+      Fn::GetAtt: [Queue, Arn]
+  EnvironmentArn:
+    Value: !GetAtt Environment.Arn
+`
+
+	err := checkReferences(template)
+
+	var danglingErr DanglingReferencesError
+	require.ErrorAs(t, err, &danglingErr)
+	assert.Equal(t, []DanglingReference{
+		{Kind: "Ref", Target: "BucketName", Location: "Resources.Bucket"},
+		{Kind: "Ref", Target: "AWS::Regoin", Location: "Resources.Bucket"},
+		{Kind: "DependsOn", Target: "Vpc", Location: "Resources.Bucket"},
+		{Kind: "Fn::GetAtt", Target: "Queue", Location: "Outputs.QueueArn"},
+		{Kind: "Fn::GetAtt", Target: "Environment", Location: "Outputs.EnvironmentArn"},
+	}, danglingErr.References)
+}
+
+func TestUnusedParameters(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected []string
+	}{
+		{
+			name: "Ref, Fn::Sub and Rules uses",
+			template: `
+Parameters:
+  Environment:
+    Type: String
+  Prefix:
+    Type: String
+  Suffix:
+    Type: String
+  Size:
+    Type: Number
+  Unused:
+    Type: String
+  AlsoUnused:
+    Type: String
+Rules:
+  ProdSize:
+    Assertions:
+      - Assert: !Not [!Equals [!Ref Size, "0"]]
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Sub "${Prefix}-${AWS::Region}-${!Unused}"
+      Tags:
+        - Key: Name
+          Value:
+            Fn::Sub:
+              - "${Name}-${Suffix}"
+              - Name: !Ref Environment
+`,
+			expected: []string{"AlsoUnused", "Unused"},
+		},
+		{
+			name:     "JSON long form",
+			template: `{"Parameters": {"Name": {"Type": "String"}, "Spare": {"Type": "String"}}, "Resources": {"Queue": {"Type": "AWS::SQS::Queue", "Properties": {"QueueName": {"Fn::Sub": "${Name}-queue"}}}}}`,
+			expected: []string{"Spare"},
+		},
+		{
+			name:     "template with a transform is not checked",
+			template: "Transform: AWS::Serverless-2016-10-31\nParameters:\n  Unused:\n    Type: String\n",
+		},
+		{
+			name:     "unparseable template is not checked",
+			template: "Parameters: [",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, unusedParameters(tt.template))
+		})
+	}
+}
+
+func TestTemplateValidator_ValidateSingleStack_DanglingReference(t *testing.T) {
+	// Dangling references are reported without asking CloudFormation
+	ctx := context.Background()
+	testStack := &model.Stack{
+		Name:         "app",
+		Context:      &model.Context{Name: "dev", Region: "us-east-1"},
+		TemplateBody: "Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n    Properties:\n      BucketName: !Ref Missing\n",
+	}
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	mockResolver.On("ResolveStack", ctx, "dev", "app").Return(testStack, nil)
+
+	validator := NewTemplateValidator(mockFactory, &config.MockConfigProvider{}, mockResolver)
+	err := validator.ValidateSingleStack(ctx, "app", "dev")
+
+	require.EqualError(t, err, "template references undefined logical IDs: Ref to 'Missing' in Resources.Bucket")
+	mockCfnOps.AssertNotCalled(t, "ValidateTemplate", mock.Anything, mock.Anything)
+	assert.Equal(t, []ValidationIssue{{
+		Title:  "Undefined Reference",
+		Detail: "Ref in Resources.Bucket refers to 'Missing', which the template does not define",
+	}}, parseValidationError(err))
+}
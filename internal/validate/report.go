@@ -0,0 +1,161 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+)
+
+// Severity classifies a validation finding
+type Severity string
+
+const (
+	// SeverityError marks a finding that fails validation
+	SeverityError Severity = "error"
+	// SeverityWarning marks a finding worth attention that does not fail validation
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a single issue found while validating a stack
+type Finding struct {
+	Stack    string   `json:"stack"`
+	File     string   `json:"file,omitempty"`     // Template the finding applies to, when known
+	Location string   `json:"location,omitempty"` // Where in the template, such as Resources.Bucket
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Report holds the findings of validating the stacks of a context
+type Report struct {
+	Context  string    `json:"context"`
+	Valid    bool      `json:"valid"` // False when any finding is an error
+	Findings []Finding `json:"findings"`
+}
+
+// Report validates a single stack, or every stack in the context when stackName is empty,
+// collecting findings instead of printing them. Only failures that would repeat for every stack,
+// such as missing credentials, are returned as errors.
+func (v *TemplateValidator) Report(ctx context.Context, contextName, stackName string) (*Report, error) {
+	stackNames := []string{stackName}
+	if stackName == "" {
+		var err error
+		stackNames, err = config.EnabledStacks(v.configProvider, contextName)
+		if err != nil {
+			return nil, err
+		}
+		stackNames = append([]string(nil), stackNames...)
+		sort.Strings(stackNames)
+	}
+
+	report := &Report{Context: contextName, Valid: true, Findings: []Finding{}}
+	for _, name := range stackNames {
+		findings, err := v.stackFindings(ctx, contextName, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, finding := range findings {
+			if finding.Severity == SeverityError {
+				report.Valid = false
+			}
+		}
+		report.Findings = append(report.Findings, findings...)
+	}
+
+	return report, nil
+}
+
+// stackFindings resolves and validates one stack, converting each problem into findings
+func (v *TemplateValidator) stackFindings(ctx context.Context, contextName, stackName string) ([]Finding, error) {
+	file := v.templateFile(contextName, stackName)
+	var credentialsErr aws.CredentialsError
+
+	stack, err := v.resolver.ResolveStack(ctx, contextName, stackName)
+	if err != nil {
+		if errors.As(err, &credentialsErr) {
+			return nil, credentialsErr
+		}
+		return []Finding{{
+			Stack:    stackName,
+			File:     file,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("failed to resolve stack: %v", err),
+		}}, nil
+	}
+
+	var findings []Finding
+	if err := v.validateStack(ctx, stack); err != nil {
+		if errors.As(err, &credentialsErr) {
+			return nil, credentialsErr
+		}
+		findings = append(findings, errorFindings(stackName, file, err)...)
+	}
+
+	for _, name := range unusedParameters(stack.TemplateBody) {
+		findings = append(findings, Finding{
+			Stack:    stackName,
+			File:     file,
+			Location: "Parameters." + name,
+			Severity: SeverityWarning,
+			Message:  unusedParameterMessage(name),
+		})
+	}
+
+	return findings, nil
+}
+
+// errorFindings converts a validation error into findings, one per reference for
+// dangling references and one per parsed issue otherwise
+func errorFindings(stackName, file string, err error) []Finding {
+	var danglingErr DanglingReferencesError
+	if errors.As(err, &danglingErr) {
+		findings := make([]Finding, len(danglingErr.References))
+		for i, ref := range danglingErr.References {
+			findings[i] = Finding{
+				Stack:    stackName,
+				File:     file,
+				Location: ref.Location,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("%s refers to '%s', which the template does not define", ref.Kind, ref.Target),
+			}
+		}
+		return findings
+	}
+
+	issues := parseValidationError(err)
+	findings := make([]Finding, len(issues))
+	for i, issue := range issues {
+		message := issue.Title
+		if issue.Detail != "" {
+			message += ": " + issue.Detail
+		}
+		findings[i] = Finding{Stack: stackName, File: file, Severity: SeverityError, Message: message}
+	}
+	return findings
+}
+
+// templateFile returns the configured template of a stack as a path for local files, or as
+// its URI otherwise. It is empty when the stack or its template cannot be found.
+func (v *TemplateValidator) templateFile(contextName, stackName string) string {
+	stackConfig, err := v.configProvider.GetStack(stackName, contextName)
+	if err != nil || stackConfig == nil {
+		return ""
+	}
+	if parsed, err := url.Parse(stackConfig.Template); err == nil && parsed.Scheme == "file" {
+		return parsed.Path
+	}
+	return stackConfig.Template
+}
+
+// unusedParameterMessage describes a parameter the template declares but never references
+func unusedParameterMessage(name string) string {
+	return fmt.Sprintf("parameter '%s' is declared but never referenced", name)
+}
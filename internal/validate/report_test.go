@@ -0,0 +1,97 @@
+/*
+Copyright © 2025 Stackaroo Contributors
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package validate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/orien/stackaroo/internal/aws"
+	"codeberg.org/orien/stackaroo/internal/config"
+	"codeberg.org/orien/stackaroo/internal/model"
+	"codeberg.org/orien/stackaroo/internal/resolve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateValidator_Report_AllStacks(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	mockConfigProvider := &config.MockConfigProvider{}
+
+	validStack := &model.Stack{
+		Name:         "vpc",
+		Context:      &model.Context{Name: "dev", Region: "us-east-1"},
+		TemplateBody: "Resources:\n  Vpc:\n    Type: AWS::EC2::VPC\n",
+	}
+
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"vpc", "app"}, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app", Template: "file:///work/templates/app.yaml"}, nil)
+	mockConfigProvider.On("GetStack", "vpc", "dev").Return(&config.StackConfig{Name: "vpc", Template: "s3://templates/vpc.yaml"}, nil)
+	mockResolver.On("ResolveStack", ctx, "dev", "app").Return(nil, errors.New("parameter Size is required"))
+	mockResolver.On("ResolveStack", ctx, "dev", "vpc").Return(validStack, nil)
+	mockCfnOps.On("ValidateTemplate", ctx, validStack.TemplateBody).Return(nil)
+
+	validator := NewTemplateValidator(mockFactory, mockConfigProvider, mockResolver)
+	report, err := validator.Report(ctx, "dev", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, &Report{
+		Context: "dev",
+		Valid:   false,
+		Findings: []Finding{{
+			Stack:    "app",
+			File:     "/work/templates/app.yaml",
+			Severity: SeverityError,
+			Message:  "failed to resolve stack: parameter Size is required",
+		}},
+	}, report)
+}
+
+func TestTemplateValidator_Report_SingleValidStack(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	mockConfigProvider := &config.MockConfigProvider{}
+
+	stack := &model.Stack{
+		Name:         "vpc",
+		Context:      &model.Context{Name: "dev", Region: "us-east-1"},
+		TemplateBody: "Resources:\n  Vpc:\n    Type: AWS::EC2::VPC\n",
+	}
+	mockConfigProvider.On("GetStack", "vpc", "dev").Return(&config.StackConfig{Name: "vpc", Template: "s3://templates/vpc.yaml"}, nil)
+	mockResolver.On("ResolveStack", ctx, "dev", "vpc").Return(stack, nil)
+	mockCfnOps.On("ValidateTemplate", ctx, stack.TemplateBody).Return(nil)
+
+	validator := NewTemplateValidator(mockFactory, mockConfigProvider, mockResolver)
+	report, err := validator.Report(ctx, "dev", "vpc")
+
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Context: "dev", Valid: true, Findings: []Finding{}}, report)
+	mockConfigProvider.AssertNotCalled(t, "ListStacks", mock.Anything)
+}
+
+func TestTemplateValidator_Report_CredentialsErrorStops(t *testing.T) {
+	ctx := context.Background()
+	mockFactory, _ := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	mockConfigProvider := &config.MockConfigProvider{}
+
+	expired := aws.CredentialsError{Err: errors.New("ExpiredToken")}
+	mockConfigProvider.On("ListStacks", "dev").Return([]string{"app", "vpc"}, nil)
+	mockConfigProvider.On("GetStack", "app", "dev").Return(&config.StackConfig{Name: "app"}, nil)
+	mockConfigProvider.On("GetStack", "vpc", "dev").Return(&config.StackConfig{Name: "vpc"}, nil)
+	mockResolver.On("ResolveStack", ctx, "dev", "app").Return(nil, expired)
+
+	validator := NewTemplateValidator(mockFactory, mockConfigProvider, mockResolver)
+	report, err := validator.Report(ctx, "dev", "")
+
+	require.ErrorAs(t, err, &aws.CredentialsError{})
+	assert.Nil(t, report)
+	mockResolver.AssertNotCalled(t, "ResolveStack", ctx, "dev", "vpc")
+}
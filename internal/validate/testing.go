@@ -26,3 +26,12 @@ func (m *MockValidator) ValidateAllStacks(ctx context.Context, contextName strin
 	args := m.Called(ctx, contextName)
 	return args.Error(0)
 }
+
+// Report mocks the Report method
+func (m *MockValidator) Report(ctx context.Context, contextName, stackName string) (*Report, error) {
+	args := m.Called(ctx, contextName, stackName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Report), args.Error(1)
+}
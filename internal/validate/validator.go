@@ -6,9 +6,11 @@ package validate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 
 	"charm.land/lipgloss/v2"
@@ -22,6 +24,8 @@ import (
 type Validator interface {
 	ValidateSingleStack(ctx context.Context, stackName, contextName string) error
 	ValidateAllStacks(ctx context.Context, contextName string) error
+	// Report validates a stack, or all stacks when stackName is empty, returning structured findings
+	Report(ctx context.Context, contextName, stackName string) (*Report, error)
 }
 
 // ValidationStyles contains styles for validation output
@@ -106,13 +110,14 @@ func (v *TemplateValidator) ValidateSingleStack(ctx context.Context, stackName,
 	}
 
 	fmt.Printf("\n%s Template is valid for stack '%s'\n", v.styles.Success.Render("✓"), stackName)
+	v.printWarnings(stack)
 	return nil
 }
 
 // ValidateAllStacks validates all stacks in a context
 func (v *TemplateValidator) ValidateAllStacks(ctx context.Context, contextName string) error {
-	// Get list of all stacks in the context
-	stackNames, err := v.configProvider.ListStacks(contextName)
+	// Get list of all stacks in the context, other than those it disables
+	stackNames, err := config.EnabledStacks(v.configProvider, contextName)
 	if err != nil {
 		return err
 	}
@@ -122,10 +127,15 @@ func (v *TemplateValidator) ValidateAllStacks(ctx context.Context, contextName s
 		return nil
 	}
 
+	// Report stacks in name order so repeated runs, such as CI checks, produce the same output
+	stackNames = append([]string(nil), stackNames...)
+	sort.Strings(stackNames)
+
 	fmt.Printf("Validating %s stack(s) in context '%s'...\n\n", v.styles.Title.Render(fmt.Sprintf("%d", len(stackNames))), contextName)
 
 	results := make([]ValidationResult, 0, len(stackNames))
 	hasErrors := false
+	var credentialsErr aws.CredentialsError
 
 	// Validate each stack
 	for _, stackName := range stackNames {
@@ -135,6 +145,10 @@ func (v *TemplateValidator) ValidateAllStacks(ctx context.Context, contextName s
 		stack, err := v.resolver.ResolveStack(ctx, contextName, stackName)
 		if err != nil {
 			fmt.Printf("%s\n", v.styles.Error.Render("✗"))
+			// Credential failures would repeat for every remaining stack
+			if errors.As(err, &credentialsErr) {
+				return credentialsErr
+			}
 			resolveErr := fmt.Errorf("failed to resolve stack: %w", err)
 			results = append(results, ValidationResult{
 				StackName: stackName,
@@ -149,6 +163,9 @@ func (v *TemplateValidator) ValidateAllStacks(ctx context.Context, contextName s
 		// Validate the template
 		if err := v.validateStack(ctx, stack); err != nil {
 			fmt.Printf("%s\n", v.styles.Error.Render("✗"))
+			if errors.As(err, &credentialsErr) {
+				return credentialsErr
+			}
 			results = append(results, ValidationResult{
 				StackName: stackName,
 				Valid:     false,
@@ -158,6 +175,7 @@ func (v *TemplateValidator) ValidateAllStacks(ctx context.Context, contextName s
 			hasErrors = true
 		} else {
 			fmt.Printf("%s\n", v.styles.Success.Render("✓"))
+			v.printWarnings(stack)
 			results = append(results, ValidationResult{
 				StackName: stackName,
 				Valid:     true,
@@ -175,8 +193,13 @@ func (v *TemplateValidator) ValidateAllStacks(ctx context.Context, contextName s
 	return nil
 }
 
-// validateStack validates a resolved stack's template using AWS CloudFormation API
+// validateStack validates a resolved stack's template, first checking its references locally
+// and then using the AWS CloudFormation API
 func (v *TemplateValidator) validateStack(ctx context.Context, stack *model.Stack) error {
+	if err := checkReferences(stack.TemplateBody); err != nil {
+		return err
+	}
+
 	// Get CloudFormation operations for the stack's region
 	cfnOps, err := v.clientFactory.GetCloudFormationOperations(ctx, stack.Context.Region)
 	if err != nil {
@@ -191,6 +214,13 @@ func (v *TemplateValidator) validateStack(ctx context.Context, stack *model.Stac
 	return nil
 }
 
+// printWarnings prints findings that do not fail validation, such as unused parameters
+func (v *TemplateValidator) printWarnings(stack *model.Stack) {
+	for _, name := range unusedParameters(stack.TemplateBody) {
+		fmt.Printf("  %s %s\n", v.styles.Warning.Render("⚠"), unusedParameterMessage(name))
+	}
+}
+
 // printValidationError formats and prints a user-friendly validation error report
 func (v *TemplateValidator) printValidationError(stackName string, err error) {
 	fmt.Printf("\n%s Validation failed for stack '%s'\n\n", v.styles.Error.Render("✗"), stackName)
@@ -222,6 +252,19 @@ func parseValidationError(err error) []ValidationIssue {
 		return nil
 	}
 
+	// References checked locally are already structured
+	var danglingErr DanglingReferencesError
+	if errors.As(err, &danglingErr) {
+		issues := make([]ValidationIssue, len(danglingErr.References))
+		for i, ref := range danglingErr.References {
+			issues[i] = ValidationIssue{
+				Title:  "Undefined Reference",
+				Detail: fmt.Sprintf("%s in %s refers to '%s', which the template does not define", ref.Kind, ref.Location, ref.Target),
+			}
+		}
+		return issues
+	}
+
 	errMsg := err.Error()
 	var issues []ValidationIssue
 
@@ -14,6 +14,7 @@ import (
 	"codeberg.org/orien/stackaroo/internal/model"
 	"codeberg.org/orien/stackaroo/internal/resolve"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -130,6 +131,9 @@ func TestTemplateValidator_ValidateAllStacks_Success(t *testing.T) {
 	mockConfigProvider := &config.MockConfigProvider{}
 
 	mockConfigProvider.On("ListStacks", contextName).Return(stackNames, nil)
+	for _, stackName := range stackNames {
+		mockConfigProvider.On("GetStack", stackName, contextName).Return(&config.StackConfig{Name: stackName}, nil)
+	}
 
 	// Mock each stack resolution and validation
 	for _, stackName := range stackNames {
@@ -158,6 +162,36 @@ func TestTemplateValidator_ValidateAllStacks_Success(t *testing.T) {
 	mockCfnOps.AssertExpectations(t)
 }
 
+func TestTemplateValidator_ValidateAllStacks_SkipsDisabledStacks(t *testing.T) {
+	// Test that stacks disabled in the context are neither resolved nor validated
+	ctx := context.Background()
+	contextName := "development"
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	mockConfigProvider := &config.MockConfigProvider{}
+
+	mockConfigProvider.On("ListStacks", contextName).Return([]string{"app", "legacy"}, nil)
+	mockConfigProvider.On("GetStack", "app", contextName).Return(&config.StackConfig{Name: "app"}, nil)
+	mockConfigProvider.On("GetStack", "legacy", contextName).Return(&config.StackConfig{Name: "legacy", Disabled: true}, nil)
+
+	testStack := &model.Stack{
+		Name:         "app",
+		Context:      &model.Context{Name: contextName, Region: "us-east-1"},
+		TemplateBody: `{"AWSTemplateFormatVersion": "2010-09-09"}`,
+	}
+	mockResolver.On("ResolveStack", ctx, contextName, "app").Return(testStack, nil)
+	mockCfnOps.On("ValidateTemplate", ctx, testStack.TemplateBody).Return(nil)
+
+	validator := NewTemplateValidator(mockFactory, mockConfigProvider, mockResolver)
+
+	err := validator.ValidateAllStacks(ctx, contextName)
+
+	assert.NoError(t, err)
+	mockResolver.AssertNotCalled(t, "ResolveStack", ctx, contextName, "legacy")
+	mockCfnOps.AssertExpectations(t)
+}
+
 func TestTemplateValidator_ValidateAllStacks_MixedResults(t *testing.T) {
 	// Test validation with some valid and some invalid templates
 	ctx := context.Background()
@@ -170,6 +204,9 @@ func TestTemplateValidator_ValidateAllStacks_MixedResults(t *testing.T) {
 	mockConfigProvider := &config.MockConfigProvider{}
 
 	mockConfigProvider.On("ListStacks", contextName).Return(stackNames, nil)
+	for _, stackName := range stackNames {
+		mockConfigProvider.On("GetStack", stackName, contextName).Return(&config.StackConfig{Name: stackName}, nil)
+	}
 
 	// vpc - valid
 	vpcStack := &model.Stack{
@@ -221,6 +258,35 @@ func TestTemplateValidator_ValidateAllStacks_MixedResults(t *testing.T) {
 	mockCfnOps.AssertExpectations(t)
 }
 
+func TestTemplateValidator_ValidateAllStacks_ValidatesInNameOrder(t *testing.T) {
+	ctx := context.Background()
+	contextName := "development"
+
+	mockFactory, mockCfnOps := aws.NewMockClientFactoryForRegion("us-east-1")
+	mockResolver := &resolve.MockResolver{}
+	mockConfigProvider := &config.MockConfigProvider{}
+
+	mockConfigProvider.On("ListStacks", contextName).Return([]string{"vpc", "app", "database"}, nil)
+	for _, stackName := range []string{"vpc", "app", "database"} {
+		mockConfigProvider.On("GetStack", stackName, contextName).Return(&config.StackConfig{Name: stackName}, nil)
+	}
+	for _, name := range []string{"vpc", "app", "database"} {
+		stack := model.NewTestStack(name, model.NewTestContext(contextName, "us-east-1", "123456789012"))
+		mockResolver.On("ResolveStack", ctx, contextName, name).Return(stack, nil)
+	}
+	mockCfnOps.On("ValidateTemplate", ctx, mock.Anything).Return(nil)
+
+	validator := NewTemplateValidator(mockFactory, mockConfigProvider, mockResolver)
+	err := validator.ValidateAllStacks(ctx, contextName)
+
+	require.NoError(t, err)
+	var order []string
+	for _, call := range mockResolver.Calls {
+		order = append(order, call.Arguments.String(2))
+	}
+	assert.Equal(t, []string{"app", "database", "vpc"}, order)
+}
+
 func TestTemplateValidator_ValidateAllStacks_NoStacks(t *testing.T) {
 	// Test validation when no stacks are defined
 	ctx := context.Background()
@@ -281,6 +347,9 @@ func TestTemplateValidator_ValidateAllStacks_ResolveFailure(t *testing.T) {
 	mockConfigProvider := &config.MockConfigProvider{}
 
 	mockConfigProvider.On("ListStacks", contextName).Return(stackNames, nil)
+	for _, stackName := range stackNames {
+		mockConfigProvider.On("GetStack", stackName, contextName).Return(&config.StackConfig{Name: stackName}, nil)
+	}
 
 	// vpc - resolve fails
 	mockResolver.On("ResolveStack", ctx, contextName, "vpc").Return(nil, errors.New("template file not found"))